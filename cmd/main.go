@@ -1,12 +1,40 @@
 package main
 
 import (
-	// "custom-database/cmd/mode"
+	"custom-database/cmd/mode"
+	"custom-database/internal/buffer_bool"
+	"custom-database/internal/executor"
 	"custom-database/internal/parser"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// Параметры buffer pool: количество страниц в памяти и K для LRU-K
+const (
+	bufferPoolSize = 128
+	lruK           = 2
 )
 
 func main() {
-	parser.NewParser()
+	strictMode := flag.Bool("strict", true, "reject values longer than VARCHAR(n)/CHAR(n) instead of truncating them")
+	workMemory := flag.Int("work-mem", executor.DEFAULT_WORK_MEMORY, "bytes of rows an operator keeps in memory before spilling to temporary files")
+	tempDir := flag.String("temp-dir", "", "directory for temporary files (system default if empty)")
+	flag.Parse()
+
+	bufferPool, err := buffer_bool.NewBufferPool(bufferPoolSize, lruK)
+	if err != nil {
+		fmt.Println("Error while creating buffer pool:", err)
+		os.Exit(1)
+	}
+	defer bufferPool.Close()
+
+	config := executor.DefaultConfig()
+	config.StrictMode = *strictMode
+	config.WorkMemory = *workMemory
+	config.TempDir = *tempDir
 
-	// mode.RunConsoleMode(parser)
+	// Парсер и executor используют общий реестр функций
+	exec := executor.NewExecutorWithConfig(bufferPool, config)
+	mode.RunConsoleMode(parser.NewParserWithFunctions(exec.Functions()), exec)
 }
@@ -1,18 +1,18 @@
 package mode
 
 import (
+	"custom-database/internal/executor"
 	"custom-database/internal/parser"
 	"fmt"
 	"io"
-
-	// "os"
+	"os"
 	"strings"
 
 	"github.com/chzyer/readline"
-	// "github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter"
 )
 
-func RunConsoleMode(parser parser.ParserService) {
+func RunConsoleMode(parser parser.ParserService, executor executor.ExecutorService) {
 	l, err := readline.NewEx(&readline.Config{
 		Prompt:          "# ",
 		HistoryFile:     "/tmp/tmp",
@@ -49,74 +49,57 @@ repl:
 			break
 		}
 
-		_, err = parser.Parse(line)
+		result, err := parser.Parse(line)
 		if err != nil {
 			fmt.Println(err)
 			continue repl
 		}
 
-		// results, err := mb.ExecuteStatement(result)
-		// if err != nil {
-		// 	fmt.Println(err)
-		// 	continue repl
-		// }
+		for _, statement := range result.Statements {
+			results, err := executor.ExecuteStatement(statement)
+			if err != nil {
+				fmt.Println(err)
+				continue repl
+			}
 
-		// if results != nil {
-		// 	printTable(results)
-		// 	continue repl
-		// }
+			if results != nil {
+				for _, notice := range results.Notices {
+					fmt.Println("NOTICE:", notice)
+				}
+				if results.Columns != nil {
+					printTable(results)
+					continue
+				}
+			}
 
-		fmt.Println("ok")
+			fmt.Println("ok")
+		}
 	}
 }
 
-// func printTable(results *models.Table) error {
-// 	if len(results.Cells) == 0 {
-// 		fmt.Println("(no results)")
-// 		return nil
-// 	}
-
-// 	table := tablewriter.NewWriter(os.Stdout)
-// 	header := []string{}
-// 	for _, col := range results.Columns {
-// 		header = append(header, col.Name)
-// 	}
-// 	table.SetHeader(header)
-// 	table.SetAutoFormatHeaders(false)
-
-// 	rows := [][]string{}
-// 	for _, result := range results.Cells {
-// 		row := []string{}
-// 		for i, cell := range result {
-// 			typ := results.Columns[i].Type
-// 			r := ""
-// 			switch typ {
-// 			case models.Int32Type:
-// 				if cell.IsNull() {
-// 					r = "null"
-// 				} else {
-// 					i := cell.AsInt()
-// 					r = fmt.Sprintf("%d", i)
-// 				}
-// 			case models.TextType:
-// 				if cell.IsNull() {
-// 					r = "null"
-// 				} else {
-// 					r = cell.AsText()
-// 				}
-// 			}
-
-// 			row = append(row, r)
-// 		}
-
-// 		rows = append(rows, row)
-// 	}
+// printTable выводит результат SELECT в виде таблицы
+func printTable(results *executor.Result) {
+	table := tablewriter.NewWriter(os.Stdout)
+	header := []string{}
+	for _, column := range results.Columns {
+		header = append(header, column.Name)
+	}
+	table.SetHeader(header)
+	table.SetAutoFormatHeaders(false)
+
+	rows := [][]string{}
+	for _, result := range results.Rows {
+		row := []string{}
+		for _, cell := range result {
+			row = append(row, cell.String())
+		}
 
-// 	table.SetBorder(true)
-// 	table.AppendBulk(rows)
-// 	table.Render()
+		rows = append(rows, row)
+	}
 
-// 	fmt.Printf("(%d rows)\n", len(rows))
+	table.SetBorder(true)
+	table.AppendBulk(rows)
+	table.Render()
 
-// 	return nil
-// }
+	fmt.Printf("(%d rows)\n", len(rows))
+}
@@ -4,6 +4,8 @@ import (
 	"custom-database/internal/disk_manager"
 	"errors"
 	"fmt"
+	"os"
+	"sync"
 	"time"
 )
 
@@ -29,12 +31,24 @@ type BufferPoolInterface interface {
 	// Управление таблицами
 	CreateTable(tableName string, columns []disk_manager.ColumnInfo) error
 	DropTable(tableName string) error
+	// RenameTable переименовывает таблицу, ее страницы в буфере и метаинформация переходят к новому имени
+	RenameTable(tableName string, newTableName string) error
+	// TruncateTable удаляет все страницы таблицы, в том числе из буфера. Мета-файл (колонки) не меняется
+	TruncateTable(tableName string) error
 
 	// Работа с метаинформацией
 	ReadMetaInfo(tableName string) (*MetaInfo, error)
 	// WriteMetaInfo записывает метаинформацию таблицы на диск
 	// Перед вызовом, все необходимые данные в *MetaInfo нужно изменить
 	WriteMetaInfo(tableName string) error
+
+	// FlushAllPages - синхронно записывает все dirty страницы на диск,
+	// нужно вызывать перед завершением работы, не дожидаясь background worker
+	FlushAllPages()
+
+	// Close - останавливает background worker и записывает все dirty страницы на диск.
+	// После Close buffer pool больше не пишет на диск сам, повторный вызов безопасен
+	Close()
 }
 
 // BufferPool реализация Buffer Pool с LRU-K и Disk Scheduler
@@ -57,9 +71,13 @@ type BufferPool struct {
 	// Управление памятью
 	DirtyPages map[disk_manager.PageID]bool // Отслеживание измененных страниц
 	PinCounts  map[disk_manager.PageID]int  // Счетчики закреплений страниц
+
+	// mu защищает состояние пула от одновременного доступа executor'а и background worker'а
+	mu sync.Mutex
 }
 
 type MetaInfo struct {
+	FileID        disk_manager.FileID // ID файла таблицы из списка таблиц
 	MetaData      *disk_manager.MetaData
 	PageDirectory *disk_manager.PageDirectory
 	DataHeaders   *disk_manager.DataFileHeader
@@ -84,8 +102,11 @@ func NewBufferPool(maxSize int, k int) (BufferPoolInterface, error) {
 	// Создаем Disk Manager
 	diskManager := disk_manager.NewDiskManager()
 
-	// TODO: решить когда вызывать CreateDataBase()
-	err := diskManager.CreateDataBase()
+	// Создаем базу данных только при первом запуске, иначе работаем с существующими таблицами
+	_, err := diskManager.ReadTableList()
+	if os.IsNotExist(err) {
+		err = diskManager.CreateDataBase()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -97,11 +118,12 @@ func NewBufferPool(maxSize int, k int) (BufferPoolInterface, error) {
 	tableList := readTableList(diskManager)
 	// Инициализируем метаинформацию всех таблиц из списка
 	metaInfo := make(map[string]*MetaInfo)
-	for tableName := range tableList.Tables {
+	for tableName, fileID := range tableList.Tables {
 		metaInfo[tableName], err = readMetaInfo(diskManager, tableName)
 		if err != nil {
 			return nil, err
 		}
+		metaInfo[tableName].FileID = fileID
 	}
 
 	bp := &BufferPool{
@@ -126,6 +148,9 @@ func NewBufferPool(maxSize int, k int) (BufferPoolInterface, error) {
 
 // GetPage получает страницу из буфера
 func (bp *BufferPool) GetPage(tableName string, pageID disk_manager.PageID) (*BufferFrame, error) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
 	// Проверяем кэш
 	if frame, exists := bp.Pages[pageID]; exists {
 		bp.LRUKCache.Access(pageID)
@@ -171,6 +196,9 @@ func (bp *BufferPool) GetPage(tableName string, pageID disk_manager.PageID) (*Bu
 
 // MarkDirty отмечает страницу как измененную
 func (bp *BufferPool) MarkDirty(tableName string, pageID disk_manager.PageID) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
 	if frame, exists := bp.Pages[pageID]; exists {
 		frame.IsDirty = true
 		bp.DirtyPages[pageID] = true
@@ -179,6 +207,9 @@ func (bp *BufferPool) MarkDirty(tableName string, pageID disk_manager.PageID) {
 
 // Unpin освобождает страницу из памяти
 func (bp *BufferPool) Unpin(tableName string, pageID disk_manager.PageID) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
 	if frame, exists := bp.Pages[pageID]; exists {
 		frame.PinCount--
 		bp.PinCounts[pageID]--
@@ -192,6 +223,9 @@ func (bp *BufferPool) Unpin(tableName string, pageID disk_manager.PageID) {
 
 // AddNewPage создает новую страницу в таблице
 func (bp *BufferPool) AddNewPage(tableName string, pageID disk_manager.PageID) (*BufferFrame, error) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
 	page, err := bp.DiskManager.AddNewPage(tableName, pageID)
 	if err != nil {
 		return nil, err
@@ -233,6 +267,9 @@ func (bp *BufferPool) startBgWorker() error {
 
 // CreateTable создает новую таблицу
 func (bp *BufferPool) CreateTable(tableName string, columns []disk_manager.ColumnInfo) error {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
 	// Создаем таблицу через DiskManager
 	err := bp.DiskManager.CreateTable(tableName, columns)
 	if err != nil {
@@ -245,23 +282,31 @@ func (bp *BufferPool) CreateTable(tableName string, columns []disk_manager.Colum
 		return err
 	}
 
-	// Кэшируем метаинформацию
-	bp.MetaInfo[tableName] = metaInfo
-
 	// Обновляем список таблиц
 	bp.TableList = readTableList(bp.DiskManager)
+	if bp.TableList != nil {
+		metaInfo.FileID = bp.TableList.Tables[tableName]
+	}
+
+	// Кэшируем метаинформацию
+	bp.MetaInfo[tableName] = metaInfo
 
 	return nil
 }
 
 // DropTable удаляет таблицу
 func (bp *BufferPool) DropTable(tableName string) error {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
 	// Удаляем таблицу через DiskManager
 	err := bp.DiskManager.DropTable(tableName)
 	if err != nil {
 		return err
 	}
 
+	bp.discardTablePages(tableName)
+
 	// Удаляем метаинформацию из кэша
 	delete(bp.MetaInfo, tableName)
 
@@ -271,11 +316,105 @@ func (bp *BufferPool) DropTable(tableName string) error {
 	return nil
 }
 
+// RenameTable переименовывает таблицу
+func (bp *BufferPool) RenameTable(tableName string, newTableName string) error {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	metaInfo, exists := bp.MetaInfo[tableName]
+	if !exists || metaInfo == nil {
+		return fmt.Errorf("table %s not found", tableName)
+	}
+
+	// Переименовываем файлы таблицы через DiskManager
+	err := bp.DiskManager.RenameTable(tableName, newTableName)
+	if err != nil {
+		return err
+	}
+
+	// Идентификаторы страниц не зависят от имени таблицы, поэтому страницы остаются в буфере,
+	// а dirty страницы будут записаны уже в файл с новым именем
+	for _, frame := range bp.Pages {
+		if frame.TableName == tableName {
+			frame.TableName = newTableName
+		}
+	}
+
+	// Переносим метаинформацию в кэше
+	metaInfo.MetaData.Header.TableName = newTableName
+	metaInfo.MetaData.Header.TableNameLen = uint32(len(newTableName))
+	metaInfo.PageDirectory.TableName = newTableName
+	delete(bp.MetaInfo, tableName)
+	bp.MetaInfo[newTableName] = metaInfo
+
+	// Обновляем список таблиц
+	bp.TableList = readTableList(bp.DiskManager)
+
+	return nil
+}
+
+// TruncateTable удаляет все страницы таблицы
+func (bp *BufferPool) TruncateTable(tableName string) error {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	metaInfo, exists := bp.MetaInfo[tableName]
+	if !exists || metaInfo == nil {
+		return fmt.Errorf("table %s not found", tableName)
+	}
+
+	// Очищаем файлы таблицы через DiskManager
+	err := bp.DiskManager.TruncateTable(tableName)
+	if err != nil {
+		return err
+	}
+
+	bp.discardTablePages(tableName)
+
+	// Перечитываем пустые page directory и заголовок data файла, колонки в кэше остаются прежними
+	pageDirectory, err := bp.DiskManager.ReadPageDirectory(tableName)
+	if err != nil {
+		return err
+	}
+	dataHeaders, err := bp.DiskManager.ReadDataHeaders(tableName)
+	if err != nil {
+		return err
+	}
+	metaInfo.PageDirectory = pageDirectory
+	metaInfo.DataHeaders = dataHeaders
+
+	return nil
+}
+
+// discardTablePages удаляет страницы таблицы из буфера без записи на диск. Незакрепленные страницы
+// удаляются из буфера, закрепленные остаются до Unpin и вытесняются как обычно
+func (bp *BufferPool) discardTablePages(tableName string) {
+	for pageID, frame := range bp.Pages {
+		if frame.TableName != tableName {
+			continue
+		}
+		frame.IsDirty = false
+		delete(bp.DirtyPages, pageID)
+		if bp.PinCounts[pageID] > 0 {
+			continue
+		}
+		delete(bp.Pages, pageID)
+		delete(bp.PinCounts, pageID)
+		bp.LRUKCache.Evict(pageID)
+	}
+}
+
 func (bp *BufferPool) ReadMetaInfo(tableName string) (*MetaInfo, error) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
 	return bp.MetaInfo[tableName], nil
 }
 
 func (bp *BufferPool) WriteMetaInfo(tableName string) error {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
 	metaInfo, exists := bp.MetaInfo[tableName]
 	if !exists || metaInfo == nil {
 		return fmt.Errorf("meta info for table %s not found", tableName)
@@ -319,8 +458,22 @@ func (bp *BufferPool) evictPage() error {
 	return nil
 }
 
+// FlushAllPages синхронно записывает все dirty страницы на диск
+func (bp *BufferPool) FlushAllPages() {
+	bp.flushDirtyPages()
+}
+
+// Close останавливает background worker и записывает все dirty страницы на диск
+func (bp *BufferPool) Close() {
+	bp.DiskScheduler.StopBgWorker()
+	bp.flushDirtyPages()
+}
+
 // flushDirtyPages записывает все dirty страницы на диск
 func (bp *BufferPool) flushDirtyPages() {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
 	dirtyPages := make([]disk_manager.PageID, 0, len(bp.DirtyPages))
 	for pageID := range bp.DirtyPages {
 		dirtyPages = append(dirtyPages, pageID)
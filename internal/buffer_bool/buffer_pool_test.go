@@ -1048,3 +1048,171 @@ func TestBufferPoolBackgroundWorker(t *testing.T) {
 		bp.DropTable(tableName)
 	})
 }
+
+func TestBufferPoolDropTable(t *testing.T) {
+	t.Run("1. Drop table discards its unpinned pages", func(t *testing.T) {
+		// Arrange
+		bp, err := NewBufferPool(5, 2)
+		require.NoError(t, err)
+
+		// Cleanup
+		defer func() {
+			os.RemoveAll("tables")
+		}()
+
+		// Очищаем перед тестом
+		os.RemoveAll("tables")
+
+		// Приводим к конкретному типу для доступа к полям
+		bufferPool := bp.(*BufferPool)
+
+		columns := []disk_manager.ColumnInfo{
+			{
+				ColumnNameLength: 2,
+				ColumnName:       "id",
+				DataType:         disk_manager.INT_32_TYPE,
+				IsNullable:       1,
+			},
+		}
+
+		err = bufferPool.DiskManager.CreateDataBase()
+		require.NoError(t, err)
+		require.NoError(t, bp.CreateTable("dropped", columns))
+		require.NoError(t, bp.CreateTable("kept", columns))
+
+		droppedPageID := disk_manager.PageID{FileID: 1, PageNumber: 1}
+		keptPageID := disk_manager.PageID{FileID: 2, PageNumber: 1}
+		_, err = bp.AddNewPage("dropped", droppedPageID)
+		require.NoError(t, err)
+		_, err = bp.AddNewPage("kept", keptPageID)
+		require.NoError(t, err)
+		bp.MarkDirty("dropped", droppedPageID)
+		bp.Unpin("dropped", droppedPageID)
+
+		// Act
+		err = bp.DropTable("dropped")
+
+		// Assert
+		require.NoError(t, err)
+		require.NotContains(t, bufferPool.Pages, droppedPageID)
+		require.NotContains(t, bufferPool.DirtyPages, droppedPageID)
+		require.NotContains(t, bufferPool.PinCounts, droppedPageID)
+		require.Contains(t, bufferPool.Pages, keptPageID)
+
+		// Cleanup
+		bp.DropTable("kept")
+	})
+}
+
+func TestBufferPoolRenameTable(t *testing.T) {
+	t.Run("1. Rename table moves its pages and meta info", func(t *testing.T) {
+		// Arrange
+		bp, err := NewBufferPool(5, 2)
+		require.NoError(t, err)
+
+		// Cleanup
+		defer func() {
+			os.RemoveAll("tables")
+		}()
+
+		// Очищаем перед тестом
+		os.RemoveAll("tables")
+
+		// Приводим к конкретному типу для доступа к полям
+		bufferPool := bp.(*BufferPool)
+
+		columns := []disk_manager.ColumnInfo{
+			{
+				ColumnNameLength: 2,
+				ColumnName:       "id",
+				DataType:         disk_manager.INT_32_TYPE,
+				IsNullable:       1,
+			},
+		}
+
+		err = bufferPool.DiskManager.CreateDataBase()
+		require.NoError(t, err)
+		require.NoError(t, bp.CreateTable("old_name", columns))
+
+		pageID := disk_manager.PageID{FileID: 1, PageNumber: 1}
+		_, err = bp.AddNewPage("old_name", pageID)
+		require.NoError(t, err)
+		bp.MarkDirty("old_name", pageID)
+		bp.Unpin("old_name", pageID)
+
+		// Act
+		err = bp.RenameTable("old_name", "new_name")
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, "new_name", bufferPool.Pages[pageID].TableName)
+		require.NotContains(t, bufferPool.MetaInfo, "old_name")
+		require.Contains(t, bufferPool.TableList.Tables, "new_name")
+
+		metaInfo, err := bp.ReadMetaInfo("new_name")
+		require.NoError(t, err)
+		require.Equal(t, "new_name", metaInfo.MetaData.Header.TableName)
+
+		// Dirty страница записывается в файл с новым именем
+		bp.FlushAllPages()
+		require.NotContains(t, bufferPool.DirtyPages, pageID)
+
+		// Cleanup
+		bp.DropTable("new_name")
+	})
+}
+
+func TestBufferPoolTruncateTable(t *testing.T) {
+	t.Run("1. Truncate table discards its pages and keeps columns", func(t *testing.T) {
+		// Arrange
+		bp, err := NewBufferPool(5, 2)
+		require.NoError(t, err)
+
+		// Cleanup
+		defer func() {
+			os.RemoveAll("tables")
+		}()
+
+		// Очищаем перед тестом
+		os.RemoveAll("tables")
+
+		// Приводим к конкретному типу для доступа к полям
+		bufferPool := bp.(*BufferPool)
+
+		columns := []disk_manager.ColumnInfo{
+			{
+				ColumnNameLength: 2,
+				ColumnName:       "id",
+				DataType:         disk_manager.INT_32_TYPE,
+				IsNullable:       1,
+			},
+		}
+
+		err = bufferPool.DiskManager.CreateDataBase()
+		require.NoError(t, err)
+		require.NoError(t, bp.CreateTable("truncated", columns))
+
+		pageID := disk_manager.PageID{FileID: 1, PageNumber: 1}
+		_, err = bp.AddNewPage("truncated", pageID)
+		require.NoError(t, err)
+		bp.MarkDirty("truncated", pageID)
+		bp.Unpin("truncated", pageID)
+
+		// Act
+		err = bp.TruncateTable("truncated")
+
+		// Assert
+		require.NoError(t, err)
+		require.NotContains(t, bufferPool.Pages, pageID)
+		require.NotContains(t, bufferPool.DirtyPages, pageID)
+
+		metaInfo, err := bp.ReadMetaInfo("truncated")
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), metaInfo.DataHeaders.PagesCount)
+		require.Empty(t, metaInfo.PageDirectory.Entries)
+		require.Len(t, metaInfo.MetaData.Columns, 1)
+
+		// Cleanup
+		bp.DropTable("truncated")
+	})
+}
@@ -6,7 +6,9 @@ import (
 
 // diskScheduler - простой планировщик для записи страниц
 type diskScheduler struct {
-	isRunning bool // Флаг работы background worker
+	isRunning bool          // Флаг работы background worker
+	stop      chan struct{} // Закрывается, чтобы остановить background worker
+	done      chan struct{} // Закрывается background worker'ом после остановки
 }
 
 // NewDiskScheduler создает новый Disk Scheduler
@@ -23,6 +25,8 @@ func (ds *diskScheduler) StartBgWorker(flushFunc func()) error {
 	}
 
 	ds.isRunning = true
+	ds.stop = make(chan struct{})
+	ds.done = make(chan struct{})
 
 	// Запускаем background worker
 	go ds.flushWorker(flushFunc)
@@ -34,15 +38,26 @@ func (ds *diskScheduler) StartBgWorker(flushFunc func()) error {
 func (ds *diskScheduler) flushWorker(flushFunc func()) {
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
+	defer close(ds.done)
 
 	for {
-		<-ticker.C
-		if ds.isRunning {
-			flushFunc()
-		} else {
-			// Финальная запись всех dirty страниц
+		select {
+		case <-ticker.C:
 			flushFunc()
+		case <-ds.stop:
 			return
 		}
 	}
 }
+
+// StopBgWorker останавливает background worker и дожидается его завершения.
+// Повторный вызов ничего не делает
+func (ds *diskScheduler) StopBgWorker() {
+	if !ds.isRunning {
+		return
+	}
+
+	ds.isRunning = false
+	close(ds.stop)
+	<-ds.done
+}
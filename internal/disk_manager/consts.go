@@ -16,7 +16,9 @@ const TABLE_LIST_FILE_PATH = "tables/list/table_list.bin"
 
 // Магические числа
 // Используются в самом начале файла для проверки корректности формата файла
-const META_FILE_MAGIC_NUMBER = 0x9ABCDEF0
+// Magic number мета-файла меняется вместе с форматом описания колонок
+const META_FILE_MAGIC_NUMBER = 0x9ABCDEF1
+const PREVIOUS_META_FILE_MAGIC_NUMBER = 0x9ABCDEF0 // Колонки без параметров типа (56 байт на колонку)
 const PAGE_DIRECTORY_MAGIC_NUMBER = 0x8ABCDEF1
 const DATA_FILE_MAGIC_NUMBER = 0x12345678
 const TABLES_LIST_MAGIC_NUMBER = 0x7ABCDEF2
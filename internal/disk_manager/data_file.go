@@ -276,3 +276,15 @@ func writeDataFileHeaders(tableName string, dataHeaders *DataFileHeader) (*DataF
 
 	return dataHeaders, nil
 }
+
+// renameDataFile переименовывает .data файл таблицы
+func renameDataFile(tableName string, newTableName string) error {
+	dataFilePath := fmt.Sprintf(DATA_FILE_PATH, tableName)
+
+	// Проверяем, что файл существует
+	if _, err := os.Stat(dataFilePath); err != nil {
+		return fmt.Errorf("data file for table %s not found", tableName)
+	}
+
+	return os.Rename(dataFilePath, fmt.Sprintf(DATA_FILE_PATH, newTableName))
+}
@@ -0,0 +1,315 @@
+package disk_manager
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Ограничения DECIMAL(p,s)
+const DECIMAL_MAX_PRECISION = 1000
+
+// Размер служебной части DECIMAL: 4 байта длины + 2 байта scale + 1 байт знака
+const DECIMAL_HEADER_SIZE = 4 + 2 + 1
+
+// Decimal точное десятичное число с фиксированной точкой: Unscaled * 10^(-Scale).
+// Например, 19.90 хранится как Unscaled = 1990, Scale = 2
+type Decimal struct {
+	Unscaled *big.Int // Число без десятичной точки
+	Scale    int32    // Количество цифр после десятичной точки (не отрицательное)
+}
+
+var bigTen = big.NewInt(10)
+
+// NewDecimalFromInt создает DECIMAL из целого числа
+func NewDecimalFromInt(value int64) Decimal {
+	return Decimal{Unscaled: big.NewInt(value), Scale: 0}
+}
+
+// pow10 возвращает 10^n
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// ParseDecimal парсит десятичное число без промежуточного перевода во float64.
+// Поддерживаются записи вида 123, -1.50, .5, 1e5, 1.2E-3
+func ParseDecimal(value string) (Decimal, error) {
+	source := strings.TrimSpace(value)
+	if source == "" {
+		return Decimal{}, fmt.Errorf("invalid input syntax for type DECIMAL: %q", value)
+	}
+
+	// Отделяем экспоненту
+	exponent := int64(0)
+	if index := strings.IndexAny(source, "eE"); index != -1 {
+		exponentValue, ok := new(big.Int).SetString(source[index+1:], 10)
+		if !ok || !exponentValue.IsInt64() {
+			return Decimal{}, fmt.Errorf("invalid input syntax for type DECIMAL: %q", value)
+		}
+		exponent = exponentValue.Int64()
+		source = source[:index]
+	}
+
+	// Отделяем знак
+	negative := false
+	if strings.HasPrefix(source, "-") || strings.HasPrefix(source, "+") {
+		negative = source[0] == '-'
+		source = source[1:]
+	}
+
+	integerPart, fractionalPart, _ := strings.Cut(source, ".")
+	digits := integerPart + fractionalPart
+	if digits == "" || strings.ContainsAny(digits, "+-") {
+		return Decimal{}, fmt.Errorf("invalid input syntax for type DECIMAL: %q", value)
+	}
+
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid input syntax for type DECIMAL: %q", value)
+	}
+	if negative {
+		unscaled.Neg(unscaled)
+	}
+
+	scale := int64(len(fractionalPart)) - exponent
+	if scale > DECIMAL_MAX_PRECISION || scale < -DECIMAL_MAX_PRECISION {
+		return Decimal{}, fmt.Errorf("value %q is out of range for type DECIMAL", value)
+	}
+
+	// Отрицательный scale переносим в само число: 12e3 -> 12000
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(int32(-scale)))
+		scale = 0
+	}
+
+	return Decimal{Unscaled: unscaled, Scale: int32(scale)}, nil
+}
+
+// String форматирует число с Scale цифрами после точки
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.Unscaled).String()
+
+	sign := ""
+	if d.Unscaled.Sign() < 0 {
+		sign = "-"
+	}
+
+	if d.Scale == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= int(d.Scale) {
+		digits = strings.Repeat("0", int(d.Scale)-len(digits)+1) + digits
+	}
+	point := len(digits) - int(d.Scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Sign возвращает -1, 0 или 1 в зависимости от знака числа
+func (d Decimal) Sign() int {
+	return d.Unscaled.Sign()
+}
+
+// Rescale приводит число к указанному scale, округляя половину от нуля (1.25 -> 1.3, -1.25 -> -1.3)
+func (d Decimal) Rescale(scale int32) Decimal {
+	if scale == d.Scale {
+		return d
+	}
+
+	if scale > d.Scale {
+		return Decimal{
+			Unscaled: new(big.Int).Mul(d.Unscaled, pow10(scale-d.Scale)),
+			Scale:    scale,
+		}
+	}
+
+	return Decimal{
+		Unscaled: divideRoundHalfAway(d.Unscaled, pow10(d.Scale-scale)),
+		Scale:    scale,
+	}
+}
+
+// Normalize убирает незначащие нули дробной части: 1.2500 -> 1.25, 10.0 -> 10
+func (d Decimal) Normalize() Decimal {
+	unscaled := new(big.Int).Set(d.Unscaled)
+	scale := d.Scale
+
+	remainder := new(big.Int)
+	quotient := new(big.Int)
+	for scale > 0 {
+		quotient.QuoRem(unscaled, bigTen, remainder)
+		if remainder.Sign() != 0 {
+			break
+		}
+		unscaled.Set(quotient)
+		scale--
+	}
+
+	return Decimal{Unscaled: unscaled, Scale: scale}
+}
+
+// divideRoundHalfAway делит целые числа с округлением половины от нуля
+func divideRoundHalfAway(numerator, denominator *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+
+	// Округляем, если остаток не меньше половины делителя
+	doubled := new(big.Int).Abs(remainder)
+	doubled.Lsh(doubled, 1)
+	if doubled.Cmp(new(big.Int).Abs(denominator)) >= 0 {
+		if numerator.Sign()*denominator.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return quotient
+}
+
+// alignScales приводит два числа к общему (большему) scale
+func alignScales(a, b Decimal) (Decimal, Decimal) {
+	if a.Scale > b.Scale {
+		return a, b.Rescale(a.Scale)
+	}
+	return a.Rescale(b.Scale), b
+}
+
+// Add складывает два числа, scale результата - больший из scale операндов
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b := alignScales(d, other)
+	return Decimal{Unscaled: new(big.Int).Add(a.Unscaled, b.Unscaled), Scale: a.Scale}
+}
+
+// Sub вычитает число, scale результата - больший из scale операндов
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b := alignScales(d, other)
+	return Decimal{Unscaled: new(big.Int).Sub(a.Unscaled, b.Unscaled), Scale: a.Scale}
+}
+
+// Neg возвращает число с противоположным знаком
+func (d Decimal) Neg() Decimal {
+	return Decimal{Unscaled: new(big.Int).Neg(d.Unscaled), Scale: d.Scale}
+}
+
+// Mul умножает числа точно, scale результата равен сумме scale операндов
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{Unscaled: new(big.Int).Mul(d.Unscaled, other.Unscaled), Scale: d.Scale + other.Scale}
+}
+
+// Div делит числа и округляет результат до указанного scale
+func (d Decimal) Div(other Decimal, scale int32) (Decimal, error) {
+	if other.Sign() == 0 {
+		return Decimal{}, fmt.Errorf("division by zero")
+	}
+
+	// d / other = (d.Unscaled * 10^(scale + other.Scale - d.Scale)) / other.Unscaled * 10^(-scale)
+	numerator := new(big.Int).Set(d.Unscaled)
+	denominator := new(big.Int).Set(other.Unscaled)
+	shift := scale + other.Scale - d.Scale
+	if shift >= 0 {
+		numerator.Mul(numerator, pow10(shift))
+	} else {
+		denominator.Mul(denominator, pow10(-shift))
+	}
+
+	return Decimal{Unscaled: divideRoundHalfAway(numerator, denominator), Scale: scale}, nil
+}
+
+// Mod возвращает остаток от деления: частное усекается к нулю, знак остатка - как у делимого.
+// Scale результата - больший из scale операндов
+func (d Decimal) Mod(other Decimal) (Decimal, error) {
+	if other.Sign() == 0 {
+		return Decimal{}, fmt.Errorf("division by zero")
+	}
+
+	a, b := alignScales(d, other)
+	return Decimal{Unscaled: new(big.Int).Rem(a.Unscaled, b.Unscaled), Scale: a.Scale}, nil
+}
+
+// Float64 возвращает ближайшее к числу значение float64
+func (d Decimal) Float64() float64 {
+	value, _ := new(big.Float).Quo(new(big.Float).SetInt(d.Unscaled), new(big.Float).SetInt(pow10(d.Scale))).Float64()
+	return value
+}
+
+// Compare сравнивает числа: -1 если d < other, 0 если равны, 1 если d > other
+func (d Decimal) Compare(other Decimal) int {
+	a, b := alignScales(d, other)
+	return a.Unscaled.Cmp(b.Unscaled)
+}
+
+// Precision возвращает количество значащих цифр числа (без учета ведущих нулей целой части)
+func (d Decimal) Precision() int32 {
+	digits := new(big.Int).Abs(d.Unscaled).String()
+	if int32(len(digits)) < d.Scale {
+		return d.Scale
+	}
+	return int32(len(digits))
+}
+
+// FitTo приводит число к типу DECIMAL(precision, scale): округляет до scale
+// и проверяет, что целая часть помещается в precision - scale цифр.
+// Precision равный 0 означает DECIMAL без ограничений
+func (d Decimal) FitTo(precision, scale uint32) (Decimal, error) {
+	if precision == 0 {
+		return d, nil
+	}
+
+	result := d.Rescale(int32(scale))
+	if result.Precision() > int32(precision) {
+		return Decimal{}, fmt.Errorf("numeric field overflow: value %s does not fit DECIMAL(%d,%d)", d, precision, scale)
+	}
+
+	return result, nil
+}
+
+// IsInt32 проверяет, что число целое и помещается в int32
+func (d Decimal) IsInt32() bool {
+	integer := d.Rescale(0).Unscaled
+	return integer.IsInt64() && integer.Int64() >= -1<<31 && integer.Int64() <= 1<<31-1
+}
+
+// serializeDecimal сериализует DECIMAL: 4 байта длины + 2 байта scale + 1 байт знака + модуль числа (big-endian)
+func (cell *DataCell) serializeDecimal() []byte {
+	decimal := cell.Data.(Decimal)
+	magnitude := new(big.Int).Abs(decimal.Unscaled).Bytes()
+
+	length := uint32(DECIMAL_HEADER_SIZE - 4 + len(magnitude))
+	data := make([]byte, 4+length)
+	binary.BigEndian.PutUint32(data[0:4], length)
+	binary.BigEndian.PutUint16(data[4:6], uint16(decimal.Scale))
+	if decimal.Unscaled.Sign() < 0 {
+		data[6] = 1
+	}
+	copy(data[7:], magnitude)
+	return data
+}
+
+// deserializeDecimal десериализует DECIMAL из байтов
+func (cell *DataCell) deserializeDecimal(data []byte) (*DataCell, error) {
+	if len(data) < DECIMAL_HEADER_SIZE {
+		return nil, fmt.Errorf("insufficient data for DECIMAL_TYPE: need at least %d bytes, got %d", DECIMAL_HEADER_SIZE, len(data))
+	}
+
+	length := binary.BigEndian.Uint32(data[0:4])
+	if len(data) < int(4+length) {
+		return nil, fmt.Errorf("insufficient data for DECIMAL_TYPE: need %d bytes, got %d", 4+length, len(data))
+	}
+
+	unscaled := new(big.Int).SetBytes(data[DECIMAL_HEADER_SIZE : 4+length])
+	if data[6] == 1 {
+		unscaled.Neg(unscaled)
+	}
+
+	cell.Data = Decimal{
+		Unscaled: unscaled,
+		Scale:    int32(binary.BigEndian.Uint16(data[4:6])),
+	}
+	return cell, nil
+}
+
+// decimalSize возвращает размер сериализованного DECIMAL в байтах
+func decimalSize(decimal Decimal) uint32 {
+	return uint32(DECIMAL_HEADER_SIZE + len(new(big.Int).Abs(decimal.Unscaled).Bytes()))
+}
@@ -0,0 +1,197 @@
+package disk_manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	t.Run("1. Parse decimal literals", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected string
+		}{
+			{"123", "123"},
+			{"-1.50", "-1.50"},
+			{".5", "0.5"},
+			{"0.001", "0.001"},
+			{"1e3", "1000"},
+			{"1.25E-3", "0.00125"},
+			{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789"},
+		}
+
+		for _, testCase := range testCases {
+			// Act
+			decimal, err := ParseDecimal(testCase.input)
+
+			// Assert
+			require.NoError(t, err, testCase.input)
+			require.Equal(t, testCase.expected, decimal.String(), testCase.input)
+		}
+	})
+
+	t.Run("2. Parse invalid decimal", func(t *testing.T) {
+		for _, input := range []string{"", "abc", "1.2.3", "1e", "-"} {
+			// Act
+			_, err := ParseDecimal(input)
+
+			// Assert
+			require.Error(t, err, input)
+		}
+	})
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	mustParse := func(value string) Decimal {
+		decimal, err := ParseDecimal(value)
+		require.NoError(t, err)
+		return decimal
+	}
+
+	t.Run("1. Add and subtract are exact", func(t *testing.T) {
+		// Arrange
+		a := mustParse("0.1")
+		b := mustParse("0.2")
+
+		// Act
+		sum := a.Add(b)
+		difference := a.Sub(mustParse("0.30"))
+
+		// Assert
+		require.Equal(t, "0.3", sum.String())
+		require.Equal(t, "-0.20", difference.String())
+		require.Equal(t, 0, sum.Compare(mustParse("0.300")))
+	})
+
+	t.Run("2. Multiply and divide", func(t *testing.T) {
+		// Arrange
+		a := mustParse("1.5")
+		b := mustParse("-2.25")
+
+		// Act
+		product := a.Mul(b)
+		quotient, err := mustParse("1").Div(mustParse("3"), 4)
+		_, divisionByZeroErr := a.Div(mustParse("0.00"), 2)
+
+		// Assert
+		require.Equal(t, "-3.375", product.String())
+		require.NoError(t, err)
+		require.Equal(t, "0.3333", quotient.String())
+		require.Error(t, divisionByZeroErr)
+	})
+
+	t.Run("3. Rounding is half away from zero", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			scale    int32
+			expected string
+		}{
+			{"1.25", 1, "1.3"},
+			{"-1.25", 1, "-1.3"},
+			{"1.24", 1, "1.2"},
+			{"0.005", 2, "0.01"},
+			{"999.5", 0, "1000"},
+			{"1.5", 3, "1.500"},
+		}
+
+		for _, testCase := range testCases {
+			// Act
+			result := mustParse(testCase.input).Rescale(testCase.scale)
+
+			// Assert
+			require.Equal(t, testCase.expected, result.String(), testCase.input)
+		}
+	})
+
+	t.Run("4. Fit to precision and scale", func(t *testing.T) {
+		// Act
+		fitted, fitErr := mustParse("12345678.995").FitTo(10, 2)
+		_, overflowErr := mustParse("99999999.995").FitTo(10, 2)
+		unconstrained, unconstrainedErr := mustParse("1.23456").FitTo(0, 0)
+
+		// Assert
+		require.NoError(t, fitErr)
+		require.Equal(t, "12345679.00", fitted.String())
+		require.Error(t, overflowErr)
+		require.NoError(t, unconstrainedErr)
+		require.Equal(t, "1.23456", unconstrained.String())
+
+		small, err := mustParse("0.125").FitTo(3, 2)
+		require.NoError(t, err)
+		require.Equal(t, "0.13", small.String())
+	})
+
+	t.Run("5. Normalize drops trailing zeros", func(t *testing.T) {
+		testCases := map[string]string{
+			"1.2500": "1.25",
+			"10.0":   "10",
+			"-0.500": "-0.5",
+			"0.000":  "0",
+			"100":    "100",
+		}
+
+		for input, expected := range testCases {
+			// Act
+			result := mustParse(input).Normalize()
+
+			// Assert
+			require.Equal(t, expected, result.String(), input)
+		}
+	})
+
+	t.Run("6. Remainder keeps the sign of the dividend", func(t *testing.T) {
+		// Act
+		remainder, err := mustParse("-7.5").Mod(mustParse("2"))
+		_, divisionByZeroErr := mustParse("1").Mod(mustParse("0.0"))
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, "-1.5", remainder.String())
+		require.Error(t, divisionByZeroErr)
+		require.Equal(t, -2.25, mustParse("-2.25").Float64())
+	})
+}
+
+func TestDecimalSerialization(t *testing.T) {
+	t.Run("1. DECIMAL round trip", func(t *testing.T) {
+		for _, input := range []string{"0", "-0.01", "19.99", "-123456789012345678901234567890.5"} {
+			// Arrange
+			decimal, err := ParseDecimal(input)
+			require.NoError(t, err)
+			cell := &DataCell{DataType: DECIMAL_TYPE, Data: decimal}
+
+			// Act
+			data := cell.SerializeData()
+			result, err := DeserializeDataCell(data, DECIMAL_TYPE, false)
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, data, int(cell.GetSize()))
+			require.Equal(t, input, result.String())
+		}
+	})
+
+	t.Run("2. Row with DECIMAL and TEXT converts to raw tuple and back", func(t *testing.T) {
+		// Arrange
+		decimal, err := ParseDecimal("-42.125")
+		require.NoError(t, err)
+		columns := []ColumnInfo{
+			{ColumnName: "amount", DataType: DECIMAL_TYPE, Precision: 10, Scale: 3},
+			{ColumnName: "note", DataType: TEXT_TYPE},
+		}
+		row := Row{
+			{DataType: DECIMAL_TYPE, Data: decimal},
+			{DataType: TEXT_TYPE, Data: "refund"},
+		}
+
+		// Act
+		rawTuple := ConvertRowToRawTuple(row)
+		result, err := ConvertRawTupleToRow(*rawTuple, columns)
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, "-42.125", result[0].String())
+		require.Equal(t, "refund", result[1].Data)
+	})
+}
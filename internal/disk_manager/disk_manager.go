@@ -2,6 +2,7 @@ package disk_manager
 
 import (
 	"fmt"
+	"os"
 )
 
 // DiskManager интерфейс для работы с диском
@@ -14,6 +15,10 @@ type DiskManager interface {
 	// ее нужно создать напрямую через в buffer pool через AddNewPage
 	CreateTable(tableName string, columns []ColumnInfo) error
 	DropTable(tableName string) error
+	// RenameTable - переименовывает файлы таблицы и запись в списке таблиц, FileID таблицы не меняется
+	RenameTable(tableName string, newTableName string) error
+	// TruncateTable - удаляет все страницы таблицы: page directory и data файл создаются заново, мета-файл не меняется
+	TruncateTable(tableName string) error
 
 	// Tables List - метод для чтения списка таблиц
 	ReadTableList() (*TablesList, error)
@@ -115,6 +120,52 @@ func (dm *diskManager) DropTable(tableName string) error {
 	return nil
 }
 
+func (dm *diskManager) RenameTable(tableName string, newTableName string) error {
+	// Проверяем, что таблицы с новым именем нет
+	if _, err := os.Stat(fmt.Sprintf(META_FILE_PATH, newTableName)); err == nil {
+		return fmt.Errorf("table %s already exists", newTableName)
+	}
+
+	// Сначала обновляем список таблиц: он проверяет длину нового имени
+	_, err := renameTableInList(tableName, newTableName)
+	if err != nil {
+		return fmt.Errorf("failed to update tables list: %w", err)
+	}
+
+	err = renameMetaFile(tableName, newTableName)
+	if err != nil {
+		return err
+	}
+
+	err = renamePageDirectory(tableName, newTableName)
+	if err != nil {
+		return err
+	}
+
+	return renameDataFile(tableName, newTableName)
+}
+
+func (dm *diskManager) TruncateTable(tableName string) error {
+	err := deletePageDirectory(tableName)
+	if err != nil {
+		return err
+	}
+
+	err = deleteDataFile(tableName)
+	if err != nil {
+		return err
+	}
+
+	// Создаем пустые page directory и data файл, как при создании таблицы
+	_, err = createPageDirectoryFile(tableName)
+	if err != nil {
+		return err
+	}
+
+	_, err = createDataFile(tableName)
+	return err
+}
+
 // ========================== Table List ==========================
 
 func (dm *diskManager) ReadTableList() (*TablesList, error) {
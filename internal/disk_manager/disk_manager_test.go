@@ -306,6 +306,124 @@ func TestDiskManagerDropTable(t *testing.T) {
 	})
 }
 
+func TestDiskManagerRenameTable(t *testing.T) {
+	t.Run("1. Rename table success", func(t *testing.T) {
+		// Arrange
+		dm := NewDiskManager()
+		columns := []ColumnInfo{
+			{ColumnNameLength: 2, ColumnName: "id", DataType: INT_32_TYPE, IsNullable: 1},
+		}
+
+		defer os.RemoveAll("tables")
+		err := os.MkdirAll("tables", 0755)
+		require.NoError(t, err)
+		err = dm.CreateDataBase()
+		require.NoError(t, err)
+		err = dm.CreateTable("old_table", columns)
+		require.NoError(t, err)
+
+		tablesList, err := dm.ReadTableList()
+		require.NoError(t, err)
+		fileID := tablesList.Tables["old_table"]
+
+		// Act
+		err = dm.RenameTable("old_table", "new_table")
+
+		// Assert
+		require.NoError(t, err)
+
+		for _, extension := range []string{".meta", ".dir", ".data"} {
+			_, err = os.Stat(filepath.Join("tables", "old_table"+extension))
+			require.True(t, os.IsNotExist(err))
+			_, err = os.Stat(filepath.Join("tables", "new_table"+extension))
+			require.NoError(t, err)
+		}
+
+		metaData, err := dm.ReadMetaFile("new_table")
+		require.NoError(t, err)
+		require.Equal(t, "new_table", metaData.Header.TableName)
+		require.Equal(t, uint32(len("new_table")), metaData.Header.TableNameLen)
+		require.Equal(t, "id", metaData.Columns[0].ColumnName)
+
+		tablesList, err = dm.ReadTableList()
+		require.NoError(t, err)
+		require.NotContains(t, tablesList.Tables, "old_table")
+		require.Equal(t, fileID, tablesList.Tables["new_table"])
+	})
+
+	t.Run("2. Rename table when new name already exists", func(t *testing.T) {
+		// Arrange
+		dm := NewDiskManager()
+		columns := []ColumnInfo{
+			{ColumnNameLength: 2, ColumnName: "id", DataType: INT_32_TYPE, IsNullable: 1},
+		}
+
+		defer os.RemoveAll("tables")
+		err := os.MkdirAll("tables", 0755)
+		require.NoError(t, err)
+		err = dm.CreateDataBase()
+		require.NoError(t, err)
+		err = dm.CreateTable("first_table", columns)
+		require.NoError(t, err)
+		err = dm.CreateTable("second_table", columns)
+		require.NoError(t, err)
+
+		// Act
+		err = dm.RenameTable("first_table", "second_table")
+
+		// Assert
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "table second_table already exists")
+
+		_, err = os.Stat(filepath.Join("tables", "first_table.meta"))
+		require.NoError(t, err)
+	})
+}
+
+func TestDiskManagerTruncateTable(t *testing.T) {
+	t.Run("1. Truncate table success", func(t *testing.T) {
+		// Arrange
+		dm := NewDiskManager()
+		tableName := "test_truncate_table"
+		columns := []ColumnInfo{
+			{ColumnNameLength: 2, ColumnName: "id", DataType: INT_32_TYPE, IsNullable: 1},
+		}
+
+		defer os.RemoveAll("tables")
+		err := os.MkdirAll("tables", 0755)
+		require.NoError(t, err)
+		err = dm.CreateDataBase()
+		require.NoError(t, err)
+		err = dm.CreateTable(tableName, columns)
+		require.NoError(t, err)
+
+		_, err = dm.AddNewPage(tableName, PageID{FileID: 1, PageNumber: 1})
+		require.NoError(t, err)
+		_, err = dm.AddNewPage(tableName, PageID{FileID: 1, PageNumber: 2})
+		require.NoError(t, err)
+
+		// Act
+		err = dm.TruncateTable(tableName)
+
+		// Assert
+		require.NoError(t, err)
+
+		dataHeaders, err := dm.ReadDataHeaders(tableName)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), dataHeaders.PagesCount)
+		require.Equal(t, uint32(0), dataHeaders.RecordCount)
+
+		pageDirectory, err := dm.ReadPageDirectory(tableName)
+		require.NoError(t, err)
+		require.Empty(t, pageDirectory.Entries)
+
+		// Колонки таблицы не меняются
+		metaData, err := dm.ReadMetaFile(tableName)
+		require.NoError(t, err)
+		require.Len(t, metaData.Columns, 1)
+	})
+}
+
 func TestDiskManagerReadMetaFile(t *testing.T) {
 	t.Run("1. Read meta file success", func(t *testing.T) {
 		// Arrange
@@ -3,6 +3,7 @@ package disk_manager
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
 	"os"
 )
 
@@ -79,16 +80,21 @@ func (meta *MetaDataHeader) Deserialize(data []byte) (*MetaDataHeader, error) {
 	}, nil
 }
 
-const COLUMN_INFO_SIZE = 56 // 4 + 4 + 4 + 4 + 4 + 4 + 32 = 56 байт (с фиксированным ColumnName)
+const COLUMN_INFO_SIZE = 68 // 4 + 4 + 4 + 4 + 4 + 4 + 32 + 4 + 4 + 4 = 68 байт (с фиксированным ColumnName)
 
 type ColumnInfo struct {
 	ColumnNameLength uint32   // 4 байта - длина имени колонки
-	DataType         DataType // 4 байта - тип данных (0=INT, 1=TEXT)
+	DataType         DataType // 4 байта - тип данных (1=INT, 2=TEXT, ...)
 	IsNullable       uint32   // 4 байта - может ли быть NULL (0=no, 1=yes)
 	IsPrimaryKey     uint32   // 4 байта - является ли первичным ключом
 	IsAutoIncrement  uint32   // 4 байта - автоинкремент
-	DefaultValue     uint32   // 4 байта - значение по умолчанию
+	DefaultValue     uint32   // 4 байта - есть ли значение по умолчанию (0=no, 1=yes), само значение хранится после списка колонок
 	ColumnName       string   // строка до 32 байт (сериализуется как фиксированные 32 байта)
+	Precision        uint32   // 4 байта - точность DECIMAL(p,s), 0 - без ограничений
+	Scale            uint32   // 4 байта - количество цифр после точки DECIMAL(p,s)
+	Length           uint32   // 4 байта - максимальная длина VARCHAR(n) и длина CHAR(n) в символах, 0 - без ограничений
+
+	Default *DataCell // Значение по умолчанию, nil если его нет. Не входит в COLUMN_INFO_SIZE
 }
 
 // Serialize сериализует ColumnInfo в байты
@@ -135,6 +141,15 @@ func (column *ColumnInfo) Serialize() []byte {
 	copy(data[24:24+copyLength], columnNameBytes)
 	// Остальные байты уже заполнены нулями благодаря make([]byte, COLUMN_INFO_SIZE)
 
+	// Записываем Precision (байты 56-60)
+	binary.BigEndian.PutUint32(data[56:60], column.Precision)
+
+	// Записываем Scale (байты 60-64)
+	binary.BigEndian.PutUint32(data[60:64], column.Scale)
+
+	// Записываем Length (байты 64-68)
+	binary.BigEndian.PutUint32(data[64:68], column.Length)
+
 	return data
 }
 
@@ -150,6 +165,9 @@ func (column *ColumnInfo) Deserialize(data []byte) (*ColumnInfo, error) {
 	isPrimaryKey := binary.BigEndian.Uint32(data[12:16])
 	isAutoIncrement := binary.BigEndian.Uint32(data[16:20])
 	defaultValue := binary.BigEndian.Uint32(data[20:24])
+	precision := binary.BigEndian.Uint32(data[56:60])
+	scale := binary.BigEndian.Uint32(data[60:64])
+	length := binary.BigEndian.Uint32(data[64:68])
 
 	// Читаем ColumnName - только значимые байты до columnNameLength
 	columnNameBytes := data[24 : 24+columnNameLength]
@@ -163,6 +181,9 @@ func (column *ColumnInfo) Deserialize(data []byte) (*ColumnInfo, error) {
 		IsAutoIncrement:  isAutoIncrement,
 		DefaultValue:     defaultValue,
 		ColumnName:       columnName,
+		Precision:        precision,
+		Scale:            scale,
+		Length:           length,
 	}, nil
 }
 
@@ -195,14 +216,21 @@ func createMetaFile(tableName string, columns []ColumnInfo) (*MetaData, error) {
 
 	// Записываем колонки в мета-файл
 	for _, column := range columns {
-		// Устанавливаем ColumnNameLength перед сериализацией
+		// Устанавливаем ColumnNameLength и DefaultValue перед сериализацией
 		column.ColumnNameLength = uint32(len(column.ColumnName))
+		column.DefaultValue = hasDefault(column)
 		_, err = metaFile.Write(column.Serialize())
 		if err != nil {
 			return nil, fmt.Errorf("failed to write column: %w", err)
 		}
 	}
 
+	// Записываем значения по умолчанию после колонок
+	_, err = metaFile.Write(serializeColumnDefaults(columns))
+	if err != nil {
+		return nil, fmt.Errorf("failed to write column defaults: %w", err)
+	}
+
 	return &MetaData{
 		Header:  header,
 		Columns: columns,
@@ -234,7 +262,11 @@ func readMetaFile(tableName string) (*MetaData, error) {
 		return nil, fmt.Errorf("incomplete header read: got %d bytes, expected %d", n, META_FILE_HEADER_SIZE)
 	}
 	// проверяем на magic number
-	if binary.BigEndian.Uint32(headerBytes[0:4]) != META_FILE_MAGIC_NUMBER {
+	switch binary.BigEndian.Uint32(headerBytes[0:4]) {
+	case META_FILE_MAGIC_NUMBER:
+	case PREVIOUS_META_FILE_MAGIC_NUMBER:
+		return nil, fmt.Errorf("unsupported meta format of table %s: the file was written by an older version, recreate the tables directory", tableName)
+	default:
 		return nil, fmt.Errorf("invalid magic number")
 	}
 
@@ -265,6 +297,28 @@ func readMetaFile(tableName string) (*MetaData, error) {
 		columns[i] = *column
 	}
 
+	// Читаем значения по умолчанию: 4 байта длины + данные для каждой колонки с DefaultValue = 1
+	for i := range columns {
+		if columns[i].DefaultValue == 0 {
+			continue
+		}
+
+		lengthBytes := make([]byte, 4)
+		if _, err := io.ReadFull(metaFile, lengthBytes); err != nil {
+			return nil, fmt.Errorf("failed to read default of column %d: %w", i, err)
+		}
+		data := make([]byte, binary.BigEndian.Uint32(lengthBytes))
+		if _, err := io.ReadFull(metaFile, data); err != nil {
+			return nil, fmt.Errorf("failed to read default of column %d: %w", i, err)
+		}
+
+		cell, err := DeserializeDataCell(data, columns[i].DataType, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize default of column %d: %w", i, err)
+		}
+		columns[i].Default = cell
+	}
+
 	return &MetaData{
 		Header:  header,
 		Columns: columns,
@@ -287,13 +341,17 @@ func writeMetaFile(tableName string, metaData *MetaData) (*MetaData, error) {
 
 	// Записываем колонки
 	for _, column := range metaData.Columns {
-		// Устанавливаем ColumnNameLength перед сериализацией
+		// Устанавливаем ColumnNameLength и DefaultValue перед сериализацией
 		column.ColumnNameLength = uint32(len(column.ColumnName))
+		column.DefaultValue = hasDefault(column)
 		data = append(data, column.Serialize()...)
 	}
 
-	// Открываем файл для записи
-	file, err := os.OpenFile(metaFilePath, os.O_WRONLY, 0644)
+	// Записываем значения по умолчанию
+	data = append(data, serializeColumnDefaults(metaData.Columns)...)
+
+	// Открываем файл для записи. После ALTER TABLE ... DROP COLUMN файл становится короче, поэтому он обрезается
+	file, err := os.OpenFile(metaFilePath, os.O_WRONLY|os.O_TRUNC, 0644)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open meta file: %w", err)
 	}
@@ -318,3 +376,47 @@ func deleteMetaFile(tableName string) error {
 	// Удаляем .meta файл в папке tables в корне проекта
 	return os.Remove(metaFilePath)
 }
+
+// hasDefault возвращает значение поля DefaultValue для колонки: 1, если у нее есть значение по умолчанию
+func hasDefault(column ColumnInfo) uint32 {
+	if column.Default != nil {
+		return 1
+	}
+	return 0
+}
+
+// serializeColumnDefaults сериализует значения по умолчанию колонок, у которых они есть:
+// 4 байта длины + данные значения в формате типа колонки
+func serializeColumnDefaults(columns []ColumnInfo) []byte {
+	data := make([]byte, 0)
+	for _, column := range columns {
+		if column.Default == nil {
+			continue
+		}
+
+		value := column.Default.SerializeData()
+		length := make([]byte, 4)
+		binary.BigEndian.PutUint32(length, uint32(len(value)))
+		data = append(data, length...)
+		data = append(data, value...)
+	}
+	return data
+}
+
+// renameMetaFile переименовывает .meta файл и записывает новое имя таблицы в его заголовок
+func renameMetaFile(tableName string, newTableName string) error {
+	metaData, err := readMetaFile(tableName)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(fmt.Sprintf(META_FILE_PATH, tableName), fmt.Sprintf(META_FILE_PATH, newTableName))
+	if err != nil {
+		return fmt.Errorf("failed to rename meta file: %w", err)
+	}
+
+	metaData.Header.TableName = newTableName
+	metaData.Header.TableNameLen = uint32(len(newTableName))
+	_, err = writeMetaFile(newTableName, metaData)
+	return err
+}
@@ -259,6 +259,27 @@ func TestDeserializeColumnInfo(t *testing.T) {
 		require.Nil(t, column)
 		require.Contains(t, err.Error(), "insufficient data for column info")
 	})
+
+	t.Run("4. Column info deserialization keeps type parameters", func(t *testing.T) {
+		// Arrange
+		originalColumn := ColumnInfo{
+			ColumnNameLength: 5,
+			DataType:         DECIMAL_TYPE,
+			IsNullable:       1,
+			ColumnName:       "price",
+			Precision:        10,
+			Scale:            2,
+			Length:           255,
+		}
+		data := originalColumn.Serialize()
+
+		// Act
+		deserializedColumn, err := (&ColumnInfo{}).Deserialize(data)
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, originalColumn, *deserializedColumn)
+	})
 }
 
 func TestCreateMetaFile(t *testing.T) {
@@ -496,6 +517,31 @@ func TestReadMetaFile(t *testing.T) {
 		require.Nil(t, metaData)
 		require.Contains(t, err.Error(), "invalid magic number")
 
+		// Cleanup
+		err = os.Remove(metaFilePath)
+		require.NoError(t, err)
+	})
+	t.Run("4. Read meta file with previous format", func(t *testing.T) {
+		// Arrange
+		tableName := "previous_format_table"
+		metaFilePath := filepath.Join("tables", tableName+".meta")
+
+		err := os.MkdirAll("tables", 0755)
+		require.NoError(t, err)
+
+		// Заголовок мета-файла в прежнем формате с 56-байтными колонками
+		header := newMetaFileHeader(tableName, 1)
+		header.MagicNumber = PREVIOUS_META_FILE_MAGIC_NUMBER
+		err = os.WriteFile(metaFilePath, append(header.Serialize(), make([]byte, 56)...), 0644)
+		require.NoError(t, err)
+
+		// Act
+		metaData, err := readMetaFile(tableName)
+
+		// Assert
+		require.Nil(t, metaData)
+		require.EqualError(t, err, "unsupported meta format of table previous_format_table: the file was written by an older version, recreate the tables directory")
+
 		// Cleanup
 		err = os.Remove(metaFilePath)
 		require.NoError(t, err)
@@ -570,6 +616,49 @@ func TestWriteMetaFile(t *testing.T) {
 		require.Nil(t, writtenMeta)
 		require.Contains(t, err.Error(), "table non_existent_write_table not found")
 	})
+
+	t.Run("3. Write meta file with column defaults", func(t *testing.T) {
+		// Arrange
+		tableName := "write_defaults_table"
+		columns := []ColumnInfo{
+			{ColumnNameLength: 2, ColumnName: "id", DataType: INT_32_TYPE, IsNullable: 1},
+			{ColumnNameLength: 4, ColumnName: "name", DataType: TEXT_TYPE, IsNullable: 1},
+		}
+
+		err := os.MkdirAll("tables", 0755)
+		require.NoError(t, err)
+		defer os.Remove(filepath.Join("tables", tableName+".meta"))
+
+		metaData, err := createMetaFile(tableName, columns)
+		require.NoError(t, err)
+
+		// Задаем значения по умолчанию и удаляем колонку: файл должен стать короче
+		metaData.Columns[1].Default = &DataCell{DataType: TEXT_TYPE, Data: "unknown"}
+		metaData.Columns = append(metaData.Columns, ColumnInfo{
+			ColumnNameLength: 5, ColumnName: "level", DataType: INT_32_TYPE, IsNullable: 0,
+			Default: &DataCell{DataType: INT_32_TYPE, Data: int32(7)},
+		})
+		metaData.Header.ColumnCount = 3
+		_, err = writeMetaFile(tableName, metaData)
+		require.NoError(t, err)
+
+		metaData.Columns = metaData.Columns[1:]
+		metaData.Header.ColumnCount = 2
+
+		// Act
+		_, err = writeMetaFile(tableName, metaData)
+		require.NoError(t, err)
+		readMeta, err := readMetaFile(tableName)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, readMeta.Columns, 2)
+		require.Equal(t, "name", readMeta.Columns[0].ColumnName)
+		require.Equal(t, uint32(1), readMeta.Columns[0].DefaultValue)
+		require.Equal(t, "unknown", readMeta.Columns[0].Default.Data)
+		require.Equal(t, "level", readMeta.Columns[1].ColumnName)
+		require.Equal(t, int32(7), readMeta.Columns[1].Default.Data)
+	})
 }
 
 func TestDeleteMetaFile(t *testing.T) {
@@ -2,7 +2,9 @@ package disk_manager
 
 import (
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"time"
 )
 
 // DataType представляет тип данных колонки
@@ -13,8 +15,83 @@ const (
 	INT_32_TYPE DataType = 1
 	// TEXT_TYPE - строка переменной длины (4 байта длины + данные)
 	TEXT_TYPE DataType = 2
+	// DATE_TYPE - дата без времени (4 байта, количество дней от 1970-01-01)
+	DATE_TYPE DataType = 3
+	// TIMESTAMP_TYPE - дата и время в UTC с точностью до микросекунд (8 байт, микросекунды от 1970-01-01)
+	TIMESTAMP_TYPE DataType = 4
+	// INTERVAL_TYPE - временной интервал (16 байт: 4 байта месяцев + 4 байта дней + 8 байт микросекунд)
+	INTERVAL_TYPE DataType = 5
+	// BOOLEAN_TYPE - логическое значение (1 байт), результат сравнений в выражениях
+	BOOLEAN_TYPE DataType = 6
+	// DECIMAL_TYPE - точное десятичное число с фиксированной точкой (4 байта длины + 2 байта scale + 1 байт знака + модуль числа)
+	DECIMAL_TYPE DataType = 7
+	// VARCHAR_TYPE - строка с ограничением максимальной длины, хранится как TEXT_TYPE
+	VARCHAR_TYPE DataType = 8
+	// CHAR_TYPE - строка фиксированной длины, дополненная пробелами, хранится как TEXT_TYPE
+	CHAR_TYPE DataType = 9
+	// BYTEA_TYPE - двоичные данные переменной длины (4 байта длины + данные)
+	BYTEA_TYPE DataType = 10
+	// JSON_TYPE - JSON документ, хранится как компактный текст (4 байта длины + данные)
+	JSON_TYPE DataType = 11
 )
 
+// Размеры типов данных фиксированной длины в байтах
+const INT_32_TYPE_SIZE = 4
+const BOOLEAN_TYPE_SIZE = 1
+
+// String возвращает SQL название типа данных
+func (dataType DataType) String() string {
+	switch dataType {
+	case INT_32_TYPE:
+		return "INT"
+	case TEXT_TYPE:
+		return "TEXT"
+	case DATE_TYPE:
+		return "DATE"
+	case TIMESTAMP_TYPE:
+		return "TIMESTAMP"
+	case INTERVAL_TYPE:
+		return "INTERVAL"
+	case BOOLEAN_TYPE:
+		return "BOOLEAN"
+	case DECIMAL_TYPE:
+		return "DECIMAL"
+	case VARCHAR_TYPE:
+		return "VARCHAR"
+	case CHAR_TYPE:
+		return "CHAR"
+	case BYTEA_TYPE:
+		return "BYTEA"
+	case JSON_TYPE:
+		return "JSON"
+	default:
+		return fmt.Sprintf("UNKNOWN(%d)", uint32(dataType))
+	}
+}
+
+// IsTextType проверяет, является ли тип строковым (TEXT, VARCHAR, CHAR)
+func IsTextType(dataType DataType) bool {
+	return dataType == TEXT_TYPE || dataType == VARCHAR_TYPE || dataType == CHAR_TYPE
+}
+
+// fixedDataTypeSize возвращает размер типов данных фиксированной длины
+func fixedDataTypeSize(dataType DataType) (uint32, bool) {
+	switch dataType {
+	case INT_32_TYPE:
+		return INT_32_TYPE_SIZE, true
+	case DATE_TYPE:
+		return DATE_TYPE_SIZE, true
+	case TIMESTAMP_TYPE:
+		return TIMESTAMP_TYPE_SIZE, true
+	case INTERVAL_TYPE:
+		return INTERVAL_TYPE_SIZE, true
+	case BOOLEAN_TYPE:
+		return BOOLEAN_TYPE_SIZE, true
+	default:
+		return 0, false
+	}
+}
+
 // Page представляет страницу данных для buffer pool
 type Page struct {
 	Header  PageHeader   // Заголовок страницы
@@ -30,6 +107,7 @@ type FileID struct {
 
 // PageID представляет идентификатор страницы
 type PageID struct {
+	FileID     uint32 // ID файла таблицы, чтобы страницы разных таблиц не пересекались в buffer pool
 	PageNumber uint32 // Номер страницы в файле
 }
 
@@ -75,8 +153,20 @@ func (cell *DataCell) SerializeData() []byte {
 	switch cell.DataType {
 	case INT_32_TYPE:
 		return cell.serializeInt32()
-	case TEXT_TYPE:
+	case TEXT_TYPE, VARCHAR_TYPE, CHAR_TYPE, JSON_TYPE:
 		return cell.serializeText()
+	case DATE_TYPE:
+		return cell.serializeDate()
+	case TIMESTAMP_TYPE:
+		return cell.serializeTimestamp()
+	case INTERVAL_TYPE:
+		return cell.serializeInterval()
+	case BOOLEAN_TYPE:
+		return cell.serializeBoolean()
+	case DECIMAL_TYPE:
+		return cell.serializeDecimal()
+	case BYTEA_TYPE:
+		return cell.serializeBytea()
 	default:
 		return []byte{}
 	}
@@ -98,6 +188,23 @@ func (cell *DataCell) deserializeInt32(data []byte) (*DataCell, error) {
 	return cell, nil
 }
 
+// serializeBoolean сериализует логическое значение в 1 байт
+func (cell *DataCell) serializeBoolean() []byte {
+	if cell.Data.(bool) {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+// deserializeBoolean десериализует логическое значение из байтов
+func (cell *DataCell) deserializeBoolean(data []byte) (*DataCell, error) {
+	if len(data) < 1 {
+		return nil, fmt.Errorf("insufficient data for BOOLEAN_TYPE: need 1 byte, got %d", len(data))
+	}
+	cell.Data = data[0] != 0
+	return cell, nil
+}
+
 // serializeText сериализует строку: 4 байта длины + данные
 func (cell *DataCell) serializeText() []byte {
 	str := cell.Data.(string)
@@ -126,6 +233,33 @@ func (cell *DataCell) deserializeText(data []byte) (*DataCell, error) {
 	return cell, nil
 }
 
+// serializeBytea сериализует двоичные данные: 4 байта длины + данные
+func (cell *DataCell) serializeBytea() []byte {
+	bytes := cell.Data.([]byte)
+	length := uint32(len(bytes))
+
+	data := make([]byte, 4+length)
+	binary.BigEndian.PutUint32(data[0:4], length)
+	copy(data[4:], bytes)
+	return data
+}
+
+// deserializeBytea десериализует двоичные данные из байтов
+func (cell *DataCell) deserializeBytea(data []byte) (*DataCell, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("insufficient data for BYTEA_TYPE: need at least 4 bytes for length, got %d", len(data))
+	}
+
+	length := binary.BigEndian.Uint32(data[0:4])
+	if len(data) < int(4+length) {
+		return nil, fmt.Errorf("insufficient data for BYTEA_TYPE: need %d bytes, got %d", 4+length, len(data))
+	}
+
+	// Копируем данные, чтобы ячейка не ссылалась на буфер страницы
+	cell.Data = append([]byte{}, data[4:4+length]...)
+	return cell, nil
+}
+
 // GetSize возвращает размер данных ячейки в байтах
 func (cell *DataCell) GetSize() uint32 {
 	if cell.IsNull {
@@ -135,8 +269,15 @@ func (cell *DataCell) GetSize() uint32 {
 	switch cell.DataType {
 	case INT_32_TYPE:
 		return 4
-	case TEXT_TYPE:
+	case TEXT_TYPE, VARCHAR_TYPE, CHAR_TYPE, JSON_TYPE:
 		return 4 + uint32(len(cell.Data.(string)))
+	case DATE_TYPE, TIMESTAMP_TYPE, INTERVAL_TYPE, BOOLEAN_TYPE:
+		size, _ := fixedDataTypeSize(cell.DataType)
+		return size
+	case DECIMAL_TYPE:
+		return decimalSize(cell.Data.(Decimal))
+	case BYTEA_TYPE:
+		return 4 + uint32(len(cell.Data.([]byte)))
 	default:
 		return 0
 	}
@@ -157,13 +298,57 @@ func DeserializeDataCell(data []byte, dataType DataType, isNull bool) (*DataCell
 	switch dataType {
 	case INT_32_TYPE:
 		return cell.deserializeInt32(data)
-	case TEXT_TYPE:
+	case TEXT_TYPE, VARCHAR_TYPE, CHAR_TYPE, JSON_TYPE:
 		return cell.deserializeText(data)
+	case DATE_TYPE:
+		return cell.deserializeDate(data)
+	case TIMESTAMP_TYPE:
+		return cell.deserializeTimestamp(data)
+	case INTERVAL_TYPE:
+		return cell.deserializeInterval(data)
+	case BOOLEAN_TYPE:
+		return cell.deserializeBoolean(data)
+	case DECIMAL_TYPE:
+		return cell.deserializeDecimal(data)
+	case BYTEA_TYPE:
+		return cell.deserializeBytea(data)
 	default:
 		return nil, fmt.Errorf("unsupported data type: %d", dataType)
 	}
 }
 
+// String возвращает текстовое представление ячейки для вывода пользователю
+func (cell *DataCell) String() string {
+	if cell.IsNull {
+		return "null"
+	}
+
+	switch cell.DataType {
+	case INT_32_TYPE:
+		return fmt.Sprintf("%d", cell.Data.(int32))
+	case TEXT_TYPE, VARCHAR_TYPE, CHAR_TYPE, JSON_TYPE:
+		return cell.Data.(string)
+	case DATE_TYPE:
+		return FormatDate(cell.Data.(time.Time))
+	case TIMESTAMP_TYPE:
+		return FormatTimestamp(cell.Data.(time.Time))
+	case INTERVAL_TYPE:
+		return cell.Data.(Interval).String()
+	case BOOLEAN_TYPE:
+		if cell.Data.(bool) {
+			return "true"
+		}
+		return "false"
+	case DECIMAL_TYPE:
+		return cell.Data.(Decimal).String()
+	case BYTEA_TYPE:
+		// Двоичные данные выводятся в hex формате, чтобы вывод был безопасным для терминала
+		return `\x` + hex.EncodeToString(cell.Data.([]byte))
+	default:
+		return fmt.Sprintf("%v", cell.Data)
+	}
+}
+
 // ConvertRawTupleToRow конвертирует RawTuple в Row
 func ConvertRawTupleToRow(rawTuple RawTuple, columns []ColumnInfo) (Row, error) {
 	row := make(Row, 0, len(columns))
@@ -195,14 +380,19 @@ func ConvertRawTupleToRow(rawTuple RawTuple, columns []ColumnInfo) (Row, error)
 			switch column.DataType {
 			case INT_32_TYPE:
 				cellDataSize = 4
-			case TEXT_TYPE:
+			case TEXT_TYPE, VARCHAR_TYPE, CHAR_TYPE, DECIMAL_TYPE, BYTEA_TYPE, JSON_TYPE:
+				// Типы переменной длины начинаются с 4 байт длины
 				if dataOffset+4 > uint32(len(rawTuple.Data)) {
-					return nil, fmt.Errorf("insufficient data for TEXT_TYPE length field at offset %d", dataOffset)
+					return nil, fmt.Errorf("insufficient data for %s length field at offset %d", column.DataType, dataOffset)
 				}
-				textLength := binary.BigEndian.Uint32(rawTuple.Data[dataOffset : dataOffset+4])
-				cellDataSize = 4 + textLength
+				dataLength := binary.BigEndian.Uint32(rawTuple.Data[dataOffset : dataOffset+4])
+				cellDataSize = 4 + dataLength
 			default:
-				return nil, fmt.Errorf("unsupported data type: %d", column.DataType)
+				size, ok := fixedDataTypeSize(column.DataType)
+				if !ok {
+					return nil, fmt.Errorf("unsupported data type: %d", column.DataType)
+				}
+				cellDataSize = size
 			}
 
 			// Проверяем, что у нас достаточно данных
@@ -844,3 +844,43 @@ func TestCalculateNullBitmapSize(t *testing.T) {
 		})
 	}
 }
+
+func TestByteaDataCell(t *testing.T) {
+	t.Run("1. BYTEA round trip keeps zero bytes", func(t *testing.T) {
+		// Arrange
+		payload := []byte{0x00, 0xde, 0xad, 0x00, 0xbe, 0xef}
+		cell := &DataCell{DataType: BYTEA_TYPE, Data: payload}
+
+		// Act
+		data := cell.SerializeData()
+		result, err := DeserializeDataCell(data, BYTEA_TYPE, false)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, data, int(cell.GetSize()))
+		require.Equal(t, uint32(len(payload)), binary.BigEndian.Uint32(data[0:4]))
+		require.Equal(t, payload, result.Data)
+		require.Equal(t, `\x00dead00beef`, result.String())
+	})
+
+	t.Run("2. Row with BYTEA converts to raw tuple and back", func(t *testing.T) {
+		// Arrange
+		columns := []ColumnInfo{
+			{ColumnName: "hash", DataType: BYTEA_TYPE},
+			{ColumnName: "id", DataType: INT_32_TYPE},
+		}
+		row := Row{
+			{DataType: BYTEA_TYPE, Data: []byte{}},
+			{DataType: INT_32_TYPE, Data: int32(7)},
+		}
+
+		// Act
+		rawTuple := ConvertRowToRawTuple(row)
+		result, err := ConvertRawTupleToRow(*rawTuple, columns)
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, []byte{}, result[0].Data)
+		require.Equal(t, int32(7), result[1].Data)
+	})
+}
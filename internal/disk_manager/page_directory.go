@@ -228,3 +228,15 @@ func deletePageDirectory(tableName string) error {
 	// Удаляем .dir файл
 	return os.Remove(dirFilePath)
 }
+
+// renamePageDirectory переименовывает .dir файл таблицы
+func renamePageDirectory(tableName string, newTableName string) error {
+	dirFilePath := fmt.Sprintf(PAGE_DIRECTORY_FILE_PATH, tableName)
+
+	// Проверяем, существует ли page directory файл
+	if _, err := os.Stat(dirFilePath); err != nil {
+		return fmt.Errorf("page directory for table %s not found", tableName)
+	}
+
+	return os.Rename(dirFilePath, fmt.Sprintf(PAGE_DIRECTORY_FILE_PATH, newTableName))
+}
@@ -290,3 +290,32 @@ func deleteTableInList(tableName string) (*TablesList, error) {
 
 	return tablesList, nil
 }
+
+// renameTableInList обновляет список таблиц после переименования таблицы, FileID таблицы не меняется
+func renameTableInList(tableName string, newTableName string) (*TablesList, error) {
+	// Пытаемся прочитать существующий список таблиц
+	tablesList, err := readTableListFile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tables list: %w", err)
+	}
+
+	fileID, exists := tablesList.Tables[tableName]
+	if !exists {
+		return nil, fmt.Errorf("table %s not found", tableName)
+	}
+	if len(newTableName) > TABLE_NAME_MAX_LENGTH {
+		return nil, fmt.Errorf("table name too long: %d bytes, maximum %d", len(newTableName), TABLE_NAME_MAX_LENGTH)
+	}
+
+	// Переносим FileID на новое имя
+	delete(tablesList.Tables, tableName)
+	tablesList.Tables[newTableName] = fileID
+
+	// Записываем обновленный список
+	tablesList, err = writeTablesListFile(tablesList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write tables list: %w", err)
+	}
+
+	return tablesList, nil
+}
@@ -592,3 +592,50 @@ func TestDeleteTableInList(t *testing.T) {
 		os.RemoveAll("tables")
 	})
 }
+
+func TestRenameTableInList(t *testing.T) {
+	t.Run("1. Rename table in list success", func(t *testing.T) {
+		// Arrange
+		defer func() {
+			os.RemoveAll("tables")
+		}()
+
+		_, err := createTableListFile()
+		require.NoError(t, err)
+
+		tablesList, err := addTableInList("old_name")
+		require.NoError(t, err)
+		fileID := tablesList.Tables["old_name"]
+
+		// Act
+		tablesList, err = renameTableInList("old_name", "new_name")
+
+		// Assert
+		require.NoError(t, err)
+		require.NotContains(t, tablesList.Tables, "old_name")
+		require.Equal(t, fileID, tablesList.Tables["new_name"])
+
+		// Проверяем, что изменения записались в файл
+		readList, err := readTableListFile()
+		require.NoError(t, err)
+		require.Equal(t, fileID, readList.Tables["new_name"])
+	})
+
+	t.Run("2. Rename table that is not in list", func(t *testing.T) {
+		// Arrange
+		defer func() {
+			os.RemoveAll("tables")
+		}()
+
+		_, err := createTableListFile()
+		require.NoError(t, err)
+
+		// Act
+		tablesList, err := renameTableInList("nonexistent_table", "new_name")
+
+		// Assert
+		require.Error(t, err)
+		require.Nil(t, tablesList)
+		require.Contains(t, err.Error(), "table nonexistent_table not found")
+	})
+}
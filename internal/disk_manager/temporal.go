@@ -0,0 +1,431 @@
+package disk_manager
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Размеры временных типов в байтах
+const DATE_TYPE_SIZE = 4
+const TIMESTAMP_TYPE_SIZE = 8
+const INTERVAL_TYPE_SIZE = 16
+
+const MICROSECONDS_PER_SECOND = 1_000_000
+const MICROSECONDS_PER_DAY = 24 * 60 * 60 * MICROSECONDS_PER_SECOND
+
+// Interval представляет временной интервал.
+// Месяцы и дни хранятся отдельно от микросекунд, так как их длительность
+// зависит от даты, к которой прибавляется интервал (28-31 день в месяце)
+type Interval struct {
+	Months       int32 // Количество месяцев (годы хранятся как 12 месяцев)
+	Days         int32 // Количество дней
+	Microseconds int64 // Время внутри дня в микросекундах
+}
+
+// serializeDate сериализует дату в 4 байта (количество дней от 1970-01-01)
+func (cell *DataCell) serializeDate() []byte {
+	data := make([]byte, DATE_TYPE_SIZE)
+	binary.BigEndian.PutUint32(data, uint32(DateToDays(cell.Data.(time.Time))))
+	return data
+}
+
+// deserializeDate десериализует дату из байтов
+func (cell *DataCell) deserializeDate(data []byte) (*DataCell, error) {
+	if len(data) < DATE_TYPE_SIZE {
+		return nil, fmt.Errorf("insufficient data for DATE_TYPE: need %d bytes, got %d", DATE_TYPE_SIZE, len(data))
+	}
+	cell.Data = DaysToDate(int32(binary.BigEndian.Uint32(data[0:DATE_TYPE_SIZE])))
+	return cell, nil
+}
+
+// serializeTimestamp сериализует timestamp в 8 байт (микросекунды от 1970-01-01 00:00:00 UTC)
+func (cell *DataCell) serializeTimestamp() []byte {
+	data := make([]byte, TIMESTAMP_TYPE_SIZE)
+	binary.BigEndian.PutUint64(data, uint64(cell.Data.(time.Time).UnixMicro()))
+	return data
+}
+
+// deserializeTimestamp десериализует timestamp из байтов
+func (cell *DataCell) deserializeTimestamp(data []byte) (*DataCell, error) {
+	if len(data) < TIMESTAMP_TYPE_SIZE {
+		return nil, fmt.Errorf("insufficient data for TIMESTAMP_TYPE: need %d bytes, got %d", TIMESTAMP_TYPE_SIZE, len(data))
+	}
+	cell.Data = time.UnixMicro(int64(binary.BigEndian.Uint64(data[0:TIMESTAMP_TYPE_SIZE]))).UTC()
+	return cell, nil
+}
+
+// serializeInterval сериализует интервал в 16 байт: месяцы (4) + дни (4) + микросекунды (8)
+func (cell *DataCell) serializeInterval() []byte {
+	interval := cell.Data.(Interval)
+
+	data := make([]byte, INTERVAL_TYPE_SIZE)
+	binary.BigEndian.PutUint32(data[0:4], uint32(interval.Months))
+	binary.BigEndian.PutUint32(data[4:8], uint32(interval.Days))
+	binary.BigEndian.PutUint64(data[8:16], uint64(interval.Microseconds))
+	return data
+}
+
+// deserializeInterval десериализует интервал из байтов
+func (cell *DataCell) deserializeInterval(data []byte) (*DataCell, error) {
+	if len(data) < INTERVAL_TYPE_SIZE {
+		return nil, fmt.Errorf("insufficient data for INTERVAL_TYPE: need %d bytes, got %d", INTERVAL_TYPE_SIZE, len(data))
+	}
+	cell.Data = Interval{
+		Months:       int32(binary.BigEndian.Uint32(data[0:4])),
+		Days:         int32(binary.BigEndian.Uint32(data[4:8])),
+		Microseconds: int64(binary.BigEndian.Uint64(data[8:16])),
+	}
+	return cell, nil
+}
+
+// DateToDays переводит дату в количество дней от 1970-01-01
+func DateToDays(date time.Time) int32 {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return int32(date.Unix() / (24 * 60 * 60))
+}
+
+// DaysToDate переводит количество дней от 1970-01-01 в дату
+func DaysToDate(days int32) time.Time {
+	return time.Unix(int64(days)*24*60*60, 0).UTC()
+}
+
+// Форматы, в которых принимаются даты и timestamp'ы (подмножество ISO-8601)
+var dateLayouts = []string{
+	"2006-01-02",
+}
+
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseDate парсит дату в формате ISO-8601 (YYYY-MM-DD)
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date.UTC(), nil
+		}
+	}
+
+	// Допускаем timestamp, отбрасывая время
+	timestamp, err := ParseTimestamp(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date: %q", value)
+	}
+	return time.Date(timestamp.Year(), timestamp.Month(), timestamp.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// ParseTimestamp парсит timestamp в формате ISO-8601 и приводит его к UTC.
+// Точность ограничивается микросекундами
+func ParseTimestamp(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range timestampLayouts {
+		if timestamp, err := time.Parse(layout, value); err == nil {
+			return timestamp.UTC().Truncate(time.Microsecond), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid timestamp: %q", value)
+}
+
+// FormatDate форматирует дату в формате YYYY-MM-DD
+func FormatDate(date time.Time) string {
+	return date.UTC().Format("2006-01-02")
+}
+
+// FormatTimestamp форматирует timestamp в формате YYYY-MM-DD HH:MM:SS[.ffffff]
+func FormatTimestamp(timestamp time.Time) string {
+	return timestamp.UTC().Format("2006-01-02 15:04:05.999999")
+}
+
+// intervalUnits единицы измерения, допустимые в текстовой записи интервала
+var intervalUnits = map[string]Interval{
+	"microsecond": {Microseconds: 1},
+	"millisecond": {Microseconds: 1000},
+	"second":      {Microseconds: MICROSECONDS_PER_SECOND},
+	"sec":         {Microseconds: MICROSECONDS_PER_SECOND},
+	"minute":      {Microseconds: 60 * MICROSECONDS_PER_SECOND},
+	"min":         {Microseconds: 60 * MICROSECONDS_PER_SECOND},
+	"hour":        {Microseconds: 60 * 60 * MICROSECONDS_PER_SECOND},
+	"day":         {Days: 1},
+	"week":        {Days: 7},
+	"month":       {Months: 1},
+	"mon":         {Months: 1},
+	"year":        {Months: 12},
+}
+
+// ParseInterval парсит интервал.
+// Поддерживаются запись вида '1 year 2 months 3 days 04:05:06'
+// и ISO-8601 запись вида 'P1Y2M3DT4H5M6S'
+func ParseInterval(value string) (Interval, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if value == "" {
+		return Interval{}, fmt.Errorf("invalid interval: %q", value)
+	}
+
+	if strings.HasPrefix(value, "p") {
+		return parseISOInterval(value)
+	}
+
+	result := Interval{}
+	fields := strings.Fields(value)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+
+		// Время в формате [-]HH:MM[:SS[.ffffff]]
+		if strings.Contains(field, ":") {
+			microseconds, err := parseIntervalTime(field)
+			if err != nil {
+				return Interval{}, fmt.Errorf("invalid interval: %q", value)
+			}
+			result.Microseconds += microseconds
+			continue
+		}
+
+		// Число с единицей измерения: '3 days' или '3days'
+		numberEnd := 0
+		for numberEnd < len(field) && (field[numberEnd] == '-' || field[numberEnd] == '+' || field[numberEnd] == '.' || (field[numberEnd] >= '0' && field[numberEnd] <= '9')) {
+			numberEnd++
+		}
+		if numberEnd == 0 {
+			return Interval{}, fmt.Errorf("invalid interval: %q", value)
+		}
+		amount, err := strconv.ParseFloat(field[:numberEnd], 64)
+		if err != nil {
+			return Interval{}, fmt.Errorf("invalid interval: %q", value)
+		}
+
+		unitName := field[numberEnd:]
+		if unitName == "" {
+			if i+1 >= len(fields) {
+				return Interval{}, fmt.Errorf("invalid interval: %q, missing unit", value)
+			}
+			i++
+			unitName = fields[i]
+		}
+
+		unit, ok := intervalUnits[strings.TrimSuffix(unitName, "s")]
+		if !ok {
+			return Interval{}, fmt.Errorf("invalid interval unit: %q", unitName)
+		}
+
+		result = result.Add(unit.Multiply(amount))
+	}
+
+	return result, nil
+}
+
+// parseISOInterval парсит интервал в формате ISO-8601: P[nY][nM][nW][nD][T[nH][nM][nS]]
+func parseISOInterval(value string) (Interval, error) {
+	result := Interval{}
+	inTimePart := false
+	number := ""
+
+	for _, char := range value[1:] {
+		switch {
+		case char == 't':
+			inTimePart = true
+		case (char >= '0' && char <= '9') || char == '.' || char == '-':
+			number += string(char)
+		default:
+			amount, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return Interval{}, fmt.Errorf("invalid interval: %q", value)
+			}
+			number = ""
+
+			var unit Interval
+			switch {
+			case char == 'y' && !inTimePart:
+				unit = intervalUnits["year"]
+			case char == 'm' && !inTimePart:
+				unit = intervalUnits["month"]
+			case char == 'w' && !inTimePart:
+				unit = intervalUnits["week"]
+			case char == 'd' && !inTimePart:
+				unit = intervalUnits["day"]
+			case char == 'h' && inTimePart:
+				unit = intervalUnits["hour"]
+			case char == 'm' && inTimePart:
+				unit = intervalUnits["minute"]
+			case char == 's' && inTimePart:
+				unit = intervalUnits["second"]
+			default:
+				return Interval{}, fmt.Errorf("invalid interval: %q", value)
+			}
+			result = result.Add(unit.Multiply(amount))
+		}
+	}
+
+	if number != "" {
+		return Interval{}, fmt.Errorf("invalid interval: %q", value)
+	}
+
+	return result, nil
+}
+
+// parseIntervalTime парсит время интервала в формате [-]HH:MM[:SS[.ffffff]] в микросекунды
+func parseIntervalTime(value string) (int64, error) {
+	sign := int64(1)
+	if strings.HasPrefix(value, "-") {
+		sign = -1
+		value = value[1:]
+	}
+
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid time: %q", value)
+	}
+
+	hours, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	minutes, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	seconds := 0.0
+	if len(parts) == 3 {
+		seconds, err = strconv.ParseFloat(parts[2], 64)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	microseconds := (hours*60+minutes)*60*MICROSECONDS_PER_SECOND + int64(seconds*MICROSECONDS_PER_SECOND)
+	return sign * microseconds, nil
+}
+
+// Add складывает два интервала
+func (interval Interval) Add(other Interval) Interval {
+	return Interval{
+		Months:       interval.Months + other.Months,
+		Days:         interval.Days + other.Days,
+		Microseconds: interval.Microseconds + other.Microseconds,
+	}
+}
+
+// Negate возвращает интервал с противоположным знаком
+func (interval Interval) Negate() Interval {
+	return Interval{
+		Months:       -interval.Months,
+		Days:         -interval.Days,
+		Microseconds: -interval.Microseconds,
+	}
+}
+
+// Multiply умножает интервал на число, дробные месяцы и дни переносятся в младшие единицы
+func (interval Interval) Multiply(factor float64) Interval {
+	months := float64(interval.Months) * factor
+	wholeMonths := int32(months)
+
+	days := float64(interval.Days)*factor + (months-float64(wholeMonths))*30
+	wholeDays := int32(days)
+
+	microseconds := float64(interval.Microseconds)*factor + (days-float64(wholeDays))*MICROSECONDS_PER_DAY
+
+	return Interval{
+		Months:       wholeMonths,
+		Days:         wholeDays,
+		Microseconds: int64(microseconds),
+	}
+}
+
+// AddTo прибавляет интервал к timestamp'у: сначала месяцы, затем дни, затем время.
+// Если в итоговом месяце нет такого дня, берется последний день месяца (2024-01-31 + 1 month = 2024-02-29)
+func (interval Interval) AddTo(timestamp time.Time) time.Time {
+	year, month, day := timestamp.Date()
+	hour, minute, second := timestamp.Clock()
+
+	// time.Date нормализует месяц вне диапазона 1..12, поэтому первое число итогового месяца считается без переполнения
+	firstOfMonth := time.Date(year, month+time.Month(interval.Months), 1, 0, 0, 0, 0, timestamp.Location())
+	if lastDay := daysInMonth(firstOfMonth.Year(), firstOfMonth.Month()); day > lastDay {
+		day = lastDay
+	}
+
+	result := time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, hour, minute, second, timestamp.Nanosecond(), timestamp.Location())
+	return result.AddDate(0, 0, int(interval.Days)).
+		Add(time.Duration(interval.Microseconds) * time.Microsecond)
+}
+
+// daysInMonth возвращает количество дней в месяце
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Compare сравнивает интервалы, приводя месяцы к 30 дням, а дни к 24 часам
+func (interval Interval) Compare(other Interval) int {
+	left := interval.approximateMicroseconds()
+	right := other.approximateMicroseconds()
+	switch {
+	case left < right:
+		return -1
+	case left > right:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (interval Interval) approximateMicroseconds() int64 {
+	return (int64(interval.Months)*30+int64(interval.Days))*MICROSECONDS_PER_DAY + interval.Microseconds
+}
+
+// Normalize приводит интервал к микросекундам по правилам Compare, поэтому равные интервалы
+// ('1 day' и '24 hours') после нормализации совпадают
+func (interval Interval) Normalize() Interval {
+	return Interval{Microseconds: interval.approximateMicroseconds()}
+}
+
+// String форматирует интервал в виде '1 year 2 mons 3 days 04:05:06'
+func (interval Interval) String() string {
+	parts := []string{}
+
+	years := interval.Months / 12
+	months := interval.Months % 12
+	if years != 0 {
+		parts = append(parts, pluralizeIntervalUnit(int64(years), "year", "years"))
+	}
+	if months != 0 {
+		parts = append(parts, pluralizeIntervalUnit(int64(months), "mon", "mons"))
+	}
+	if interval.Days != 0 {
+		parts = append(parts, pluralizeIntervalUnit(int64(interval.Days), "day", "days"))
+	}
+
+	if interval.Microseconds != 0 || len(parts) == 0 {
+		microseconds := interval.Microseconds
+		sign := ""
+		if microseconds < 0 {
+			sign = "-"
+			microseconds = -microseconds
+		}
+
+		seconds := microseconds / MICROSECONDS_PER_SECOND
+		fraction := microseconds % MICROSECONDS_PER_SECOND
+		timePart := fmt.Sprintf("%s%02d:%02d:%02d", sign, seconds/3600, (seconds/60)%60, seconds%60)
+		if fraction != 0 {
+			timePart += strings.TrimRight(fmt.Sprintf(".%06d", fraction), "0")
+		}
+		parts = append(parts, timePart)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func pluralizeIntervalUnit(amount int64, singular, plural string) string {
+	if amount == 1 || amount == -1 {
+		return fmt.Sprintf("%d %s", amount, singular)
+	}
+	return fmt.Sprintf("%d %s", amount, plural)
+}
@@ -0,0 +1,181 @@
+package disk_manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemporalSerialization(t *testing.T) {
+	t.Run("1. DATE round trip", func(t *testing.T) {
+		// Arrange
+		date := time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)
+		cell := &DataCell{DataType: DATE_TYPE, Data: date}
+
+		// Act
+		data := cell.SerializeData()
+		result, err := DeserializeDataCell(data, DATE_TYPE, false)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, data, DATE_TYPE_SIZE)
+		require.Equal(t, date, result.Data)
+	})
+
+	t.Run("2. TIMESTAMP round trip keeps microseconds", func(t *testing.T) {
+		// Arrange
+		timestamp := time.Date(1969, time.December, 31, 23, 59, 59, 123456000, time.UTC)
+		cell := &DataCell{DataType: TIMESTAMP_TYPE, Data: timestamp}
+
+		// Act
+		data := cell.SerializeData()
+		result, err := DeserializeDataCell(data, TIMESTAMP_TYPE, false)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, data, TIMESTAMP_TYPE_SIZE)
+		require.True(t, timestamp.Equal(result.Data.(time.Time)))
+	})
+
+	t.Run("3. INTERVAL round trip with negative parts", func(t *testing.T) {
+		// Arrange
+		interval := Interval{Months: -14, Days: 3, Microseconds: -5 * MICROSECONDS_PER_SECOND}
+		cell := &DataCell{DataType: INTERVAL_TYPE, Data: interval}
+
+		// Act
+		data := cell.SerializeData()
+		result, err := DeserializeDataCell(data, INTERVAL_TYPE, false)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, data, INTERVAL_TYPE_SIZE)
+		require.Equal(t, interval, result.Data)
+	})
+
+	t.Run("4. Row with temporal cells converts to raw tuple and back", func(t *testing.T) {
+		// Arrange
+		columns := []ColumnInfo{
+			{ColumnName: "d", DataType: DATE_TYPE},
+			{ColumnName: "ts", DataType: TIMESTAMP_TYPE},
+			{ColumnName: "i", DataType: INTERVAL_TYPE},
+		}
+		row := Row{
+			{DataType: DATE_TYPE, Data: time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)},
+			{DataType: TIMESTAMP_TYPE, IsNull: true},
+			{DataType: INTERVAL_TYPE, Data: Interval{Days: 1}},
+		}
+
+		// Act
+		rawTuple := ConvertRowToRawTuple(row)
+		result, err := ConvertRawTupleToRow(*rawTuple, columns)
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, row[0].Data, result[0].Data)
+		require.True(t, result[1].IsNull)
+		require.Equal(t, row[2].Data, result[2].Data)
+		require.Equal(t, row.GetSize(), rawTuple.Length)
+	})
+}
+
+func TestParseTemporal(t *testing.T) {
+	t.Run("1. Parse ISO-8601 date and timestamp", func(t *testing.T) {
+		// Act
+		date, dateErr := ParseDate("2024-01-31")
+		timestamp, timestampErr := ParseTimestamp("2024-01-31T10:20:30.5+03:00")
+
+		// Assert
+		require.NoError(t, dateErr)
+		require.NoError(t, timestampErr)
+		require.Equal(t, "2024-01-31", FormatDate(date))
+		require.Equal(t, "2024-01-31 07:20:30.5", FormatTimestamp(timestamp))
+	})
+
+	t.Run("2. Parse invalid date", func(t *testing.T) {
+		// Act
+		_, err := ParseDate("2024-02-30")
+
+		// Assert
+		require.Error(t, err)
+	})
+
+	t.Run("3. Parse intervals", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected Interval
+			output   string
+		}{
+			{"1 year 2 mons 3 days 04:05:06", Interval{Months: 14, Days: 3, Microseconds: 14706 * MICROSECONDS_PER_SECOND}, "1 year 2 mons 3 days 04:05:06"},
+			{"2 hours", Interval{Microseconds: 2 * 3600 * MICROSECONDS_PER_SECOND}, "02:00:00"},
+			{"P1Y2M3DT4H5M6S", Interval{Months: 14, Days: 3, Microseconds: 14706 * MICROSECONDS_PER_SECOND}, "1 year 2 mons 3 days 04:05:06"},
+			{"1 week", Interval{Days: 7}, "7 days"},
+		}
+
+		for _, testCase := range testCases {
+			// Act
+			interval, err := ParseInterval(testCase.input)
+
+			// Assert
+			require.NoError(t, err, testCase.input)
+			require.Equal(t, testCase.expected, interval, testCase.input)
+			require.Equal(t, testCase.output, interval.String(), testCase.input)
+		}
+	})
+
+	t.Run("4. Interval added to timestamp", func(t *testing.T) {
+		// Arrange
+		timestamp := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+		interval := Interval{Months: 1, Days: 1, Microseconds: MICROSECONDS_PER_SECOND}
+
+		// Act
+		result := interval.AddTo(timestamp)
+
+		// Assert
+		require.Equal(t, "2024-03-01 00:00:01", FormatTimestamp(result))
+	})
+
+	t.Run("5. Adding months clamps to the end of month", func(t *testing.T) {
+		// Arrange
+		testCases := []struct {
+			timestamp time.Time
+			interval  Interval
+			expected  string
+		}{
+			{time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC), Interval{Months: 1}, "2024-02-29 00:00:00"},
+			{time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC), Interval{Months: -1}, "2024-02-29 00:00:00"},
+			{time.Date(2023, time.January, 31, 12, 30, 0, 0, time.UTC), Interval{Months: 1}, "2023-02-28 12:30:00"},
+			{time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), Interval{Months: 12}, "2025-02-28 00:00:00"},
+			{time.Date(2024, time.May, 31, 0, 0, 0, 0, time.UTC), Interval{Months: -15}, "2023-02-28 00:00:00"},
+			{time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC), Interval{Months: 1, Days: -1}, "2024-02-28 00:00:00"},
+			{time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC), Interval{Months: 1}, "2024-02-15 00:00:00"},
+		}
+
+		for _, testCase := range testCases {
+			// Act
+			result := testCase.interval.AddTo(testCase.timestamp)
+
+			// Assert
+			require.Equal(t, testCase.expected, FormatTimestamp(result), testCase.interval.String())
+		}
+	})
+
+	t.Run("6. Equal intervals have the same normalized form", func(t *testing.T) {
+		// Arrange
+		day, err := ParseInterval("1 day")
+		require.NoError(t, err)
+		hours, err := ParseInterval("24 hours")
+		require.NoError(t, err)
+		month, err := ParseInterval("1 mon")
+		require.NoError(t, err)
+
+		// Act
+		compared := day.Compare(hours)
+
+		// Assert
+		require.Equal(t, 0, compared)
+		require.Equal(t, day.Normalize(), hours.Normalize())
+		require.Equal(t, Interval{Days: 30}.Normalize(), month.Normalize())
+		require.NotEqual(t, day.Normalize(), month.Normalize())
+	})
+}
@@ -0,0 +1,315 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/functions"
+	"custom-database/internal/parser/ast"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// AVG_MIN_SCALE минимальный scale результата avg для точных чисел, как в PostgreSQL
+const AVG_MIN_SCALE = 16
+
+// aggregateState накопленное состояние агрегатной функции для одной группы
+type aggregateState interface {
+	// add учитывает очередное не NULL значение
+	add(cell disk_manager.DataCell) error
+	// result возвращает значение агрегатной функции
+	result() (disk_manager.DataCell, error)
+	// size возвращает примерный объем памяти, занятый состоянием
+	size() int
+}
+
+// aggregateFunction описание агрегатной функции
+type aggregateFunction struct {
+	// returnType проверяет тип аргумента и возвращает тип результата
+	returnType func(argumentType disk_manager.DataType) (disk_manager.DataType, error)
+	// newState создает пустое состояние для новой группы
+	newState func(resultType disk_manager.DataType) aggregateState
+}
+
+// aggregateFunctions реестр агрегатных функций
+var aggregateFunctions = map[string]aggregateFunction{
+	"count": {
+		returnType: func(disk_manager.DataType) (disk_manager.DataType, error) {
+			return disk_manager.INT_32_TYPE, nil
+		},
+		newState: func(disk_manager.DataType) aggregateState {
+			return &countState{}
+		},
+	},
+	"sum": {
+		returnType: sumResultType("sum"),
+		newState: func(resultType disk_manager.DataType) aggregateState {
+			return &sumState{resultType: resultType}
+		},
+	},
+	"avg": {
+		returnType: sumResultType("avg"),
+		newState: func(resultType disk_manager.DataType) aggregateState {
+			return &sumState{resultType: resultType, average: true}
+		},
+	},
+	"min": {
+		returnType: minMaxResultType("min"),
+		newState: func(resultType disk_manager.DataType) aggregateState {
+			return &minMaxState{resultType: resultType}
+		},
+	},
+	"max": {
+		returnType: minMaxResultType("max"),
+		newState: func(resultType disk_manager.DataType) aggregateState {
+			return &minMaxState{resultType: resultType, max: true}
+		},
+	},
+}
+
+// lookupAggregate возвращает встроенную или зарегистрированную пользователем агрегатную функцию по имени
+func (e *executor) lookupAggregate(name string) (aggregateFunction, error) {
+	if function, ok := aggregateFunctions[strings.ToLower(name)]; ok {
+		return function, nil
+	}
+
+	function, ok := e.functions.Lookup(name)
+	if !ok || function.Kind != functions.AggregateKind || function.Aggregate == nil {
+		return aggregateFunction{}, fmt.Errorf("aggregate function %s does not exist", name)
+	}
+	return userAggregateFunction(function), nil
+}
+
+// aggregateResultType выводит тип результата агрегатной функции по типам колонок входа
+func (e *executor) aggregateResultType(aggregate *ast.AggregateExpression, columns []ResultColumn) (disk_manager.DataType, error) {
+	function, err := e.lookupAggregate(aggregate.Name.Value)
+	if err != nil {
+		return unknownType, err
+	}
+
+	argumentType := disk_manager.INT_32_TYPE
+	if !aggregate.Star {
+		if len(aggregate.Arguments) != 1 {
+			return unknownType, fmt.Errorf("aggregate function %s expects exactly 1 argument", aggregate.Name.Value)
+		}
+		argumentType, err = e.inferExpressionType(aggregate.Arguments[0], columns)
+		if err != nil {
+			return unknownType, err
+		}
+	}
+
+	return function.returnType(argumentType)
+}
+
+// sumResultType sum и avg считаются точно: по числам в DECIMAL, по интервалам в INTERVAL
+func sumResultType(name string) func(disk_manager.DataType) (disk_manager.DataType, error) {
+	return func(argumentType disk_manager.DataType) (disk_manager.DataType, error) {
+		switch {
+		case argumentType == unknownType || isNumericType(argumentType):
+			return disk_manager.DECIMAL_TYPE, nil
+		case argumentType == disk_manager.INTERVAL_TYPE:
+			return disk_manager.INTERVAL_TYPE, nil
+		}
+		return unknownType, fmt.Errorf("function %s(%s) does not exist", name, argumentType)
+	}
+}
+
+// minMaxResultType min и max определены для всех сравнимых типов, результат того же типа
+func minMaxResultType(name string) func(disk_manager.DataType) (disk_manager.DataType, error) {
+	return func(argumentType disk_manager.DataType) (disk_manager.DataType, error) {
+		if argumentType == unknownType {
+			return disk_manager.TEXT_TYPE, nil
+		}
+		if _, ok := comparisonType(argumentType, argumentType); !ok {
+			return unknownType, fmt.Errorf("function %s(%s) does not exist", name, argumentType)
+		}
+		return argumentType, nil
+	}
+}
+
+// ========================== States ==========================
+
+// countState считает не NULL значения (для count(*) - все строки)
+type countState struct {
+	count int32
+}
+
+func (s *countState) add(disk_manager.DataCell) error {
+	s.count++
+	return nil
+}
+
+func (s *countState) result() (disk_manager.DataCell, error) {
+	return disk_manager.DataCell{DataType: disk_manager.INT_32_TYPE, Data: s.count}, nil
+}
+
+func (s *countState) size() int {
+	return 8
+}
+
+// sumState накапливает сумму (и количество для avg). Без значений результат равен NULL
+type sumState struct {
+	resultType disk_manager.DataType
+	average    bool
+	count      int64
+	decimal    disk_manager.Decimal
+	interval   disk_manager.Interval
+}
+
+func (s *sumState) add(cell disk_manager.DataCell) error {
+	if s.resultType == disk_manager.INTERVAL_TYPE {
+		s.interval = s.interval.Add(cell.Data.(disk_manager.Interval))
+		s.count++
+		return nil
+	}
+
+	value, err := coerceCell(cell, disk_manager.DECIMAL_TYPE)
+	if err != nil {
+		return err
+	}
+	if s.count == 0 {
+		s.decimal = value.Data.(disk_manager.Decimal)
+	} else {
+		s.decimal = s.decimal.Add(value.Data.(disk_manager.Decimal))
+	}
+	s.count++
+	return nil
+}
+
+func (s *sumState) result() (disk_manager.DataCell, error) {
+	if s.count == 0 {
+		return nullCell(s.resultType), nil
+	}
+
+	if s.resultType == disk_manager.INTERVAL_TYPE {
+		interval := s.interval
+		if s.average {
+			interval = interval.Multiply(1 / float64(s.count))
+		}
+		return disk_manager.DataCell{DataType: disk_manager.INTERVAL_TYPE, Data: interval}, nil
+	}
+
+	decimal := s.decimal
+	if s.average {
+		scale := max(decimal.Scale, AVG_MIN_SCALE)
+		average, err := decimal.Div(disk_manager.NewDecimalFromInt(s.count), scale)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		decimal = average
+	}
+	return disk_manager.DataCell{DataType: disk_manager.DECIMAL_TYPE, Data: decimal}, nil
+}
+
+func (s *sumState) size() int {
+	// Сумма интервалов имеет фиксированный размер, decimal для нее не используется
+	if s.count == 0 || s.resultType == disk_manager.INTERVAL_TYPE {
+		return 48
+	}
+	return 48 + len(s.decimal.Unscaled.Bits())*8
+}
+
+// minMaxState хранит наименьшее (или наибольшее) значение
+type minMaxState struct {
+	resultType disk_manager.DataType
+	max        bool
+	value      *disk_manager.DataCell
+}
+
+func (s *minMaxState) add(cell disk_manager.DataCell) error {
+	if s.value == nil {
+		s.value = &cell
+		return nil
+	}
+
+	compared, err := compareCells(cell, *s.value)
+	if err != nil {
+		return err
+	}
+	if (s.max && compared > 0) || (!s.max && compared < 0) {
+		s.value = &cell
+	}
+	return nil
+}
+
+func (s *minMaxState) result() (disk_manager.DataCell, error) {
+	if s.value == nil {
+		return nullCell(s.resultType), nil
+	}
+	return *s.value, nil
+}
+
+func (s *minMaxState) size() int {
+	if s.value == nil {
+		return 16
+	}
+	return 16 + int(s.value.GetSize())
+}
+
+// distinctState передает во вложенное состояние только значения, которые еще не встречались (DISTINCT)
+type distinctState struct {
+	inner  aggregateState
+	seen   map[string]struct{}
+	memory int
+}
+
+func newDistinctState(inner aggregateState) *distinctState {
+	return &distinctState{
+		inner: inner,
+		seen:  map[string]struct{}{},
+	}
+}
+
+func (s *distinctState) add(cell disk_manager.DataCell) error {
+	key := encodeGroupKey(disk_manager.Row{cell})
+	if _, ok := s.seen[key]; ok {
+		return nil
+	}
+
+	s.seen[key] = struct{}{}
+	s.memory += len(key) + 16
+	return s.inner.add(cell)
+}
+
+func (s *distinctState) result() (disk_manager.DataCell, error) {
+	return s.inner.result()
+}
+
+func (s *distinctState) size() int {
+	return s.inner.size() + s.memory
+}
+
+// encodeGroupKey кодирует значения в строку, одинаковую для равных значений.
+// Используется как ключ hash таблицы группировки и для DISTINCT
+func encodeGroupKey(cells disk_manager.Row) string {
+	var builder strings.Builder
+	for _, cell := range cells {
+		if cell.IsNull {
+			builder.WriteByte(0)
+			continue
+		}
+		builder.WriteByte(1)
+
+		var data []byte
+		switch cell.DataType {
+		case disk_manager.CHAR_TYPE:
+			// Хвостовые пробелы CHAR не учитываются при сравнении
+			data = []byte(strings.TrimRight(cell.Data.(string), " "))
+		case disk_manager.DECIMAL_TYPE:
+			// 1.0 и 1.00 - одно и то же значение
+			data = []byte(cell.Data.(disk_manager.Decimal).Normalize().String())
+		case disk_manager.INTERVAL_TYPE:
+			// '1 day' и '24 hours' равны при сравнении, поэтому и ключи у них одинаковые
+			normalized := disk_manager.DataCell{DataType: cell.DataType, Data: cell.Data.(disk_manager.Interval).Normalize()}
+			data = normalized.SerializeData()
+		default:
+			data = cell.SerializeData()
+		}
+
+		length := make([]byte, 4)
+		binary.BigEndian.PutUint32(length, uint32(len(data)))
+		builder.Write(length)
+		builder.Write(data)
+	}
+
+	return builder.String()
+}
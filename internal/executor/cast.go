@@ -0,0 +1,133 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"custom-database/internal/parser/lex"
+	"fmt"
+	"strings"
+)
+
+// castTarget возвращает описание целевого типа приведения вместе с параметрами: DECIMAL(p,s), VARCHAR(n), CHAR(n)
+func castTarget(cast *ast.CastExpression) (disk_manager.ColumnInfo, error) {
+	// BOOLEAN - тип приведения, но не тип колонки, поэтому его нет в dataTypeFromToken
+	if strings.EqualFold(cast.DataType.Value, string(lex.BooleanKeyword)) {
+		return disk_manager.ColumnInfo{DataType: disk_manager.BOOLEAN_TYPE}, nil
+	}
+
+	dataType, err := dataTypeFromToken(cast.DataType)
+	if err != nil {
+		return disk_manager.ColumnInfo{}, err
+	}
+
+	target := disk_manager.ColumnInfo{DataType: dataType}
+	if err := applyTypeParameters(&target, cast.Parameters); err != nil {
+		return disk_manager.ColumnInfo{}, err
+	}
+	return target, nil
+}
+
+// canCast проверяет, есть ли явное приведение значения типа from к типу to:
+//
+//	любой тип       -> строки (TEXT, VARCHAR, CHAR): текстовое представление значения
+//	строки          -> любой тип: разбор текста (INT, DECIMAL, DATE, TIMESTAMP, INTERVAL, BYTEA, JSON)
+//	INT <-> DECIMAL: DECIMAL округляется до целого
+//	DATE <-> TIMESTAMP: TIMESTAMP обрезается до даты
+//	BOOLEAN <-> INT: true = 1, false = 0; 0 - false, остальные числа - true
+//	строки          -> BOOLEAN: 't', 'true', 'yes', 'on', '1' и 'f', 'false', 'no', 'off', '0' без учета регистра
+//
+// NULL без типа приводится к любому типу, значение приводится к своему типу без изменений
+func canCast(from, to disk_manager.DataType) bool {
+	switch {
+	case from == to, from == unknownType:
+		return true
+	case disk_manager.IsTextType(to), disk_manager.IsTextType(from):
+		return true
+	case isNumericType(from) && isNumericType(to):
+		return true
+	case (from == disk_manager.DATE_TYPE || from == disk_manager.TIMESTAMP_TYPE) &&
+		(to == disk_manager.DATE_TYPE || to == disk_manager.TIMESTAMP_TYPE):
+		return true
+	case from == disk_manager.BOOLEAN_TYPE && to == disk_manager.INT_32_TYPE,
+		from == disk_manager.INT_32_TYPE && to == disk_manager.BOOLEAN_TYPE:
+		return true
+	}
+
+	return false
+}
+
+// castResultType проверяет, что приведение допустимо, и возвращает целевой тип
+func (e *executor) castResultType(cast *ast.CastExpression, columns []ResultColumn) (disk_manager.DataType, error) {
+	operandType, err := e.inferExpressionType(cast.Operand, columns)
+	if err != nil {
+		return unknownType, err
+	}
+
+	target, err := castTarget(cast)
+	if err != nil {
+		return unknownType, err
+	}
+	if !canCast(operandType, target.DataType) {
+		return unknownType, fmt.Errorf("cannot cast type %s to %s", operandType, target.DataType)
+	}
+	return target.DataType, nil
+}
+
+// castCell явно приводит значение к целевому типу по правилам canCast. В отличие от записи в колонку,
+// значение длиннее VARCHAR(n)/CHAR(n) обрезается без ошибки, а DECIMAL(p,s) округляется до scale
+func castCell(cell disk_manager.DataCell, target disk_manager.ColumnInfo) (disk_manager.DataCell, error) {
+	if cell.IsNull {
+		return nullCell(target.DataType), nil
+	}
+	if !canCast(cell.DataType, target.DataType) {
+		return disk_manager.DataCell{}, fmt.Errorf("cannot cast type %s to %s", cell.DataType, target.DataType)
+	}
+
+	var result disk_manager.DataCell
+	var err error
+	switch {
+	case disk_manager.IsTextType(target.DataType) && !disk_manager.IsTextType(cell.DataType):
+		result = disk_manager.DataCell{DataType: target.DataType, Data: cell.String()}
+	case disk_manager.IsTextType(cell.DataType) && !disk_manager.IsTextType(target.DataType):
+		text, _ := coerceCell(cell, disk_manager.TEXT_TYPE)
+		result, err = parseTextAs(strings.TrimSpace(text.Data.(string)), target.DataType)
+	case cell.DataType == disk_manager.BOOLEAN_TYPE && target.DataType == disk_manager.INT_32_TYPE:
+		result = disk_manager.DataCell{DataType: target.DataType, Data: int32(boolToInt(cell.Data.(bool)))}
+	case cell.DataType == disk_manager.INT_32_TYPE && target.DataType == disk_manager.BOOLEAN_TYPE:
+		result = disk_manager.DataCell{DataType: target.DataType, Data: cell.Data.(int32) != 0}
+	default:
+		result, err = coerceCell(cell, target.DataType)
+	}
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+
+	switch target.DataType {
+	case disk_manager.DECIMAL_TYPE:
+		decimal, err := result.Data.(disk_manager.Decimal).FitTo(target.Precision, target.Scale)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		result.Data = decimal
+	case disk_manager.VARCHAR_TYPE, disk_manager.CHAR_TYPE:
+		result.Data = castToLength(result.Data.(string), target)
+	}
+
+	return result, nil
+}
+
+// castToLength обрезает строку до длины VARCHAR(n)/CHAR(n) и дополняет пробелами значения CHAR(n)
+func castToLength(value string, target disk_manager.ColumnInfo) string {
+	if target.Length == 0 {
+		return value
+	}
+
+	runes := []rune(value)
+	if uint32(len(runes)) > target.Length {
+		runes = runes[:target.Length]
+	}
+	if target.DataType == disk_manager.CHAR_TYPE && uint32(len(runes)) < target.Length {
+		return string(runes) + strings.Repeat(" ", int(target.Length)-len(runes))
+	}
+	return string(runes)
+}
@@ -0,0 +1,83 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/functions"
+	"strings"
+)
+
+// SYSTEM_FUNCTIONS_VIEW имя системного представления со списком функций
+const SYSTEM_FUNCTIONS_VIEW = "system_functions"
+
+// isSystemView проверяет, что имя из FROM ссылается на системное представление, а не на запрос WITH
+func (e *executor) isSystemView(name string) bool {
+	return name == SYSTEM_FUNCTIONS_VIEW && e.lookupCommonTable(name) == nil
+}
+
+// newSystemFunctionsOperator возвращает строки представления system_functions: по строке на каждую сигнатуру
+// встроенных и зарегистрированных функций. Колонки доступны как qualifier.column
+func (e *executor) newSystemFunctionsOperator(qualifier string) *rowsOperator {
+	columns := []ResultColumn{
+		{Name: "name", DataType: disk_manager.TEXT_TYPE, Table: qualifier},
+		{Name: "kind", DataType: disk_manager.TEXT_TYPE, Table: qualifier},
+		{Name: "arguments", DataType: disk_manager.TEXT_TYPE, Table: qualifier},
+		{Name: "return_type", DataType: disk_manager.TEXT_TYPE, Table: qualifier},
+		{Name: "builtin", DataType: disk_manager.BOOLEAN_TYPE, Table: qualifier},
+	}
+
+	var rows []disk_manager.Row
+	for _, function := range e.functions.Functions() {
+		for _, signature := range function.Signatures {
+			rows = append(rows, disk_manager.Row{
+				{DataType: disk_manager.TEXT_TYPE, Data: function.Name},
+				{DataType: disk_manager.TEXT_TYPE, Data: string(function.Kind)},
+				{DataType: disk_manager.TEXT_TYPE, Data: formatSignatureArguments(signature)},
+				{DataType: disk_manager.TEXT_TYPE, Data: functions.TypeName(signature.Result)},
+				{DataType: disk_manager.BOOLEAN_TYPE, Data: function.Builtin},
+			})
+		}
+	}
+
+	return &rowsOperator{columns: columns, rows: rows}
+}
+
+// formatSignatureArguments форматирует типы аргументов сигнатуры, повторяющийся аргумент отмечается многоточием
+func formatSignatureArguments(signature functions.Signature) string {
+	names := make([]string, 0, len(signature.Arguments))
+	for _, argument := range signature.Arguments {
+		names = append(names, functions.TypeName(argument))
+	}
+	if signature.Variadic {
+		names = append(names, "...")
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// ========================== Rows ==========================
+
+// rowsOperator возвращает заранее вычисленные строки
+type rowsOperator struct {
+	columns []ResultColumn
+	rows    []disk_manager.Row
+	index   int
+}
+
+func (op *rowsOperator) Columns() []ResultColumn {
+	return op.columns
+}
+
+func (op *rowsOperator) Next() (disk_manager.Row, bool, error) {
+	if op.index >= len(op.rows) {
+		return nil, false, nil
+	}
+
+	row := op.rows[op.index]
+	op.index++
+	return row, true, nil
+}
+
+func (op *rowsOperator) Close() error {
+	op.rows = nil
+	return nil
+}
@@ -0,0 +1,148 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"fmt"
+)
+
+// commonType возвращает общий тип значений выражения (CASE, COALESCE), как для колонок UNION.
+// NULL без типа не влияет на результат, если типов нет совсем - результат TEXT
+func commonType(construct string, dataTypes []disk_manager.DataType) (disk_manager.DataType, error) {
+	result := unknownType
+	for _, dataType := range dataTypes {
+		if dataType == unknownType {
+			continue
+		}
+		if result == unknownType {
+			result = dataType
+			continue
+		}
+
+		matched, ok := setOperationType(result, dataType)
+		if !ok {
+			return unknownType, fmt.Errorf("%s types %s and %s cannot be matched", construct, result, dataType)
+		}
+		result = matched
+	}
+
+	if result == unknownType {
+		return disk_manager.TEXT_TYPE, nil
+	}
+	return result, nil
+}
+
+// caseResultType проверяет типы условий CASE и возвращает общий тип результатов веток
+func (e *executor) caseResultType(caseExpression *ast.CaseExpression, columns []ResultColumn) (disk_manager.DataType, error) {
+	if dataType, ok := e.caseTypes[caseExpression]; ok {
+		return dataType, nil
+	}
+
+	operandType := unknownType
+	if caseExpression.Operand != nil {
+		var err error
+		operandType, err = e.inferExpressionType(caseExpression.Operand, columns)
+		if err != nil {
+			return unknownType, err
+		}
+	}
+
+	resultTypes := make([]disk_manager.DataType, 0, len(caseExpression.Whens)+1)
+	for _, when := range caseExpression.Whens {
+		conditionType, err := e.inferExpressionType(when.Condition, columns)
+		if err != nil {
+			return unknownType, err
+		}
+
+		if caseExpression.Operand != nil {
+			// Простая форма: значение WHEN сравнивается с operand
+			if _, ok := comparisonType(operandType, conditionType); !ok {
+				return unknownType, fmt.Errorf("cannot compare %s with %s", operandType, conditionType)
+			}
+		} else if conditionType != disk_manager.BOOLEAN_TYPE && conditionType != unknownType {
+			return unknownType, fmt.Errorf("argument of CASE/WHEN must be type BOOLEAN, not type %s", conditionType)
+		}
+
+		resultType, err := e.inferExpressionType(when.Result, columns)
+		if err != nil {
+			return unknownType, err
+		}
+		resultTypes = append(resultTypes, resultType)
+	}
+
+	if caseExpression.Else != nil {
+		elseType, err := e.inferExpressionType(caseExpression.Else, columns)
+		if err != nil {
+			return unknownType, err
+		}
+		resultTypes = append(resultTypes, elseType)
+	}
+
+	dataType, err := commonType("CASE", resultTypes)
+	if err != nil {
+		return unknownType, err
+	}
+	e.caseTypes[caseExpression] = dataType
+	return dataType, nil
+}
+
+// evaluateCase вычисляет CASE: результат первой ветки, условие которой истинно (NULL считается ложью).
+// Остальные ветки не вычисляются, поэтому CASE WHEN x = 0 THEN 0 ELSE 1 / x END не делит на ноль
+func (e *executor) evaluateCase(caseExpression *ast.CaseExpression, scope *rowScope) (disk_manager.DataCell, error) {
+	var columns []ResultColumn
+	if scope != nil {
+		columns = scope.columns
+	}
+	resultType, err := e.caseResultType(caseExpression, columns)
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+
+	var operand disk_manager.DataCell
+	if caseExpression.Operand != nil {
+		operand, err = e.evaluateExpression(caseExpression.Operand, scope)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+	}
+
+	for _, when := range caseExpression.Whens {
+		condition, err := e.evaluateExpression(when.Condition, scope)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+
+		matched := false
+		switch {
+		case condition.IsNull:
+		case caseExpression.Operand != nil:
+			if !operand.IsNull {
+				result, err := compareCells(operand, condition)
+				if err != nil {
+					return disk_manager.DataCell{}, err
+				}
+				matched = result == 0
+			}
+		default:
+			matched = condition.Data.(bool)
+		}
+
+		if matched {
+			return e.evaluateCaseResult(when.Result, resultType, scope)
+		}
+	}
+
+	if caseExpression.Else == nil {
+		return nullCell(resultType), nil
+	}
+	return e.evaluateCaseResult(caseExpression.Else, resultType, scope)
+}
+
+// evaluateCaseResult вычисляет результат ветки CASE и приводит его к общему типу результатов
+func (e *executor) evaluateCaseResult(result *ast.Expression, resultType disk_manager.DataType, scope *rowScope) (disk_manager.DataCell, error) {
+	cell, err := e.evaluateExpression(result, scope)
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+	return coerceCell(cell, resultType)
+}
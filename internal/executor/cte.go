@@ -0,0 +1,379 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"fmt"
+)
+
+// WORKTABLE_PREFIX начало имени временной таблицы. Символ $ не встречается в именах таблиц без кавычек
+const WORKTABLE_PREFIX = "worktable$"
+
+// commonTable общее табличное выражение (WITH) текущего statement'а.
+// Результат запроса сохраняется во временную таблицу (worktable) в buffer pool при первом обращении
+// и читается ее сканированием. Рекурсивная часть запроса выполняется итерациями по мере чтения результата,
+// поэтому запрос с LIMIT вычисляет только нужные итерации. Временные таблицы удаляются после выполнения statement'а
+type commonTable struct {
+	query     *ast.CommonTableExpression
+	visible   []*commonTable // Запросы WITH, на которые может ссылаться этот запрос
+	recursive bool           // Запрос объявлен в WITH RECURSIVE
+
+	columns       []ResultColumn       // Колонки результата с именами из запроса
+	table         string               // Временная таблица с результатом, пустое имя - запрос еще не выполнен
+	pending       *ast.SelectStatement // Рекурсивная часть, nil - все итерации выполнены
+	previous      string               // Временная таблица со строками последней выполненной итерации
+	distinct      bool                 // UNION без ALL: строки, которые уже есть в результате, отбрасываются
+	seen          map[string]struct{}  // Строки результата для UNION без ALL
+	working       string               // Временная таблица, которую читает выполняемая итерация рекурсивной части
+	referenced    bool                 // Рекурсивная часть ссылается на сам запрос
+	materializing bool
+}
+
+// defineCommonTables делает запросы WITH видимыми для statement'а. Без RECURSIVE запрос видит только
+// объявленные раньше него, с RECURSIVE - все запросы, в том числе самого себя
+func (e *executor) defineCommonTables(with *ast.WithClause) {
+	tables := make([]*commonTable, 0, len(with.Queries))
+	for _, query := range with.Queries {
+		tables = append(tables, &commonTable{query: query, recursive: with.Recursive})
+	}
+
+	for i, table := range tables {
+		table.visible = tables[:i]
+		if with.Recursive {
+			table.visible = tables
+		}
+	}
+	e.commonTables = tables
+}
+
+// lookupCommonTable ищет видимый запрос WITH по имени
+func (e *executor) lookupCommonTable(name string) *commonTable {
+	for _, table := range e.commonTables {
+		if table.query.Name.Value == name {
+			return table
+		}
+	}
+	return nil
+}
+
+// buildCommonTableScan создает чтение результата запроса WITH и оценивает его объем.
+// Рекурсивная часть запроса читает строки, добавленные на предыдущей итерации
+func (e *executor) buildCommonTableScan(table *commonTable, qualifier string) (operator, int, error) {
+	if table.working != "" {
+		table.referenced = true
+		metaInfo, err := e.readMetaInfo(table.working)
+		if err != nil {
+			return nil, 0, err
+		}
+		scan := newTableScanOperator(e.bufferPool, table.working, qualifier, metaInfo)
+		// Колонки временной таблицы названы по позиции, имена берутся из запроса WITH
+		for i := range scan.columns {
+			scan.columns[i].Name = table.columns[i].Name
+		}
+		return scan, scan.estimatedSize(), nil
+	}
+
+	if err := e.startCommonTable(table); err != nil {
+		return nil, 0, err
+	}
+	metaInfo, err := e.readMetaInfo(table.table)
+	if err != nil {
+		return nil, 0, err
+	}
+	columns := make([]ResultColumn, 0, len(table.columns))
+	for _, column := range table.columns {
+		columns = append(columns, ResultColumn{Name: column.Name, DataType: column.DataType, Table: qualifier})
+	}
+	scan := &commonTableScanOperator{executor: e, table: table, columns: columns, pageIndex: -1}
+	return scan, int(metaInfo.DataHeaders.PagesCount) * disk_manager.PAGE_SIZE, nil
+}
+
+// startCommonTable выполняет запрос WITH при первом обращении к нему. Запрос WITH RECURSIVE вида
+// anchor UNION [ALL] recursive сохраняет строки anchor, рекурсивная часть выполняется nextIteration
+func (e *executor) startCommonTable(table *commonTable) error {
+	switch {
+	case table.table != "":
+		return nil
+	case table.materializing:
+		return fmt.Errorf("recursive reference to query %s must not appear within its non-recursive term", table.query.Name.Value)
+	}
+
+	// Запрос WITH не зависит от места, где на него сослались: внешние строки и запросы ему не видны
+	visible, outerScopes := e.commonTables, e.outerScopes
+	e.commonTables, e.outerScopes = table.visible, nil
+	table.materializing = true
+	defer func() {
+		e.commonTables, e.outerScopes = visible, outerScopes
+		table.materializing = false
+	}()
+
+	query := table.query
+	anchor := query.Select
+	if operation := query.Select.SetOperation; table.recursive && operation != nil && operation.Kind == ast.UnionOperation &&
+		query.Select.OrderBy == nil && query.Select.Limit == nil && query.Select.Offset == nil {
+		anchor, table.pending, table.distinct = operation.Left, operation.Right, !operation.All
+		table.seen = map[string]struct{}{}
+	}
+
+	plan, err := e.buildSelectPlan(anchor)
+	if err != nil {
+		return err
+	}
+	columns, err := commonTableColumns(query, plan.Columns())
+	if err != nil {
+		plan.Close()
+		return err
+	}
+	table.columns = columns
+
+	result, err := e.createWorktable(columns)
+	if err != nil {
+		plan.Close()
+		return err
+	}
+	if table.pending != nil {
+		if table.previous, err = e.createWorktable(columns); err != nil {
+			plan.Close()
+			return err
+		}
+	}
+	if _, err := e.addCommonTableRows(table, plan, result, table.previous); err != nil {
+		return err
+	}
+
+	table.table = result
+	return nil
+}
+
+// nextIteration выполняет очередную итерацию рекурсивной части запроса WITH: она читает строки
+// предыдущей итерации, новые строки добавляются в результат и в таблицу следующей итерации.
+// Итерации заканчиваются, когда рекурсивная часть не добавила строк
+func (e *executor) nextIteration(table *commonTable) error {
+	visible, outerScopes := e.commonTables, e.outerScopes
+	e.commonTables, e.outerScopes = table.visible, nil
+	defer func() {
+		e.commonTables, e.outerScopes = visible, outerScopes
+	}()
+
+	table.working, table.referenced = table.previous, false
+	plan, err := e.buildSelectPlan(table.pending)
+	table.working = ""
+	if err != nil {
+		return err
+	}
+
+	next, err := e.createWorktable(table.columns)
+	if err != nil {
+		plan.Close()
+		return err
+	}
+	// Строки предыдущей итерации читаются при выполнении плана
+	table.working = table.previous
+	added, err := e.addCommonTableRows(table, plan, table.table, next)
+	table.working = ""
+	if err != nil {
+		return err
+	}
+
+	if err := e.dropWorktable(table.previous); err != nil {
+		return err
+	}
+	table.previous = next
+	// Без ссылки на сам запрос вторая часть - обычный UNION, она выполняется один раз
+	if added == 0 || !table.referenced {
+		table.pending = nil
+		table.previous = ""
+		return e.dropWorktable(next)
+	}
+	return nil
+}
+
+// addCommonTableRows добавляет строки плана в результат запроса WITH и в таблицу итерации working,
+// если она указана. Возвращает количество добавленных строк
+func (e *executor) addCommonTableRows(table *commonTable, plan operator, result, working string) (int, error) {
+	defer plan.Close()
+	if len(plan.Columns()) != len(table.columns) {
+		return 0, fmt.Errorf("each UNION query must have the same number of columns")
+	}
+
+	added := 0
+	for {
+		row, ok, err := plan.Next()
+		if err != nil || !ok {
+			return added, err
+		}
+
+		for i, column := range table.columns {
+			if row[i], err = coerceCell(row[i], column.DataType); err != nil {
+				return added, err
+			}
+		}
+		if table.distinct {
+			key := encodeGroupKey(row)
+			if _, ok := table.seen[key]; ok {
+				continue
+			}
+			table.seen[key] = struct{}{}
+		}
+
+		if err := e.insertWorktableRow(result, row); err != nil {
+			return added, err
+		}
+		if working != "" {
+			if err := e.insertWorktableRow(working, row); err != nil {
+				return added, err
+			}
+		}
+		added++
+	}
+}
+
+// commonTableScanOperator читает результат запроса WITH. Строки добавляются в конец временной таблицы,
+// поэтому последняя прочитанная страница перечитывается, а когда новых строк нет, выполняется
+// следующая итерация рекурсивной части
+type commonTableScanOperator struct {
+	executor *executor
+	table    *commonTable
+	columns  []ResultColumn
+
+	pageIndex int                // Индекс текущей страницы в page directory
+	rows      []disk_manager.Row // Строки текущей страницы
+	rowIndex  int                // Индекс следующей строки текущей страницы
+}
+
+func (op *commonTableScanOperator) Columns() []ResultColumn {
+	return op.columns
+}
+
+func (op *commonTableScanOperator) Next() (disk_manager.Row, bool, error) {
+	for op.rowIndex >= len(op.rows) {
+		metaInfo, err := op.executor.readMetaInfo(op.table.table)
+		if err != nil {
+			return nil, false, err
+		}
+		entries := metaInfo.PageDirectory.Entries
+
+		// Строки текущей страницы прочитаны, но в нее могли добавиться новые строки
+		if op.pageIndex >= 0 {
+			rows, err := readPageRows(op.executor.bufferPool, op.table.table, metaInfo, entries[op.pageIndex].PageID)
+			if err != nil {
+				return nil, false, err
+			}
+			if len(rows) > len(op.rows) {
+				op.rows = rows
+				continue
+			}
+		}
+
+		if op.pageIndex+1 < len(entries) {
+			op.pageIndex++
+			if op.rows, err = readPageRows(op.executor.bufferPool, op.table.table, metaInfo, entries[op.pageIndex].PageID); err != nil {
+				return nil, false, err
+			}
+			op.rowIndex = 0
+			continue
+		}
+
+		if op.table.pending == nil {
+			return nil, false, nil
+		}
+		if err := op.executor.nextIteration(op.table); err != nil {
+			return nil, false, err
+		}
+	}
+
+	row := op.rows[op.rowIndex]
+	op.rowIndex++
+	return row, true, nil
+}
+
+func (op *commonTableScanOperator) Close() error {
+	op.rows = nil
+	return nil
+}
+
+// commonTableColumns возвращает колонки результата запроса WITH с именами из списка колонок запроса
+func commonTableColumns(query *ast.CommonTableExpression, planColumns []ResultColumn) ([]ResultColumn, error) {
+	if len(query.Columns) > len(planColumns) {
+		return nil, fmt.Errorf("WITH query %s has %d columns available but %d columns specified",
+			query.Name.Value, len(planColumns), len(query.Columns))
+	}
+
+	columns := make([]ResultColumn, 0, len(planColumns))
+	for i, column := range planColumns {
+		name := column.Name
+		if i < len(query.Columns) {
+			name = query.Columns[i].Value
+		}
+		// NULL без типа хранится как TEXT
+		dataType := column.DataType
+		if dataType == unknownType {
+			dataType = disk_manager.TEXT_TYPE
+		}
+		columns = append(columns, ResultColumn{Name: name, DataType: dataType})
+	}
+
+	return columns, nil
+}
+
+// createWorktable создает временную таблицу с колонками columns. Имя колонки в meta файле ограничено
+// по длине, поэтому колонки называются по позиции (c1, c2, ...), а имена из запроса хранятся в commonTable
+func (e *executor) createWorktable(columns []ResultColumn) (string, error) {
+	infos := make([]disk_manager.ColumnInfo, 0, len(columns))
+	for i, column := range columns {
+		name := fmt.Sprintf("c%d", i+1)
+		infos = append(infos, disk_manager.ColumnInfo{
+			ColumnNameLength: uint32(len(name)),
+			ColumnName:       name,
+			DataType:         column.DataType,
+			IsNullable:       1,
+		})
+	}
+
+	for {
+		e.worktableCount++
+		name := fmt.Sprintf("%s%d", WORKTABLE_PREFIX, e.worktableCount)
+		// Таблица могла остаться от прерванного выполнения
+		if metaInfo, _ := e.bufferPool.ReadMetaInfo(name); metaInfo != nil {
+			continue
+		}
+
+		if err := e.bufferPool.CreateTable(name, infos); err != nil {
+			return "", err
+		}
+		e.worktables = append(e.worktables, name)
+		return name, nil
+	}
+}
+
+// insertWorktableRow добавляет строку в конец временной таблицы
+func (e *executor) insertWorktableRow(name string, row disk_manager.Row) error {
+	metaInfo, err := e.readMetaInfo(name)
+	if err != nil {
+		return err
+	}
+	return e.appendRow(name, metaInfo, row)
+}
+
+// dropWorktable удаляет временную таблицу
+func (e *executor) dropWorktable(name string) error {
+	for i, worktable := range e.worktables {
+		if worktable == name {
+			e.worktables = append(e.worktables[:i], e.worktables[i+1:]...)
+			break
+		}
+	}
+	return e.bufferPool.DropTable(name)
+}
+
+// dropWorktables удаляет все временные таблицы statement'а и забывает его запросы WITH
+func (e *executor) dropWorktables() error {
+	var dropErr error
+	for len(e.worktables) > 0 {
+		if err := e.dropWorktable(e.worktables[0]); err != nil && dropErr == nil {
+			dropErr = err
+		}
+	}
+	e.commonTables = nil
+	return dropErr
+}
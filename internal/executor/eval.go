@@ -0,0 +1,359 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"custom-database/internal/parser/lex"
+	"fmt"
+	"strings"
+)
+
+// comparisonOperators операторы сравнения, результат которых имеет тип BOOLEAN
+var comparisonOperators = map[lex.MathOperator]func(int) bool{
+	lex.EqualOperator:          func(c int) bool { return c == 0 },
+	lex.NotEqualOperator:       func(c int) bool { return c != 0 },
+	lex.GreaterThanOperator:    func(c int) bool { return c > 0 },
+	lex.LessThanOperator:       func(c int) bool { return c < 0 },
+	lex.GreaterOrEqualOperator: func(c int) bool { return c >= 0 },
+	lex.LessOrEqualOperator:    func(c int) bool { return c <= 0 },
+}
+
+// findColumn возвращает индекс колонки по имени. Имя может быть уточнено таблицей: table.column.
+// Если без уточнения подходят колонки разных таблиц, ссылка неоднозначна
+func findColumn(columns []ResultColumn, name string) (int, error) {
+	matches := matchColumns(columns, name)
+	switch len(matches) {
+	case 0:
+		return -1, fmt.Errorf("column %s does not exist", name)
+	case 1:
+		return matches[0], nil
+	}
+	return -1, fmt.Errorf("column reference %s is ambiguous", name)
+}
+
+// matchColumns возвращает индексы всех колонок, подходящих под имя (возможно, уточненное таблицей)
+func matchColumns(columns []ResultColumn, name string) []int {
+	table, columnName := splitColumnName(name)
+
+	var matches []int
+	for i, column := range columns {
+		// Вычисленные выражения (агрегаты, ключи группировки вида a + 1) по имени недоступны
+		if column.Expression != nil && !isColumnReference(column.Expression) {
+			continue
+		}
+		if !strings.EqualFold(column.Name, columnName) {
+			continue
+		}
+		if table != "" && !strings.EqualFold(column.Table, table) {
+			continue
+		}
+		matches = append(matches, i)
+	}
+
+	return matches
+}
+
+// findOuterColumn ищет колонку, которой нет в текущем запросе, среди колонок внешних запросов
+// (ссылка коррелированного подзапроса). Возвращает область видимости внешнего запроса и индекс колонки в ней
+func (e *executor) findOuterColumn(name string) (*rowScope, int, bool, error) {
+	for i := len(e.outerScopes) - 1; i >= 0; i-- {
+		outer := e.outerScopes[i]
+		matches := matchColumns(outer.scope.columns, name)
+		if len(matches) == 0 {
+			continue
+		}
+		if len(matches) > 1 {
+			return nil, -1, false, fmt.Errorf("column reference %s is ambiguous", name)
+		}
+
+		// Ссылка через границы подзапросов: все подзапросы внутри этого внешнего запроса коррелированы
+		for _, inner := range e.outerScopes[i:] {
+			inner.referenced = true
+		}
+		return outer.scope, matches[0], true, nil
+	}
+
+	return nil, -1, false, nil
+}
+
+// splitColumnName разделяет имя table.column на имя таблицы и имя колонки.
+// Для имени без таблицы возвращается пустое имя таблицы
+func splitColumnName(name string) (string, string) {
+	index := strings.LastIndex(name, ".")
+	if index < 0 {
+		return "", name
+	}
+	return name[:index], name[index+1:]
+}
+
+// isColumnReference проверяет, что выражение - имя колонки
+func isColumnReference(expression *ast.Expression) bool {
+	return expression.Kind == ast.LiteralKind && expression.Literal.Kind == lex.IdentifierToken
+}
+
+// findComputedColumn ищет колонку, в которой оператор ниже по плану уже вычислил это выражение
+func findComputedColumn(columns []ResultColumn, expression *ast.Expression) (int, bool) {
+	for i, column := range columns {
+		if column.Expression != nil && column.Expression.Equals(expression) {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
+
+// evaluateExpression вычисляет выражение относительно строки.
+// scope может быть nil, если выражение не ссылается на колонки (например, значения INSERT)
+func (e *executor) evaluateExpression(expression *ast.Expression, scope *rowScope) (disk_manager.DataCell, error) {
+	if scope != nil {
+		if index, ok := findComputedColumn(scope.columns, expression); ok {
+			return scope.row[index], nil
+		}
+	}
+
+	switch expression.Kind {
+	case ast.LiteralKind:
+		if expression.Literal.Kind != lex.IdentifierToken {
+			return literalToCell(expression.Literal)
+		}
+
+		var columns []ResultColumn
+		if scope != nil {
+			columns = scope.columns
+		}
+		index, err := findColumn(columns, expression.Literal.Value)
+		if err == nil {
+			return scope.row[index], nil
+		}
+		if len(matchColumns(columns, expression.Literal.Value)) == 0 {
+			outer, index, ok, outerErr := e.findOuterColumn(expression.Literal.Value)
+			if outerErr != nil {
+				return disk_manager.DataCell{}, outerErr
+			}
+			if ok {
+				return outer.row[index], nil
+			}
+		}
+		return disk_manager.DataCell{}, err
+
+	case ast.TypedLiteralKind:
+		dataType, err := dataTypeFromToken(*expression.DataType)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		return parseTextAs(unescapeString(expression.Literal.Value), dataType)
+
+	case ast.BinaryKind:
+		return e.evaluateBinaryExpression(expression.Binary, scope)
+
+	case ast.FunctionCallKind:
+		arguments := make([]disk_manager.DataCell, 0, len(expression.FunctionCall.Arguments))
+		for _, argument := range expression.FunctionCall.Arguments {
+			cell, err := e.evaluateExpression(argument, scope)
+			if err != nil {
+				return disk_manager.DataCell{}, err
+			}
+			arguments = append(arguments, cell)
+		}
+		return e.callFunction(expression.FunctionCall.Name.Value, arguments)
+
+	case ast.SubqueryKind:
+		return e.evaluateScalarSubquery(expression.Subquery.Select, scope)
+
+	case ast.ExistsKind:
+		exists, err := e.evaluateExists(expression.Subquery.Select, scope)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		return disk_manager.DataCell{DataType: disk_manager.BOOLEAN_TYPE, Data: exists != expression.Subquery.Negated}, nil
+
+	case ast.InKind:
+		return e.evaluateInSubquery(expression.Subquery, scope)
+
+	case ast.LikeKind, ast.InListKind, ast.BetweenKind, ast.IsNullKind:
+		return e.evaluatePredicate(expression, scope)
+
+	case ast.CaseKind:
+		return e.evaluateCase(expression.Case, scope)
+
+	case ast.LogicalKind:
+		return e.evaluateLogical(expression.Logical, scope)
+
+	case ast.CastKind:
+		cell, err := e.evaluateExpression(expression.Cast.Operand, scope)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		target, err := castTarget(expression.Cast)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		return castCell(cell, target)
+
+	case ast.AggregateKind:
+		// Агрегаты вычисляет hashAggregateOperator, здесь они доступны только через findComputedColumn
+		return disk_manager.DataCell{}, fmt.Errorf("aggregate function %s is not allowed here", expression.Aggregate.Name.Value)
+
+	case ast.WindowKind:
+		// Оконные функции вычисляет windowOperator, здесь они доступны только через findComputedColumn
+		return disk_manager.DataCell{}, fmt.Errorf("window function %s is not allowed here", expression.Window.Name.Value)
+	}
+
+	return disk_manager.DataCell{}, fmt.Errorf("unsupported expression: %s", expression.Kind)
+}
+
+// evaluateBinaryExpression вычисляет сравнение, арифметическую операцию или доступ к JSON.
+// Если хотя бы один из операндов NULL, результат тоже NULL
+func (e *executor) evaluateBinaryExpression(binary *ast.BinaryExpression, scope *rowScope) (disk_manager.DataCell, error) {
+	left, err := e.evaluateExpression(binary.A, scope)
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+	right, err := e.evaluateExpression(binary.B, scope)
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+
+	operator := lex.MathOperator(binary.Operator.Value)
+	if compare, ok := comparisonOperators[operator]; ok {
+		if left.IsNull || right.IsNull {
+			return nullCell(disk_manager.BOOLEAN_TYPE), nil
+		}
+
+		result, err := compareCells(left, right)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		return disk_manager.DataCell{DataType: disk_manager.BOOLEAN_TYPE, Data: compare(result)}, nil
+	}
+
+	if _, ok := jsonOperatorResultTypes[operator]; ok {
+		if left.IsNull || right.IsNull {
+			resultType, err := jsonOperatorResultType(operator, left.DataType, right.DataType)
+			if err != nil {
+				return disk_manager.DataCell{}, err
+			}
+			return nullCell(resultType), nil
+		}
+		return applyJSONOperator(operator, left, right)
+	}
+
+	if left.IsNull || right.IsNull {
+		resultType, err := arithmeticResultType(operator, left.DataType, right.DataType)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		return nullCell(resultType), nil
+	}
+
+	return applyArithmetic(operator, left, right)
+}
+
+// inferExpressionType выводит тип результата выражения без его вычисления.
+// Используется для описания колонок результата и проверки типов до начала выполнения запроса
+func (e *executor) inferExpressionType(expression *ast.Expression, columns []ResultColumn) (disk_manager.DataType, error) {
+	if index, ok := findComputedColumn(columns, expression); ok {
+		return columns[index].DataType, nil
+	}
+
+	switch expression.Kind {
+	case ast.LiteralKind:
+		if expression.Literal.Kind != lex.IdentifierToken {
+			cell, err := literalToCell(expression.Literal)
+			return cell.DataType, err
+		}
+
+		index, err := findColumn(columns, expression.Literal.Value)
+		if err == nil {
+			return columns[index].DataType, nil
+		}
+		if len(matchColumns(columns, expression.Literal.Value)) == 0 {
+			outer, index, ok, outerErr := e.findOuterColumn(expression.Literal.Value)
+			if outerErr != nil {
+				return unknownType, outerErr
+			}
+			if ok {
+				return outer.columns[index].DataType, nil
+			}
+		}
+		return unknownType, err
+
+	case ast.TypedLiteralKind:
+		return dataTypeFromToken(*expression.DataType)
+
+	case ast.BinaryKind:
+		left, err := e.inferExpressionType(expression.Binary.A, columns)
+		if err != nil {
+			return unknownType, err
+		}
+		right, err := e.inferExpressionType(expression.Binary.B, columns)
+		if err != nil {
+			return unknownType, err
+		}
+
+		operator := lex.MathOperator(expression.Binary.Operator.Value)
+		if _, ok := comparisonOperators[operator]; ok {
+			if _, ok := comparisonType(left, right); !ok {
+				return unknownType, fmt.Errorf("cannot compare %s with %s", left, right)
+			}
+			return disk_manager.BOOLEAN_TYPE, nil
+		}
+		if _, ok := jsonOperatorResultTypes[operator]; ok {
+			return jsonOperatorResultType(operator, left, right)
+		}
+		return arithmeticResultType(operator, left, right)
+
+	case ast.FunctionCallKind:
+		argumentTypes := make([]disk_manager.DataType, 0, len(expression.FunctionCall.Arguments))
+		for _, argument := range expression.FunctionCall.Arguments {
+			argumentType, err := e.inferExpressionType(argument, columns)
+			if err != nil {
+				return unknownType, err
+			}
+			argumentTypes = append(argumentTypes, argumentType)
+		}
+		return e.functionResultType(expression.FunctionCall.Name.Value, argumentTypes)
+
+	case ast.AggregateKind:
+		return e.aggregateResultType(expression.Aggregate, columns)
+
+	case ast.WindowKind:
+		return e.windowResultType(expression.Window, columns)
+
+	case ast.SubqueryKind:
+		state, err := e.planSubquery(expression.Subquery.Select, columns)
+		if err != nil {
+			return unknownType, err
+		}
+		if err := state.checkSingleColumn(); err != nil {
+			return unknownType, err
+		}
+		return state.columns[0].DataType, nil
+
+	case ast.ExistsKind:
+		if _, err := e.planSubquery(expression.Subquery.Select, columns); err != nil {
+			return unknownType, err
+		}
+		return disk_manager.BOOLEAN_TYPE, nil
+
+	case ast.InKind:
+		if _, err := e.planInSubquery(expression.Subquery, columns); err != nil {
+			return unknownType, err
+		}
+		return disk_manager.BOOLEAN_TYPE, nil
+
+	case ast.LikeKind, ast.InListKind, ast.BetweenKind, ast.IsNullKind:
+		return e.predicateResultType(expression, columns)
+
+	case ast.LogicalKind:
+		return e.logicalResultType(expression.Logical, columns)
+
+	case ast.CaseKind:
+		return e.caseResultType(expression.Case, columns)
+
+	case ast.CastKind:
+		return e.castResultType(expression.Cast, columns)
+	}
+
+	return unknownType, fmt.Errorf("unsupported expression: %s", expression.Kind)
+}
@@ -0,0 +1,126 @@
+package executor
+
+import (
+	"custom-database/internal/buffer_bool"
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/functions"
+	"custom-database/internal/parser/ast"
+	"errors"
+	"fmt"
+)
+
+// ExecutorService выполняет statement'ы из AST над таблицами в buffer pool
+type ExecutorService interface {
+	// ExecuteStatement выполняет один statement.
+	// Для SELECT возвращает набор строк, для остальных statement'ов результат равен nil
+	ExecuteStatement(statement *ast.AstStatement) (*Result, error)
+
+	// RegisterScalarFunc регистрирует скалярную функцию, которую можно вызывать из SQL
+	RegisterScalarFunc(name string, argumentTypes []disk_manager.DataType, returnType disk_manager.DataType, fn functions.ScalarFunc) error
+	// RegisterAggregateFunc регистрирует агрегатную функцию одного аргумента. init создает состояние группы,
+	// step учитывает очередное не NULL значение, final возвращает результат
+	RegisterAggregateFunc(
+		name string,
+		argumentType disk_manager.DataType,
+		returnType disk_manager.DataType,
+		init functions.AggregateInitFunc,
+		step functions.AggregateStepFunc,
+		final functions.AggregateFinalFunc,
+	) error
+	// Functions возвращает реестр функций executor'а. Его нужно передать парсеру,
+	// чтобы валидатор знал о зарегистрированных функциях
+	Functions() *functions.Registry
+}
+
+// Config настройки executor'а
+type Config struct {
+	// StrictMode - строгий режим: значения длиннее VARCHAR(n)/CHAR(n) отклоняются с ошибкой.
+	// В нестрогом режиме такие значения обрезаются до длины колонки
+	StrictMode bool
+	// WorkMemory - сколько байт строк оператор может держать в памяти (например, при сортировке).
+	// При превышении строки выгружаются во временные файлы
+	WorkMemory int
+	// TempDir - каталог для временных файлов операторов, пустая строка означает системный каталог
+	TempDir string
+}
+
+// DEFAULT_WORK_MEMORY объем памяти операторов по умолчанию (4 МБ)
+const DEFAULT_WORK_MEMORY = 4 * 1024 * 1024
+
+// DefaultConfig возвращает настройки executor'а по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		StrictMode: true,
+		WorkMemory: DEFAULT_WORK_MEMORY,
+	}
+}
+
+type executor struct {
+	bufferPool buffer_bool.BufferPoolInterface
+	config     Config
+	// functions реестр встроенных и пользовательских функций, которые можно вызывать из SQL
+	functions *functions.Registry
+
+	// outerScopes строки внешних запросов, относительно которых выполняются подзапросы (от внешнего к внутреннему)
+	outerScopes []*outerScope
+	// subqueries состояние подзапросов текущего statement'а: описание колонок и результат некоррелированных подзапросов
+	subqueries map[*ast.SelectStatement]*subqueryState
+	// caseTypes типы результатов выражений CASE текущего statement'а, чтобы не выводить их для каждой строки
+	caseTypes map[*ast.CaseExpression]disk_manager.DataType
+
+	// commonTables запросы WITH, видимые в текущем месте statement'а
+	commonTables []*commonTable
+	// worktables временные таблицы с результатами запросов WITH, удаляются после выполнения statement'а
+	worktables     []string
+	worktableCount int
+
+	// keyIndexes индексы первичного ключа таблиц по имени таблицы. Индекс строится при первом INSERT в таблицу
+	// и дальше поддерживается при записи строк
+	keyIndexes map[string]*keyIndex
+}
+
+// NewExecutor создает новый экземпляр executor'а поверх buffer pool с настройками по умолчанию
+func NewExecutor(bufferPool buffer_bool.BufferPoolInterface) ExecutorService {
+	return NewExecutorWithConfig(bufferPool, DefaultConfig())
+}
+
+// NewExecutorWithConfig создает новый экземпляр executor'а поверх buffer pool с указанными настройками
+func NewExecutorWithConfig(bufferPool buffer_bool.BufferPoolInterface, config Config) ExecutorService {
+	if config.WorkMemory <= 0 {
+		config.WorkMemory = DEFAULT_WORK_MEMORY
+	}
+
+	return &executor{
+		bufferPool: bufferPool,
+		config:     config,
+		functions:  functions.NewRegistry(),
+		keyIndexes: map[string]*keyIndex{},
+	}
+}
+
+// ExecuteStatement выполняет один statement
+func (e *executor) ExecuteStatement(statement *ast.AstStatement) (*Result, error) {
+	if statement == nil {
+		return nil, errors.New("statement is nil")
+	}
+	e.outerScopes = nil
+	e.subqueries = map[*ast.SelectStatement]*subqueryState{}
+	e.caseTypes = map[*ast.CaseExpression]disk_manager.DataType{}
+
+	switch statement.Kind {
+	case ast.SelectKind:
+		return e.executeSelect(statement.SelectStatement)
+	case ast.InsertKind:
+		return e.executeInsert(statement.InsertStatement)
+	case ast.CreateTableKind:
+		return e.executeCreateTable(statement.CreateTableStatement)
+	case ast.DropTableKind:
+		return e.executeDropTable(statement.DropTableStatement)
+	case ast.AlterTableKind:
+		return nil, e.executeAlterTable(statement.AlterTableStatement)
+	case ast.TruncateTableKind:
+		return nil, e.executeTruncateTable(statement.TruncateTableStatement)
+	default:
+		return nil, fmt.Errorf("unsupported statement type: %s", statement.Kind)
+	}
+}
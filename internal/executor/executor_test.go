@@ -0,0 +1,2892 @@
+package executor
+
+import (
+	"custom-database/internal/buffer_bool"
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/functions"
+	"custom-database/internal/parser"
+	"custom-database/internal/parser/ast"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestExecutor создает executor поверх чистой базы данных в папке tables
+func newTestExecutor(t *testing.T) ExecutorService {
+	return newTestExecutorWithConfig(t, DefaultConfig())
+}
+
+// newTestExecutorWithConfig создает executor с указанными настройками поверх чистой базы данных в папке tables
+func newTestExecutorWithConfig(t *testing.T, config Config) ExecutorService {
+	// Buffer pool предыдущего executor'а в этом же тесте не должен писать в новую базу данных
+	closeTestBufferPool()
+	os.RemoveAll("tables")
+	t.Cleanup(func() {
+		os.RemoveAll("tables")
+	})
+
+	return NewExecutorWithConfig(openTestBufferPool(t), config)
+}
+
+// testBufferPool последний открытый в тестах buffer pool. Все тесты работают с одной папкой tables,
+// поэтому одновременно открыт только один buffer pool
+var testBufferPool buffer_bool.BufferPoolInterface
+
+// openTestBufferPool закрывает предыдущий buffer pool и открывает новый поверх папки tables.
+// Background worker останавливается в конце теста, чтобы он не писал в файлы следующего теста
+func openTestBufferPool(t *testing.T) buffer_bool.BufferPoolInterface {
+	closeTestBufferPool()
+
+	bufferPool, err := buffer_bool.NewBufferPool(10, 2)
+	require.NoError(t, err)
+	t.Cleanup(bufferPool.Close)
+	testBufferPool = bufferPool
+
+	return bufferPool
+}
+
+// closeTestBufferPool закрывает последний открытый в тестах buffer pool
+func closeTestBufferPool() {
+	if testBufferPool != nil {
+		testBufferPool.Close()
+		testBufferPool = nil
+	}
+}
+
+// restartTestExecutor закрывает buffer pool executor'а и открывает базу данных заново, как после перезапуска
+func restartTestExecutor(t *testing.T, e *executor) ExecutorService {
+	e.bufferPool.Close()
+
+	return NewExecutor(openTestBufferPool(t))
+}
+
+// execute парсит и выполняет запрос, возвращая результат последнего statement'а
+func execute(t *testing.T, executor ExecutorService, query string) (*Result, error) {
+	t.Helper()
+
+	tree, err := parser.NewParserWithFunctions(executor.Functions()).Parse(query)
+	require.NoError(t, err)
+
+	var result *Result
+	for _, statement := range tree.Statements {
+		result, err = executor.ExecuteStatement(statement)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// mustExecute выполняет запрос и проверяет, что он выполнился без ошибок
+func mustExecute(t *testing.T, executor ExecutorService, query string) *Result {
+	t.Helper()
+
+	result, err := execute(t, executor, query)
+	require.NoError(t, err)
+
+	return result
+}
+
+// resultStrings переводит строки результата в текстовый вид для удобства сравнения
+func resultStrings(result *Result) [][]string {
+	rows := [][]string{}
+	for _, row := range result.Rows {
+		values := []string{}
+		for _, cell := range row {
+			values = append(values, cell.String())
+		}
+		rows = append(rows, values)
+	}
+
+	return rows
+}
+
+func TestExecuteBasicStatements(t *testing.T) {
+	t.Run("1. Create, insert and select", func(t *testing.T) {
+		// Arrange
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE users (id INT, name TEXT);")
+
+		// Act
+		mustExecute(t, executor, "INSERT INTO users VALUES (1, 'Joffrey');")
+		mustExecute(t, executor, "INSERT INTO users VALUES (2, 'it''s');")
+		mustExecute(t, executor, "INSERT INTO users VALUES (3, null);")
+		result := mustExecute(t, executor, "SELECT name, id FROM users;")
+
+		// Assert
+		require.Equal(t, []ResultColumn{
+			{Name: "name", DataType: disk_manager.TEXT_TYPE},
+			{Name: "id", DataType: disk_manager.INT_32_TYPE},
+		}, result.Columns)
+		require.Equal(t, [][]string{{"Joffrey", "1"}, {"it's", "2"}, {"null", "3"}}, resultStrings(result))
+	})
+
+	t.Run("2. Insert rows spanning several pages", func(t *testing.T) {
+		// Arrange
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE logs (id INT, message TEXT);")
+
+		// Act
+		for i := 0; i < 300; i++ {
+			mustExecute(t, executor, "INSERT INTO logs VALUES (1, 'some fairly long log message to fill pages quickly');")
+		}
+		result := mustExecute(t, executor, "SELECT id FROM logs;")
+
+		// Assert
+		require.Len(t, result.Rows, 300)
+	})
+
+	t.Run("3. Errors for unknown table, column and wrong arity", func(t *testing.T) {
+		// Arrange
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE users (id INT, name TEXT);")
+
+		// Act
+		_, unknownTableErr := execute(t, executor, "SELECT id FROM missing;")
+		_, unknownColumnErr := execute(t, executor, "SELECT age FROM users;")
+		_, arityErr := execute(t, executor, "INSERT INTO users VALUES (1);")
+		_, typeErr := execute(t, executor, "INSERT INTO users VALUES ('one', 'Phil');")
+
+		// Assert
+		require.Error(t, unknownTableErr)
+		require.Error(t, unknownColumnErr)
+		require.Error(t, arityErr)
+		require.Error(t, typeErr)
+	})
+
+	t.Run("4. Drop table", func(t *testing.T) {
+		// Arrange
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE users (id INT, name TEXT);")
+
+		// Act
+		mustExecute(t, executor, "DROP TABLE users;")
+		_, err := execute(t, executor, "SELECT id FROM users;")
+
+		// Assert
+		require.Error(t, err)
+	})
+}
+
+func TestExecuteWhere(t *testing.T) {
+	t.Run("1. Filter with comparisons and arithmetic", func(t *testing.T) {
+		// Arrange
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE users (id INT, age INT);")
+		mustExecute(t, executor, "INSERT INTO users VALUES (1, 17);")
+		mustExecute(t, executor, "INSERT INTO users VALUES (2, 30);")
+		mustExecute(t, executor, "INSERT INTO users VALUES (3, null);")
+
+		// Act
+		adults := mustExecute(t, executor, "SELECT id FROM users WHERE age + 1 >= 19;")
+		computed := mustExecute(t, executor, "SELECT id, age - (id + 1) FROM users WHERE id != 3;")
+
+		// Assert
+		require.Equal(t, [][]string{{"2"}}, resultStrings(adults))
+		require.Equal(t, [][]string{{"1", "15"}, {"2", "27"}}, resultStrings(computed))
+	})
+
+	t.Run("2. Invalid conditions", func(t *testing.T) {
+		// Arrange
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE users (id INT, name TEXT);")
+
+		// Act
+		_, comparisonErr := execute(t, executor, "SELECT id FROM users WHERE name = 1;")
+		_, whereErr := execute(t, executor, "SELECT id FROM users WHERE id;")
+
+		// Assert
+		require.Error(t, comparisonErr)
+		require.Error(t, whereErr)
+	})
+}
+
+func TestExecuteTemporalTypes(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE events (id INT, day DATE, at TIMESTAMP, duration INTERVAL);")
+		mustExecute(t, executor, "INSERT INTO events VALUES (1, '2024-01-31', '2024-01-31T10:20:30.123456Z', '1 day 02:00:00');")
+		mustExecute(t, executor, "INSERT INTO events VALUES (2, DATE '2024-03-01', TIMESTAMP '2024-03-01 00:00:00+03:00', INTERVAL '1 month');")
+		mustExecute(t, executor, "INSERT INTO events VALUES (3, null, null, null);")
+		return executor
+	}
+
+	t.Run("1. Select temporal columns", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT day, at, duration FROM events;")
+
+		// Assert
+		require.Equal(t, disk_manager.DATE_TYPE, result.Columns[0].DataType)
+		require.Equal(t, disk_manager.TIMESTAMP_TYPE, result.Columns[1].DataType)
+		require.Equal(t, disk_manager.INTERVAL_TYPE, result.Columns[2].DataType)
+		require.Equal(t, [][]string{
+			{"2024-01-31", "2024-01-31 10:20:30.123456", "1 day 02:00:00"},
+			{"2024-03-01", "2024-02-29 21:00:00", "1 mon"},
+			{"null", "null", "null"},
+		}, resultStrings(result))
+	})
+
+	t.Run("2. Filter by comparison with string and typed literals", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		afterFebruary := mustExecute(t, executor, "SELECT id FROM events WHERE day >= '2024-02-01';")
+		beforeTimestamp := mustExecute(t, executor, "SELECT id FROM events WHERE at < TIMESTAMP '2024-02-01 00:00:00';")
+		longDuration := mustExecute(t, executor, "SELECT id FROM events WHERE duration > INTERVAL '1 day';")
+
+		// Assert
+		require.Equal(t, [][]string{{"2"}}, resultStrings(afterFebruary))
+		require.Equal(t, [][]string{{"1"}}, resultStrings(beforeTimestamp))
+		require.Equal(t, [][]string{{"1"}, {"2"}}, resultStrings(longDuration))
+	})
+
+	t.Run("3. Interval arithmetic", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT day + 30, day - DATE '2024-01-01', at + duration, at - INTERVAL '1 hour', duration + INTERVAL '1 day' FROM events WHERE id = 1;")
+
+		// Assert
+		require.Equal(t, disk_manager.DATE_TYPE, result.Columns[0].DataType)
+		require.Equal(t, disk_manager.INT_32_TYPE, result.Columns[1].DataType)
+		require.Equal(t, disk_manager.TIMESTAMP_TYPE, result.Columns[2].DataType)
+		require.Equal(t, [][]string{{
+			"2024-03-01",
+			"30",
+			"2024-02-01 12:20:30.123456",
+			"2024-01-31 09:20:30.123456",
+			"2 days 02:00:00",
+		}}, resultStrings(result))
+	})
+
+	t.Run("4. Timestamp difference is an interval", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT TIMESTAMP '2024-01-02 03:00:00' - TIMESTAMP '2024-01-01 00:00:00' FROM events WHERE id = 1;")
+		centuries := mustExecute(t, executor, "SELECT TIMESTAMP '2500-01-01 00:00:00' - TIMESTAMP '1900-01-01 00:00:00', TIMESTAMP '1900-01-01 00:00:00' - TIMESTAMP '2500-01-01 12:00:00' FROM events WHERE id = 1;")
+
+		// Assert
+		require.Equal(t, [][]string{{"1 day 03:00:00"}}, resultStrings(result))
+		require.Equal(t, [][]string{{"219146 days", "-219146 days -12:00:00"}}, resultStrings(centuries))
+	})
+
+	t.Run("5. Functions now, date_trunc and extract", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT date_trunc('month', at), date_trunc('week', day), extract(year FROM day), extract(hour FROM duration), extract(dow FROM at) FROM events WHERE id = 1;")
+		past := mustExecute(t, executor, "SELECT id FROM events WHERE at < now();")
+		nowResult := mustExecute(t, executor, "SELECT now() FROM events WHERE id = 1;")
+
+		// Assert
+		require.Equal(t, [][]string{{"2024-01-01 00:00:00", "2024-01-29 00:00:00", "2024", "2", "3"}}, resultStrings(result))
+		require.Equal(t, [][]string{{"1"}, {"2"}}, resultStrings(past))
+		require.WithinDuration(t, time.Now(), nowResult.Rows[0][0].Data.(time.Time), time.Minute)
+	})
+
+	t.Run("6. extract returns DECIMAL with fractional seconds", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, `SELECT extract(epoch FROM TIMESTAMP '2040-01-01 00:00:00'), extract(second FROM TIMESTAMP '2024-01-31 10:20:05.75'),
+			extract(epoch FROM DATE '1900-01-01'), extract(second FROM INTERVAL '1 day 00:01:02.5'), extract(epoch FROM INTERVAL '1 day 00:00:01') FROM events WHERE id = 1;`)
+
+		// Assert
+		require.Equal(t, disk_manager.DECIMAL_TYPE, result.Columns[0].DataType)
+		require.Equal(t, [][]string{{"2208988800.000000", "5.750000", "-2208988800.000000", "2.500000", "86401.000000"}}, resultStrings(result))
+	})
+
+	t.Run("7. Invalid temporal values and operations", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, invalidDateErr := execute(t, executor, "INSERT INTO events VALUES (4, '2024-02-30', null, null);")
+		_, invalidOperationErr := execute(t, executor, "SELECT day + at FROM events;")
+		_, invalidComparisonErr := execute(t, executor, "SELECT id FROM events WHERE day = 1;")
+		_, invalidWhereErr := execute(t, executor, "SELECT id FROM events WHERE day;")
+
+		// Assert
+		require.Error(t, invalidDateErr)
+		require.Error(t, invalidOperationErr)
+		require.Error(t, invalidComparisonErr)
+		require.Error(t, invalidWhereErr)
+	})
+
+	t.Run("8. Type names as table and column names", func(t *testing.T) {
+		// Arrange
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE date (date DATE, timestamp TIMESTAMP);")
+		mustExecute(t, executor, "ALTER TABLE date ADD COLUMN interval INTERVAL DEFAULT INTERVAL '1 day';")
+		mustExecute(t, executor, "INSERT INTO date (date, timestamp) VALUES ('2024-01-31', TIMESTAMP '2024-01-31 10:00:00');")
+
+		// Act
+		result := mustExecute(t, executor, "SELECT date, date + interval, timestamp::date FROM date WHERE date >= DATE '2024-01-01';")
+
+		// Assert
+		require.Equal(t, "date", result.Columns[0].Name)
+		require.Equal(t, [][]string{{"2024-01-31", "2024-02-01 00:00:00", "2024-01-31"}}, resultStrings(result))
+	})
+
+	t.Run("9. Equal intervals are one value in groups, joins and set operations", func(t *testing.T) {
+		// Arrange
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE a (id INT, iv INTERVAL);")
+		mustExecute(t, executor, "INSERT INTO a VALUES (1, '1 day'), (2, '24 hours'), (3, '1 mon'), (4, '30 days');")
+		mustExecute(t, executor, "CREATE TABLE b (id INT, iv INTERVAL);")
+		mustExecute(t, executor, "INSERT INTO b VALUES (1, '24:00:00'), (2, '2 days');")
+
+		// Act
+		grouped := mustExecute(t, executor, "SELECT count(*) FROM a GROUP BY iv ORDER BY 1;")
+		distinct := mustExecute(t, executor, "SELECT count(DISTINCT iv) FROM a;")
+		hashJoin := mustExecute(t, executor, "SELECT a.id, b.id FROM a JOIN b ON a.iv = b.iv ORDER BY a.id;")
+		nestedLoop := mustExecute(t, executor, "SELECT a.id, b.id FROM a CROSS JOIN b WHERE a.iv = b.iv ORDER BY a.id;")
+		in := mustExecute(t, executor, "SELECT id FROM a WHERE iv IN (SELECT iv FROM b) ORDER BY id;")
+		where := mustExecute(t, executor, "SELECT id FROM a WHERE iv = INTERVAL '24 hours' ORDER BY id;")
+		intersect := mustExecute(t, executor, "SELECT iv FROM b INTERSECT SELECT iv FROM a;")
+
+		// Assert
+		require.Equal(t, [][]string{{"2"}, {"2"}}, resultStrings(grouped))
+		require.Equal(t, [][]string{{"2"}}, resultStrings(distinct))
+		require.Equal(t, [][]string{{"1", "1"}, {"2", "1"}}, resultStrings(hashJoin))
+		require.Equal(t, resultStrings(nestedLoop), resultStrings(hashJoin))
+		require.Equal(t, [][]string{{"1"}, {"2"}}, resultStrings(in))
+		require.Equal(t, resultStrings(where), resultStrings(in))
+		require.Equal(t, [][]string{{"24:00:00"}}, resultStrings(intersect))
+	})
+}
+
+func TestExecuteDecimalType(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE accounts (id INT, balance DECIMAL(10,2), rate NUMERIC(5,4), total DECIMAL);")
+		mustExecute(t, executor, "INSERT INTO accounts VALUES (1, 19.995, 0.12345, 123456789012345678901234567890.123);")
+		mustExecute(t, executor, "INSERT INTO accounts VALUES (2, -0.005, '1.5', 1e3);")
+		mustExecute(t, executor, "INSERT INTO accounts VALUES (3, 100, null, 5);")
+		return executor
+	}
+
+	t.Run("1. Values are rounded to column scale", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT balance, rate, total FROM accounts;")
+
+		// Assert
+		require.Equal(t, disk_manager.DECIMAL_TYPE, result.Columns[0].DataType)
+		require.Equal(t, [][]string{
+			{"20.00", "0.1235", "123456789012345678901234567890.123"},
+			{"-0.01", "1.5000", "1000"},
+			{"100.00", "null", "5"},
+		}, resultStrings(result))
+	})
+
+	t.Run("2. Exact arithmetic, comparison and round", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT balance + 0.1, balance - 1, total + 1, round(balance, 1), round(0.5) FROM accounts WHERE balance > 0.5;")
+		equal := mustExecute(t, executor, "SELECT id FROM accounts WHERE balance = 100;")
+
+		// Assert
+		require.Equal(t, [][]string{
+			{"20.10", "19.00", "123456789012345678901234567891.123", "20.0", "1"},
+			{"100.10", "99.00", "6", "100.0", "1"},
+		}, resultStrings(result))
+		require.Equal(t, [][]string{{"3"}}, resultStrings(equal))
+	})
+
+	t.Run("3. Overflow and invalid values", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, overflowErr := execute(t, executor, "INSERT INTO accounts VALUES (4, 123456789.00, 0, 0);")
+		_, invalidErr := execute(t, executor, "INSERT INTO accounts VALUES (4, 'abc', 0, 0);")
+		_, intOverflowErr := execute(t, executor, "INSERT INTO accounts VALUES (3000000000, 0, 0, 0);")
+
+		// Assert
+		require.Error(t, overflowErr)
+		require.Error(t, invalidErr)
+		require.Error(t, intOverflowErr)
+	})
+}
+
+func TestExecuteCharacterTypes(t *testing.T) {
+	setup := func(t *testing.T, config Config) ExecutorService {
+		executor := newTestExecutorWithConfig(t, config)
+		mustExecute(t, executor, "CREATE TABLE users (code CHAR(3), name VARCHAR(5), flag CHAR);")
+		return executor
+	}
+
+	t.Run("1. CHAR is padded and VARCHAR keeps value as is", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+
+		// Act
+		mustExecute(t, executor, "INSERT INTO users VALUES ('ab', 'Дима', 'y');")
+		mustExecute(t, executor, "INSERT INTO users VALUES ('xyz', 'Bob  ', null);")
+		result := mustExecute(t, executor, "SELECT code, name, flag FROM users;")
+
+		// Assert
+		require.Equal(t, disk_manager.CHAR_TYPE, result.Columns[0].DataType)
+		require.Equal(t, disk_manager.VARCHAR_TYPE, result.Columns[1].DataType)
+		require.Equal(t, [][]string{{"ab ", "Дима", "y"}, {"xyz", "Bob  ", "null"}}, resultStrings(result))
+	})
+
+	t.Run("2. Strict mode rejects oversize values", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+
+		// Act
+		_, varcharErr := execute(t, executor, "INSERT INTO users VALUES ('ab', 'Дмитрий', 'y');")
+		_, charErr := execute(t, executor, "INSERT INTO users VALUES ('abcd', 'Bob', 'y');")
+		_, trailingSpacesErr := execute(t, executor, "INSERT INTO users VALUES ('abc   ', 'Alice   ', 'y');")
+		result := mustExecute(t, executor, "SELECT code, name FROM users;")
+
+		// Assert
+		require.ErrorContains(t, varcharErr, "value too long for type VARCHAR(5)")
+		require.ErrorContains(t, charErr, "value too long for type CHAR(3)")
+		require.NoError(t, trailingSpacesErr)
+		require.Equal(t, [][]string{{"abc", "Alice"}}, resultStrings(result))
+	})
+
+	t.Run("3. Non-strict mode truncates oversize values", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, Config{StrictMode: false})
+
+		// Act
+		mustExecute(t, executor, "INSERT INTO users VALUES ('abcd', 'Дмитрий', 'yes');")
+		result := mustExecute(t, executor, "SELECT code, name, flag FROM users;")
+
+		// Assert
+		require.Equal(t, [][]string{{"abc", "Дмитр", "y"}}, resultStrings(result))
+	})
+
+	t.Run("4. CHAR comparison ignores trailing spaces", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+		mustExecute(t, executor, "INSERT INTO users VALUES ('ab', 'ab', 'y');")
+		mustExecute(t, executor, "INSERT INTO users VALUES ('xyz', 'Bob', 'n');")
+
+		// Act
+		byLiteral := mustExecute(t, executor, "SELECT name FROM users WHERE code = 'ab';")
+		byColumn := mustExecute(t, executor, "SELECT name FROM users WHERE code = name;")
+
+		// Assert
+		require.Equal(t, [][]string{{"ab"}}, resultStrings(byLiteral))
+		require.Equal(t, [][]string{{"ab"}}, resultStrings(byColumn))
+	})
+}
+
+func TestExecuteByteaType(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE blobs (id INT, payload BYTEA);")
+		mustExecute(t, executor, "INSERT INTO blobs VALUES (1, X'DEADBEEF');")
+		mustExecute(t, executor, "INSERT INTO blobs VALUES (2, decode('aGVsbG8=', 'base64'));")
+		mustExecute(t, executor, "INSERT INTO blobs VALUES (3, '\\x00ff');")
+		return executor
+	}
+
+	t.Run("1. Hex literals, decode and text input", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id, payload FROM blobs;")
+
+		// Assert
+		require.Equal(t, disk_manager.BYTEA_TYPE, result.Columns[1].DataType)
+		require.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, result.Rows[0][1].Data)
+		require.Equal(t, [][]string{{"1", `\xdeadbeef`}, {"2", `\x68656c6c6f`}, {"3", `\x00ff`}}, resultStrings(result))
+	})
+
+	t.Run("2. Encode to hex and base64", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT encode(payload, 'hex'), encode(payload, 'base64') FROM blobs WHERE payload = X'deadbeef';")
+
+		// Assert
+		require.Equal(t, disk_manager.TEXT_TYPE, result.Columns[0].DataType)
+		require.Equal(t, [][]string{{"deadbeef", "3q2+7w=="}}, resultStrings(result))
+	})
+
+	t.Run("3. Invalid binary data", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, oddHexErr := execute(t, executor, "INSERT INTO blobs VALUES (4, X'ABC');")
+		_, invalidBase64Err := execute(t, executor, "INSERT INTO blobs VALUES (4, decode('***', 'base64'));")
+		_, unknownEncodingErr := execute(t, executor, "SELECT encode(payload, 'base32') FROM blobs;")
+		_, wrongTypeErr := execute(t, executor, "SELECT encode(id, 'hex') FROM blobs;")
+
+		// Assert
+		require.Error(t, oddHexErr)
+		require.Error(t, invalidBase64Err)
+		require.Error(t, unknownEncodingErr)
+		require.Error(t, wrongTypeErr)
+	})
+}
+
+func TestExecuteJsonType(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE events (id INT, payload JSON);")
+		mustExecute(t, executor, `INSERT INTO events VALUES (1, '{"type": "click", "user": {"id": 7, "tags": ["a", "b"]}}');`)
+		mustExecute(t, executor, `INSERT INTO events VALUES (2, '[1, 2, {"x": null}]');`)
+		return executor
+	}
+
+	t.Run("1. Documents are validated and stored compact", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT payload FROM events;")
+		_, invalidErr := execute(t, executor, "INSERT INTO events VALUES (3, '{bad json}');")
+
+		// Assert
+		require.Equal(t, disk_manager.JSON_TYPE, result.Columns[0].DataType)
+		require.Equal(t, [][]string{{`{"type":"click","user":{"id":7,"tags":["a","b"]}}`}, {`[1,2,{"x":null}]`}}, resultStrings(result))
+		require.Error(t, invalidErr)
+	})
+
+	t.Run("2. Path operators", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT payload -> 'user', payload ->> 'type', payload #> '{user,tags,1}', payload #>> '{user,id}' FROM events WHERE id = 1;")
+		arrayResult := mustExecute(t, executor, "SELECT payload -> 2 -> 'x', payload -> -1, payload ->> 'type' FROM events WHERE id = 2;")
+
+		// Assert
+		require.Equal(t, disk_manager.JSON_TYPE, result.Columns[0].DataType)
+		require.Equal(t, disk_manager.TEXT_TYPE, result.Columns[1].DataType)
+		require.Equal(t, [][]string{{`{"id":7,"tags":["a","b"]}`, "click", `"b"`, "7"}}, resultStrings(result))
+		require.Equal(t, [][]string{{"null", `{"x":null}`, "null"}}, resultStrings(arrayResult))
+		require.False(t, arrayResult.Rows[0][0].IsNull)
+		require.True(t, arrayResult.Rows[0][2].IsNull)
+	})
+
+	t.Run("3. Filter by extracted text", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id FROM events WHERE payload ->> 'type' = 'click';")
+
+		// Assert
+		require.Equal(t, [][]string{{"1"}}, resultStrings(result))
+	})
+
+	t.Run("4. JSON functions", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT json_extract(payload, '$.user.tags[0]'), json_typeof(payload), json_typeof(payload -> 'user' -> 'id') FROM events WHERE id = 1;")
+		lengthResult := mustExecute(t, executor, "SELECT json_array_length(payload), json_array_length('[1, [2, 3]]') FROM events WHERE id = 2;")
+		_, notArrayErr := execute(t, executor, "SELECT json_array_length(payload) FROM events WHERE id = 1;")
+		_, wrongTypeErr := execute(t, executor, "SELECT json_typeof(id) FROM events;")
+
+		// Assert
+		require.Equal(t, [][]string{{`"a"`, "object", "number"}}, resultStrings(result))
+		require.Equal(t, [][]string{{"3", "2"}}, resultStrings(lengthResult))
+		require.Error(t, notArrayErr)
+		require.Error(t, wrongTypeErr)
+	})
+}
+
+func TestExecuteOrderBy(t *testing.T) {
+	setup := func(t *testing.T, config Config) ExecutorService {
+		executor := newTestExecutorWithConfig(t, config)
+		mustExecute(t, executor, "CREATE TABLE users (id INT, name TEXT, score DECIMAL(5,1));")
+		mustExecute(t, executor, "INSERT INTO users VALUES (1, 'Joffrey', 3.5);")
+		mustExecute(t, executor, "INSERT INTO users VALUES (2, 'Walter', null);")
+		mustExecute(t, executor, "INSERT INTO users VALUES (3, null, 1.0);")
+		mustExecute(t, executor, "INSERT INTO users VALUES (4, 'Arya', 3.5);")
+		return executor
+	}
+
+	t.Run("1. Several keys with directions", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id FROM users ORDER BY score DESC, name;")
+
+		// Assert
+		require.Equal(t, [][]string{{"2"}, {"4"}, {"1"}, {"3"}}, resultStrings(result))
+	})
+
+	t.Run("2. NULLS FIRST and NULLS LAST", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+
+		// Act
+		nullsFirst := mustExecute(t, executor, "SELECT id FROM users ORDER BY name NULLS FIRST;")
+		nullsLast := mustExecute(t, executor, "SELECT id FROM users ORDER BY score DESC NULLS LAST, id;")
+
+		// Assert
+		require.Equal(t, [][]string{{"3"}, {"4"}, {"1"}, {"2"}}, resultStrings(nullsFirst))
+		require.Equal(t, [][]string{{"1"}, {"4"}, {"3"}, {"2"}}, resultStrings(nullsLast))
+	})
+
+	t.Run("3. Positions and expressions", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+
+		// Act
+		byPosition := mustExecute(t, executor, "SELECT id + 10, name FROM users WHERE id > 1 ORDER BY 1 DESC;")
+		byStarPosition := mustExecute(t, executor, "SELECT * FROM users ORDER BY 3, 1 DESC;")
+		byExpression := mustExecute(t, executor, "SELECT id FROM users ORDER BY 0 - id;")
+		_, outOfRangeErr := execute(t, executor, "SELECT id FROM users ORDER BY 2;")
+
+		// Assert
+		require.Equal(t, [][]string{{"14", "Arya"}, {"13", "null"}, {"12", "Walter"}}, resultStrings(byPosition))
+		require.Equal(t, []string{"3", "4", "1", "2"}, []string{
+			byStarPosition.Rows[0][0].String(), byStarPosition.Rows[1][0].String(),
+			byStarPosition.Rows[2][0].String(), byStarPosition.Rows[3][0].String(),
+		})
+		require.Equal(t, [][]string{{"4"}, {"3"}, {"2"}, {"1"}}, resultStrings(byExpression))
+		require.Error(t, outOfRangeErr)
+	})
+
+	t.Run("4. External sort spills runs to temporary files", func(t *testing.T) {
+		// Arrange
+		tempDir := t.TempDir()
+		executor := setup(t, Config{StrictMode: true, WorkMemory: 64, TempDir: tempDir})
+		for i := 5; i <= 60; i++ {
+			mustExecute(t, executor, fmt.Sprintf("INSERT INTO users VALUES (%d, 'user %02d', %d.5);", i, i%7, i%4))
+		}
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id, score FROM users ORDER BY score DESC, id;")
+
+		// Assert
+		require.Len(t, result.Rows, 60)
+		for i := 1; i < len(result.Rows); i++ {
+			previous, current := result.Rows[i-1], result.Rows[i]
+			if previous[1].IsNull || current[1].IsNull {
+				require.True(t, previous[1].IsNull || !current[1].IsNull)
+				continue
+			}
+			compared, err := compareCells(previous[1], current[1])
+			require.NoError(t, err)
+			require.True(t, compared > 0 || compared == 0 && previous[0].Data.(int32) < current[0].Data.(int32))
+		}
+
+		// Временные файлы удаляются после выполнения запроса
+		entries, err := os.ReadDir(tempDir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("5. FIRST and LAST as column names", func(t *testing.T) {
+		// Arrange
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE k3 (first INT, last INT);")
+		mustExecute(t, executor, "INSERT INTO k3 VALUES (1, null), (2, 5), (3, 5);")
+
+		// Act
+		result := mustExecute(t, executor, "SELECT first, last FROM k3 ORDER BY last NULLS FIRST, first DESC NULLS LAST;")
+
+		// Assert
+		require.Equal(t, [][]string{{"1", "null"}, {"3", "5"}, {"2", "5"}}, resultStrings(result))
+	})
+}
+
+// countingOperator возвращает заранее заданные строки и считает, сколько раз у него запросили строку
+type countingOperator struct {
+	columns []ResultColumn
+	rows    []disk_manager.Row
+	calls   int
+}
+
+func (op *countingOperator) Columns() []ResultColumn {
+	return op.columns
+}
+
+func (op *countingOperator) Next() (disk_manager.Row, bool, error) {
+	op.calls++
+	if op.calls > len(op.rows) {
+		return nil, false, nil
+	}
+	return op.rows[op.calls-1], true, nil
+}
+
+func (op *countingOperator) Close() error {
+	return nil
+}
+
+func TestExecuteLimitOffset(t *testing.T) {
+	setup := func(t *testing.T, config Config) ExecutorService {
+		executor := newTestExecutorWithConfig(t, config)
+		mustExecute(t, executor, "CREATE TABLE items (id INT, grp INT);")
+		for i := 1; i <= 10; i++ {
+			mustExecute(t, executor, fmt.Sprintf("INSERT INTO items VALUES (%d, %d);", i, i%3))
+		}
+		return executor
+	}
+
+	t.Run("1. LIMIT and OFFSET without ordering", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+
+		// Act
+		limited := mustExecute(t, executor, "SELECT id FROM items LIMIT 3;")
+		paged := mustExecute(t, executor, "SELECT id FROM items LIMIT 2 OFFSET 4;")
+		offsetOnly := mustExecute(t, executor, "SELECT id FROM items OFFSET 8;")
+		empty := mustExecute(t, executor, "SELECT id FROM items LIMIT 0;")
+		pastEnd := mustExecute(t, executor, "SELECT id FROM items LIMIT 5 OFFSET 20;")
+
+		// Assert
+		require.Equal(t, [][]string{{"1"}, {"2"}, {"3"}}, resultStrings(limited))
+		require.Equal(t, [][]string{{"5"}, {"6"}}, resultStrings(paged))
+		require.Equal(t, [][]string{{"9"}, {"10"}}, resultStrings(offsetOnly))
+		require.Empty(t, empty.Rows)
+		require.Empty(t, pastEnd.Rows)
+	})
+
+	t.Run("2. Top-N with ORDER BY keeps ties in input order", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id, grp FROM items ORDER BY grp DESC LIMIT 4 OFFSET 1;")
+
+		// Assert
+		require.Equal(t, [][]string{{"5", "2"}, {"8", "2"}, {"1", "1"}, {"4", "1"}}, resultStrings(result))
+	})
+
+	t.Run("3. Top-N falls back to external sort when rows do not fit in memory", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, Config{StrictMode: true, WorkMemory: 16, TempDir: t.TempDir()})
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id FROM items ORDER BY grp, id DESC LIMIT 5;")
+
+		// Assert
+		require.Equal(t, [][]string{{"9"}, {"6"}, {"3"}, {"10"}, {"7"}}, resultStrings(result))
+	})
+
+	t.Run("4. LIMIT stops pulling rows from the input", func(t *testing.T) {
+		// Arrange
+		input := &countingOperator{
+			columns: []ResultColumn{{Name: "id", DataType: disk_manager.INT_32_TYPE}},
+		}
+		for i := 0; i < 100; i++ {
+			input.rows = append(input.rows, disk_manager.Row{{DataType: disk_manager.INT_32_TYPE, Data: int32(i)}})
+		}
+		limit := newLimitOperator(input, 3, 2)
+
+		// Act
+		rows := []disk_manager.Row{}
+		for {
+			row, ok, err := limit.Next()
+			require.NoError(t, err)
+			if !ok {
+				break
+			}
+			rows = append(rows, row)
+		}
+
+		// Assert
+		require.Len(t, rows, 3)
+		require.Equal(t, int32(2), rows[0][0].Data)
+		require.Equal(t, 5, input.calls)
+	})
+}
+
+func TestExecuteAggregates(t *testing.T) {
+	setup := func(t *testing.T, config Config) ExecutorService {
+		executor := newTestExecutorWithConfig(t, config)
+		mustExecute(t, executor, "CREATE TABLE sales (id INT, region TEXT, amount DECIMAL(8,2), qty INT);")
+		mustExecute(t, executor, "INSERT INTO sales VALUES (1, 'north', 10.50, 1);")
+		mustExecute(t, executor, "INSERT INTO sales VALUES (2, 'south', 20.00, 2);")
+		mustExecute(t, executor, "INSERT INTO sales VALUES (3, 'north', 5.25, 2);")
+		mustExecute(t, executor, "INSERT INTO sales VALUES (4, null, 1.00, null);")
+		mustExecute(t, executor, "INSERT INTO sales VALUES (5, 'south', null, 2);")
+		return executor
+	}
+
+	t.Run("1. Aggregates without GROUP BY", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+
+		// Act
+		result := mustExecute(t, executor, "SELECT count(*), count(amount), sum(qty), avg(qty), min(region), max(amount) FROM sales;")
+
+		// Assert
+		require.Equal(t, []ResultColumn{
+			{Name: "count", DataType: disk_manager.INT_32_TYPE},
+			{Name: "count", DataType: disk_manager.INT_32_TYPE},
+			{Name: "sum", DataType: disk_manager.DECIMAL_TYPE},
+			{Name: "avg", DataType: disk_manager.DECIMAL_TYPE},
+			{Name: "min", DataType: disk_manager.TEXT_TYPE},
+			{Name: "max", DataType: disk_manager.DECIMAL_TYPE},
+		}, result.Columns)
+		require.Equal(t, [][]string{{"5", "4", "7", "1.7500000000000000", "north", "20.00"}}, resultStrings(result))
+	})
+
+	t.Run("2. GROUP BY with DISTINCT and NULL group", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+
+		// Act
+		result := mustExecute(t, executor, "SELECT region, count(*), sum(amount), count(DISTINCT qty) FROM sales GROUP BY region ORDER BY region;")
+
+		// Assert
+		require.Equal(t, [][]string{
+			{"north", "2", "15.75", "2"},
+			{"south", "2", "20.00", "1"},
+			{"null", "1", "1.00", "0"},
+		}, resultStrings(result))
+	})
+
+	t.Run("3. HAVING, expression keys and ordering by aggregates", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+
+		// Act
+		having := mustExecute(t, executor, "SELECT region, sum(qty) FROM sales GROUP BY region HAVING count(*) > 1 ORDER BY sum(qty) DESC;")
+		expressionKey := mustExecute(t, executor, "SELECT qty + 1, count(*) FROM sales GROUP BY qty + 1 ORDER BY 1;")
+		star := mustExecute(t, executor, "SELECT * FROM sales WHERE id < 3 GROUP BY id, region, amount, qty ORDER BY id;")
+
+		// Assert
+		require.Equal(t, [][]string{{"south", "4"}, {"north", "3"}}, resultStrings(having))
+		require.Equal(t, [][]string{{"2", "1"}, {"3", "3"}, {"null", "1"}}, resultStrings(expressionKey))
+		require.Equal(t, [][]string{{"1", "north", "10.50", "1"}, {"2", "south", "20.00", "2"}}, resultStrings(star))
+	})
+
+	t.Run("4. Empty input", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+
+		// Act
+		global := mustExecute(t, executor, "SELECT count(*), sum(amount), max(region) FROM sales WHERE id > 100;")
+		grouped := mustExecute(t, executor, "SELECT region, count(*) FROM sales WHERE id > 100 GROUP BY region;")
+
+		// Assert
+		require.Equal(t, [][]string{{"0", "null", "null"}}, resultStrings(global))
+		require.Empty(t, grouped.Rows)
+	})
+
+	t.Run("5. Invalid aggregates", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+
+		// Act
+		_, sumTextErr := execute(t, executor, "SELECT sum(region) FROM sales;")
+		_, starErr := execute(t, executor, "SELECT * FROM sales GROUP BY region;")
+		_, havingTypeErr := execute(t, executor, "SELECT region FROM sales GROUP BY region HAVING count(*);")
+
+		// Assert
+		require.Error(t, sumTextErr)
+		require.ErrorContains(t, starErr, "must appear in the GROUP BY clause")
+		require.ErrorContains(t, havingTypeErr, "argument of HAVING must be type BOOLEAN")
+	})
+
+	t.Run("6. sum and avg of intervals", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+		mustExecute(t, executor, "CREATE TABLE shifts (worker TEXT, duration INTERVAL);")
+		mustExecute(t, executor, "INSERT INTO shifts VALUES ('ann', '1 day'), ('ann', '12:00:00'), ('bob', '1 mon'), ('bob', null);")
+
+		// Act
+		global := mustExecute(t, executor, "SELECT sum(duration), avg(duration) FROM shifts;")
+		grouped := mustExecute(t, executor, "SELECT worker, sum(duration), avg(duration) FROM shifts GROUP BY worker ORDER BY worker;")
+
+		// Assert
+		require.Equal(t, disk_manager.INTERVAL_TYPE, global.Columns[0].DataType)
+		require.Equal(t, disk_manager.INTERVAL_TYPE, global.Columns[1].DataType)
+		require.Equal(t, [][]string{{"1 mon 1 day 12:00:00", "10 days 12:00:00"}}, resultStrings(global))
+		require.Equal(t, [][]string{
+			{"ann", "1 day 12:00:00", "18:00:00"},
+			{"bob", "1 mon", "1 mon"},
+		}, resultStrings(grouped))
+	})
+
+	t.Run("7. Groups that do not fit in memory are spilled to partitions", func(t *testing.T) {
+		// Arrange
+		tempDir := t.TempDir()
+		executor := setup(t, Config{StrictMode: true, WorkMemory: 256, TempDir: tempDir})
+		for i := 6; i <= 125; i++ {
+			mustExecute(t, executor, fmt.Sprintf("INSERT INTO sales VALUES (%d, 'region %d', %d.25, %d);", i, i%40, i, i%3))
+		}
+
+		// Act
+		result := mustExecute(t, executor, "SELECT region, count(*), sum(qty), count(DISTINCT qty) FROM sales WHERE id > 5 GROUP BY region ORDER BY region;")
+
+		// Assert
+		require.Len(t, result.Rows, 40)
+		for _, row := range result.Rows {
+			require.Equal(t, int32(3), row[1].Data)
+			require.Equal(t, "3", row[2].String())
+			require.Equal(t, int32(3), row[3].Data)
+		}
+		require.Equal(t, "region 0", result.Rows[0][0].String())
+
+		// Временные файлы партиций удаляются после выполнения запроса
+		entries, err := os.ReadDir(tempDir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+}
+
+func TestExecuteJoins(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE users (id INT, name TEXT);")
+		mustExecute(t, executor, "CREATE TABLE posts (id INT, user_id INT, title TEXT);")
+		mustExecute(t, executor, "INSERT INTO users VALUES (1, 'Joffrey');")
+		mustExecute(t, executor, "INSERT INTO users VALUES (2, 'Walter');")
+		mustExecute(t, executor, "INSERT INTO users VALUES (3, null);")
+		mustExecute(t, executor, "INSERT INTO posts VALUES (1, 1, 'Hello');")
+		mustExecute(t, executor, "INSERT INTO posts VALUES (2, 1, 'Again');")
+		mustExecute(t, executor, "INSERT INTO posts VALUES (3, 2, 'Chemistry');")
+		mustExecute(t, executor, "INSERT INTO posts VALUES (4, 7, 'Orphan');")
+		mustExecute(t, executor, "INSERT INTO posts VALUES (5, null, 'Draft');")
+		return executor
+	}
+
+	t.Run("1. Inner join with aliases and qualified columns", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT u.name, p.title FROM users u JOIN posts AS p ON u.id = p.user_id;")
+		swapped := mustExecute(t, executor, "SELECT users.name, title FROM posts INNER JOIN users ON posts.user_id = users.id;")
+
+		// Assert
+		require.Equal(t, []ResultColumn{
+			{Name: "name", DataType: disk_manager.TEXT_TYPE},
+			{Name: "title", DataType: disk_manager.TEXT_TYPE},
+		}, result.Columns)
+		require.Equal(t, [][]string{{"Joffrey", "Hello"}, {"Joffrey", "Again"}, {"Walter", "Chemistry"}}, resultStrings(result))
+		require.Equal(t, [][]string{{"Joffrey", "Hello"}, {"Joffrey", "Again"}, {"Walter", "Chemistry"}}, resultStrings(swapped))
+	})
+
+	t.Run("2. Outer joins pad rows without a match with NULL", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		left := mustExecute(t, executor, "SELECT u.id, p.id FROM users u LEFT JOIN posts p ON u.id = p.user_id;")
+		right := mustExecute(t, executor, "SELECT u.id, p.id FROM users u RIGHT OUTER JOIN posts p ON u.id = p.user_id;")
+		full := mustExecute(t, executor, "SELECT u.id, p.id FROM users u FULL JOIN posts p ON u.id = p.user_id;")
+
+		// Assert
+		require.Equal(t, [][]string{{"1", "1"}, {"1", "2"}, {"2", "3"}, {"3", "null"}}, resultStrings(left))
+		require.Equal(t, [][]string{{"1", "1"}, {"1", "2"}, {"2", "3"}, {"null", "4"}, {"null", "5"}}, resultStrings(right))
+		require.Equal(t, [][]string{{"1", "1"}, {"1", "2"}, {"2", "3"}, {"3", "null"}, {"null", "4"}, {"null", "5"}}, resultStrings(full))
+	})
+
+	t.Run("3. Cross join and non-equality conditions", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		cross := mustExecute(t, executor, "SELECT count(*) FROM users CROSS JOIN posts;")
+		comma := mustExecute(t, executor, "SELECT u.id, p.id FROM users u, posts p WHERE p.id = u.id + 2;")
+		nonEqui := mustExecute(t, executor, "SELECT u.id, p.id FROM users u LEFT JOIN posts p ON p.id < u.id ORDER BY u.id, p.id;")
+
+		// Assert
+		require.Equal(t, [][]string{{"15"}}, resultStrings(cross))
+		require.Equal(t, [][]string{{"1", "3"}, {"2", "4"}, {"3", "5"}}, resultStrings(comma))
+		require.Equal(t, [][]string{{"1", "null"}, {"2", "1"}, {"3", "1"}, {"3", "2"}}, resultStrings(nonEqui))
+	})
+
+	t.Run("4. Join with grouping and SELECT *", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		grouped := mustExecute(t, executor, "SELECT u.name, count(p.id) FROM users u LEFT JOIN posts p ON p.user_id = u.id GROUP BY name ORDER BY u.name;")
+		star := mustExecute(t, executor, "SELECT * FROM users u JOIN posts p ON u.id = p.user_id WHERE p.id = 3;")
+
+		// Assert
+		require.Equal(t, [][]string{{"Joffrey", "2"}, {"Walter", "1"}, {"null", "0"}}, resultStrings(grouped))
+		require.Equal(t, []string{"id", "name", "id", "user_id", "title"}, columnNames(star))
+		require.Equal(t, [][]string{{"2", "Walter", "3", "2", "Chemistry"}}, resultStrings(star))
+	})
+
+	t.Run("5. Planner chooses hash join for equality of both sides", func(t *testing.T) {
+		// Arrange
+		e := setup(t).(*executor)
+		plan := func(query string) operator {
+			tree, err := parser.NewParser().Parse(query)
+			require.NoError(t, err)
+			from, _, err := e.buildFromPlan(tree.Statements[0].SelectStatement)
+			require.NoError(t, err)
+			return from
+		}
+
+		// Act
+		equality := plan("SELECT * FROM users u JOIN posts p ON p.user_id = u.id;")
+		inequality := plan("SELECT * FROM users u JOIN posts p ON p.user_id < u.id;")
+		oneSided := plan("SELECT * FROM users u JOIN posts p ON u.id = u.id + 0;")
+		cross := plan("SELECT * FROM users CROSS JOIN posts;")
+
+		// Assert
+		require.IsType(t, &hashJoinOperator{}, equality)
+		require.IsType(t, &nestedLoopJoinOperator{}, inequality)
+		require.IsType(t, &nestedLoopJoinOperator{}, oneSided)
+		require.IsType(t, &nestedLoopJoinOperator{}, cross)
+	})
+
+	t.Run("6. Errors", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, ambiguousErr := execute(t, executor, "SELECT id FROM users JOIN posts ON users.id = posts.user_id;")
+		_, unknownTableErr := execute(t, executor, "SELECT u.id FROM users u JOIN comments c ON u.id = c.user_id;")
+		_, hiddenNameErr := execute(t, executor, "SELECT users.id FROM users u JOIN posts p ON u.id = p.user_id;")
+		_, typeErr := execute(t, executor, "SELECT * FROM users u JOIN posts p ON u.id;")
+
+		// Assert
+		require.ErrorContains(t, ambiguousErr, "column reference id is ambiguous")
+		require.Error(t, unknownTableErr)
+		require.ErrorContains(t, hiddenNameErr, "column users.id does not exist")
+		require.ErrorContains(t, typeErr, "argument of JOIN/ON must be type BOOLEAN")
+	})
+}
+
+// columnNames возвращает имена колонок результата
+func columnNames(result *Result) []string {
+	names := []string{}
+	for _, column := range result.Columns {
+		names = append(names, column.Name)
+	}
+	return names
+}
+
+func TestExecuteLargeJoins(t *testing.T) {
+	setup := func(t *testing.T, config Config) *executor {
+		e := newTestExecutorWithConfig(t, config).(*executor)
+		mustExecute(t, e, "CREATE TABLE users (id INT, name TEXT);")
+		mustExecute(t, e, "CREATE TABLE posts (id INT, user_id DECIMAL(6,1), title TEXT);")
+		for i := 1; i <= 60; i++ {
+			mustExecute(t, e, fmt.Sprintf("INSERT INTO users VALUES (%d, 'user %d');", i, i))
+		}
+		for i := 1; i <= 200; i++ {
+			userID := fmt.Sprintf("%d.0", i%70)
+			if i%25 == 0 {
+				userID = "null"
+			}
+			mustExecute(t, e, fmt.Sprintf("INSERT INTO posts VALUES (%d, %s, 'post %d');", i, userID, i))
+		}
+		mustExecute(t, e, "INSERT INTO users VALUES (null, 'nobody');")
+		return e
+	}
+
+	// join выполняет соединение, передавая планировщику размеры сторон leftSize и rightSize,
+	// и возвращает тип выбранного оператора и отсортированные строки результата
+	join := func(t *testing.T, e *executor, kind string, leftSize, rightSize int) (operator, []string) {
+		tree, err := parser.NewParser().Parse(fmt.Sprintf("SELECT * FROM users u %s JOIN posts p ON u.id = p.user_id;", kind))
+		require.NoError(t, err)
+		stmt := tree.Statements[0].SelectStatement
+
+		left, err := e.buildTableScan(stmt.Table, stmt.Alias)
+		require.NoError(t, err)
+		right, err := e.buildTableScan(stmt.Joins[0].Table, stmt.Joins[0].Alias)
+		require.NoError(t, err)
+		plan, err := e.buildJoin(left, right, stmt.Joins[0], leftSize, rightSize)
+		require.NoError(t, err)
+
+		rows := []string{}
+		for {
+			row, ok, err := plan.Next()
+			require.NoError(t, err)
+			if !ok {
+				break
+			}
+			values := []string{}
+			for _, cell := range row {
+				values = append(values, cell.String())
+			}
+			rows = append(rows, strings.Join(values, "|"))
+		}
+		require.NoError(t, plan.Close())
+
+		sort.Strings(rows)
+		return plan, rows
+	}
+
+	t.Run("1. Grace hash join and sort-merge join return the same rows as hash join", func(t *testing.T) {
+		// Arrange
+		tempDir := t.TempDir()
+		e := setup(t, Config{StrictMode: true, WorkMemory: 64, TempDir: tempDir})
+		expectedCounts := map[string]int{"INNER": 172, "LEFT": 173, "RIGHT": 200, "FULL": 201}
+		largeSize := 64*JOIN_PARTITIONS + 1
+
+		for kind, expectedCount := range expectedCounts {
+			// Act
+			hash, hashRows := join(t, e, kind, largeSize, 0)
+			grace, graceRows := join(t, e, kind, largeSize, 64*JOIN_PARTITIONS)
+			merge, mergeRows := join(t, e, kind, largeSize, 64*JOIN_PARTITIONS+1)
+			swappedHash, swappedHashRows := join(t, e, kind, 0, largeSize)
+			swappedGrace, swappedGraceRows := join(t, e, kind, 64*JOIN_PARTITIONS, largeSize)
+
+			// Assert
+			require.IsType(t, &hashJoinOperator{}, hash)
+			require.IsType(t, &graceHashJoinOperator{}, grace)
+			require.IsType(t, &mergeJoinOperator{}, merge)
+			require.IsType(t, &hashJoinOperator{}, swappedHash.(*swappedJoinOperator).input)
+			require.IsType(t, &graceHashJoinOperator{}, swappedGrace.(*swappedJoinOperator).input)
+			require.Len(t, hashRows, expectedCount, kind)
+			require.Equal(t, hashRows, graceRows, kind)
+			require.Equal(t, hashRows, mergeRows, kind)
+			require.Equal(t, hashRows, swappedHashRows, kind)
+			require.Equal(t, hashRows, swappedGraceRows, kind)
+		}
+
+		// Временные файлы партиций и сортировки удаляются после выполнения соединения
+		entries, err := os.ReadDir(tempDir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("2. Planner chooses join by table sizes", func(t *testing.T) {
+		// Arrange
+		plan := func(workMemory int, query string) operator {
+			e := setup(t, Config{StrictMode: true, WorkMemory: workMemory, TempDir: t.TempDir()})
+			tree, err := parser.NewParser().Parse(query)
+			require.NoError(t, err)
+			from, _, err := e.buildFromPlan(tree.Statements[0].SelectStatement)
+			require.NoError(t, err)
+			return from
+		}
+		tables := setup(t, DefaultConfig())
+		usersMeta, err := tables.readMetaInfo("users")
+		require.NoError(t, err)
+		postsMeta, err := tables.readMetaInfo("posts")
+		require.NoError(t, err)
+		usersSize := int(usersMeta.DataHeaders.PagesCount) * disk_manager.PAGE_SIZE
+		postsSize := int(postsMeta.DataHeaders.PagesCount) * disk_manager.PAGE_SIZE
+		postsFirst := "SELECT * FROM posts p JOIN users u ON u.id = p.user_id;"
+		usersFirst := "SELECT * FROM users u LEFT JOIN posts p ON u.id = p.user_id;"
+
+		// Act
+		inMemory := plan(usersSize, postsFirst)
+		grace := plan(usersSize/JOIN_PARTITIONS, postsFirst)
+		merge := plan(usersSize/JOIN_PARTITIONS-1, postsFirst)
+		swapped := plan(usersSize, usersFirst)
+
+		// Assert
+		require.Less(t, usersSize, postsSize)
+		require.IsType(t, &hashJoinOperator{}, inMemory)
+		require.IsType(t, &graceHashJoinOperator{}, grace)
+		require.IsType(t, &mergeJoinOperator{}, merge)
+		// Меньшая сторона слева: hash таблица строится по ней, LEFT JOIN выполняется как RIGHT JOIN
+		require.IsType(t, &hashJoinOperator{}, swapped.(*swappedJoinOperator).input)
+		require.Equal(t, ast.RightJoin, swapped.(*swappedJoinOperator).input.(*hashJoinOperator).kind)
+		require.Equal(t, []string{"id", "name", "id", "user_id", "title"}, columnNames(&Result{Columns: swapped.Columns()}))
+	})
+
+	t.Run("3. Join queries with little memory", func(t *testing.T) {
+		// Arrange
+		e := setup(t, Config{StrictMode: true, WorkMemory: 256, TempDir: t.TempDir()})
+
+		// Act
+		result := mustExecute(t, e, "SELECT u.name, count(p.id) FROM users u LEFT JOIN posts p ON u.id = p.user_id GROUP BY u.name ORDER BY count(p.id) DESC, u.name LIMIT 3;")
+
+		// Assert
+		require.Equal(t, [][]string{{"user 1", "3"}, {"user 11", "3"}, {"user 12", "3"}}, resultStrings(result))
+	})
+}
+
+func TestExecuteSubqueries(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE users (id INT, name TEXT);")
+		mustExecute(t, executor, "CREATE TABLE posts (id INT, user_id INT, title TEXT);")
+		mustExecute(t, executor, "INSERT INTO users VALUES (1, 'Joffrey');")
+		mustExecute(t, executor, "INSERT INTO users VALUES (2, 'Walter');")
+		mustExecute(t, executor, "INSERT INTO users VALUES (3, 'Arya');")
+		mustExecute(t, executor, "INSERT INTO users VALUES (null, 'Nobody');")
+		mustExecute(t, executor, "INSERT INTO posts VALUES (1, 1, 'Hello');")
+		mustExecute(t, executor, "INSERT INTO posts VALUES (2, 1, 'Again');")
+		mustExecute(t, executor, "INSERT INTO posts VALUES (3, 2, 'Chemistry');")
+		mustExecute(t, executor, "INSERT INTO posts VALUES (4, null, 'Draft');")
+		return executor
+	}
+
+	t.Run("1. Scalar subqueries", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		uncorrelated := mustExecute(t, executor, "SELECT name FROM users WHERE id = (SELECT max(user_id) FROM posts);")
+		correlated := mustExecute(t, executor, "SELECT name, (SELECT count(*) FROM posts p WHERE p.user_id = u.id) FROM users u;")
+		empty := mustExecute(t, executor, "SELECT (SELECT title FROM posts WHERE id = 10) FROM users WHERE id = 1;")
+
+		// Assert
+		require.Equal(t, [][]string{{"Walter"}}, resultStrings(uncorrelated))
+		require.Equal(t, []ResultColumn{
+			{Name: "name", DataType: disk_manager.TEXT_TYPE},
+			{Name: "count", DataType: disk_manager.INT_32_TYPE},
+		}, correlated.Columns)
+		require.Equal(t, [][]string{{"Joffrey", "2"}, {"Walter", "1"}, {"Arya", "0"}, {"Nobody", "0"}}, resultStrings(correlated))
+		require.Equal(t, [][]string{{"null"}}, resultStrings(empty))
+	})
+
+	t.Run("2. IN and NOT IN follow NULL semantics", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		in := mustExecute(t, executor, "SELECT name FROM users WHERE id IN (SELECT user_id FROM posts);")
+		notIn := mustExecute(t, executor, "SELECT name FROM users WHERE id NOT IN (SELECT user_id FROM posts);")
+		notInWithoutNull := mustExecute(t, executor, "SELECT name FROM users WHERE id NOT IN (SELECT user_id FROM posts WHERE user_id > 0);")
+		values := mustExecute(t, executor, "SELECT name, id IN (SELECT user_id FROM posts) FROM users;")
+
+		// Assert
+		require.Equal(t, [][]string{{"Joffrey"}, {"Walter"}}, resultStrings(in))
+		require.Empty(t, resultStrings(notIn))
+		require.Equal(t, [][]string{{"Arya"}}, resultStrings(notInWithoutNull))
+		require.Equal(t, [][]string{{"Joffrey", "true"}, {"Walter", "true"}, {"Arya", "null"}, {"Nobody", "null"}}, resultStrings(values))
+	})
+
+	t.Run("3. Correlated EXISTS and NOT EXISTS", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		exists := mustExecute(t, executor, "SELECT name FROM users u WHERE EXISTS (SELECT * FROM posts p WHERE p.user_id = u.id);")
+		notExists := mustExecute(t, executor, "SELECT name FROM users u WHERE NOT EXISTS (SELECT * FROM posts p WHERE p.user_id = u.id);")
+		reevaluated := mustExecute(t, executor, "SELECT name, EXISTS (SELECT * FROM posts p WHERE p.user_id = u.id + 1) FROM users u;")
+
+		// Assert
+		require.Equal(t, [][]string{{"Joffrey"}, {"Walter"}}, resultStrings(exists))
+		require.Equal(t, [][]string{{"Arya"}, {"Nobody"}}, resultStrings(notExists))
+		require.Equal(t, []string{"name", "exists"}, columnNames(reevaluated))
+		require.Equal(t, [][]string{{"Joffrey", "true"}, {"Walter", "false"}, {"Arya", "false"}, {"Nobody", "false"}}, resultStrings(reevaluated))
+	})
+
+	t.Run("4. Derived tables", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		grouped := mustExecute(t, executor, "SELECT d.user_id, d.count FROM (SELECT user_id, count(*) FROM posts GROUP BY user_id) d WHERE d.count > 1;")
+		joined := mustExecute(t, executor, "SELECT u.name, p.title FROM users u JOIN (SELECT * FROM posts WHERE id > 1) p ON p.user_id = u.id;")
+
+		// Assert
+		require.Equal(t, [][]string{{"1", "2"}}, resultStrings(grouped))
+		require.Equal(t, [][]string{{"Joffrey", "Again"}, {"Walter", "Chemistry"}}, resultStrings(joined))
+	})
+
+	t.Run("5. Planner decorrelates EXISTS and IN into semi and anti joins", func(t *testing.T) {
+		// Arrange
+		e := setup(t).(*executor)
+		plan := func(query string) operator {
+			tree, err := parser.NewParser().Parse(query)
+			require.NoError(t, err)
+			e.subqueries = map[*ast.SelectStatement]*subqueryState{}
+			plan, err := e.buildSelectPlan(tree.Statements[0].SelectStatement)
+			require.NoError(t, err)
+			return plan.(*projectionOperator).input
+		}
+
+		// Act
+		exists := plan("SELECT name FROM users u WHERE EXISTS (SELECT * FROM posts p WHERE p.user_id = u.id);")
+		notExists := plan("SELECT name FROM users u WHERE NOT EXISTS (SELECT * FROM posts p WHERE u.id = p.user_id);")
+		in := plan("SELECT name FROM users WHERE id IN (SELECT user_id FROM posts);")
+		notIn := plan("SELECT name FROM users WHERE id NOT IN (SELECT user_id FROM posts);")
+		nonEqui := plan("SELECT name FROM users u WHERE EXISTS (SELECT * FROM posts p WHERE p.user_id > u.id);")
+		conjuncts := plan("SELECT name FROM users u WHERE u.name != 'Arya' AND " +
+			"EXISTS (SELECT * FROM posts p WHERE p.user_id = u.id AND p.title != 'Draft');")
+		outerConjunct := plan("SELECT name FROM users u WHERE EXISTS (SELECT * FROM posts p WHERE p.user_id = u.id AND u.name != 'Arya');")
+
+		// Assert
+		require.IsType(t, &hashJoinOperator{}, exists)
+		require.Equal(t, semiJoin, exists.(*hashJoinOperator).kind)
+		require.IsType(t, &hashJoinOperator{}, notExists)
+		require.Equal(t, antiJoin, notExists.(*hashJoinOperator).kind)
+		require.IsType(t, &hashJoinOperator{}, in)
+		require.Equal(t, semiJoin, in.(*hashJoinOperator).kind)
+		require.IsType(t, &filterOperator{}, notIn)
+		require.IsType(t, &filterOperator{}, nonEqui)
+		// Условия без подзапроса и условия на колонки подзапроса проверяются фильтрами сторон semi join
+		require.IsType(t, &hashJoinOperator{}, conjuncts)
+		require.Equal(t, semiJoin, conjuncts.(*hashJoinOperator).kind)
+		require.IsType(t, &filterOperator{}, conjuncts.(*hashJoinOperator).left)
+		require.IsType(t, &filterOperator{}, conjuncts.(*hashJoinOperator).right)
+		require.IsType(t, &filterOperator{}, outerConjunct)
+	})
+
+	t.Run("6. Subquery errors", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, manyRows := execute(t, executor, "SELECT name FROM users WHERE id = (SELECT user_id FROM posts);")
+		_, manyColumns := execute(t, executor, "SELECT name FROM users WHERE id IN (SELECT id, user_id FROM posts);")
+		_, incomparable := execute(t, executor, "SELECT name FROM users WHERE name IN (SELECT id FROM posts);")
+
+		// Assert
+		require.EqualError(t, manyRows, "more than one row returned by a subquery used as an expression")
+		require.EqualError(t, manyColumns, "subquery must return only one column")
+		require.EqualError(t, incomparable, "cannot compare TEXT with INT")
+	})
+}
+
+func TestExecuteCommonTableExpressions(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE employees (id INT, boss INT, name TEXT);")
+		mustExecute(t, executor, "INSERT INTO employees VALUES (1, null, 'Tywin');")
+		mustExecute(t, executor, "INSERT INTO employees VALUES (2, 1, 'Jaime');")
+		mustExecute(t, executor, "INSERT INTO employees VALUES (3, 1, 'Cersei');")
+		mustExecute(t, executor, "INSERT INTO employees VALUES (4, 3, 'Joffrey');")
+		mustExecute(t, executor, "INSERT INTO employees VALUES (5, null, 'Walter');")
+		return executor
+	}
+
+	t.Run("1. WITH queries and column lists", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		simple := mustExecute(t, executor, "WITH lannisters AS (SELECT id, name FROM employees WHERE id < 5) SELECT name FROM lannisters WHERE id > 2;")
+		renamed := mustExecute(t, executor, "WITH bosses(employee, manager) AS (SELECT id, boss FROM employees) SELECT b.employee FROM bosses b WHERE b.manager = 1;")
+		chained := mustExecute(t, executor, "WITH a AS (SELECT id FROM employees WHERE id > 1), b AS (SELECT id FROM a WHERE id < 5) SELECT count(*) FROM b;")
+
+		// Assert
+		require.Equal(t, [][]string{{"Cersei"}, {"Joffrey"}}, resultStrings(simple))
+		require.Equal(t, []ResultColumn{{Name: "employee", DataType: disk_manager.INT_32_TYPE}}, renamed.Columns)
+		require.Equal(t, [][]string{{"2"}, {"3"}}, resultStrings(renamed))
+		require.Equal(t, [][]string{{"3"}}, resultStrings(chained))
+	})
+
+	t.Run("2. WITH RECURSIVE walks a hierarchy", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "WITH RECURSIVE subordinates(id, name) AS ("+
+			"SELECT id, name FROM employees WHERE id = 1 "+
+			"UNION ALL SELECT e.id, e.name FROM employees e JOIN subordinates s ON e.boss = s.id"+
+			") SELECT name FROM subordinates;")
+		counter := mustExecute(t, executor, "WITH RECURSIVE t(n) AS (SELECT id FROM employees WHERE id = 1 UNION ALL SELECT n + 1 FROM t WHERE n < 10) SELECT count(*) FROM t;")
+
+		// Assert
+		require.Equal(t, [][]string{{"Tywin"}, {"Jaime"}, {"Cersei"}, {"Joffrey"}}, resultStrings(result))
+		require.Equal(t, [][]string{{"10"}}, resultStrings(counter))
+	})
+
+	t.Run("3. UNION stops recursion on a cycle", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+		mustExecute(t, executor, "INSERT INTO employees VALUES (1, 4, 'Tywin');")
+
+		// Act
+		result := mustExecute(t, executor, "WITH RECURSIVE chain(id) AS ("+
+			"SELECT id FROM employees WHERE id = 4 "+
+			"UNION SELECT e.boss FROM employees e JOIN chain c ON e.id = c.id WHERE e.boss > 0"+
+			") SELECT id FROM chain;")
+
+		// Assert
+		require.Equal(t, [][]string{{"4"}, {"3"}, {"1"}}, resultStrings(result))
+	})
+
+	t.Run("4. Worktables are dropped after the statement", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		mustExecute(t, executor, "WITH RECURSIVE t(n) AS (SELECT id FROM employees WHERE id = 1 UNION ALL SELECT n + 1 FROM t WHERE n < 3) SELECT n FROM t;")
+		_, err := execute(t, executor, "WITH RECURSIVE t(n) AS (SELECT id FROM employees UNION SELECT * FROM employees) SELECT n FROM t;")
+		entries, readErr := os.ReadDir("tables")
+
+		// Assert
+		require.Error(t, err)
+		require.NoError(t, readErr)
+		for _, entry := range entries {
+			require.False(t, strings.HasPrefix(entry.Name(), WORKTABLE_PREFIX), entry.Name())
+		}
+	})
+
+	t.Run("5. WITH query errors", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, columns := execute(t, executor, "WITH t(a, b) AS (SELECT id FROM employees) SELECT a FROM t;")
+		_, anchor := execute(t, executor, "WITH RECURSIVE t AS (SELECT id FROM t UNION SELECT id FROM employees) SELECT id FROM t;")
+		_, union := execute(t, executor, "WITH RECURSIVE t AS (SELECT id FROM employees UNION SELECT * FROM t, employees) SELECT id FROM t;")
+		_, notRecursive := execute(t, executor, "WITH t AS (SELECT id FROM t) SELECT id FROM t;")
+
+		// Assert
+		require.EqualError(t, columns, "WITH query t has 1 columns available but 2 columns specified")
+		require.EqualError(t, anchor, "recursive reference to query t must not appear within its non-recursive term")
+		require.EqualError(t, union, "each UNION query must have the same number of columns")
+		require.EqualError(t, notRecursive, "table t not found")
+	})
+
+	t.Run("6. Column names longer than the meta file limit", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+		name := "a_very_long_column_alias_exceeding_thirty_two_bytes"
+
+		// Act
+		aliased := mustExecute(t, executor, "WITH t AS (SELECT id AS "+name+" FROM employees WHERE id < 3) SELECT "+name+" FROM t;")
+		recursive := mustExecute(t, executor, "WITH RECURSIVE t("+name+") AS (SELECT id FROM employees WHERE id = 1 "+
+			"UNION ALL SELECT "+name+" + 1 FROM t WHERE "+name+" < 3) SELECT * FROM t;")
+
+		// Assert
+		require.Equal(t, []string{name}, columnNames(aliased))
+		require.Equal(t, [][]string{{"1"}, {"2"}}, resultStrings(aliased))
+		require.Equal(t, []string{name}, columnNames(recursive))
+		require.Equal(t, [][]string{{"1"}, {"2"}, {"3"}}, resultStrings(recursive))
+	})
+
+	t.Run("7. Recursion runs only the iterations the query reads", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+		infinite := "WITH RECURSIVE r(n) AS (SELECT 1 FROM employees WHERE id = 1 UNION ALL SELECT n + 1 FROM r) "
+
+		// Act
+		limited := mustExecute(t, executor, infinite+"SELECT n FROM r LIMIT 3;")
+		offset := mustExecute(t, executor, infinite+"SELECT n * 10 FROM r WHERE n > 2 LIMIT 2 OFFSET 1;")
+		// Строки результата занимают несколько страниц, чтение продолжается после каждой итерации
+		pages := mustExecute(t, executor, "WITH RECURSIVE r(n) AS (SELECT id FROM employees WHERE id = 1 "+
+			"UNION ALL SELECT n + 1 FROM r WHERE n < 1000) SELECT count(*), sum(n) FROM r;")
+		selfJoin := mustExecute(t, executor, "WITH RECURSIVE r(n) AS (SELECT id FROM employees WHERE id = 1 "+
+			"UNION ALL SELECT n + 1 FROM r WHERE n < 3) SELECT a.n, b.n FROM r a JOIN r b ON a.n = b.n;")
+
+		// Assert
+		require.Equal(t, [][]string{{"1"}, {"2"}, {"3"}}, resultStrings(limited))
+		require.Equal(t, [][]string{{"40"}, {"50"}}, resultStrings(offset))
+		require.Equal(t, [][]string{{"1000", "500500"}}, resultStrings(pages))
+		require.Equal(t, [][]string{{"1", "1"}, {"2", "2"}, {"3", "3"}}, resultStrings(selfJoin))
+	})
+}
+
+func TestExecuteSetOperations(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE a (x INT, s TEXT);")
+		mustExecute(t, executor, "CREATE TABLE b (y DECIMAL, s TEXT);")
+		mustExecute(t, executor, "INSERT INTO a VALUES (1, 'one');")
+		mustExecute(t, executor, "INSERT INTO a VALUES (1, 'one');")
+		mustExecute(t, executor, "INSERT INTO a VALUES (2, 'two');")
+		mustExecute(t, executor, "INSERT INTO a VALUES (null, 'none');")
+		mustExecute(t, executor, "INSERT INTO b VALUES (1, 'one');")
+		mustExecute(t, executor, "INSERT INTO b VALUES (3, 'three');")
+		mustExecute(t, executor, "INSERT INTO b VALUES (null, 'none');")
+		return executor
+	}
+
+	t.Run("1. UNION removes duplicates, UNION ALL keeps them", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		union := mustExecute(t, executor, "SELECT x, s FROM a UNION SELECT y, s FROM b;")
+		unionAll := mustExecute(t, executor, "SELECT x FROM a UNION ALL SELECT y FROM b;")
+
+		// Assert
+		require.Equal(t, []ResultColumn{
+			{Name: "x", DataType: disk_manager.DECIMAL_TYPE},
+			{Name: "s", DataType: disk_manager.TEXT_TYPE},
+		}, union.Columns)
+		require.Equal(t, [][]string{{"1", "one"}, {"2", "two"}, {"null", "none"}, {"3", "three"}}, resultStrings(union))
+		require.Equal(t, [][]string{{"1"}, {"1"}, {"2"}, {"null"}, {"1"}, {"3"}, {"null"}}, resultStrings(unionAll))
+	})
+
+	t.Run("2. INTERSECT and EXCEPT with and without ALL", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+		mustExecute(t, executor, "INSERT INTO b VALUES (1, 'one');")
+
+		// Act
+		intersect := mustExecute(t, executor, "SELECT x, s FROM a INTERSECT SELECT y, s FROM b;")
+		intersectAll := mustExecute(t, executor, "SELECT x FROM a INTERSECT ALL SELECT y FROM b;")
+		except := mustExecute(t, executor, "SELECT x FROM a EXCEPT SELECT y FROM b;")
+		exceptAll := mustExecute(t, executor, "SELECT s FROM a EXCEPT ALL SELECT s FROM b WHERE y = 3;")
+
+		// Assert
+		require.Equal(t, [][]string{{"1", "one"}, {"null", "none"}}, resultStrings(intersect))
+		require.Equal(t, [][]string{{"1"}, {"1"}, {"null"}}, resultStrings(intersectAll))
+		require.Equal(t, [][]string{{"2"}}, resultStrings(except))
+		require.Equal(t, [][]string{{"one"}, {"one"}, {"two"}, {"none"}}, resultStrings(exceptAll))
+	})
+
+	t.Run("3. INTERSECT binds tighter, ORDER BY and LIMIT apply to the whole query", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		precedence := mustExecute(t, executor, "SELECT s FROM a EXCEPT SELECT s FROM b INTERSECT SELECT s FROM a;")
+		ordered := mustExecute(t, executor, "SELECT s FROM a UNION SELECT s FROM b ORDER BY s DESC LIMIT 2;")
+		positional := mustExecute(t, executor, "SELECT x FROM a UNION SELECT y FROM b ORDER BY 1 NULLS FIRST;")
+		parenthesized := mustExecute(t, executor, "(SELECT x FROM a ORDER BY x LIMIT 1) UNION ALL (SELECT y FROM b ORDER BY y LIMIT 1);")
+
+		// Assert
+		require.Equal(t, [][]string{{"two"}}, resultStrings(precedence))
+		require.Equal(t, [][]string{{"two"}, {"three"}}, resultStrings(ordered))
+		require.Equal(t, [][]string{{"null"}, {"1"}, {"2"}, {"3"}}, resultStrings(positional))
+		require.Equal(t, [][]string{{"1"}, {"1"}}, resultStrings(parenthesized))
+	})
+
+	t.Run("4. Set operations in subqueries", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		in := mustExecute(t, executor, "SELECT s FROM a WHERE x IN (SELECT y FROM b UNION SELECT 2 FROM b);")
+		derived := mustExecute(t, executor, "SELECT count(*) FROM (SELECT s FROM a UNION SELECT s FROM b) u;")
+
+		// Assert
+		require.Equal(t, [][]string{{"one"}, {"one"}, {"two"}}, resultStrings(in))
+		require.Equal(t, [][]string{{"4"}}, resultStrings(derived))
+	})
+
+	t.Run("5. Set operation errors", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, columns := execute(t, executor, "SELECT * FROM a UNION SELECT y FROM b;")
+		_, types := execute(t, executor, "SELECT x FROM a INTERSECT SELECT s FROM b;")
+		_, orderBy := execute(t, executor, "SELECT x FROM a UNION SELECT y FROM b ORDER BY y;")
+
+		// Assert
+		require.EqualError(t, columns, "each UNION query must have the same number of columns")
+		require.EqualError(t, types, "INTERSECT types INT and TEXT cannot be matched")
+		require.EqualError(t, orderBy, "column y does not exist")
+	})
+}
+
+func TestExecuteWindowFunctions(t *testing.T) {
+	setup := func(t *testing.T, config Config) ExecutorService {
+		executor := newTestExecutorWithConfig(t, config)
+		mustExecute(t, executor, "CREATE TABLE emp (id INT, dept TEXT, salary INT);")
+		mustExecute(t, executor, "INSERT INTO emp VALUES (1, 'a', 100);")
+		mustExecute(t, executor, "INSERT INTO emp VALUES (2, 'a', 200);")
+		mustExecute(t, executor, "INSERT INTO emp VALUES (3, 'a', 200);")
+		mustExecute(t, executor, "INSERT INTO emp VALUES (4, 'b', 50);")
+		mustExecute(t, executor, "INSERT INTO emp VALUES (5, 'b', null);")
+		return executor
+	}
+
+	t.Run("1. Ranking functions number rows within partitions", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id, row_number() OVER (PARTITION BY dept ORDER BY salary), "+
+			"rank() OVER (PARTITION BY dept ORDER BY salary), dense_rank() OVER (ORDER BY salary DESC) FROM emp ORDER BY id;")
+
+		// Assert
+		require.Equal(t, []ResultColumn{
+			{Name: "id", DataType: disk_manager.INT_32_TYPE},
+			{Name: "row_number", DataType: disk_manager.INT_32_TYPE},
+			{Name: "rank", DataType: disk_manager.INT_32_TYPE},
+			{Name: "dense_rank", DataType: disk_manager.INT_32_TYPE},
+		}, result.Columns)
+		require.Equal(t, [][]string{
+			{"1", "1", "1", "3"},
+			{"2", "2", "2", "2"},
+			{"3", "3", "2", "2"},
+			{"4", "1", "1", "4"},
+			{"5", "2", "2", "1"},
+		}, resultStrings(result))
+	})
+
+	t.Run("2. lag, lead and first_value", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id, lag(salary) OVER (ORDER BY id), lead(salary, 2, 0) OVER (ORDER BY id), "+
+			"first_value(id) OVER (PARTITION BY dept ORDER BY id DESC) FROM emp ORDER BY id;")
+
+		// Assert
+		require.Equal(t, [][]string{
+			{"1", "null", "200", "3"},
+			{"2", "100", "50", "3"},
+			{"3", "200", "null", "3"},
+			{"4", "200", "0", "5"},
+			{"5", "50", "0", "5"},
+		}, resultStrings(result))
+	})
+
+	t.Run("3. Aggregates over default and ROWS frames", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+
+		// Act
+		running := mustExecute(t, executor, "SELECT id, sum(salary) OVER (ORDER BY salary), count(*) OVER (), "+
+			"count(salary) OVER (PARTITION BY dept) FROM emp ORDER BY id;")
+		sliding := mustExecute(t, executor, "SELECT id, sum(salary) OVER (ORDER BY id ROWS BETWEEN 1 PRECEDING AND 1 FOLLOWING), "+
+			"max(salary) OVER (ORDER BY id ROWS 1 PRECEDING), min(id) OVER (ORDER BY id ROWS BETWEEN 2 FOLLOWING AND UNBOUNDED FOLLOWING) FROM emp;")
+
+		// Assert
+		// Строки с равным salary (peers) входят в рамку по умолчанию вместе
+		require.Equal(t, [][]string{
+			{"1", "150", "5", "3"},
+			{"2", "550", "5", "3"},
+			{"3", "550", "5", "3"},
+			{"4", "50", "5", "1"},
+			{"5", "550", "5", "1"},
+		}, resultStrings(running))
+		require.Equal(t, [][]string{
+			{"1", "300", "100", "3"},
+			{"2", "500", "200", "4"},
+			{"3", "450", "200", "5"},
+			{"4", "250", "200", "null"},
+			{"5", "50", "50", "null"},
+		}, resultStrings(sliding))
+	})
+
+	t.Run("4. Window functions over grouped rows and in ORDER BY", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, Config{StrictMode: true, WorkMemory: 16, TempDir: t.TempDir()})
+
+		// Act
+		grouped := mustExecute(t, executor, "SELECT dept, sum(salary), rank() OVER (ORDER BY sum(salary) DESC) FROM emp GROUP BY dept;")
+		ordered := mustExecute(t, executor, "SELECT id, row_number() OVER (ORDER BY id DESC) + 10 FROM emp "+
+			"ORDER BY row_number() OVER (ORDER BY id DESC) LIMIT 3;")
+
+		// Assert
+		require.Equal(t, [][]string{{"a", "500", "1"}, {"b", "50", "2"}}, resultStrings(grouped))
+		require.Equal(t, [][]string{{"5", "11"}, {"4", "12"}, {"3", "13"}}, resultStrings(ordered))
+	})
+
+	t.Run("5. Window function errors", func(t *testing.T) {
+		// Arrange
+		executor := setup(t, DefaultConfig())
+
+		// Act
+		_, types := execute(t, executor, "SELECT lag(salary, 1, dept) OVER () FROM emp;")
+		_, offset := execute(t, executor, "SELECT lead(salary, dept) OVER () FROM emp;")
+		_, sum := execute(t, executor, "SELECT sum(dept) OVER (PARTITION BY id) FROM emp;")
+
+		// Assert
+		require.EqualError(t, types, "window function lag types INT and TEXT cannot be matched")
+		require.EqualError(t, offset, "window function lead offset must be a number, got TEXT")
+		require.EqualError(t, sum, "function sum(TEXT) does not exist")
+	})
+}
+
+func TestExecuteSelectList(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE users (id INT, name TEXT, score DECIMAL(5,2));")
+		mustExecute(t, executor, "CREATE TABLE posts (id INT, user_id INT, title TEXT);")
+		mustExecute(t, executor, "INSERT INTO users VALUES (1, 'ann', 10.50);")
+		mustExecute(t, executor, "INSERT INTO users VALUES (2, 'bob', 3.25);")
+		mustExecute(t, executor, "INSERT INTO users VALUES (3, 'ann', null);")
+		mustExecute(t, executor, "INSERT INTO posts VALUES (10, 1, 'hello');")
+		mustExecute(t, executor, "INSERT INTO posts VALUES (11, 1, 'world');")
+		mustExecute(t, executor, "INSERT INTO posts VALUES (12, 2, 'hello');")
+		return executor
+	}
+
+	t.Run("1. Arithmetic and concatenation with aliases", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id * 2 + 1 AS doubled, 7 / id, 7 % id, score / 2 AS half, "+
+			"name || '#' || id AS tag FROM users ORDER BY id;")
+
+		// Assert
+		require.Equal(t, []ResultColumn{
+			{Name: "doubled", DataType: disk_manager.INT_32_TYPE},
+			{Name: "?column?", DataType: disk_manager.INT_32_TYPE},
+			{Name: "?column?", DataType: disk_manager.INT_32_TYPE},
+			{Name: "half", DataType: disk_manager.DECIMAL_TYPE},
+			{Name: "tag", DataType: disk_manager.TEXT_TYPE},
+		}, result.Columns)
+		require.Equal(t, [][]string{
+			{"3", "7", "0", "5.2500000000000000", "ann#1"},
+			{"5", "3", "1", "1.6250000000000000", "bob#2"},
+			{"7", "2", "1", "null", "ann#3"},
+		}, resultStrings(result))
+	})
+
+	t.Run("2. Unary minus before columns and expressions", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+		mustExecute(t, executor, "CREATE TABLE shifts (id INT, duration INTERVAL);")
+		mustExecute(t, executor, "INSERT INTO shifts VALUES (1, '1 day 02:00:00');")
+
+		// Act
+		result := mustExecute(t, executor, "SELECT -id, -(id + 1) * 2, -score, - -id, 2 - -id FROM users ORDER BY -id;")
+		where := mustExecute(t, executor, "SELECT id FROM users WHERE -id < -1 ORDER BY id;")
+		interval := mustExecute(t, executor, "SELECT -duration FROM shifts;")
+		_, textErr := execute(t, executor, "SELECT -name FROM users;")
+
+		// Assert
+		require.Equal(t, disk_manager.INT_32_TYPE, result.Columns[0].DataType)
+		require.Equal(t, disk_manager.DECIMAL_TYPE, result.Columns[2].DataType)
+		require.Equal(t, [][]string{
+			{"-3", "-8", "null", "3", "5"},
+			{"-2", "-6", "-3.25", "2", "4"},
+			{"-1", "-4", "-10.50", "1", "3"},
+		}, resultStrings(result))
+		require.Equal(t, [][]string{{"2"}, {"3"}}, resultStrings(where))
+		require.Equal(t, [][]string{{"-1 day -02:00:00"}}, resultStrings(interval))
+		require.Error(t, textErr)
+	})
+
+	t.Run("3. DISTINCT with ORDER BY on an alias", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		names := mustExecute(t, executor, "SELECT DISTINCT name AS n FROM users ORDER BY n DESC;")
+		titles := mustExecute(t, executor, "SELECT DISTINCT p.title FROM users u JOIN posts p ON u.id = p.user_id ORDER BY p.title;")
+
+		// Assert
+		require.Equal(t, [][]string{{"bob"}, {"ann"}}, resultStrings(names))
+		require.Equal(t, [][]string{{"hello"}, {"world"}}, resultStrings(titles))
+	})
+
+	t.Run("4. Star of a single table and star with other expressions", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		joined := mustExecute(t, executor, "SELECT p.*, u.name FROM users u JOIN posts p ON u.id = p.user_id WHERE p.id = 12;")
+		mixed := mustExecute(t, executor, "SELECT *, id + 1 AS next FROM posts WHERE id = 10;")
+
+		// Assert
+		require.Equal(t, [][]string{{"12", "2", "hello", "bob"}}, resultStrings(joined))
+		require.Equal(t, [][]string{{"10", "1", "hello", "11"}}, resultStrings(mixed))
+	})
+
+	t.Run("5. Select list errors", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, division := execute(t, executor, "SELECT id / (id - 1) FROM users;")
+		_, missing := execute(t, executor, "SELECT x.* FROM users;")
+		_, grouped := execute(t, executor, "SELECT u.* FROM users u GROUP BY u.name;")
+		_, concat := execute(t, executor, "SELECT id * name FROM users;")
+
+		// Assert
+		require.EqualError(t, division, "division by zero")
+		require.EqualError(t, missing, "missing FROM-clause entry for table x")
+		require.EqualError(t, grouped, "column id must appear in the GROUP BY clause or be used in an aggregate function")
+		require.EqualError(t, concat, "operator does not exist: INT * TEXT")
+	})
+}
+
+func TestExecutePredicates(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE items (id INT, name TEXT, code CHAR(5), price DECIMAL(5,2));")
+		mustExecute(t, executor, "INSERT INTO items VALUES (1, 'Apple', 'ab', 1.50);")
+		mustExecute(t, executor, "INSERT INTO items VALUES (2, 'banana', 'a_c', null);")
+		mustExecute(t, executor, "INSERT INTO items VALUES (3, null, 'x%y', 3);")
+		mustExecute(t, executor, "INSERT INTO items VALUES (4, 'apricot', null, 10);")
+		return executor
+	}
+
+	t.Run("1. LIKE and ILIKE with wildcards and escapes", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id, name LIKE 'A%', name ILIKE 'a%', name NOT LIKE '%an%', "+
+			"name LIKE '_pp__' FROM items ORDER BY id;")
+		underscore := mustExecute(t, executor, "SELECT id FROM items WHERE code LIKE 'a\\_c';")
+		percent := mustExecute(t, executor, "SELECT id FROM items WHERE code LIKE '%\\%%';")
+
+		// Assert
+		require.Equal(t, []ResultColumn{
+			{Name: "id", DataType: disk_manager.INT_32_TYPE},
+			{Name: "?column?", DataType: disk_manager.BOOLEAN_TYPE},
+			{Name: "?column?", DataType: disk_manager.BOOLEAN_TYPE},
+			{Name: "?column?", DataType: disk_manager.BOOLEAN_TYPE},
+			{Name: "?column?", DataType: disk_manager.BOOLEAN_TYPE},
+		}, result.Columns)
+		require.Equal(t, [][]string{
+			{"1", "true", "true", "true", "true"},
+			{"2", "false", "false", "false", "false"},
+			{"3", "null", "null", "null", "null"},
+			{"4", "false", "true", "true", "false"},
+		}, resultStrings(result))
+		require.Equal(t, [][]string{{"2"}}, resultStrings(underscore))
+		require.Equal(t, [][]string{{"3"}}, resultStrings(percent))
+	})
+
+	t.Run("2. IN list and BETWEEN follow NULL semantics", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id, id IN (1, 3), id NOT IN (1, null), price BETWEEN 1 AND 3, "+
+			"price NOT BETWEEN 2 AND null FROM items ORDER BY id;")
+		filtered := mustExecute(t, executor, "SELECT id FROM items WHERE id + 1 BETWEEN 2 AND 4 ORDER BY id;")
+
+		// Assert
+		require.Equal(t, [][]string{
+			{"1", "true", "false", "true", "true"},
+			{"2", "false", "null", "null", "null"},
+			{"3", "true", "null", "true", "null"},
+			{"4", "false", "null", "false", "null"},
+		}, resultStrings(result))
+		require.Equal(t, [][]string{{"1"}, {"2"}, {"3"}}, resultStrings(filtered))
+	})
+
+	t.Run("3. IS NULL and IS NOT NULL", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		isNull := mustExecute(t, executor, "SELECT id FROM items WHERE name IS NULL;")
+		isNotNull := mustExecute(t, executor, "SELECT id FROM items WHERE code IS NOT NULL ORDER BY id;")
+		equalsNull := mustExecute(t, executor, "SELECT id FROM items WHERE name = null;")
+		grouped := mustExecute(t, executor, "SELECT price IS NULL, count(*) FROM items GROUP BY price IS NULL ORDER BY 1;")
+
+		// Assert
+		require.Equal(t, [][]string{{"3"}}, resultStrings(isNull))
+		require.Equal(t, [][]string{{"1"}, {"2"}, {"3"}}, resultStrings(isNotNull))
+		require.Empty(t, equalsNull.Rows)
+		require.Equal(t, [][]string{{"false", "3"}, {"true", "1"}}, resultStrings(grouped))
+	})
+
+	t.Run("4. LIKE with many wildcards does not backtrack exponentially", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+		mustExecute(t, executor, "INSERT INTO items VALUES (5, '"+strings.Repeat("a", 2000)+"', null, null);")
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id FROM items WHERE name LIKE '"+strings.Repeat("%a", 30)+"%b';")
+
+		// Assert
+		require.Empty(t, result.Rows)
+	})
+
+	t.Run("5. Predicate errors", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, like := execute(t, executor, "SELECT id FROM items WHERE id LIKE '1';")
+		_, in := execute(t, executor, "SELECT id FROM items WHERE id IN (1, 'x');")
+		_, escape := execute(t, executor, "SELECT id FROM items WHERE name ILIKE 'a\\';")
+
+		// Assert
+		require.EqualError(t, like, "operator does not exist: INT LIKE TEXT")
+		require.EqualError(t, in, "cannot compare INT with TEXT")
+		require.EqualError(t, escape, "LIKE pattern must not end with escape character")
+	})
+}
+
+func TestExecuteLogicalOperators(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE flags (id INT, a INT, b INT);")
+		mustExecute(t, executor, "INSERT INTO flags VALUES (1, 1, 1), (2, 1, 0), (3, 1, null), (4, 0, 0), (5, 0, null), (6, null, null);")
+		mustExecute(t, executor, "CREATE TABLE events (id INT, d DATE, ts TIMESTAMP);")
+		mustExecute(t, executor, "INSERT INTO events VALUES (1, DATE '2024-02-01', TIMESTAMP '2024-02-01 10:00:00');")
+		mustExecute(t, executor, "INSERT INTO events VALUES (2, DATE '2023-12-31', TIMESTAMP '2023-12-31 10:00:00');")
+		mustExecute(t, executor, "INSERT INTO events VALUES (3, DATE '2024-03-01', null);")
+		mustExecute(t, executor, "CREATE TABLE scores (event_id INT, v INT);")
+		mustExecute(t, executor, "INSERT INTO scores VALUES (1, 60), (1, 10), (2, 40), (3, 90), (3, 95);")
+		return executor
+	}
+
+	t.Run("1. AND, OR and NOT follow three-valued logic", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id, a = 1 AND b = 1, a = 1 OR b = 1, NOT a = 1, NOT (a = 1 AND b = 1) FROM flags ORDER BY id;")
+		precedence := mustExecute(t, executor, "SELECT id FROM flags WHERE a = 0 OR a = 1 AND b = 1 ORDER BY id;")
+		between := mustExecute(t, executor, "SELECT id FROM flags WHERE id BETWEEN 2 AND 5 AND NOT b IS NULL ORDER BY id;")
+
+		// Assert
+		require.Equal(t, []ResultColumn{
+			{Name: "id", DataType: disk_manager.INT_32_TYPE},
+			{Name: "?column?", DataType: disk_manager.BOOLEAN_TYPE},
+			{Name: "?column?", DataType: disk_manager.BOOLEAN_TYPE},
+			{Name: "?column?", DataType: disk_manager.BOOLEAN_TYPE},
+			{Name: "?column?", DataType: disk_manager.BOOLEAN_TYPE},
+		}, result.Columns)
+		require.Equal(t, [][]string{
+			{"1", "true", "true", "false", "false"},
+			{"2", "false", "true", "false", "true"},
+			{"3", "null", "true", "false", "null"},
+			{"4", "false", "false", "true", "true"},
+			{"5", "false", "null", "true", "true"},
+			{"6", "null", "null", "null", "null"},
+		}, resultStrings(result))
+		require.Equal(t, [][]string{{"1"}, {"4"}, {"5"}}, resultStrings(precedence))
+		require.Equal(t, [][]string{{"2"}, {"4"}}, resultStrings(between))
+	})
+
+	t.Run("2. Conditions in WHERE, HAVING, ON and subqueries", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		where := mustExecute(t, executor, "SELECT id FROM events WHERE d > DATE '2024-01-01' AND ts < now();")
+		exists := mustExecute(t, executor, "SELECT id FROM events e WHERE EXISTS (SELECT 1 FROM scores s WHERE s.event_id = e.id AND s.v > 50) ORDER BY id;")
+		notExists := mustExecute(t, executor, "SELECT id FROM events e WHERE id > 1 AND NOT EXISTS (SELECT 1 FROM scores s WHERE s.event_id = e.id AND s.v > 50);")
+		having := mustExecute(t, executor, "SELECT event_id FROM scores GROUP BY event_id HAVING count(*) > 1 AND NOT max(v) < 90 OR min(v) = 40 ORDER BY event_id;")
+		inner := mustExecute(t, executor, "SELECT e.id, s.v FROM events e JOIN scores s ON e.id = s.event_id AND s.v > 50 AND e.ts IS NOT NULL;")
+		left := mustExecute(t, executor, "SELECT e.id, s.v FROM events e LEFT JOIN scores s ON s.event_id = e.id AND (s.v < 20 OR e.id = 2) ORDER BY e.id;")
+
+		// Assert
+		require.Equal(t, [][]string{{"1"}}, resultStrings(where))
+		require.Equal(t, [][]string{{"1"}, {"3"}}, resultStrings(exists))
+		require.Equal(t, [][]string{{"2"}}, resultStrings(notExists))
+		require.Equal(t, [][]string{{"2"}, {"3"}}, resultStrings(having))
+		require.Equal(t, [][]string{{"1", "60"}}, resultStrings(inner))
+		require.Equal(t, [][]string{{"1", "10"}, {"2", "40"}, {"3", "null"}}, resultStrings(left))
+	})
+
+	t.Run("3. Logical operator errors", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, and := execute(t, executor, "SELECT id FROM flags WHERE a AND b = 1;")
+		_, not := execute(t, executor, "SELECT NOT id FROM flags;")
+
+		// Assert
+		require.EqualError(t, and, "argument of AND must be type BOOLEAN, not INT")
+		require.EqualError(t, not, "argument of NOT must be type BOOLEAN, not INT")
+	})
+}
+
+func TestExecuteConditionalExpressions(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE items (id INT, name TEXT, price DECIMAL(6,2), created DATE);")
+		mustExecute(t, executor, "INSERT INTO items VALUES (1, 'apple', 1.5, '2024-01-31');")
+		mustExecute(t, executor, "INSERT INTO items VALUES (2, null, null, null);")
+		mustExecute(t, executor, "INSERT INTO items VALUES (0, 'zero', 10, '2024-02-29');")
+		return executor
+	}
+
+	t.Run("1. Searched and simple CASE", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id, CASE WHEN id = 0 THEN 0 ELSE 10 / id END AS ratio, "+
+			"CASE id WHEN 1 THEN 'one' WHEN 2 THEN 'two' END, CASE WHEN price > 2 THEN price ELSE 1 END FROM items;")
+
+		// Assert
+		require.Equal(t, []ResultColumn{
+			{Name: "id", DataType: disk_manager.INT_32_TYPE},
+			{Name: "ratio", DataType: disk_manager.INT_32_TYPE},
+			{Name: "case", DataType: disk_manager.TEXT_TYPE},
+			{Name: "case", DataType: disk_manager.DECIMAL_TYPE},
+		}, result.Columns)
+		// Ветка ELSE не вычисляется для id = 0, поэтому деления на ноль нет
+		require.Equal(t, [][]string{
+			{"1", "10", "one", "1"},
+			{"2", "5", "two", "1"},
+			{"0", "0", "null", "10.00"},
+		}, resultStrings(result))
+	})
+
+	t.Run("2. COALESCE and NULLIF", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT coalesce(name, 'none'), coalesce(price, id), nullif(id, 1), nullif(name, 'apple') FROM items;")
+
+		// Assert
+		require.Equal(t, []ResultColumn{
+			{Name: "coalesce", DataType: disk_manager.TEXT_TYPE},
+			{Name: "coalesce", DataType: disk_manager.DECIMAL_TYPE},
+			{Name: "nullif", DataType: disk_manager.INT_32_TYPE},
+			{Name: "nullif", DataType: disk_manager.TEXT_TYPE},
+		}, result.Columns)
+		require.Equal(t, [][]string{
+			{"apple", "1.50", "null", "null"},
+			{"none", "2", "2", "null"},
+			{"zero", "10.00", "0", "zero"},
+		}, resultStrings(result))
+	})
+
+	t.Run("3. CAST and :: conversions", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT CAST(price AS INT), price::text || '$', '42'::int + 1, id::decimal(5,2), "+
+			"CAST(name AS VARCHAR(3)), created::timestamp, '2024-01-01 10:00:00'::timestamp::date, CAST(id = 1 AS INT) FROM items;")
+
+		// Assert
+		require.Equal(t, []ResultColumn{
+			{Name: "price", DataType: disk_manager.INT_32_TYPE},
+			{Name: "?column?", DataType: disk_manager.TEXT_TYPE},
+			{Name: "?column?", DataType: disk_manager.INT_32_TYPE},
+			{Name: "id", DataType: disk_manager.DECIMAL_TYPE},
+			{Name: "name", DataType: disk_manager.VARCHAR_TYPE},
+			{Name: "created", DataType: disk_manager.TIMESTAMP_TYPE},
+			{Name: "date", DataType: disk_manager.DATE_TYPE},
+			{Name: "int", DataType: disk_manager.INT_32_TYPE},
+		}, result.Columns)
+		require.Equal(t, [][]string{
+			{"2", "1.50$", "43", "1.00", "app", "2024-01-31 00:00:00", "2024-01-01", "1"},
+			{"null", "null", "43", "2.00", "null", "null", "2024-01-01", "0"},
+			{"10", "10.00$", "43", "0.00", "zer", "2024-02-29 00:00:00", "2024-01-01", "0"},
+		}, resultStrings(result))
+	})
+
+	t.Run("4. Casts to BOOLEAN", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id::boolean, CAST(CASE WHEN name IS NULL THEN 'off' ELSE ' Yes ' END AS BOOLEAN), "+
+			"'T'::boolean, CAST(id AS BOOLEAN)::int FROM items ORDER BY id;")
+		filtered := mustExecute(t, executor, "SELECT id FROM items WHERE id::boolean AND NOT 'false'::boolean ORDER BY id;")
+
+		// Assert
+		require.Equal(t, []ResultColumn{
+			{Name: "id", DataType: disk_manager.BOOLEAN_TYPE},
+			{Name: "case", DataType: disk_manager.BOOLEAN_TYPE},
+			{Name: "boolean", DataType: disk_manager.BOOLEAN_TYPE},
+			{Name: "id", DataType: disk_manager.INT_32_TYPE},
+		}, result.Columns)
+		require.Equal(t, [][]string{
+			{"false", "true", "true", "0"},
+			{"true", "true", "true", "1"},
+			{"true", "false", "true", "1"},
+		}, resultStrings(result))
+		require.Equal(t, [][]string{{"1"}, {"2"}}, resultStrings(filtered))
+	})
+
+	t.Run("5. Conditional expressions with aggregates", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT CASE WHEN count(*) > 2 THEN 'many' ELSE 'few' END, "+
+			"sum(CASE WHEN price IS NULL THEN 1 ELSE 0 END), coalesce(max(name), '-') FROM items;")
+
+		// Assert
+		require.Equal(t, [][]string{{"many", "1", "zero"}}, resultStrings(result))
+	})
+
+	t.Run("6. Type errors", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, condition := execute(t, executor, "SELECT CASE WHEN id THEN 1 END FROM items;")
+		_, results := execute(t, executor, "SELECT CASE WHEN id = 1 THEN 1 ELSE 'x' END FROM items;")
+		_, coalesce := execute(t, executor, "SELECT coalesce(id, 'x') FROM items;")
+		_, cast := execute(t, executor, "SELECT created::int FROM items;")
+		_, syntax := execute(t, executor, "SELECT 'abc'::int FROM items;")
+		_, overflow := execute(t, executor, "SELECT price::decimal(2,1) FROM items;")
+		_, booleanSyntax := execute(t, executor, "SELECT 'maybe'::boolean FROM items;")
+		_, booleanCast := execute(t, executor, "SELECT price::boolean FROM items;")
+
+		// Assert
+		require.EqualError(t, condition, "argument of CASE/WHEN must be type BOOLEAN, not type INT")
+		require.EqualError(t, results, "CASE types INT and TEXT cannot be matched")
+		require.EqualError(t, coalesce, "COALESCE types INT and TEXT cannot be matched")
+		require.EqualError(t, cast, "cannot cast type DATE to INT")
+		require.EqualError(t, syntax, `invalid input syntax for type INT: "abc"`)
+		require.EqualError(t, overflow, "numeric field overflow: value 10.00 does not fit DECIMAL(2,1)")
+		require.EqualError(t, booleanSyntax, `invalid input syntax for type BOOLEAN: "maybe"`)
+		require.EqualError(t, booleanCast, "cannot cast type DECIMAL to BOOLEAN")
+	})
+}
+
+func TestExecuteScalarFunctions(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE items (id INT, name TEXT, code CHAR(5), price DECIMAL(6,2));")
+		mustExecute(t, executor, "INSERT INTO items VALUES (1, 'Привет мир', 'ab', -12.35);")
+		mustExecute(t, executor, "INSERT INTO items VALUES (2, null, 'xyz', 4.5);")
+		return executor
+	}
+
+	t.Run("1. String functions", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT upper(name), length(name), length(code), substr(name, 2, 3), substr(name, 0, 2), "+
+			"trim(code), trim('xxaxx', 'x'), replace(name, 'мир', 'world'), position('мир' IN name), position('z', code) FROM items;")
+
+		// Assert
+		require.Equal(t, []ResultColumn{
+			{Name: "upper", DataType: disk_manager.TEXT_TYPE},
+			{Name: "length", DataType: disk_manager.INT_32_TYPE},
+			{Name: "length", DataType: disk_manager.INT_32_TYPE},
+			{Name: "substr", DataType: disk_manager.TEXT_TYPE},
+			{Name: "substr", DataType: disk_manager.TEXT_TYPE},
+			{Name: "trim", DataType: disk_manager.TEXT_TYPE},
+			{Name: "trim", DataType: disk_manager.TEXT_TYPE},
+			{Name: "replace", DataType: disk_manager.TEXT_TYPE},
+			{Name: "position", DataType: disk_manager.INT_32_TYPE},
+			{Name: "position", DataType: disk_manager.INT_32_TYPE},
+		}, result.Columns)
+		// Длина CHAR считается без хвостовых пробелов, позиции и длины - в символах, а не в байтах
+		require.Equal(t, [][]string{
+			{"ПРИВЕТ МИР", "10", "2", "рив", "П", "ab", "a", "Привет world", "8", "0"},
+			{"null", "null", "3", "null", "null", "xyz", "a", "null", "null", "3"},
+		}, resultStrings(result))
+	})
+
+	t.Run("2. concat skips NULL values", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT concat(id, ':', name, ':', price), lower(name) || '!' FROM items;")
+
+		// Assert
+		require.Equal(t, [][]string{
+			{"1:Привет мир:-12.35", "привет мир!"},
+			{"2::4.50", "null"},
+		}, resultStrings(result))
+	})
+
+	t.Run("3. Math functions", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT abs(price), abs(id - 5), floor(price), ceil(price), mod(id, 2), mod(price, 2), "+
+			"power(2, 10), power(2, 0 - 2), sqrt(2), sqrt(price * price) FROM items;")
+
+		// Assert
+		require.Equal(t, []ResultColumn{
+			{Name: "abs", DataType: disk_manager.DECIMAL_TYPE},
+			{Name: "abs", DataType: disk_manager.INT_32_TYPE},
+			{Name: "floor", DataType: disk_manager.DECIMAL_TYPE},
+			{Name: "ceil", DataType: disk_manager.DECIMAL_TYPE},
+			{Name: "mod", DataType: disk_manager.INT_32_TYPE},
+			{Name: "mod", DataType: disk_manager.DECIMAL_TYPE},
+			{Name: "power", DataType: disk_manager.DECIMAL_TYPE},
+			{Name: "power", DataType: disk_manager.DECIMAL_TYPE},
+			{Name: "sqrt", DataType: disk_manager.DECIMAL_TYPE},
+			{Name: "sqrt", DataType: disk_manager.DECIMAL_TYPE},
+		}, result.Columns)
+		require.Equal(t, [][]string{
+			{"12.35", "4", "-13", "-12", "1", "-0.35", "1024", "0.2500000000000000", "1.4142135623730950", "12.3500000000000000"},
+			{"4.50", "3", "4", "5", "0", "0.50", "1024", "0.2500000000000000", "1.4142135623730950", "4.5000000000000000"},
+		}, resultStrings(result))
+	})
+
+	t.Run("4. random returns a number in [0, 1)", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT random() >= 0, random() < 1 FROM items;")
+
+		// Assert
+		require.Equal(t, [][]string{{"true", "true"}, {"true", "true"}}, resultStrings(result))
+	})
+
+	t.Run("5. Argument types and value errors", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, upper := execute(t, executor, "SELECT upper(id) FROM items;")
+		_, substrLength := execute(t, executor, "SELECT substr(name, 1, id - 5) FROM items;")
+		_, zeroPower := execute(t, executor, "SELECT power(0, id - 5) FROM items;")
+		_, complexPower := execute(t, executor, "SELECT power(price, 0.5) FROM items;")
+		_, negativeSqrt := execute(t, executor, "SELECT sqrt(price) FROM items;")
+		_, modZero := execute(t, executor, "SELECT mod(id, id - id) FROM items;")
+
+		// Assert
+		require.EqualError(t, upper, "function upper(INT) does not exist")
+		require.EqualError(t, substrLength, "negative substring length not allowed")
+		require.EqualError(t, zeroPower, "zero raised to a negative power is undefined")
+		require.EqualError(t, complexPower, "a negative number raised to a non-integer power yields a complex result")
+		require.EqualError(t, negativeSqrt, "cannot take square root of a negative number")
+		require.EqualError(t, modZero, "division by zero")
+	})
+}
+
+func TestExecuteUserDefinedFunctions(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE orders (id INT, customer TEXT, amount DECIMAL(8,2));")
+		mustExecute(t, executor, "INSERT INTO orders VALUES (1, 'ann', 10.50);")
+		mustExecute(t, executor, "INSERT INTO orders VALUES (2, 'bob', 3);")
+		mustExecute(t, executor, "INSERT INTO orders VALUES (3, 'ann', 7.25);")
+		mustExecute(t, executor, "INSERT INTO orders VALUES (4, 'bob', null);")
+		return executor
+	}
+
+	// registerProduct регистрирует агрегатную функцию product - произведение значений группы
+	registerProduct := func(t *testing.T, executor ExecutorService) {
+		err := executor.RegisterAggregateFunc("product", disk_manager.DECIMAL_TYPE, disk_manager.DECIMAL_TYPE,
+			func() any {
+				return disk_manager.NewDecimalFromInt(1)
+			},
+			func(state any, value disk_manager.DataCell) (any, error) {
+				return state.(disk_manager.Decimal).Mul(value.Data.(disk_manager.Decimal)), nil
+			},
+			func(state any) (disk_manager.DataCell, error) {
+				return disk_manager.DataCell{DataType: disk_manager.DECIMAL_TYPE, Data: state}, nil
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	t.Run("1. Scalar function receives coerced arguments", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+		err := executor.RegisterScalarFunc("Greet", []disk_manager.DataType{disk_manager.TEXT_TYPE, disk_manager.INT_32_TYPE}, disk_manager.TEXT_TYPE,
+			func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+				if hasNullArgument(arguments) {
+					return disk_manager.DataCell{IsNull: true}, nil
+				}
+				text := strings.Repeat("!", int(arguments[1].Data.(int32)))
+				return disk_manager.DataCell{DataType: disk_manager.TEXT_TYPE, Data: "hi " + arguments[0].Data.(string) + text}, nil
+			},
+		)
+		require.NoError(t, err)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id, greet(customer, id) FROM orders WHERE GREET(customer, 1) = 'hi ann!' ORDER BY id;")
+
+		// Assert
+		require.Equal(t, []ResultColumn{
+			{Name: "id", DataType: disk_manager.INT_32_TYPE},
+			{Name: "greet", DataType: disk_manager.TEXT_TYPE},
+		}, result.Columns)
+		require.Equal(t, [][]string{{"1", "hi ann!"}, {"3", "hi ann!!!"}}, resultStrings(result))
+	})
+
+	t.Run("2. Aggregate function with GROUP BY skips NULL values", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+		registerProduct(t, executor)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT customer, product(amount), product(id) FROM orders GROUP BY customer ORDER BY customer;")
+
+		// Assert
+		require.Equal(t, []ResultColumn{
+			{Name: "customer", DataType: disk_manager.TEXT_TYPE},
+			{Name: "product", DataType: disk_manager.DECIMAL_TYPE},
+			{Name: "product", DataType: disk_manager.DECIMAL_TYPE},
+		}, result.Columns)
+		require.Equal(t, [][]string{{"ann", "76.1250", "3"}, {"bob", "3.00", "8"}}, resultStrings(result))
+	})
+
+	t.Run("3. Aggregate function as window function", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+		registerProduct(t, executor)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT id, product(id) OVER (ORDER BY id) FROM orders ORDER BY id;")
+
+		// Assert
+		require.Equal(t, [][]string{{"1", "1"}, {"2", "2"}, {"3", "6"}, {"4", "24"}}, resultStrings(result))
+	})
+
+	t.Run("4. system_functions lists builtin and registered functions", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+		registerProduct(t, executor)
+
+		// Act
+		result := mustExecute(t, executor, "SELECT f.name, f.kind, f.arguments, f.return_type, f.builtin FROM system_functions f "+
+			"WHERE f.name IN ('product', 'substr', 'concat', 'count') ORDER BY f.name, f.arguments;")
+
+		// Assert
+		require.Equal(t, [][]string{
+			{"concat", "scalar", "ANY, ...", "TEXT", "true"},
+			{"count", "aggregate", "", "INT", "true"},
+			{"count", "aggregate", "ANY", "INT", "true"},
+			{"product", "aggregate", "DECIMAL", "DECIMAL", "false"},
+			{"substr", "scalar", "TEXT, INT", "TEXT", "true"},
+			{"substr", "scalar", "TEXT, INT, INT", "TEXT", "true"},
+		}, resultStrings(result))
+	})
+
+	t.Run("5. Registration errors", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+		identity := func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+			return arguments[0], nil
+		}
+
+		// Act
+		duplicate := executor.RegisterScalarFunc("upper", []disk_manager.DataType{disk_manager.TEXT_TYPE}, disk_manager.TEXT_TYPE, identity)
+		window := executor.RegisterScalarFunc("row_number", nil, disk_manager.INT_32_TYPE, identity)
+		invalidName := executor.RegisterScalarFunc("my-func", nil, disk_manager.INT_32_TYPE, identity)
+		invalidType := executor.RegisterScalarFunc("my_func", []disk_manager.DataType{functions.AnyElementType}, disk_manager.INT_32_TYPE, identity)
+		missingStep := executor.RegisterAggregateFunc("my_agg", disk_manager.INT_32_TYPE, disk_manager.INT_32_TYPE, func() any { return nil }, nil, nil)
+
+		// Assert
+		require.EqualError(t, duplicate, "function upper already exists")
+		require.EqualError(t, window, "function row_number already exists")
+		require.EqualError(t, invalidName, `invalid function name: "my-func"`)
+		require.EqualError(t, invalidType, "function my_func: unsupported argument type ANYELEMENT")
+		require.EqualError(t, missingStep, "function my_agg: init, step and final must be set")
+	})
+
+	t.Run("6. Type errors of calls and results", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+		registerProduct(t, executor)
+		err := executor.RegisterScalarFunc("broken", []disk_manager.DataType{disk_manager.INT_32_TYPE}, disk_manager.INT_32_TYPE,
+			func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+				return disk_manager.DataCell{DataType: disk_manager.BOOLEAN_TYPE, Data: true}, nil
+			},
+		)
+		require.NoError(t, err)
+		parser := parser.NewParserWithFunctions(executor.Functions())
+
+		// Act
+		_, literal := parser.Parse("SELECT broken('text') FROM orders;")
+		_, column := execute(t, executor, "SELECT broken(customer) FROM orders;")
+		_, aggregate := execute(t, executor, "SELECT product(customer) FROM orders;")
+		_, result := execute(t, executor, "SELECT broken(id) FROM orders;")
+
+		// Assert
+		require.EqualError(t, literal, "Validation error: function broken does not accept argument 1 of type TEXT")
+		require.EqualError(t, column, "function broken(TEXT) does not exist")
+		require.EqualError(t, aggregate, "function product(TEXT) does not exist")
+		require.EqualError(t, result, "function broken returned value of type BOOLEAN instead of INT")
+	})
+}
+
+func TestExecuteInsert(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE users (id INT, name TEXT, created DATE);")
+		return executor
+	}
+
+	t.Run("1. Column list and several rows", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		mustExecute(t, executor, "INSERT INTO users (name, id) VALUES ('Ann', 1), ('Bob', 2), (null, 3);")
+		mustExecute(t, executor, "INSERT INTO users (created) VALUES ('2024-01-31');")
+		result := mustExecute(t, executor, "SELECT id, name, created FROM users;")
+
+		// Assert
+		require.Equal(t, [][]string{
+			{"1", "Ann", "null"},
+			{"2", "Bob", "null"},
+			{"3", "null", "null"},
+			{"null", "null", "2024-01-31"},
+		}, resultStrings(result))
+	})
+
+	t.Run("2. INSERT ... SELECT", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+		mustExecute(t, executor, "CREATE TABLE names (value VARCHAR(10));")
+		mustExecute(t, executor, "INSERT INTO users VALUES (1, 'Ann', '2024-01-01'), (2, 'Bob', '2024-01-02');")
+
+		// Act
+		mustExecute(t, executor, "INSERT INTO names SELECT upper(name) FROM users ORDER BY id DESC;")
+		mustExecute(t, executor, "INSERT INTO users (id, name) SELECT id + 10, name || '!' FROM users;")
+		mustExecute(t, executor, "INSERT INTO names WITH n AS (SELECT count(*) AS c FROM users) SELECT c::text FROM n;")
+		users := mustExecute(t, executor, "SELECT id, name, created FROM users ORDER BY id;")
+		names := mustExecute(t, executor, "SELECT value FROM names;")
+
+		// Assert
+		// Запрос читает таблицу до вставки, поэтому строки, вставленные тем же statement'ом, не копируются повторно
+		require.Equal(t, [][]string{
+			{"1", "Ann", "2024-01-01"},
+			{"2", "Bob", "2024-01-02"},
+			{"11", "Ann!", "null"},
+			{"12", "Bob!", "null"},
+		}, resultStrings(users))
+		require.Equal(t, [][]string{{"BOB"}, {"ANN"}, {"4"}}, resultStrings(names))
+	})
+
+	t.Run("3. Errors leave the table unchanged", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, unknownColumn := execute(t, executor, "INSERT INTO users (id, age) VALUES (1, 2);")
+		_, badRow := execute(t, executor, "INSERT INTO users (id) VALUES (1), ('two'), (3);")
+		_, selectArity := execute(t, executor, "INSERT INTO users (id, name) SELECT id FROM users;")
+		_, tableArity := execute(t, executor, "INSERT INTO users VALUES (1, 'Ann'), (2, 'Bob');")
+		result := mustExecute(t, executor, "SELECT id FROM users;")
+
+		// Assert
+		require.EqualError(t, unknownColumn, "column age of table users does not exist")
+		require.EqualError(t, badRow, `column id: cannot use value of type TEXT as INT`)
+		require.EqualError(t, selectArity, "INSERT has 1 values, but 2 target columns")
+		require.EqualError(t, tableArity, "INSERT has 2 values, but table users has 3 columns")
+		require.Empty(t, result.Rows)
+	})
+
+	t.Run("4. RETURNING", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+		mustExecute(t, executor, "INSERT INTO users VALUES (1, 'Ann', '2024-01-01');")
+
+		// Act
+		all := mustExecute(t, executor, "INSERT INTO users (id, name) VALUES (2, 'Bob'), (3, null) RETURNING *;")
+		computed := mustExecute(t, executor, "INSERT INTO users (id) SELECT id + 10 FROM users WHERE id < 3 RETURNING id AS new_id, users.id * 2, (SELECT count(*) FROM users);")
+		_, returningErr := execute(t, executor, "INSERT INTO users (id) VALUES (4) RETURNING age;")
+		result := mustExecute(t, executor, "SELECT id FROM users ORDER BY id;")
+
+		// Assert
+		require.Equal(t, []string{"id", "name", "created"}, columnNames(all))
+		require.Equal(t, [][]string{{"2", "Bob", "null"}, {"3", "null", "null"}}, resultStrings(all))
+		require.Equal(t, []string{"new_id", "?column?", "count"}, columnNames(computed))
+		// Подзапрос RETURNING видит таблицу до INSERT
+		require.Equal(t, [][]string{{"11", "22", "3"}, {"12", "24", "3"}}, resultStrings(computed))
+		require.EqualError(t, returningErr, "column age does not exist")
+		require.Equal(t, [][]string{{"1"}, {"2"}, {"3"}, {"11"}, {"12"}}, resultStrings(result))
+	})
+}
+
+func TestExecuteOnConflict(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE counters (name VARCHAR(20) PRIMARY KEY, hits INT, updated DATE);")
+		mustExecute(t, executor, "INSERT INTO counters VALUES ('home', 1, '2024-01-01'), ('about', 5, '2024-01-01');")
+		return executor
+	}
+
+	t.Run("1. Duplicate key is rejected and leaves the table unchanged", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, existingErr := execute(t, executor, "INSERT INTO counters VALUES ('faq', 1, null), ('home', 2, null);")
+		_, statementErr := execute(t, executor, "INSERT INTO counters VALUES ('faq', 1, null), ('faq', 2, null);")
+		_, nullErr := execute(t, executor, "INSERT INTO counters (hits) VALUES (1);")
+		result := mustExecute(t, executor, "SELECT name, hits FROM counters ORDER BY name;")
+
+		// Assert
+		require.EqualError(t, existingErr, "duplicate key value (name)=(home) violates primary key of table counters")
+		require.EqualError(t, statementErr, "duplicate key value (name)=(faq) violates primary key of table counters")
+		require.EqualError(t, nullErr, "column name does not allow NULL values")
+		require.Equal(t, [][]string{{"about", "5"}, {"home", "1"}}, resultStrings(result))
+	})
+
+	t.Run("2. DO NOTHING skips conflicting rows", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		mustExecute(t, executor, "INSERT INTO counters VALUES ('home', 10, null), ('faq', 1, null), ('faq', 2, null) ON CONFLICT DO NOTHING;")
+		mustExecute(t, executor, "INSERT INTO counters (name, hits) VALUES ('about', 10), ('blog', 3) ON CONFLICT (name) DO NOTHING;")
+		result := mustExecute(t, executor, "SELECT name, hits FROM counters ORDER BY name;")
+
+		// Assert
+		require.Equal(t, [][]string{{"about", "5"}, {"blog", "3"}, {"faq", "1"}, {"home", "1"}}, resultStrings(result))
+	})
+
+	t.Run("3. DO UPDATE uses existing and EXCLUDED values", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		mustExecute(t, executor, `INSERT INTO counters VALUES ('home', 2, '2024-02-01'), ('faq', 1, '2024-02-01')
+			ON CONFLICT (name) DO UPDATE SET hits = counters.hits + excluded.hits, updated = excluded.updated;`)
+		mustExecute(t, executor, "INSERT INTO counters SELECT name, 0, null FROM counters WHERE name = 'about' ON CONFLICT (name) DO UPDATE SET name = 'contacts';")
+		returning := mustExecute(t, executor, "INSERT INTO counters VALUES ('home', 1, null), ('blog', 1, null) ON CONFLICT (name) DO UPDATE SET hits = counters.hits + 1 RETURNING name, hits;")
+		skipped := mustExecute(t, executor, "INSERT INTO counters VALUES ('home', 1, null) ON CONFLICT DO NOTHING RETURNING name;")
+		result := mustExecute(t, executor, "SELECT name, hits, updated FROM counters ORDER BY name;")
+
+		// Assert
+		require.Equal(t, [][]string{{"home", "4"}, {"blog", "1"}}, resultStrings(returning))
+		require.Empty(t, skipped.Rows)
+		require.Equal(t, [][]string{
+			{"blog", "1", "null"},
+			{"contacts", "5", "2024-01-01"},
+			{"faq", "1", "2024-02-01"},
+			{"home", "4", "2024-02-01"},
+		}, resultStrings(result))
+	})
+
+	t.Run("4. Composite primary key", func(t *testing.T) {
+		// Arrange
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE visits (page TEXT, day DATE, hits INT, PRIMARY KEY (page, day));")
+		mustExecute(t, executor, "INSERT INTO visits VALUES ('home', '2024-01-01', 1), ('home', '2024-01-02', 1);")
+
+		// Act
+		_, duplicateErr := execute(t, executor, "INSERT INTO visits VALUES ('home', '2024-01-02', 5);")
+		_, targetErr := execute(t, executor, "INSERT INTO visits VALUES ('home', '2024-01-02', 5) ON CONFLICT (page) DO NOTHING;")
+		mustExecute(t, executor, "INSERT INTO visits VALUES ('home', '2024-01-02', 5) ON CONFLICT (day, page) DO UPDATE SET hits = excluded.hits;")
+		result := mustExecute(t, executor, "SELECT page, day, hits FROM visits ORDER BY day;")
+
+		// Assert
+		require.EqualError(t, duplicateErr, "duplicate key value (page, day)=(home, 2024-01-02) violates primary key of table visits")
+		require.EqualError(t, targetErr, "there is no unique or exclusion constraint matching the ON CONFLICT specification")
+		require.Equal(t, [][]string{{"home", "2024-01-01", "1"}, {"home", "2024-01-02", "5"}}, resultStrings(result))
+	})
+
+	t.Run("5. DO UPDATE errors leave the table unchanged", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, secondTimeErr := execute(t, executor, "INSERT INTO counters VALUES ('home', 1, null), ('home', 2, null) ON CONFLICT (name) DO UPDATE SET hits = excluded.hits;")
+		_, keyErr := execute(t, executor, "INSERT INTO counters VALUES ('home', 1, null) ON CONFLICT (name) DO UPDATE SET name = 'about';")
+		_, ambiguousErr := execute(t, executor, "INSERT INTO counters VALUES ('home', 1, null) ON CONFLICT (name) DO UPDATE SET hits = hits + 1;")
+		_, columnErr := execute(t, executor, "INSERT INTO counters VALUES ('home', 1, null) ON CONFLICT (name) DO UPDATE SET total = 1;")
+		mustExecute(t, executor, "CREATE TABLE log (id INT);")
+		_, noKeyErr := execute(t, executor, "INSERT INTO log VALUES (1) ON CONFLICT (id) DO NOTHING;")
+		result := mustExecute(t, executor, "SELECT name, hits FROM counters ORDER BY name;")
+
+		// Assert
+		require.EqualError(t, secondTimeErr, "ON CONFLICT DO UPDATE command cannot affect row a second time")
+		require.EqualError(t, keyErr, "duplicate key value (name)=(about) violates primary key of table counters")
+		require.EqualError(t, ambiguousErr, "column reference hits is ambiguous")
+		require.EqualError(t, columnErr, "column total of table counters does not exist")
+		require.EqualError(t, noKeyErr, "there is no unique or exclusion constraint matching the ON CONFLICT specification")
+		require.Equal(t, [][]string{{"about", "5"}, {"home", "1"}}, resultStrings(result))
+	})
+
+	t.Run("6. Primary key index follows table changes", func(t *testing.T) {
+		// Arrange
+		e := setup(t).(*executor)
+
+		// Act
+		mustExecute(t, e, "INSERT INTO counters VALUES ('about', 0, null) ON CONFLICT (name) DO UPDATE SET name = 'contacts';")
+		mustExecute(t, e, "INSERT INTO counters VALUES ('about', 1, null);")
+		_, updatedErr := execute(t, e, "INSERT INTO counters VALUES ('contacts', 1, null);")
+		indexed := len(e.keyIndexes["counters"].locations)
+
+		mustExecute(t, e, "ALTER TABLE counters ADD COLUMN note TEXT;")
+		_, rewrittenErr := execute(t, e, "INSERT INTO counters VALUES ('home', 1, null, null);")
+		mustExecute(t, e, "TRUNCATE counters;")
+		mustExecute(t, e, "INSERT INTO counters VALUES ('home', 7, null, null);")
+		mustExecute(t, e, "ALTER TABLE counters RENAME TO pages;")
+		mustExecute(t, e, "CREATE TABLE counters (name TEXT PRIMARY KEY);")
+		mustExecute(t, e, "INSERT INTO counters VALUES ('home');")
+		mustExecute(t, e, "DROP TABLE counters;")
+		mustExecute(t, e, "CREATE TABLE counters (name TEXT PRIMARY KEY);")
+		mustExecute(t, e, "INSERT INTO counters VALUES ('home');")
+		restarted := restartTestExecutor(t, e)
+		_, restartedErr := execute(t, restarted, "INSERT INTO pages VALUES ('home', 1, null, null);")
+		result := mustExecute(t, restarted, "SELECT name, hits FROM pages;")
+
+		// Assert
+		require.EqualError(t, updatedErr, "duplicate key value (name)=(contacts) violates primary key of table counters")
+		require.Equal(t, 3, indexed)
+		require.EqualError(t, rewrittenErr, "duplicate key value (name)=(home) violates primary key of table counters")
+		require.EqualError(t, restartedErr, "duplicate key value (name)=(home) violates primary key of table pages")
+		require.Equal(t, [][]string{{"home", "7"}}, resultStrings(result))
+	})
+}
+
+func TestExecuteAlterTable(t *testing.T) {
+	setup := func(t *testing.T) ExecutorService {
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE items (id INT PRIMARY KEY, title VARCHAR(10), price DECIMAL(5,2));")
+		mustExecute(t, executor, "INSERT INTO items VALUES (1, 'pen', 1.50), (2, 'book', null);")
+		return executor
+	}
+
+	t.Run("1. ADD COLUMN fills existing rows with the default", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		mustExecute(t, executor, "ALTER TABLE items ADD COLUMN stock INT NOT NULL DEFAULT 10;")
+		mustExecute(t, executor, "ALTER TABLE items ADD note TEXT;")
+		mustExecute(t, executor, "INSERT INTO items (id, title) VALUES (3, 'cup');")
+		_, nullErr := execute(t, executor, "INSERT INTO items (id, stock) VALUES (4, null);")
+		result := mustExecute(t, executor, "SELECT * FROM items ORDER BY id;")
+
+		// Assert
+		require.EqualError(t, nullErr, "column stock does not allow NULL values")
+		require.Equal(t, []string{"id", "title", "price", "stock", "note"}, columnNames(result))
+		require.Equal(t, [][]string{
+			{"1", "pen", "1.50", "10", "null"},
+			{"2", "book", "null", "10", "null"},
+			{"3", "cup", "null", "10", "null"},
+		}, resultStrings(result))
+	})
+
+	t.Run("2. DROP COLUMN and RENAME COLUMN", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		mustExecute(t, executor, "ALTER TABLE items DROP COLUMN price;")
+		mustExecute(t, executor, "ALTER TABLE items RENAME COLUMN title TO name;")
+		mustExecute(t, executor, "INSERT INTO items VALUES (3, 'cup');")
+		result := mustExecute(t, executor, "SELECT * FROM items ORDER BY id;")
+		_, oldNameErr := execute(t, executor, "SELECT title FROM items;")
+
+		// Assert
+		require.Equal(t, []string{"id", "name"}, columnNames(result))
+		require.Equal(t, [][]string{{"1", "pen"}, {"2", "book"}, {"3", "cup"}}, resultStrings(result))
+		require.Error(t, oldNameErr)
+	})
+
+	t.Run("3. Dropping a primary key column drops the primary key", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		mustExecute(t, executor, "ALTER TABLE items DROP id;")
+		mustExecute(t, executor, "INSERT INTO items VALUES ('pen', 1.50);")
+		_, conflictErr := execute(t, executor, "INSERT INTO items VALUES ('pen', 2) ON CONFLICT (title) DO NOTHING;")
+		result := mustExecute(t, executor, "SELECT title, price FROM items ORDER BY title;")
+
+		// Assert
+		require.EqualError(t, conflictErr, "there is no unique or exclusion constraint matching the ON CONFLICT specification")
+		require.Equal(t, [][]string{{"book", "null"}, {"pen", "1.50"}, {"pen", "1.50"}}, resultStrings(result))
+	})
+
+	t.Run("4. SET and DROP NOT NULL and DEFAULT", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, setNotNullErr := execute(t, executor, "ALTER TABLE items ALTER COLUMN price SET NOT NULL;")
+		_, primaryKeyErr := execute(t, executor, "ALTER TABLE items ALTER COLUMN id DROP NOT NULL;")
+		mustExecute(t, executor, "ALTER TABLE items ALTER COLUMN price SET DEFAULT 2 * 1.25;")
+		mustExecute(t, executor, "ALTER TABLE items ALTER title SET NOT NULL;")
+		mustExecute(t, executor, "INSERT INTO items (id, title) VALUES (3, 'cup');")
+		_, titleErr := execute(t, executor, "INSERT INTO items (id) VALUES (4);")
+		mustExecute(t, executor, "ALTER TABLE items ALTER COLUMN price DROP DEFAULT;")
+		mustExecute(t, executor, "ALTER TABLE items ALTER COLUMN title DROP NOT NULL;")
+		mustExecute(t, executor, "INSERT INTO items (id) VALUES (4);")
+		result := mustExecute(t, executor, "SELECT * FROM items ORDER BY id;")
+
+		// Assert
+		require.EqualError(t, setNotNullErr, "column price of table items contains null values")
+		require.EqualError(t, primaryKeyErr, "column id is in a primary key")
+		require.EqualError(t, titleErr, "column title does not allow NULL values")
+		require.Equal(t, [][]string{
+			{"1", "pen", "1.50"},
+			{"2", "book", "null"},
+			{"3", "cup", "2.50"},
+			{"4", "null", "null"},
+		}, resultStrings(result))
+	})
+
+	t.Run("5. RENAME TO keeps rows and survives restart", func(t *testing.T) {
+		// Arrange
+		e := setup(t).(*executor)
+		mustExecute(t, e, "ALTER TABLE items ALTER COLUMN title SET DEFAULT 'unknown';")
+
+		// Act
+		mustExecute(t, e, "ALTER TABLE items RENAME TO goods;")
+		mustExecute(t, e, "INSERT INTO goods (id) VALUES (3);")
+		_, oldNameErr := execute(t, e, "SELECT * FROM items;")
+		_, existsErr := execute(t, e, "CREATE TABLE items (id INT); ALTER TABLE items RENAME TO goods;")
+		restarted := restartTestExecutor(t, e)
+		mustExecute(t, restarted, "INSERT INTO goods (id) VALUES (4);")
+		result := mustExecute(t, restarted, "SELECT id, title FROM goods ORDER BY id;")
+
+		// Assert
+		require.EqualError(t, oldNameErr, "table items not found")
+		require.EqualError(t, existsErr, "table goods already exists")
+		require.Equal(t, [][]string{{"1", "pen"}, {"2", "book"}, {"3", "unknown"}, {"4", "unknown"}}, resultStrings(result))
+	})
+
+	t.Run("6. Errors leave the table unchanged", func(t *testing.T) {
+		// Arrange
+		executor := setup(t)
+
+		// Act
+		_, unknownErr := execute(t, executor, "ALTER TABLE items DROP COLUMN stock;")
+		_, existsErr := execute(t, executor, "ALTER TABLE items ADD COLUMN title TEXT;")
+		_, renameErr := execute(t, executor, "ALTER TABLE items RENAME title TO price;")
+		_, notNullErr := execute(t, executor, "ALTER TABLE items ADD COLUMN stock INT NOT NULL;")
+		_, defaultErr := execute(t, executor, "ALTER TABLE items ALTER COLUMN price SET DEFAULT 12345.678;")
+		_, tableErr := execute(t, executor, "ALTER TABLE missing ADD COLUMN stock INT;")
+		mustExecute(t, executor, "CREATE TABLE single (id INT);")
+		_, onlyColumnErr := execute(t, executor, "ALTER TABLE single DROP COLUMN id;")
+		result := mustExecute(t, executor, "SELECT * FROM items ORDER BY id;")
+
+		// Assert
+		require.EqualError(t, unknownErr, "column stock of table items does not exist")
+		require.EqualError(t, existsErr, "column title of table items already exists")
+		require.EqualError(t, renameErr, "column price of table items already exists")
+		require.EqualError(t, notNullErr, "column stock of table items contains null values")
+		require.Error(t, defaultErr)
+		require.EqualError(t, tableErr, "table missing not found")
+		require.EqualError(t, onlyColumnErr, "cannot drop the only column of table single")
+		require.Equal(t, []string{"id", "title", "price"}, columnNames(result))
+		require.Equal(t, [][]string{{"1", "pen", "1.50"}, {"2", "book", "null"}}, resultStrings(result))
+	})
+
+	t.Run("7. Column names longer than the meta file limit are rejected", func(t *testing.T) {
+		// Arrange
+		e := setup(t).(*executor)
+		longest := strings.Repeat("t", disk_manager.COLUMN_NAME_MAX_LENGTH)
+		tooLong := longest + "x"
+
+		// Act
+		mustExecute(t, e, "ALTER TABLE items RENAME COLUMN title TO "+longest+";")
+		_, renameErr := execute(t, e, "ALTER TABLE items RENAME COLUMN price TO "+tooLong+";")
+		_, addErr := execute(t, e, "ALTER TABLE items ADD COLUMN "+tooLong+" INT;")
+		_, createErr := execute(t, e, "CREATE TABLE other ("+tooLong+" INT);")
+		restarted := restartTestExecutor(t, e)
+		result := mustExecute(t, restarted, "SELECT "+longest+" FROM items ORDER BY id;")
+		columns := mustExecute(t, restarted, "SELECT * FROM items;")
+
+		// Assert
+		require.EqualError(t, renameErr, "column name too long: 33 bytes, maximum 32")
+		require.EqualError(t, addErr, "column name too long: 33 bytes, maximum 32")
+		require.EqualError(t, createErr, "column name too long: 33 bytes, maximum 32")
+		require.Equal(t, [][]string{{"pen"}, {"book"}}, resultStrings(result))
+		require.Equal(t, []string{"id", longest, "price"}, columnNames(columns))
+	})
+}
+
+func TestExecuteTruncateTable(t *testing.T) {
+	setup := func(t *testing.T) *executor {
+		e := newTestExecutor(t).(*executor)
+		mustExecute(t, e, "CREATE TABLE items (id INT PRIMARY KEY, title TEXT DEFAULT 'none');")
+		mustExecute(t, e, "INSERT INTO items VALUES (1, 'pen'), (2, 'book');")
+		return e
+	}
+
+	t.Run("1. TRUNCATE removes rows and pages and keeps columns", func(t *testing.T) {
+		// Arrange
+		e := setup(t)
+
+		// Act
+		mustExecute(t, e, "TRUNCATE TABLE items;")
+		empty := mustExecute(t, e, "SELECT * FROM items;")
+		metaInfo, err := e.readMetaInfo("items")
+		require.NoError(t, err)
+		pagesCount := metaInfo.DataHeaders.PagesCount
+		entries := len(metaInfo.PageDirectory.Entries)
+		mustExecute(t, e, "INSERT INTO items (id) VALUES (1);")
+		result := mustExecute(t, e, "SELECT * FROM items;")
+
+		// Assert
+		require.Equal(t, []string{"id", "title"}, columnNames(empty))
+		require.Empty(t, empty.Rows)
+		require.Equal(t, uint32(0), pagesCount)
+		require.Equal(t, 0, entries)
+		require.Equal(t, [][]string{{"1", "none"}}, resultStrings(result))
+	})
+
+	t.Run("2. RESTART IDENTITY resets NextRowID and survives restart", func(t *testing.T) {
+		// Arrange
+		e := setup(t)
+		metaInfo, err := e.readMetaInfo("items")
+		require.NoError(t, err)
+		metaInfo.MetaData.Header.NextRowID = 42
+		require.NoError(t, e.bufferPool.WriteMetaInfo("items"))
+
+		// Act
+		mustExecute(t, e, "TRUNCATE items;")
+		kept := metaInfo.MetaData.Header.NextRowID
+		mustExecute(t, e, "TRUNCATE TABLE items RESTART IDENTITY;")
+		restarted := restartTestExecutor(t, e).(*executor)
+		result := mustExecute(t, restarted, "SELECT count(*) FROM items;")
+		restartedMeta, err := restarted.bufferPool.ReadMetaInfo("items")
+		require.NoError(t, err)
+
+		// Assert
+		require.Equal(t, uint64(42), kept)
+		require.Equal(t, uint64(0), restartedMeta.MetaData.Header.NextRowID)
+		require.Equal(t, [][]string{{"0"}}, resultStrings(result))
+	})
+
+	t.Run("3. Unknown table", func(t *testing.T) {
+		// Arrange
+		e := setup(t)
+
+		// Act
+		_, err := execute(t, e, "TRUNCATE TABLE missing;")
+
+		// Assert
+		require.EqualError(t, err, "table missing not found")
+	})
+}
+
+func TestExecuteIfExists(t *testing.T) {
+	t.Run("1. CREATE TABLE IF NOT EXISTS keeps the existing table", func(t *testing.T) {
+		// Arrange
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE users (id INT, name TEXT);")
+		mustExecute(t, executor, "INSERT INTO users VALUES (1, 'Phil');")
+
+		// Act
+		notice := mustExecute(t, executor, "CREATE TABLE IF NOT EXISTS users (id INT);")
+		systemNotice := mustExecute(t, executor, "CREATE TABLE IF NOT EXISTS system_functions (id INT);")
+		_, existsErr := execute(t, executor, "CREATE TABLE users (id INT);")
+		created := mustExecute(t, executor, "CREATE TABLE IF NOT EXISTS posts (id INT);")
+		result := mustExecute(t, executor, "SELECT * FROM users;")
+
+		// Assert
+		require.Equal(t, []string{"table users already exists, skipping"}, notice.Notices)
+		require.Nil(t, notice.Columns)
+		require.Equal(t, []string{"table system_functions already exists, skipping"}, systemNotice.Notices)
+		require.EqualError(t, existsErr, "table users already exists")
+		require.Nil(t, created)
+		require.Equal(t, [][]string{{"1", "Phil"}}, resultStrings(result))
+	})
+
+	t.Run("2. DROP TABLE IF EXISTS skips a missing table", func(t *testing.T) {
+		// Arrange
+		executor := newTestExecutor(t)
+		mustExecute(t, executor, "CREATE TABLE users (id INT);")
+
+		// Act
+		dropped := mustExecute(t, executor, "DROP TABLE IF EXISTS users;")
+		notice := mustExecute(t, executor, "DROP TABLE IF EXISTS users;")
+		_, missingErr := execute(t, executor, "DROP TABLE users;")
+
+		// Assert
+		require.Nil(t, dropped)
+		require.Equal(t, []string{"table users does not exist, skipping"}, notice.Notices)
+		require.EqualError(t, missingErr, "table users not found")
+	})
+
+	t.Run("3. test.txt can be executed twice and after an interrupted run", func(t *testing.T) {
+		// Arrange
+		executor := newTestExecutor(t)
+		script, err := os.ReadFile("../../test.txt")
+		require.NoError(t, err)
+
+		// Таблицы users и posts уже созданы и заполнены: скрипт прервался после вставки строк
+		interrupted := string(script[:strings.Index(string(script), "SELECT")])
+		mustExecute(t, executor, interrupted)
+
+		// Act
+		_, firstErr := execute(t, executor, string(script))
+		_, secondErr := execute(t, executor, string(script))
+
+		// Assert
+		require.NoError(t, firstErr)
+		require.NoError(t, secondErr)
+	})
+}
@@ -0,0 +1,446 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/functions"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// builtinFunction реализация встроенной скалярной функции. Сигнатуры функций находятся в реестре functions
+type builtinFunction struct {
+	// resultType возвращает тип результата полиморфной функции (результат AnyElementType в сигнатуре) по типам аргументов
+	resultType func(argumentTypes []disk_manager.DataType) (disk_manager.DataType, error)
+	// call вычисляет функцию. Аргументы уже приведены к типам параметров выбранной сигнатуры
+	call func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error)
+}
+
+// builtinFunctions реализации встроенных функций по имени
+var builtinFunctions = map[string]builtinFunction{
+	"now": {
+		call: func(_ []disk_manager.DataCell) (disk_manager.DataCell, error) {
+			return disk_manager.DataCell{
+				DataType: disk_manager.TIMESTAMP_TYPE,
+				Data:     time.Now().UTC().Truncate(time.Microsecond),
+			}, nil
+		},
+	},
+	"date_trunc": {
+		call: func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+			if hasNullArgument(arguments) {
+				return nullCell(disk_manager.TIMESTAMP_TYPE), nil
+			}
+
+			timestamp, err := dateTrunc(arguments[0].Data.(string), arguments[1].Data.(time.Time))
+			if err != nil {
+				return disk_manager.DataCell{}, err
+			}
+			return disk_manager.DataCell{DataType: disk_manager.TIMESTAMP_TYPE, Data: timestamp}, nil
+		},
+	},
+	"extract": {
+		call: func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+			if hasNullArgument(arguments) {
+				return nullCell(disk_manager.DECIMAL_TYPE), nil
+			}
+
+			field := strings.ToLower(arguments[0].Data.(string))
+			var value disk_manager.Decimal
+			var err error
+			if interval, ok := arguments[1].Data.(disk_manager.Interval); ok {
+				value, err = extractFromInterval(field, interval)
+			} else {
+				value, err = extractFromTimestamp(field, arguments[1].Data.(time.Time))
+			}
+			if err != nil {
+				return disk_manager.DataCell{}, err
+			}
+			return disk_manager.DataCell{DataType: disk_manager.DECIMAL_TYPE, Data: value}, nil
+		},
+	},
+
+	"upper":    {call: textFunction(strings.ToUpper)},
+	"lower":    {call: textFunction(strings.ToLower)},
+	"length":   {call: length},
+	"substr":   {call: substr},
+	"trim":     {call: trim},
+	"replace":  {call: replace},
+	"concat":   {call: concat},
+	"position": {call: position},
+
+	"abs": {call: abs},
+	"round": {
+		call: func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+			if hasNullArgument(arguments) {
+				return nullCell(disk_manager.DECIMAL_TYPE), nil
+			}
+
+			scale := int32(0)
+			if len(arguments) == 2 {
+				scale = arguments[1].Data.(int32)
+			}
+			if scale < 0 || scale > disk_manager.DECIMAL_MAX_PRECISION {
+				return disk_manager.DataCell{}, fmt.Errorf("round scale %d is out of range", scale)
+			}
+
+			return disk_manager.DataCell{
+				DataType: disk_manager.DECIMAL_TYPE,
+				Data:     arguments[0].Data.(disk_manager.Decimal).Rescale(scale),
+			}, nil
+		},
+	},
+	"floor":  {call: decimalFunction(floorDecimal)},
+	"ceil":   {call: decimalFunction(ceilDecimal)},
+	"mod":    {call: mod},
+	"power":  {call: power},
+	"sqrt":   {call: sqrt},
+	"random": {call: random},
+
+	"coalesce": {
+		resultType: func(argumentTypes []disk_manager.DataType) (disk_manager.DataType, error) {
+			return commonType("COALESCE", argumentTypes)
+		},
+		call: func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+			argumentTypes := make([]disk_manager.DataType, 0, len(arguments))
+			for _, argument := range arguments {
+				argumentTypes = append(argumentTypes, argument.DataType)
+			}
+			resultType, err := commonType("COALESCE", argumentTypes)
+			if err != nil {
+				return disk_manager.DataCell{}, err
+			}
+
+			// Первое значение, отличное от NULL
+			for _, argument := range arguments {
+				if !argument.IsNull {
+					return coerceCell(argument, resultType)
+				}
+			}
+			return nullCell(resultType), nil
+		},
+	},
+	"nullif": {
+		resultType: func(argumentTypes []disk_manager.DataType) (disk_manager.DataType, error) {
+			if _, ok := comparisonType(argumentTypes[0], argumentTypes[1]); !ok {
+				return unknownType, fmt.Errorf("cannot compare %s with %s", argumentTypes[0], argumentTypes[1])
+			}
+			return nullifResultType(argumentTypes[0], argumentTypes[1]), nil
+		},
+		call: func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+			value, other := arguments[0], arguments[1]
+			resultType := nullifResultType(value.DataType, other.DataType)
+			if value.IsNull {
+				return nullCell(resultType), nil
+			}
+			if other.IsNull {
+				return value, nil
+			}
+
+			// NULL, если значения равны, иначе первое значение
+			result, err := compareCells(value, other)
+			if err != nil {
+				return disk_manager.DataCell{}, err
+			}
+			if result == 0 {
+				return nullCell(resultType), nil
+			}
+			return value, nil
+		},
+	},
+
+	"encode": {
+		call: func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+			if hasNullArgument(arguments) {
+				return nullCell(disk_manager.TEXT_TYPE), nil
+			}
+
+			data := arguments[0].Data.([]byte)
+			switch strings.ToLower(arguments[1].Data.(string)) {
+			case "hex":
+				return disk_manager.DataCell{DataType: disk_manager.TEXT_TYPE, Data: hex.EncodeToString(data)}, nil
+			case "base64":
+				return disk_manager.DataCell{DataType: disk_manager.TEXT_TYPE, Data: base64.StdEncoding.EncodeToString(data)}, nil
+			default:
+				return disk_manager.DataCell{}, fmt.Errorf("unrecognized encoding: %q", arguments[1].Data)
+			}
+		},
+	},
+	"decode": {
+		call: func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+			if hasNullArgument(arguments) {
+				return nullCell(disk_manager.BYTEA_TYPE), nil
+			}
+
+			value := arguments[0].Data.(string)
+			var data []byte
+			var err error
+			switch strings.ToLower(arguments[1].Data.(string)) {
+			case "hex":
+				data, err = hex.DecodeString(value)
+			case "base64":
+				data, err = base64.StdEncoding.DecodeString(value)
+			default:
+				return disk_manager.DataCell{}, fmt.Errorf("unrecognized encoding: %q", arguments[1].Data)
+			}
+			if err != nil {
+				return disk_manager.DataCell{}, fmt.Errorf("invalid %s data: %q", arguments[1].Data, value)
+			}
+			return disk_manager.DataCell{DataType: disk_manager.BYTEA_TYPE, Data: data}, nil
+		},
+	},
+
+	"json_extract": {
+		call: func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+			if hasNullArgument(arguments) {
+				return nullCell(disk_manager.JSON_TYPE), nil
+			}
+
+			path, err := parseJSONExtractPath(arguments[1].Data.(string))
+			if err != nil {
+				return disk_manager.DataCell{}, err
+			}
+
+			value, found := jsonGetPath(arguments[0].Data.(string), path)
+			if !found {
+				return nullCell(disk_manager.JSON_TYPE), nil
+			}
+			return disk_manager.DataCell{DataType: disk_manager.JSON_TYPE, Data: value}, nil
+		},
+	},
+	"json_array_length": {
+		call: func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+			if hasNullArgument(arguments) {
+				return nullCell(disk_manager.INT_32_TYPE), nil
+			}
+
+			length, err := jsonArrayLength(arguments[0].Data.(string))
+			if err != nil {
+				return disk_manager.DataCell{}, err
+			}
+			return disk_manager.DataCell{DataType: disk_manager.INT_32_TYPE, Data: length}, nil
+		},
+	},
+	"json_typeof": {
+		call: func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+			if hasNullArgument(arguments) {
+				return nullCell(disk_manager.TEXT_TYPE), nil
+			}
+			return disk_manager.DataCell{DataType: disk_manager.TEXT_TYPE, Data: jsonTypeof(arguments[0].Data.(string))}, nil
+		},
+	},
+}
+
+// resolveFunction находит реализацию функции и сигнатуру, подходящую под типы аргументов
+func (e *executor) resolveFunction(name string, argumentTypes []disk_manager.DataType) (builtinFunction, functions.Signature, error) {
+	function, ok := e.functions.Lookup(name)
+	if !ok {
+		return builtinFunction{}, functions.Signature{}, fmt.Errorf("function %s does not exist", name)
+	}
+	if function.Kind != functions.ScalarKind {
+		return builtinFunction{}, functions.Signature{}, fmt.Errorf("function %s does not exist", name)
+	}
+	implementation, ok := builtinFunctions[function.Name]
+	if function.Scalar != nil {
+		implementation, ok = userScalarFunction(function), true
+	}
+	if !ok {
+		return builtinFunction{}, functions.Signature{}, fmt.Errorf("function %s is not implemented", name)
+	}
+
+	signature, ok := function.Resolve(argumentTypes)
+	if !ok {
+		return builtinFunction{}, functions.Signature{}, fmt.Errorf("function %s(%s) does not exist", name, formatTypes(argumentTypes))
+	}
+	return implementation, signature, nil
+}
+
+// functionResultType проверяет типы аргументов вызова функции и возвращает тип результата
+func (e *executor) functionResultType(name string, argumentTypes []disk_manager.DataType) (disk_manager.DataType, error) {
+	implementation, signature, err := e.resolveFunction(name, argumentTypes)
+	if err != nil {
+		return unknownType, err
+	}
+
+	if signature.Result == functions.AnyElementType {
+		return implementation.resultType(argumentTypes)
+	}
+	return signature.Result, nil
+}
+
+// callFunction вычисляет функцию: аргументы приводятся к типам параметров сигнатуры,
+// аргументы полиморфных параметров передаются как есть
+func (e *executor) callFunction(name string, arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+	argumentTypes := make([]disk_manager.DataType, 0, len(arguments))
+	for _, argument := range arguments {
+		argumentTypes = append(argumentTypes, argument.DataType)
+	}
+	implementation, signature, err := e.resolveFunction(name, argumentTypes)
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+
+	for i, argument := range arguments {
+		parameter := signature.Parameter(i)
+		if parameter == functions.AnyType || parameter == functions.AnyElementType {
+			continue
+		}
+		if arguments[i], err = coerceCell(argument, parameter); err != nil {
+			return disk_manager.DataCell{}, err
+		}
+	}
+	return implementation.call(arguments)
+}
+
+// hasNullArgument проверяет, есть ли среди аргументов NULL: большинство функций тогда возвращают NULL
+func hasNullArgument(arguments []disk_manager.DataCell) bool {
+	for _, argument := range arguments {
+		if argument.IsNull {
+			return true
+		}
+	}
+	return false
+}
+
+// nullifResultType возвращает тип результата nullif(a, b): тип первого аргумента,
+// а для NULL без типа - тип второго
+func nullifResultType(first, second disk_manager.DataType) disk_manager.DataType {
+	switch {
+	case first != unknownType:
+		return first
+	case second != unknownType:
+		return second
+	default:
+		return disk_manager.TEXT_TYPE
+	}
+}
+
+// isTypeOrUnknown проверяет, что тип аргумента входит в список допустимых (NULL без типа допустим всегда).
+// Там, где допустим TEXT, допустимы и остальные строковые типы
+func isTypeOrUnknown(dataType disk_manager.DataType, allowed ...disk_manager.DataType) bool {
+	if dataType == unknownType {
+		return true
+	}
+	for _, allowedType := range allowed {
+		if dataType == allowedType ||
+			(allowedType == disk_manager.TEXT_TYPE && disk_manager.IsTextType(dataType)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// formatTypes форматирует список типов для сообщений об ошибках
+func formatTypes(dataTypes []disk_manager.DataType) string {
+	names := make([]string, 0, len(dataTypes))
+	for _, dataType := range dataTypes {
+		names = append(names, dataType.String())
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// dateTrunc обрезает timestamp до указанной единицы (неделя начинается с понедельника)
+func dateTrunc(field string, timestamp time.Time) (time.Time, error) {
+	timestamp = timestamp.UTC()
+	year, month, day := timestamp.Date()
+
+	switch strings.ToLower(field) {
+	case "microseconds":
+		return timestamp.Truncate(time.Microsecond), nil
+	case "milliseconds":
+		return timestamp.Truncate(time.Millisecond), nil
+	case "second":
+		return timestamp.Truncate(time.Second), nil
+	case "minute":
+		return timestamp.Truncate(time.Minute), nil
+	case "hour":
+		return timestamp.Truncate(time.Hour), nil
+	case "day":
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+	case "week":
+		offset := (int(timestamp.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, time.UTC), nil
+	case "month":
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC), nil
+	case "quarter":
+		return time.Date(year, month-(month-1)%3, 1, 0, 0, 0, 0, time.UTC), nil
+	case "year":
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), nil
+	default:
+		return time.Time{}, fmt.Errorf("unit %q not supported by date_trunc", field)
+	}
+}
+
+// extractFromTimestamp извлекает поле из даты или timestamp'а. second и epoch возвращаются с дробной частью
+// (микросекундами), остальные поля - целые числа
+func extractFromTimestamp(field string, timestamp time.Time) (disk_manager.Decimal, error) {
+	timestamp = timestamp.UTC()
+
+	var value int64
+	switch field {
+	case "year":
+		value = int64(timestamp.Year())
+	case "quarter":
+		value = int64(timestamp.Month()-1)/3 + 1
+	case "month":
+		value = int64(timestamp.Month())
+	case "week":
+		_, week := timestamp.ISOWeek()
+		value = int64(week)
+	case "day":
+		value = int64(timestamp.Day())
+	case "dow":
+		value = int64(timestamp.Weekday())
+	case "isodow":
+		value = int64(timestamp.Weekday()+6)%7 + 1
+	case "doy":
+		value = int64(timestamp.YearDay())
+	case "hour":
+		value = int64(timestamp.Hour())
+	case "minute":
+		value = int64(timestamp.Minute())
+	case "second":
+		return microsecondsDecimal(int64(timestamp.Second())*disk_manager.MICROSECONDS_PER_SECOND + int64(timestamp.Nanosecond()/1000)), nil
+	case "epoch":
+		return microsecondsDecimal(timestamp.UnixMicro()), nil
+	default:
+		return disk_manager.Decimal{}, fmt.Errorf("unit %q not supported by extract", field)
+	}
+	return disk_manager.NewDecimalFromInt(value), nil
+}
+
+// extractFromInterval извлекает поле из интервала
+func extractFromInterval(field string, interval disk_manager.Interval) (disk_manager.Decimal, error) {
+	seconds := interval.Microseconds / disk_manager.MICROSECONDS_PER_SECOND
+
+	var value int64
+	switch field {
+	case "year":
+		value = int64(interval.Months / 12)
+	case "month":
+		value = int64(interval.Months % 12)
+	case "day":
+		value = int64(interval.Days)
+	case "hour":
+		value = seconds / 3600
+	case "minute":
+		value = seconds % 3600 / 60
+	case "second":
+		return microsecondsDecimal(interval.Microseconds % (60 * disk_manager.MICROSECONDS_PER_SECOND)), nil
+	case "epoch":
+		return microsecondsDecimal(interval.Normalize().Microseconds), nil
+	default:
+		return disk_manager.Decimal{}, fmt.Errorf("unit %q not supported for INTERVAL", field)
+	}
+	return disk_manager.NewDecimalFromInt(value), nil
+}
+
+// microsecondsDecimal переводит микросекунды в секунды с шестью знаками после точки
+func microsecondsDecimal(microseconds int64) disk_manager.Decimal {
+	return disk_manager.Decimal{Unscaled: big.NewInt(microseconds), Scale: 6}
+}
@@ -0,0 +1,170 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+)
+
+// ========================== Grace Hash Join ==========================
+
+// graceHashJoinOperator соединяет стороны, правая из которых не помещается в WorkMemory.
+// Сначала обе стороны раскладываются по JOIN_PARTITIONS временным файлам по hash ключа соединения,
+// так что строки с равными ключами попадают в партиции с одним номером. Затем каждая пара партиций
+// соединяется hash join в памяти. Если правая партиция все равно не помещается в память,
+// пара партиций делится повторно (не глубже JOIN_MAX_DEPTH).
+// Строки с NULL ключом попадают в первую партицию: пары они не находят, но для внешних
+// соединений возвращаются дополненными NULL значениями
+type graceHashJoinOperator struct {
+	joinBase
+	keys  equiJoin
+	depth int // Глубина разбиения: 0 для исходных сторон, больше для повторно разделяемых партиций
+
+	started         bool
+	leftPartitions  []*spillFile
+	rightPartitions []*spillFile
+	partition       int      // Номер следующей пары партиций
+	current         operator // Соединение текущей пары партиций
+}
+
+func (op *graceHashJoinOperator) Next() (disk_manager.Row, bool, error) {
+	if !op.started {
+		op.started = true
+		if err := op.partitionInputs(); err != nil {
+			return nil, false, err
+		}
+	}
+
+	for {
+		if op.current != nil {
+			row, ok, err := op.current.Next()
+			if err != nil || ok {
+				return row, ok, err
+			}
+
+			err = op.current.Close()
+			op.current = nil
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		if op.partition >= len(op.rightPartitions) {
+			return nil, false, nil
+		}
+
+		current, err := op.joinPartition(op.partition)
+		if err != nil {
+			return nil, false, err
+		}
+		op.current = current
+		op.partition++
+	}
+}
+
+func (op *graceHashJoinOperator) Close() error {
+	var closeErr error
+	if op.current != nil {
+		closeErr = op.current.Close()
+		op.current = nil
+	}
+
+	// Файлы партиций, которые еще не переданы в соединение
+	for _, partitions := range [][]*spillFile{op.leftPartitions, op.rightPartitions} {
+		for _, file := range partitions {
+			if file == nil {
+				continue
+			}
+			if err := file.Close(); err != nil && closeErr == nil {
+				closeErr = err
+			}
+		}
+	}
+	op.leftPartitions = nil
+	op.rightPartitions = nil
+
+	if err := op.joinBase.Close(); err != nil && closeErr == nil {
+		closeErr = err
+	}
+	return closeErr
+}
+
+// partitionInputs раскладывает строки обеих сторон по файлам партиций
+func (op *graceHashJoinOperator) partitionInputs() error {
+	var err error
+	op.rightPartitions, err = op.partitionInput(op.right, op.keys.rightKey)
+	if err != nil {
+		return err
+	}
+	op.leftPartitions, err = op.partitionInput(op.left, op.keys.leftKey)
+	return err
+}
+
+// partitionInput раскладывает строки входа по JOIN_PARTITIONS файлам по hash ключа соединения
+func (op *graceHashJoinOperator) partitionInput(input operator, expression *ast.Expression) ([]*spillFile, error) {
+	partitions := make([]*spillFile, 0, JOIN_PARTITIONS)
+	for i := 0; i < JOIN_PARTITIONS; i++ {
+		file, err := newSpillFile(op.executor.config.TempDir, input.Columns())
+		if err != nil {
+			return partitions, err
+		}
+		partitions = append(partitions, file)
+	}
+
+	for {
+		row, ok, err := input.Next()
+		if err != nil {
+			return partitions, err
+		}
+		if !ok {
+			break
+		}
+
+		key, ok, err := op.keys.encode(op.executor, expression, input.Columns(), row)
+		if err != nil {
+			return partitions, err
+		}
+
+		index := 0
+		if ok {
+			index = partitionIndex(key, op.depth, JOIN_PARTITIONS)
+		}
+		if err := partitions[index].WriteRow(row); err != nil {
+			return partitions, err
+		}
+	}
+
+	return partitions, nil
+}
+
+// joinPartition создает соединение пары партиций с номером index. Файлы партиций переходят
+// во владение созданного оператора и удаляются при его закрытии
+func (op *graceHashJoinOperator) joinPartition(index int) (operator, error) {
+	leftFile, rightFile := op.leftPartitions[index], op.rightPartitions[index]
+	op.leftPartitions[index], op.rightPartitions[index] = nil, nil
+
+	left, err := newSpillScanOperator(leftFile, op.left.Columns())
+	if err != nil {
+		leftFile.Close()
+		rightFile.Close()
+		return nil, err
+	}
+	right, err := newSpillScanOperator(rightFile, op.right.Columns())
+	if err != nil {
+		left.Close()
+		rightFile.Close()
+		return nil, err
+	}
+
+	base := joinBase{
+		executor: op.executor,
+		kind:     op.kind,
+		left:     left,
+		right:    right,
+		columns:  op.columns,
+	}
+
+	if rightFile.size > op.executor.config.WorkMemory && op.depth < JOIN_MAX_DEPTH {
+		return &graceHashJoinOperator{joinBase: base, keys: op.keys, depth: op.depth + 1}, nil
+	}
+	return &hashJoinOperator{joinBase: base, keys: op.keys}, nil
+}
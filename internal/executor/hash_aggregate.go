@@ -0,0 +1,319 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"hash/fnv"
+)
+
+// AGGREGATE_PARTITIONS на сколько файлов делятся строки групп, не поместившихся в память
+const AGGREGATE_PARTITIONS = 8
+
+// AGGREGATE_MAX_DEPTH сколько раз партиция может быть разделена повторно.
+// Глубже все группы партиции агрегируются в памяти
+const AGGREGATE_MAX_DEPTH = 4
+
+// AGGREGATE_GROUP_OVERHEAD примерный объем памяти на группу помимо ключа и состояний
+const AGGREGATE_GROUP_OVERHEAD = 64
+
+// aggregateGroup одна группа: значения ключа и состояния агрегатных функций
+type aggregateGroup struct {
+	key    disk_manager.Row
+	states []aggregateState
+}
+
+// aggregatePartition партиция строк, отложенных на диск, и глубина разбиения, на которой она создана
+type aggregatePartition struct {
+	file  *spillFile
+	depth int
+}
+
+// ========================== Hash Aggregate ==========================
+
+// hashAggregateOperator группирует строки по выражениям GROUP BY и вычисляет агрегатные функции.
+// Группы хранятся в hash таблице. Когда память (WorkMemory) заканчивается, строки новых групп
+// раскладываются по партициям во временных файлах (по hash ключа), а после обработки входа
+// каждая партиция агрегируется отдельно тем же способом.
+// Строки результата: значения ключей группировки, затем значения агрегатных функций
+type hashAggregateOperator struct {
+	executor   *executor
+	input      operator
+	groupBy    []*ast.Expression
+	aggregates []*ast.Expression
+	functions  []aggregateFunction
+	columns    []ResultColumn
+	rowColumns []ResultColumn // Колонки строк, которые агрегируются: ключи, затем аргументы агрегатов
+
+	started    bool
+	groups     []*aggregateGroup // Группы текущей партиции в порядке появления
+	groupIndex int
+	pending    []aggregatePartition // Партиции, которые еще предстоит обработать
+}
+
+func newHashAggregateOperator(executor *executor, input operator, groupBy, aggregates []*ast.Expression) (*hashAggregateOperator, error) {
+	op := &hashAggregateOperator{
+		executor:   executor,
+		input:      input,
+		groupBy:    groupBy,
+		aggregates: aggregates,
+	}
+
+	for _, expression := range groupBy {
+		dataType, err := executor.inferExpressionType(expression, input.Columns())
+		if err != nil {
+			return nil, err
+		}
+
+		column := ResultColumn{Name: expressionName(expression), DataType: dataType, Expression: expression}
+		// Ключ - колонка входа: она остается доступной по имени, в том числе уточненному таблицей
+		if isColumnReference(expression) {
+			index, err := findColumn(input.Columns(), expression.Literal.Value)
+			if err != nil {
+				return nil, err
+			}
+			column.Name = input.Columns()[index].Name
+			column.Table = input.Columns()[index].Table
+		}
+		op.columns = append(op.columns, column)
+		op.rowColumns = append(op.rowColumns, column)
+	}
+
+	for _, expression := range aggregates {
+		function, err := executor.lookupAggregate(expression.Aggregate.Name.Value)
+		if err != nil {
+			return nil, err
+		}
+		resultType, err := executor.aggregateResultType(expression.Aggregate, input.Columns())
+		if err != nil {
+			return nil, err
+		}
+
+		// count(*) получает на вход константу, остальные функции - значение аргумента
+		argumentType := disk_manager.BOOLEAN_TYPE
+		if !expression.Aggregate.Star {
+			argumentType, err = executor.inferExpressionType(expression.Aggregate.Arguments[0], input.Columns())
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		op.functions = append(op.functions, function)
+		op.columns = append(op.columns, ResultColumn{Name: expressionName(expression), DataType: resultType, Expression: expression})
+		op.rowColumns = append(op.rowColumns, ResultColumn{Name: "?argument?", DataType: argumentType})
+	}
+
+	return op, nil
+}
+
+func (op *hashAggregateOperator) Columns() []ResultColumn {
+	return op.columns
+}
+
+func (op *hashAggregateOperator) Next() (disk_manager.Row, bool, error) {
+	if !op.started {
+		op.started = true
+		if err := op.aggregate(op.nextInputRow, 0); err != nil {
+			return nil, false, err
+		}
+
+		// Без GROUP BY у пустого входа одна группа: count = 0, остальные агрегаты NULL
+		if len(op.groupBy) == 0 && len(op.groups) == 0 && len(op.pending) == 0 {
+			op.groups = append(op.groups, op.newGroup(disk_manager.Row{}))
+		}
+	}
+
+	// Группы текущей партиции закончились - агрегируем следующую отложенную партицию
+	for op.groupIndex >= len(op.groups) {
+		if len(op.pending) == 0 {
+			return nil, false, nil
+		}
+
+		partition := op.pending[0]
+		op.pending = op.pending[1:]
+		if err := partition.file.Rewind(); err != nil {
+			return nil, false, err
+		}
+		err := op.aggregate(partition.file.ReadRow, partition.depth)
+		if closeErr := partition.file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	group := op.groups[op.groupIndex]
+	op.groupIndex++
+
+	row := make(disk_manager.Row, 0, len(op.columns))
+	row = append(row, group.key...)
+	for _, state := range group.states {
+		cell, err := state.result()
+		if err != nil {
+			return nil, false, err
+		}
+		row = append(row, cell)
+	}
+
+	return row, true, nil
+}
+
+func (op *hashAggregateOperator) Close() error {
+	op.groups = nil
+
+	var closeErr error
+	for _, partition := range op.pending {
+		if err := partition.file.Close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+	op.pending = nil
+
+	if err := op.input.Close(); err != nil && closeErr == nil {
+		closeErr = err
+	}
+	return closeErr
+}
+
+// nextInputRow читает строку входа и вычисляет по ней ключи группировки и аргументы агрегатов
+func (op *hashAggregateOperator) nextInputRow() (disk_manager.Row, bool, error) {
+	row, ok, err := op.input.Next()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	scope := &rowScope{
+		columns: op.input.Columns(),
+		row:     row,
+	}
+
+	result := make(disk_manager.Row, 0, len(op.rowColumns))
+	for _, expression := range op.groupBy {
+		cell, err := op.executor.evaluateExpression(expression, scope)
+		if err != nil {
+			return nil, false, err
+		}
+		result = append(result, cell)
+	}
+
+	for _, expression := range op.aggregates {
+		if expression.Aggregate.Star {
+			result = append(result, disk_manager.DataCell{DataType: disk_manager.BOOLEAN_TYPE, Data: true})
+			continue
+		}
+
+		cell, err := op.executor.evaluateExpression(expression.Aggregate.Arguments[0], scope)
+		if err != nil {
+			return nil, false, err
+		}
+		result = append(result, cell)
+	}
+
+	return result, true, nil
+}
+
+// aggregate группирует строки источника next в op.groups.
+// Строки групп, не поместившихся в память, откладываются в партиции глубины depth + 1
+func (op *hashAggregateOperator) aggregate(next func() (disk_manager.Row, bool, error), depth int) error {
+	table := map[string]*aggregateGroup{}
+	op.groups = nil
+	op.groupIndex = 0
+
+	var partitions []*spillFile
+	memoryUsed := 0
+	keyCount := len(op.groupBy)
+
+	for {
+		row, ok, err := next()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+
+		key := encodeGroupKey(row[:keyCount])
+		group, ok := table[key]
+		if !ok {
+			// Память закончилась - строки новых групп откладываются на диск
+			if memoryUsed > op.executor.config.WorkMemory && depth < AGGREGATE_MAX_DEPTH {
+				if partitions == nil {
+					partitions, err = op.createPartitions(depth + 1)
+					if err != nil {
+						return err
+					}
+				}
+				if err := partitions[partitionIndex(key, depth, AGGREGATE_PARTITIONS)].WriteRow(row); err != nil {
+					return err
+				}
+				continue
+			}
+
+			group = op.newGroup(row[:keyCount])
+			table[key] = group
+			op.groups = append(op.groups, group)
+			memoryUsed += AGGREGATE_GROUP_OVERHEAD + len(key)
+			for _, state := range group.states {
+				memoryUsed += state.size()
+			}
+		}
+
+		for i, state := range group.states {
+			argument := row[keyCount+i]
+			// Агрегатные функции пропускают NULL значения
+			if argument.IsNull {
+				continue
+			}
+
+			before := state.size()
+			if err := state.add(argument); err != nil {
+				return err
+			}
+			memoryUsed += state.size() - before
+		}
+	}
+
+	return nil
+}
+
+// createPartitions создает файлы партиций и ставит их в очередь на обработку
+func (op *hashAggregateOperator) createPartitions(depth int) ([]*spillFile, error) {
+	partitions := make([]*spillFile, 0, AGGREGATE_PARTITIONS)
+	for i := 0; i < AGGREGATE_PARTITIONS; i++ {
+		file, err := newSpillFile(op.executor.config.TempDir, op.rowColumns)
+		if err != nil {
+			return nil, err
+		}
+		partitions = append(partitions, file)
+		op.pending = append(op.pending, aggregatePartition{file: file, depth: depth})
+	}
+
+	return partitions, nil
+}
+
+// newGroup создает группу с пустыми состояниями агрегатных функций
+func (op *hashAggregateOperator) newGroup(key disk_manager.Row) *aggregateGroup {
+	group := &aggregateGroup{
+		key:    key,
+		states: make([]aggregateState, 0, len(op.aggregates)),
+	}
+
+	for i, expression := range op.aggregates {
+		state := op.functions[i].newState(op.columns[len(op.groupBy)+i].DataType)
+		if expression.Aggregate.Distinct {
+			state = newDistinctState(state)
+		}
+		group.states = append(group.states, state)
+	}
+
+	return group
+}
+
+// partitionIndex выбирает партицию по hash ключа. Глубина участвует в hash,
+// чтобы при повторном разбиении строки одной партиции распределялись по разным файлам
+func partitionIndex(key string, depth, partitions int) int {
+	hash := fnv.New32a()
+	hash.Write([]byte{byte(depth)})
+	hash.Write([]byte(key))
+	return int(hash.Sum32() % uint32(partitions))
+}
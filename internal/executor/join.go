@@ -0,0 +1,524 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"custom-database/internal/parser/lex"
+)
+
+// JOIN_PARTITIONS на сколько партиций Grace hash join делит обе стороны соединения
+const JOIN_PARTITIONS = 8
+
+// JOIN_MAX_DEPTH сколько раз партиция Grace hash join может быть разделена повторно.
+// Глубже партиция соединяется в памяти, даже если не помещается в WorkMemory
+const JOIN_MAX_DEPTH = 4
+
+// buildJoin выбирает способ соединения. leftSize и rightSize - оценка объема сторон в байтах.
+// Если условие ON - равенство, части которого ссылаются только на колонки своей стороны (a.id = b.user_id),
+// возможно вместе с условиями на колонки одной стороны через AND, hash таблица строится по меньшей из сторон:
+//   - меньшая сторона помещается в WorkMemory - hash join в памяти;
+//   - помещается каждая из JOIN_PARTITIONS партиций меньшей стороны - Grace hash join;
+//   - иначе обе стороны большие - sort-merge join с внешней сортировкой обеих сторон
+//     (если ключи сторон можно сортировать без приведения типов).
+//
+// Для остальных условий и CROSS JOIN выполняется nested loop join
+func (e *executor) buildJoin(left, right operator, join *ast.JoinClause, leftSize, rightSize int) (operator, error) {
+	base := joinBase{
+		executor:  e,
+		kind:      join.Kind,
+		left:      left,
+		right:     right,
+		condition: join.Condition,
+		columns:   append(append([]ResultColumn{}, left.Columns()...), right.Columns()...),
+	}
+
+	if join.Condition == nil {
+		return &nestedLoopJoinOperator{joinBase: base}, nil
+	}
+	if err := e.checkCondition(join.Condition, base.columns, "JOIN/ON"); err != nil {
+		return nil, err
+	}
+
+	keys, rest, ok, err := e.splitEquiJoinKeys(join.Condition, left.Columns(), right.Columns())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return &nestedLoopJoinOperator{joinBase: base}, nil
+	}
+
+	// Остальные условия ON проверяются до соединения фильтром стороны, на колонки которой они ссылаются.
+	// Так можно, только если строки этой стороны без пары не попадают в результат:
+	// для INNER JOIN - обе стороны, для LEFT JOIN - правая, для RIGHT JOIN - левая
+	var leftFilters, rightFilters []*ast.Expression
+	for _, conjunct := range rest {
+		switch {
+		case (join.Kind == ast.InnerJoin || join.Kind == ast.LeftJoin) && referencesOnly(conjunct, right.Columns()):
+			rightFilters = append(rightFilters, conjunct)
+		case (join.Kind == ast.InnerJoin || join.Kind == ast.RightJoin) && referencesOnly(conjunct, left.Columns()):
+			leftFilters = append(leftFilters, conjunct)
+		default:
+			return &nestedLoopJoinOperator{joinBase: base}, nil
+		}
+	}
+	if condition := joinConjuncts(leftFilters); condition != nil {
+		base.left = newFilterOperator(e, left, condition)
+	}
+	if condition := joinConjuncts(rightFilters); condition != nil {
+		base.right = newFilterOperator(e, right, condition)
+	}
+
+	// Равенство ключей проверяет сам оператор соединения, условие целиком проверять уже не нужно
+	base.condition = nil
+	return e.buildEquiJoin(base, keys, leftSize, rightSize)
+}
+
+// buildEquiJoin выбирает оператор соединения по равенству ключей по оценкам объема сторон.
+// Hash join строит hash таблицу по правой стороне, поэтому если левая сторона меньше, стороны меняются местами
+func (e *executor) buildEquiJoin(base joinBase, keys equiJoin, leftSize, rightSize int) (operator, error) {
+	swap := leftSize < rightSize && swappableJoin(base.kind)
+	buildSize := rightSize
+	if swap {
+		buildSize = leftSize
+	}
+
+	workMemory := e.config.WorkMemory
+	switch {
+	case buildSize <= workMemory:
+		return swapJoinSides(base, keys, swap, func(base joinBase, keys equiJoin) operator {
+			return &hashJoinOperator{joinBase: base, keys: keys}
+		}), nil
+	case buildSize <= workMemory*JOIN_PARTITIONS || !keys.ordered:
+		return swapJoinSides(base, keys, swap, func(base joinBase, keys equiJoin) operator {
+			return &graceHashJoinOperator{joinBase: base, keys: keys}
+		}), nil
+	}
+	return newMergeJoinOperator(base, keys)
+}
+
+// swappableJoin проверяет, что стороны соединения можно поменять местами.
+// Semi и anti join возвращают строки только левой стороны, для них стороны не меняются
+func swappableJoin(kind ast.JoinKind) bool {
+	return kind != semiJoin && kind != antiJoin
+}
+
+// swapJoinSides создает оператор соединения build. Если swap, оператор получает стороны в обратном порядке
+// (LEFT JOIN становится RIGHT JOIN и наоборот), а колонки его строк переставляются обратно
+func swapJoinSides(base joinBase, keys equiJoin, swap bool, build func(base joinBase, keys equiJoin) operator) operator {
+	if !swap {
+		return build(base, keys)
+	}
+
+	swapped := joinBase{
+		executor: base.executor,
+		kind:     base.kind,
+		left:     base.right,
+		right:    base.left,
+		columns:  append(append([]ResultColumn{}, base.right.Columns()...), base.left.Columns()...),
+	}
+	switch base.kind {
+	case ast.LeftJoin:
+		swapped.kind = ast.RightJoin
+	case ast.RightJoin:
+		swapped.kind = ast.LeftJoin
+	}
+	keys.leftKey, keys.rightKey = keys.rightKey, keys.leftKey
+
+	return &swappedJoinOperator{
+		input:     build(swapped, keys),
+		columns:   base.columns,
+		leftWidth: len(base.right.Columns()),
+	}
+}
+
+// swappedJoinOperator возвращает строки соединения, стороны которого поменяны местами,
+// в исходном порядке колонок: сначала колонки левой стороны запроса, затем правой
+type swappedJoinOperator struct {
+	input     operator
+	columns   []ResultColumn
+	leftWidth int // Количество колонок левой стороны входа (правой стороны запроса)
+}
+
+func (op *swappedJoinOperator) Columns() []ResultColumn {
+	return op.columns
+}
+
+func (op *swappedJoinOperator) Next() (disk_manager.Row, bool, error) {
+	row, ok, err := op.input.Next()
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+
+	result := make(disk_manager.Row, 0, len(row))
+	result = append(result, row[op.leftWidth:]...)
+	return append(result, row[:op.leftWidth]...), true, nil
+}
+
+func (op *swappedJoinOperator) Close() error {
+	return op.input.Close()
+}
+
+// equiJoin ключи соединения по равенству: выражения левой и правой стороны и общий тип, к которому они приводятся
+type equiJoin struct {
+	leftKey  *ast.Expression
+	rightKey *ast.Expression
+	keyType  disk_manager.DataType
+	// ordered - значения сторон упорядочены одинаково и до приведения к общему типу
+	// (нельзя сравнивать строки с датами), поэтому стороны можно сортировать по исходным ключам
+	ordered bool
+}
+
+// encode вычисляет ключ соединения для строки и кодирует его для hash таблицы. false - если ключ равен NULL
+func (k equiJoin) encode(executor *executor, expression *ast.Expression, columns []ResultColumn, row disk_manager.Row) (string, bool, error) {
+	cell, err := k.evaluate(executor, expression, columns, row)
+	if err != nil || cell.IsNull {
+		return "", false, err
+	}
+	return encodeGroupKey(disk_manager.Row{cell}), true, nil
+}
+
+// evaluate вычисляет ключ соединения для строки и приводит его к общему типу
+func (k equiJoin) evaluate(executor *executor, expression *ast.Expression, columns []ResultColumn, row disk_manager.Row) (disk_manager.DataCell, error) {
+	cell, err := executor.evaluateExpression(expression, &rowScope{
+		columns: columns,
+		row:     row,
+	})
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+	return coerceCell(cell, k.keyType)
+}
+
+// equiJoinKeys проверяет, что условие - равенство, одна часть которого ссылается только на колонки
+// левой стороны, а другая - только на колонки правой, и что значения частей можно сравнить
+func (e *executor) equiJoinKeys(condition *ast.Expression, leftColumns, rightColumns []ResultColumn) (equiJoin, bool, error) {
+	if condition.Kind != ast.BinaryKind || condition.Binary.Operator.Value != string(lex.EqualOperator) {
+		return equiJoin{}, false, nil
+	}
+
+	keys := equiJoin{}
+	a, b := condition.Binary.A, condition.Binary.B
+	switch {
+	case referencesOnly(a, leftColumns) && referencesOnly(b, rightColumns):
+		keys.leftKey, keys.rightKey = a, b
+	case referencesOnly(b, leftColumns) && referencesOnly(a, rightColumns):
+		keys.leftKey, keys.rightKey = b, a
+	default:
+		return equiJoin{}, false, nil
+	}
+
+	leftType, err := e.inferExpressionType(keys.leftKey, leftColumns)
+	if err != nil {
+		return equiJoin{}, false, err
+	}
+	rightType, err := e.inferExpressionType(keys.rightKey, rightColumns)
+	if err != nil {
+		return equiJoin{}, false, err
+	}
+
+	keyType, ok := comparisonType(leftType, rightType)
+	if !ok || keyType == unknownType {
+		return equiJoin{}, false, nil
+	}
+	keys.keyType = keyType
+	keys.ordered = orderedKeys(leftType, rightType, keyType)
+	return keys, true, nil
+}
+
+// splitEquiJoinKeys ищет среди условий, объединенных AND, равенство ключей сторон (см. equiJoinKeys).
+// Возвращает ключи и остальные условия
+func (e *executor) splitEquiJoinKeys(condition *ast.Expression, leftColumns, rightColumns []ResultColumn) (equiJoin, []*ast.Expression, bool, error) {
+	conjuncts := splitConjuncts(condition)
+	for i, conjunct := range conjuncts {
+		keys, ok, err := e.equiJoinKeys(conjunct, leftColumns, rightColumns)
+		if err != nil {
+			return equiJoin{}, nil, false, err
+		}
+		if ok {
+			rest := append(append([]*ast.Expression{}, conjuncts[:i]...), conjuncts[i+1:]...)
+			return keys, rest, true, nil
+		}
+	}
+	return equiJoin{}, nil, false, nil
+}
+
+// orderedKeys проверяет, что значения типов leftType и rightType упорядочены одинаково и без приведения к keyType
+func orderedKeys(leftType, rightType, keyType disk_manager.DataType) bool {
+	return leftType == rightType ||
+		(isNumericType(leftType) && isNumericType(rightType)) ||
+		(disk_manager.IsTextType(leftType) && disk_manager.IsTextType(rightType)) ||
+		(keyType == disk_manager.TIMESTAMP_TYPE && !disk_manager.IsTextType(leftType) && !disk_manager.IsTextType(rightType))
+}
+
+// referencesOnly проверяет, что выражение ссылается хотя бы на одну колонку и все его колонки есть среди columns.
+// Выражение с подзапросом не подходит: подзапрос может ссылаться на другие колонки
+func referencesOnly(expression *ast.Expression, columns []ResultColumn) bool {
+	references, resolved := false, true
+
+	var walk func(expression *ast.Expression)
+	walk = func(expression *ast.Expression) {
+		switch expression.Kind {
+		case ast.SubqueryKind, ast.ExistsKind, ast.InKind:
+			resolved = false
+			return
+		}
+		if isColumnReference(expression) {
+			references = true
+			if _, err := findColumn(columns, expression.Literal.Value); err != nil {
+				resolved = false
+			}
+			return
+		}
+		for _, child := range expression.Children() {
+			walk(child)
+		}
+	}
+	walk(expression)
+
+	return references && resolved
+}
+
+// ========================== Join Base ==========================
+
+// joinBase общая часть операторов соединения. Правая сторона целиком читается в память,
+// левая читается построчно. Для каждой строки левой стороны оператор перебирает строки-кандидаты
+// правой стороны и возвращает пары, для которых выполняется условие.
+// Строки без пары дополняются NULL значениями: левые для LEFT и FULL, правые для RIGHT и FULL.
+// Строки результата: колонки левой стороны, затем колонки правой.
+// Semi и anti join возвращают только строки левой стороны: имеющие пару и не имеющие пары соответственно
+type joinBase struct {
+	executor  *executor
+	kind      ast.JoinKind
+	left      operator
+	right     operator
+	condition *ast.Expression // Условие, проверяемое для каждой пары строк, nil - подходит любая пара
+	columns   []ResultColumn  // Для semi и anti join - только колонки левой стороны
+
+	rightRows []disk_manager.Row
+	matched   []bool // Нашлась ли пара для строки правой стороны (для RIGHT и FULL)
+
+	leftRow        disk_manager.Row
+	leftMatched    bool  // Нашлась ли пара для текущей строки левой стороны
+	candidates     []int // Индексы строк правой стороны, которые могут составить пару текущей строке
+	candidateIndex int
+	leftDone       bool
+	unmatchedIndex int // Следующая строка правой стороны, проверяемая на отсутствие пары
+}
+
+func (j *joinBase) Columns() []ResultColumn {
+	return j.columns
+}
+
+func (j *joinBase) Close() error {
+	j.rightRows = nil
+	j.matched = nil
+
+	leftErr := j.left.Close()
+	if err := j.right.Close(); err != nil {
+		return err
+	}
+	return leftErr
+}
+
+// readRight читает все строки правой стороны, вызывая add для каждой
+func (j *joinBase) readRight(add func(index int, row disk_manager.Row) error) error {
+	for {
+		row, ok, err := j.right.Next()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+
+		j.rightRows = append(j.rightRows, row)
+		if err := add(len(j.rightRows)-1, row); err != nil {
+			return err
+		}
+	}
+
+	j.matched = make([]bool, len(j.rightRows))
+	return nil
+}
+
+// next возвращает следующую строку соединения. lookup возвращает кандидатов для строки левой стороны
+func (j *joinBase) next(lookup func(leftRow disk_manager.Row) ([]int, error)) (disk_manager.Row, bool, error) {
+	for !j.leftDone {
+		// Кандидаты текущей строки закончились - переходим к следующей строке левой стороны
+		if j.leftRow == nil || j.candidateIndex >= len(j.candidates) {
+			if j.leftRow != nil && !j.leftMatched {
+				switch j.kind {
+				case ast.LeftJoin, ast.FullJoin:
+					row := j.combine(j.leftRow, nullRow(j.right.Columns()))
+					j.leftRow = nil
+					return row, true, nil
+				case antiJoin:
+					row := j.leftRow
+					j.leftRow = nil
+					return row, true, nil
+				}
+			}
+
+			row, ok, err := j.left.Next()
+			if err != nil {
+				return nil, false, err
+			}
+			if !ok {
+				j.leftDone = true
+				break
+			}
+
+			candidates, err := lookup(row)
+			if err != nil {
+				return nil, false, err
+			}
+			j.leftRow = row
+			j.leftMatched = false
+			j.candidates = candidates
+			j.candidateIndex = 0
+			continue
+		}
+
+		index := j.candidates[j.candidateIndex]
+		j.candidateIndex++
+
+		row := j.combine(j.leftRow, j.rightRows[index])
+		ok, err := j.satisfies(row)
+		if err != nil {
+			return nil, false, err
+		}
+		if !ok {
+			continue
+		}
+
+		j.leftMatched = true
+		j.matched[index] = true
+		switch j.kind {
+		case semiJoin:
+			// Строка левой стороны возвращается один раз, остальные кандидаты не нужны
+			j.candidateIndex = len(j.candidates)
+			return j.leftRow, true, nil
+		case antiJoin:
+			j.candidateIndex = len(j.candidates)
+		default:
+			return row, true, nil
+		}
+	}
+
+	// Левая сторона закончилась - строки правой стороны без пары для RIGHT и FULL
+	if j.kind == ast.RightJoin || j.kind == ast.FullJoin {
+		for j.unmatchedIndex < len(j.rightRows) {
+			index := j.unmatchedIndex
+			j.unmatchedIndex++
+			if !j.matched[index] {
+				return j.combine(nullRow(j.left.Columns()), j.rightRows[index]), true, nil
+			}
+		}
+	}
+
+	return nil, false, nil
+}
+
+// satisfies проверяет условие соединения для пары строк. NULL в условии считается ложью
+func (j *joinBase) satisfies(row disk_manager.Row) (bool, error) {
+	if j.condition == nil {
+		return true, nil
+	}
+
+	value, err := j.executor.evaluateExpression(j.condition, &rowScope{
+		columns: j.columns,
+		row:     row,
+	})
+	if err != nil {
+		return false, err
+	}
+	return !value.IsNull && value.Data.(bool), nil
+}
+
+// combine склеивает строки левой и правой стороны
+func (j *joinBase) combine(left, right disk_manager.Row) disk_manager.Row {
+	row := make(disk_manager.Row, 0, len(j.columns))
+	row = append(row, left...)
+	return append(row, right...)
+}
+
+// nullRow возвращает строку из NULL значений для строки без пары
+func nullRow(columns []ResultColumn) disk_manager.Row {
+	row := make(disk_manager.Row, 0, len(columns))
+	for _, column := range columns {
+		row = append(row, nullCell(column.DataType))
+	}
+	return row
+}
+
+// ========================== Nested Loop Join ==========================
+
+// nestedLoopJoinOperator сравнивает каждую строку левой стороны со всеми строками правой.
+// Подходит для любого условия соединения и для CROSS JOIN
+type nestedLoopJoinOperator struct {
+	joinBase
+
+	started bool
+	all     []int // Индексы всех строк правой стороны
+}
+
+func (op *nestedLoopJoinOperator) Next() (disk_manager.Row, bool, error) {
+	if !op.started {
+		op.started = true
+		err := op.readRight(func(index int, _ disk_manager.Row) error {
+			op.all = append(op.all, index)
+			return nil
+		})
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	return op.next(func(disk_manager.Row) ([]int, error) {
+		return op.all, nil
+	})
+}
+
+// ========================== Hash Join ==========================
+
+// hashJoinOperator строит hash таблицу по ключу правой стороны и для каждой строки левой стороны
+// перебирает только строки с тем же значением ключа. Ключи приводятся к общему типу сравнения,
+// строки с NULL ключом пары не находят
+type hashJoinOperator struct {
+	joinBase
+	keys equiJoin
+
+	started bool
+	table   map[string][]int // Значение ключа -> индексы строк правой стороны
+}
+
+func (op *hashJoinOperator) Next() (disk_manager.Row, bool, error) {
+	if !op.started {
+		op.started = true
+		op.table = map[string][]int{}
+		err := op.readRight(func(index int, row disk_manager.Row) error {
+			key, ok, err := op.keys.encode(op.executor, op.keys.rightKey, op.right.Columns(), row)
+			if err != nil || !ok {
+				return err
+			}
+			op.table[key] = append(op.table[key], index)
+			return nil
+		})
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	return op.next(func(row disk_manager.Row) ([]int, error) {
+		key, ok, err := op.keys.encode(op.executor, op.keys.leftKey, op.left.Columns(), row)
+		if err != nil || !ok {
+			return nil, err
+		}
+		return op.table[key], nil
+	})
+}
+
+func (op *hashJoinOperator) Close() error {
+	op.table = nil
+	return op.joinBase.Close()
+}
@@ -0,0 +1,257 @@
+package executor
+
+import (
+	"bytes"
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/lex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonOperatorResultTypes операторы доступа к JSON и типы их результата
+var jsonOperatorResultTypes = map[lex.MathOperator]disk_manager.DataType{
+	lex.JsonGetOperator:      disk_manager.JSON_TYPE,
+	lex.JsonGetTextOperator:  disk_manager.TEXT_TYPE,
+	lex.JsonPathOperator:     disk_manager.JSON_TYPE,
+	lex.JsonPathTextOperator: disk_manager.TEXT_TYPE,
+}
+
+// normalizeJSON проверяет JSON документ и возвращает его компактную запись
+func normalizeJSON(value string) (string, error) {
+	if !json.Valid([]byte(value)) {
+		return "", fmt.Errorf("invalid input syntax for type JSON: %q", value)
+	}
+
+	var buffer bytes.Buffer
+	if err := json.Compact(&buffer, []byte(value)); err != nil {
+		return "", fmt.Errorf("invalid input syntax for type JSON: %q", value)
+	}
+	return buffer.String(), nil
+}
+
+// isJSONOperand проверяет, что значение типа можно использовать как JSON (строки приводятся к JSON)
+func isJSONOperand(dataType disk_manager.DataType) bool {
+	return isTypeOrUnknown(dataType, disk_manager.JSON_TYPE, disk_manager.TEXT_TYPE)
+}
+
+// jsonOperatorResultType проверяет типы операндов оператора доступа к JSON и возвращает тип результата
+func jsonOperatorResultType(operator lex.MathOperator, left, right disk_manager.DataType) (disk_manager.DataType, error) {
+	resultType := jsonOperatorResultTypes[operator]
+
+	rightIsValid := isTypeOrUnknown(right, disk_manager.TEXT_TYPE)
+	if operator == lex.JsonGetOperator || operator == lex.JsonGetTextOperator {
+		// Ключ объекта или индекс массива
+		rightIsValid = isTypeOrUnknown(right, disk_manager.TEXT_TYPE, disk_manager.INT_32_TYPE)
+	}
+
+	if !isJSONOperand(left) || !rightIsValid {
+		return unknownType, fmt.Errorf("operator does not exist: %s %s %s", left, operator, right)
+	}
+	return resultType, nil
+}
+
+// applyJSONOperator выполняет оператор доступа к JSON над двумя не NULL значениями.
+// Если ключ, индекс или путь не найден, результат - NULL
+func applyJSONOperator(operator lex.MathOperator, left, right disk_manager.DataCell) (disk_manager.DataCell, error) {
+	resultType, err := jsonOperatorResultType(operator, left.DataType, right.DataType)
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+
+	document, err := coerceCell(left, disk_manager.JSON_TYPE)
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+
+	var value string
+	var found bool
+	switch operator {
+	case lex.JsonGetOperator, lex.JsonGetTextOperator:
+		// Индекс массива для чисел, ключ объекта для строк
+		if index, ok := right.Data.(int32); ok {
+			value, found = jsonElement(document.Data.(string), int(index))
+		} else {
+			value, found = jsonField(document.Data.(string), right.Data.(string))
+		}
+	default:
+		path, err := parseJSONPathArray(right.Data.(string))
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		value, found = jsonGetPath(document.Data.(string), path)
+	}
+	if !found {
+		return nullCell(resultType), nil
+	}
+
+	return jsonValueCell(value, resultType), nil
+}
+
+// jsonValueCell переводит JSON значение в ячейку: как JSON или как текст (для ->> и #>>)
+func jsonValueCell(value string, resultType disk_manager.DataType) disk_manager.DataCell {
+	if resultType == disk_manager.JSON_TYPE {
+		return disk_manager.DataCell{DataType: resultType, Data: value}
+	}
+
+	// JSON null в виде текста - это NULL, строки выводятся без кавычек
+	if value == "null" {
+		return nullCell(resultType)
+	}
+	var text string
+	if json.Unmarshal([]byte(value), &text) == nil {
+		return disk_manager.DataCell{DataType: resultType, Data: text}
+	}
+	return disk_manager.DataCell{DataType: resultType, Data: value}
+}
+
+// jsonField возвращает значение по ключу JSON объекта
+func jsonField(document string, key string) (string, bool) {
+	var object map[string]json.RawMessage
+	if !strings.HasPrefix(document, "{") || json.Unmarshal([]byte(document), &object) != nil {
+		return "", false
+	}
+
+	value, ok := object[key]
+	return string(value), ok
+}
+
+// jsonElement возвращает элемент JSON массива, отрицательный индекс отсчитывается с конца массива
+func jsonElement(document string, index int) (string, bool) {
+	var array []json.RawMessage
+	if !strings.HasPrefix(document, "[") || json.Unmarshal([]byte(document), &array) != nil {
+		return "", false
+	}
+
+	if index < 0 {
+		index += len(array)
+	}
+	if index < 0 || index >= len(array) {
+		return "", false
+	}
+	return string(array[index]), true
+}
+
+// jsonGetPath проходит по пути из ключей объектов и индексов массивов
+func jsonGetPath(document string, path []string) (string, bool) {
+	current := document
+
+	for _, step := range path {
+		var found bool
+		if strings.HasPrefix(current, "[") {
+			index, err := strconv.Atoi(step)
+			if err != nil {
+				return "", false
+			}
+			current, found = jsonElement(current, index)
+		} else {
+			current, found = jsonField(current, step)
+		}
+		if !found {
+			return "", false
+		}
+	}
+
+	return current, true
+}
+
+// parseJSONPathArray парсит путь в формате текстового массива: '{a,0,"b c"}'
+func parseJSONPathArray(value string) ([]string, error) {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, "{") || !strings.HasSuffix(trimmed, "}") {
+		return nil, fmt.Errorf("malformed JSON path: %q, expected format '{key,0,key}'", value)
+	}
+
+	inner := strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+	if inner == "" {
+		return []string{}, nil
+	}
+
+	path := []string{}
+	for _, element := range strings.Split(inner, ",") {
+		element = strings.TrimSpace(element)
+		if unquoted, err := strconv.Unquote(element); err == nil && strings.HasPrefix(element, `"`) {
+			element = unquoted
+		}
+		path = append(path, element)
+	}
+	return path, nil
+}
+
+// parseJSONExtractPath парсит путь в формате json_extract: '$.a.b[0]', '$."key with spaces"'
+func parseJSONExtractPath(value string) ([]string, error) {
+	if !strings.HasPrefix(value, "$") {
+		return nil, fmt.Errorf("malformed JSON path: %q, path must start with $", value)
+	}
+
+	path := []string{}
+	rest := value[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			if strings.HasPrefix(rest, `"`) {
+				end := strings.Index(rest[1:], `"`)
+				if end == -1 {
+					return nil, fmt.Errorf("malformed JSON path: %q", value)
+				}
+				path = append(path, rest[1:end+1])
+				rest = rest[end+2:]
+				continue
+			}
+
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("malformed JSON path: %q", value)
+			}
+			path = append(path, rest[:end])
+			rest = rest[end:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("malformed JSON path: %q", value)
+			}
+			index := rest[1:end]
+			if _, err := strconv.Atoi(index); err != nil {
+				return nil, fmt.Errorf("malformed JSON path: %q, array index must be an integer", value)
+			}
+			path = append(path, index)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("malformed JSON path: %q", value)
+		}
+	}
+
+	return path, nil
+}
+
+// jsonTypeof возвращает тип JSON значения: object, array, string, number, boolean или null
+func jsonTypeof(value string) string {
+	switch value[0] {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	default:
+		return "number"
+	}
+}
+
+// jsonArrayLength возвращает количество элементов JSON массива
+func jsonArrayLength(value string) (int32, error) {
+	var array []json.RawMessage
+	if !strings.HasPrefix(value, "[") || json.Unmarshal([]byte(value), &array) != nil {
+		return 0, fmt.Errorf("cannot get array length of a non-array")
+	}
+	return int32(len(array)), nil
+}
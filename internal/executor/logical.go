@@ -0,0 +1,88 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"custom-database/internal/parser/lex"
+	"fmt"
+	"strings"
+)
+
+// logicalResultType проверяет, что операнды AND, OR и NOT - условия. Результат всегда BOOLEAN
+func (e *executor) logicalResultType(logical *ast.LogicalExpression, columns []ResultColumn) (disk_manager.DataType, error) {
+	for _, operand := range logical.Operands {
+		operandType, err := e.inferExpressionType(operand, columns)
+		if err != nil {
+			return unknownType, err
+		}
+		if operandType != disk_manager.BOOLEAN_TYPE && operandType != unknownType {
+			return unknownType, fmt.Errorf("argument of %s must be type BOOLEAN, not %s", strings.ToUpper(string(logical.Operator)), operandType)
+		}
+	}
+	return disk_manager.BOOLEAN_TYPE, nil
+}
+
+// evaluateLogical вычисляет AND, OR и NOT по трехзначной логике: NULL - неизвестное значение.
+// FALSE AND NULL - FALSE, TRUE OR NULL - TRUE, остальные выражения с NULL операндом - NULL.
+// Второй операнд не вычисляется, если результат известен по первому
+func (e *executor) evaluateLogical(logical *ast.LogicalExpression, scope *rowScope) (disk_manager.DataCell, error) {
+	// Значение, при котором результат известен без остальных операндов: FALSE для AND, TRUE для OR
+	decisive := logical.Operator == lex.OrKeyword
+
+	unknown := false
+	for _, operand := range logical.Operands {
+		cell, err := e.evaluateExpression(operand, scope)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		if cell.IsNull {
+			unknown = true
+			continue
+		}
+
+		value, ok := cell.Data.(bool)
+		if !ok {
+			return disk_manager.DataCell{}, fmt.Errorf("argument of %s must be type BOOLEAN, not %s", strings.ToUpper(string(logical.Operator)), cell.DataType)
+		}
+		if logical.Operator == lex.NotKeyword {
+			return disk_manager.DataCell{DataType: disk_manager.BOOLEAN_TYPE, Data: !value}, nil
+		}
+		if value == decisive {
+			return disk_manager.DataCell{DataType: disk_manager.BOOLEAN_TYPE, Data: decisive}, nil
+		}
+	}
+
+	if unknown {
+		return nullCell(disk_manager.BOOLEAN_TYPE), nil
+	}
+	return disk_manager.DataCell{DataType: disk_manager.BOOLEAN_TYPE, Data: !decisive}, nil
+}
+
+// splitConjuncts разбирает условие a AND b AND ... на условия, объединенные AND
+func splitConjuncts(condition *ast.Expression) []*ast.Expression {
+	if condition.Kind != ast.LogicalKind || condition.Logical.Operator != lex.AndKeyword {
+		return []*ast.Expression{condition}
+	}
+
+	conjuncts := []*ast.Expression{}
+	for _, operand := range condition.Logical.Operands {
+		conjuncts = append(conjuncts, splitConjuncts(operand)...)
+	}
+	return conjuncts
+}
+
+// joinConjuncts объединяет условия через AND. nil - если условий нет
+func joinConjuncts(conjuncts []*ast.Expression) *ast.Expression {
+	var condition *ast.Expression
+	for _, conjunct := range conjuncts {
+		if condition == nil {
+			condition = conjunct
+			continue
+		}
+		condition = &ast.Expression{
+			Logical: &ast.LogicalExpression{Operator: lex.AndKeyword, Operands: []*ast.Expression{condition, conjunct}},
+			Kind:    ast.LogicalKind,
+		}
+	}
+	return condition
+}
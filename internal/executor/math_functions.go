@@ -0,0 +1,174 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/lex"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"strconv"
+)
+
+// decimalFunction создает функцию одного аргумента DECIMAL: floor, ceil
+func decimalFunction(apply func(disk_manager.Decimal) disk_manager.Decimal) func([]disk_manager.DataCell) (disk_manager.DataCell, error) {
+	return func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+		if hasNullArgument(arguments) {
+			return nullCell(disk_manager.DECIMAL_TYPE), nil
+		}
+		return disk_manager.DataCell{DataType: disk_manager.DECIMAL_TYPE, Data: apply(arguments[0].Data.(disk_manager.Decimal))}, nil
+	}
+}
+
+// abs возвращает модуль числа того же типа
+func abs(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+	if hasNullArgument(arguments) {
+		return nullCell(arguments[0].DataType), nil
+	}
+
+	if value, ok := arguments[0].Data.(int32); ok {
+		if value == math.MinInt32 {
+			return disk_manager.DataCell{}, fmt.Errorf("integer out of range")
+		}
+		return disk_manager.DataCell{DataType: disk_manager.INT_32_TYPE, Data: max(value, -value)}, nil
+	}
+
+	decimal := arguments[0].Data.(disk_manager.Decimal)
+	if decimal.Sign() < 0 {
+		decimal = decimal.Neg()
+	}
+	return disk_manager.DataCell{DataType: disk_manager.DECIMAL_TYPE, Data: decimal}, nil
+}
+
+// floorDecimal округляет число вниз до целого
+func floorDecimal(value disk_manager.Decimal) disk_manager.Decimal {
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(value.Scale)), nil)
+	// Div делит с остатком, который всегда не отрицателен, то есть округляет вниз
+	return disk_manager.Decimal{Unscaled: new(big.Int).Div(value.Unscaled, divisor), Scale: 0}
+}
+
+// ceilDecimal округляет число вверх до целого
+func ceilDecimal(value disk_manager.Decimal) disk_manager.Decimal {
+	return floorDecimal(value.Neg()).Neg()
+}
+
+// mod возвращает остаток от деления, как оператор %
+func mod(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+	if hasNullArgument(arguments) {
+		return nullCell(arguments[0].DataType), nil
+	}
+	return applyArithmetic(lex.ModuloOperator, arguments[0], arguments[1])
+}
+
+// power возводит число в степень. Целая степень вычисляется точно, дробная - через float64
+func power(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+	if hasNullArgument(arguments) {
+		return nullCell(disk_manager.DECIMAL_TYPE), nil
+	}
+
+	base, exponent := arguments[0].Data.(disk_manager.Decimal), arguments[1].Data.(disk_manager.Decimal)
+	integerExponent := exponent.Normalize().Scale == 0
+	switch {
+	case base.Sign() == 0 && exponent.Sign() < 0:
+		return disk_manager.DataCell{}, fmt.Errorf("zero raised to a negative power is undefined")
+	case base.Sign() < 0 && !integerExponent:
+		return disk_manager.DataCell{}, fmt.Errorf("a negative number raised to a non-integer power yields a complex result")
+	}
+
+	var result disk_manager.Decimal
+	var err error
+	if integerExponent && exponent.Normalize().Unscaled.IsInt64() {
+		result, err = integerPower(base, exponent.Normalize().Unscaled.Int64())
+	} else {
+		result, err = decimalFromFloat(math.Pow(base.Float64(), exponent.Float64()))
+	}
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+	return disk_manager.DataCell{DataType: disk_manager.DECIMAL_TYPE, Data: result}, nil
+}
+
+// integerPower возводит число в целую степень возведением в квадрат. Scale промежуточных результатов
+// ограничивается, чтобы длина чисел не росла вместе со степенью
+func integerPower(base disk_manager.Decimal, exponent int64) (disk_manager.Decimal, error) {
+	negative := exponent < 0
+	if negative {
+		exponent = -exponent
+	}
+
+	intermediateScale := int32(2 * DIVISION_MIN_SCALE)
+	result := disk_manager.NewDecimalFromInt(1)
+	for ; exponent > 0; exponent >>= 1 {
+		if exponent&1 == 1 {
+			result = limitScale(result.Mul(base), intermediateScale)
+		}
+		if exponent > 1 {
+			base = limitScale(base.Mul(base), intermediateScale)
+		}
+		if result.Precision()-result.Scale > disk_manager.DECIMAL_MAX_PRECISION ||
+			base.Precision()-base.Scale > disk_manager.DECIMAL_MAX_PRECISION {
+			return disk_manager.Decimal{}, fmt.Errorf("value overflows numeric format")
+		}
+	}
+
+	if negative {
+		return disk_manager.NewDecimalFromInt(1).Div(result, max(result.Scale, DIVISION_MIN_SCALE))
+	}
+	return limitScale(result, DIVISION_MIN_SCALE), nil
+}
+
+// limitScale округляет число, если у него больше scale знаков после точки
+func limitScale(value disk_manager.Decimal, scale int32) disk_manager.Decimal {
+	if value.Scale > scale {
+		return value.Rescale(scale)
+	}
+	return value
+}
+
+// sqrt возвращает квадратный корень числа с DIVISION_MIN_SCALE знаками после точки
+func sqrt(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+	if hasNullArgument(arguments) {
+		return nullCell(disk_manager.DECIMAL_TYPE), nil
+	}
+
+	value := arguments[0].Data.(disk_manager.Decimal)
+	if value.Sign() < 0 {
+		return disk_manager.DataCell{}, fmt.Errorf("cannot take square root of a negative number")
+	}
+
+	// Точности хватает на все цифры числа и DIVISION_MIN_SCALE знаков результата с запасом
+	precision := uint(value.Precision()+DIVISION_MIN_SCALE+10) * 4
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(value.Scale)), nil)
+	number := new(big.Float).SetPrec(precision).Quo(
+		new(big.Float).SetPrec(precision).SetInt(value.Unscaled),
+		new(big.Float).SetPrec(precision).SetInt(divisor),
+	)
+
+	result, err := disk_manager.ParseDecimal(new(big.Float).SetPrec(precision).Sqrt(number).Text('f', DIVISION_MIN_SCALE))
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+	return disk_manager.DataCell{DataType: disk_manager.DECIMAL_TYPE, Data: result}, nil
+}
+
+// random возвращает случайное число в диапазоне [0, 1)
+func random(_ []disk_manager.DataCell) (disk_manager.DataCell, error) {
+	result, err := decimalFromFloat(rand.Float64())
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+	return disk_manager.DataCell{DataType: disk_manager.DECIMAL_TYPE, Data: result}, nil
+}
+
+// decimalFromFloat переводит float64 в DECIMAL не больше чем с DIVISION_MIN_SCALE знаками после точки
+func decimalFromFloat(value float64) (disk_manager.Decimal, error) {
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return disk_manager.Decimal{}, fmt.Errorf("value overflows numeric format")
+	}
+
+	result, err := disk_manager.ParseDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+	if err != nil {
+		return disk_manager.Decimal{}, err
+	}
+	return limitScale(result, DIVISION_MIN_SCALE), nil
+}
@@ -0,0 +1,232 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+)
+
+// ========================== Sort-Merge Join ==========================
+
+// mergeJoinOperator соединяет стороны, отсортированные по ключу соединения внешней сортировкой
+// (sortOperator), поэтому объем памяти не зависит от размера сторон: в памяти держится только
+// группа строк правой стороны с одинаковым ключом. Стороны читаются параллельно по возрастанию ключа,
+// для каждой строки левой стороны пары ищутся в текущей группе правой.
+// NULL ключи при сортировке идут последними и пары не находят
+type mergeJoinOperator struct {
+	joinBase
+	keys equiJoin
+
+	leftDone     bool
+	group        []disk_manager.Row // Строки правой стороны с ключом groupKey
+	groupKey     disk_manager.DataCell
+	groupMatched []bool // Нашлась ли пара для строки группы (для RIGHT и FULL)
+	peeked       disk_manager.Row
+	peekedKey    disk_manager.DataCell
+	output       []disk_manager.Row // Готовые строки результата
+}
+
+// newMergeJoinOperator добавляет к сторонам соединения сортировку по ключу
+func newMergeJoinOperator(base joinBase, keys equiJoin) (*mergeJoinOperator, error) {
+	left, err := newSortOperator(base.executor, base.left, []sortKey{{expression: keys.leftKey}})
+	if err != nil {
+		return nil, err
+	}
+	right, err := newSortOperator(base.executor, base.right, []sortKey{{expression: keys.rightKey}})
+	if err != nil {
+		return nil, err
+	}
+
+	base.left, base.right = left, right
+	return &mergeJoinOperator{joinBase: base, keys: keys}, nil
+}
+
+func (op *mergeJoinOperator) Next() (disk_manager.Row, bool, error) {
+	for len(op.output) == 0 {
+		done, err := op.step()
+		if err != nil {
+			return nil, false, err
+		}
+		if done {
+			return nil, false, nil
+		}
+	}
+
+	row := op.output[0]
+	op.output = op.output[1:]
+	return row, true, nil
+}
+
+func (op *mergeJoinOperator) Close() error {
+	op.group = nil
+	op.output = nil
+	return op.joinBase.Close()
+}
+
+// step обрабатывает очередную строку левой стороны (или, когда она закончилась, правой),
+// добавляя строки результата в output. true - если строк больше не будет
+func (op *mergeJoinOperator) step() (bool, error) {
+	if op.leftDone {
+		return op.stepUnmatchedRight()
+	}
+
+	leftRow, ok, err := op.left.Next()
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		op.leftDone = true
+		return false, nil
+	}
+
+	leftKey, err := op.keys.evaluate(op.executor, op.keys.leftKey, op.left.Columns(), leftRow)
+	if err != nil {
+		return false, err
+	}
+
+	if !leftKey.IsNull {
+		// Группы правой стороны с меньшими ключами пар больше не найдут
+		compared := 1
+		for {
+			if op.group == nil {
+				loaded, err := op.loadGroup()
+				if err != nil {
+					return false, err
+				}
+				if !loaded {
+					break
+				}
+			}
+
+			compared, err = compareJoinKeys(op.groupKey, leftKey)
+			if err != nil {
+				return false, err
+			}
+			if compared >= 0 {
+				break
+			}
+			op.finishGroup()
+		}
+
+		if op.group != nil && compared == 0 {
+			switch op.kind {
+			case semiJoin:
+				op.output = append(op.output, leftRow)
+			case antiJoin:
+			default:
+				for i, rightRow := range op.group {
+					op.output = append(op.output, op.combine(leftRow, rightRow))
+					op.groupMatched[i] = true
+				}
+			}
+			return false, nil
+		}
+	}
+
+	switch op.kind {
+	case ast.LeftJoin, ast.FullJoin:
+		op.output = append(op.output, op.combine(leftRow, nullRow(op.right.Columns())))
+	case antiJoin:
+		op.output = append(op.output, leftRow)
+	}
+	return false, nil
+}
+
+// stepUnmatchedRight после окончания левой стороны возвращает строки правой стороны без пары для RIGHT и FULL
+func (op *mergeJoinOperator) stepUnmatchedRight() (bool, error) {
+	if op.kind != ast.RightJoin && op.kind != ast.FullJoin {
+		return true, nil
+	}
+
+	if op.group != nil {
+		op.finishGroup()
+		return false, nil
+	}
+
+	row, _, ok, err := op.nextRight()
+	if err != nil || !ok {
+		return true, err
+	}
+	op.output = append(op.output, op.combine(nullRow(op.left.Columns()), row))
+	return false, nil
+}
+
+// loadGroup читает следующую группу строк правой стороны с одинаковым ключом. false - если строки закончились
+func (op *mergeJoinOperator) loadGroup() (bool, error) {
+	row, key, ok, err := op.nextRight()
+	if err != nil || !ok {
+		return false, err
+	}
+	op.group = []disk_manager.Row{row}
+	op.groupKey = key
+
+	for {
+		row, key, ok, err := op.nextRight()
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			break
+		}
+
+		same := key.IsNull && op.groupKey.IsNull
+		if !key.IsNull && !op.groupKey.IsNull {
+			compared, err := compareCells(key, op.groupKey)
+			if err != nil {
+				return false, err
+			}
+			same = compared == 0
+		}
+
+		if !same {
+			op.peeked, op.peekedKey = row, key
+			break
+		}
+		op.group = append(op.group, row)
+	}
+
+	op.groupMatched = make([]bool, len(op.group))
+	return true, nil
+}
+
+// finishGroup завершает группу правой стороны: строки без пары возвращаются для RIGHT и FULL
+func (op *mergeJoinOperator) finishGroup() {
+	if op.kind == ast.RightJoin || op.kind == ast.FullJoin {
+		for i, row := range op.group {
+			if !op.groupMatched[i] {
+				op.output = append(op.output, op.combine(nullRow(op.left.Columns()), row))
+			}
+		}
+	}
+
+	op.group = nil
+	op.groupMatched = nil
+}
+
+// nextRight возвращает следующую строку правой стороны вместе со значением ключа
+func (op *mergeJoinOperator) nextRight() (disk_manager.Row, disk_manager.DataCell, bool, error) {
+	if op.peeked != nil {
+		row, key := op.peeked, op.peekedKey
+		op.peeked = nil
+		return row, key, true, nil
+	}
+
+	row, ok, err := op.right.Next()
+	if err != nil || !ok {
+		return nil, disk_manager.DataCell{}, false, err
+	}
+
+	key, err := op.keys.evaluate(op.executor, op.keys.rightKey, op.right.Columns(), row)
+	if err != nil {
+		return nil, disk_manager.DataCell{}, false, err
+	}
+	return row, key, true, nil
+}
+
+// compareJoinKeys сравнивает ключ группы правой стороны с ключом строки левой.
+// NULL ключ группы больше любого значения: при сортировке NULL идут последними
+func compareJoinKeys(groupKey, leftKey disk_manager.DataCell) (int, error) {
+	if groupKey.IsNull {
+		return 1, nil
+	}
+	return compareCells(groupKey, leftKey)
+}
@@ -0,0 +1,29 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+)
+
+// Result представляет результат выполнения запроса: описание колонок и строки
+type Result struct {
+	Columns []ResultColumn     // Описание колонок результата, nil для statement'ов без результата
+	Rows    []disk_manager.Row // Строки результата
+	Notices []string           // Сообщения для пользователя, например о пропущенном CREATE TABLE IF NOT EXISTS
+}
+
+// ResultColumn описывает одну колонку результата (или промежуточной строки в плане запроса)
+type ResultColumn struct {
+	Name     string                // Имя колонки
+	DataType disk_manager.DataType // Тип данных колонки
+	Table    string                // Таблица колонки (псевдоним или имя), по которой колонка доступна как table.column
+	// Expression - выражение, уже вычисленное оператором (ключ группировки или агрегатная функция).
+	// Такое выражение выше по плану не вычисляется заново, а берется из этой колонки. nil для обычных колонок
+	Expression *ast.Expression
+}
+
+// rowScope строка вместе с описанием ее колонок, относительно которой вычисляются выражения
+type rowScope struct {
+	columns []ResultColumn
+	row     disk_manager.Row
+}
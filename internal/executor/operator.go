@@ -0,0 +1,315 @@
+package executor
+
+import (
+	"custom-database/internal/buffer_bool"
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"custom-database/internal/parser/lex"
+	"strings"
+)
+
+// operator - узел плана выполнения запроса.
+// Операторы соединяются в дерево, каждый вызов Next возвращает очередную строку (модель Volcano)
+type operator interface {
+	// Columns возвращает описание колонок строк, которые возвращает оператор
+	Columns() []ResultColumn
+	// Next возвращает следующую строку, false - если строки закончились
+	Next() (disk_manager.Row, bool, error)
+	// Close освобождает ресурсы оператора
+	Close() error
+}
+
+// ========================== Table Scan ==========================
+
+// tableScanOperator последовательно читает строки таблицы страница за страницей через buffer pool
+type tableScanOperator struct {
+	bufferPool buffer_bool.BufferPoolInterface
+	tableName  string
+	metaInfo   *buffer_bool.MetaInfo
+	columns    []ResultColumn
+
+	pageIndex int                // Индекс следующей страницы в page directory
+	rows      []disk_manager.Row // Строки текущей страницы
+	rowIndex  int                // Индекс следующей строки текущей страницы
+}
+
+// newTableScanOperator создает сканирование таблицы. qualifier - имя (или псевдоним),
+// по которому колонки таблицы доступны как qualifier.column
+func newTableScanOperator(bufferPool buffer_bool.BufferPoolInterface, tableName, qualifier string, metaInfo *buffer_bool.MetaInfo) *tableScanOperator {
+	columns := make([]ResultColumn, 0, len(metaInfo.MetaData.Columns))
+	for _, column := range metaInfo.MetaData.Columns {
+		columns = append(columns, ResultColumn{
+			Name:     column.ColumnName,
+			DataType: column.DataType,
+			Table:    qualifier,
+		})
+	}
+
+	return &tableScanOperator{
+		bufferPool: bufferPool,
+		tableName:  tableName,
+		metaInfo:   metaInfo,
+		columns:    columns,
+	}
+}
+
+func (op *tableScanOperator) Columns() []ResultColumn {
+	return op.columns
+}
+
+// estimatedSize оценивает объем таблицы в байтах по количеству страниц в заголовке файла данных
+func (op *tableScanOperator) estimatedSize() int {
+	return int(op.metaInfo.DataHeaders.PagesCount) * disk_manager.PAGE_SIZE
+}
+
+func (op *tableScanOperator) Next() (disk_manager.Row, bool, error) {
+	// Пока строки текущей страницы закончились, читаем следующую страницу
+	for op.rowIndex >= len(op.rows) {
+		entries := op.metaInfo.PageDirectory.Entries
+		if op.pageIndex >= len(entries) {
+			return nil, false, nil
+		}
+
+		entry := entries[op.pageIndex]
+		op.pageIndex++
+
+		// Пропускаем удаленные страницы
+		if entry.Flags != 0 {
+			continue
+		}
+
+		rows, err := readPageRows(op.bufferPool, op.tableName, op.metaInfo, entry.PageID)
+		if err != nil {
+			return nil, false, err
+		}
+		op.rows = rows
+		op.rowIndex = 0
+	}
+
+	row := op.rows[op.rowIndex]
+	op.rowIndex++
+	return row, true, nil
+}
+
+func (op *tableScanOperator) Close() error {
+	op.rows = nil
+	return nil
+}
+
+// ========================== Filter ==========================
+
+// filterOperator пропускает только строки, для которых условие истинно (WHERE)
+type filterOperator struct {
+	executor  *executor
+	input     operator
+	condition *ast.Expression
+}
+
+func newFilterOperator(executor *executor, input operator, condition *ast.Expression) *filterOperator {
+	return &filterOperator{
+		executor:  executor,
+		input:     input,
+		condition: condition,
+	}
+}
+
+func (op *filterOperator) Columns() []ResultColumn {
+	return op.input.Columns()
+}
+
+func (op *filterOperator) Next() (disk_manager.Row, bool, error) {
+	for {
+		row, ok, err := op.input.Next()
+		if err != nil || !ok {
+			return nil, false, err
+		}
+
+		value, err := op.executor.evaluateExpression(op.condition, &rowScope{
+			columns: op.input.Columns(),
+			row:     row,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+
+		// NULL в условии считается ложью
+		if !value.IsNull && value.Data.(bool) {
+			return row, true, nil
+		}
+	}
+}
+
+func (op *filterOperator) Close() error {
+	return op.input.Close()
+}
+
+// ========================== Projection ==========================
+
+// projectionOperator вычисляет выражения списка SELECT для каждой строки
+type projectionOperator struct {
+	executor    *executor
+	input       operator
+	expressions []*ast.Expression
+	columns     []ResultColumn
+}
+
+// newProjectionOperator создает проекцию, пустой список выражений означает SELECT * (все колонки)
+func newProjectionOperator(executor *executor, input operator, expressions []*ast.Expression) (*projectionOperator, error) {
+	columns := make([]ResultColumn, 0, len(expressions))
+	for _, expression := range expressions {
+		dataType, err := executor.inferExpressionType(expression, input.Columns())
+		if err != nil {
+			return nil, err
+		}
+
+		columns = append(columns, ResultColumn{
+			Name:     expressionName(expression),
+			DataType: dataType,
+		})
+	}
+	if len(expressions) == 0 {
+		for _, column := range input.Columns() {
+			columns = append(columns, ResultColumn{Name: column.Name, DataType: column.DataType})
+		}
+	}
+
+	return &projectionOperator{
+		executor:    executor,
+		input:       input,
+		expressions: expressions,
+		columns:     columns,
+	}, nil
+}
+
+func (op *projectionOperator) Columns() []ResultColumn {
+	return op.columns
+}
+
+func (op *projectionOperator) Next() (disk_manager.Row, bool, error) {
+	row, ok, err := op.input.Next()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	if len(op.expressions) == 0 {
+		return row, true, nil
+	}
+
+	scope := &rowScope{
+		columns: op.input.Columns(),
+		row:     row,
+	}
+
+	result := make(disk_manager.Row, 0, len(op.expressions))
+	for _, expression := range op.expressions {
+		cell, err := op.executor.evaluateExpression(expression, scope)
+		if err != nil {
+			return nil, false, err
+		}
+		result = append(result, cell)
+	}
+
+	return result, true, nil
+}
+
+func (op *projectionOperator) Close() error {
+	return op.input.Close()
+}
+
+// ========================== Limit ==========================
+
+// limitOperator пропускает offset строк и возвращает не больше limit строк (LIMIT/OFFSET).
+// Получив нужное количество строк, оператор больше не запрашивает строки у входа,
+// поэтому сканирование таблицы останавливается, не дочитав оставшиеся страницы
+type limitOperator struct {
+	input    operator
+	limit    int // Максимальное количество строк, отрицательное значение - без ограничения
+	offset   int
+	returned int // Сколько строк уже возвращено
+	skipped  bool
+}
+
+func newLimitOperator(input operator, limit, offset int) *limitOperator {
+	return &limitOperator{
+		input:  input,
+		limit:  limit,
+		offset: offset,
+	}
+}
+
+func (op *limitOperator) Columns() []ResultColumn {
+	return op.input.Columns()
+}
+
+func (op *limitOperator) Next() (disk_manager.Row, bool, error) {
+	if op.limit >= 0 && op.returned >= op.limit {
+		return nil, false, nil
+	}
+
+	// Пропускаем первые offset строк
+	if !op.skipped {
+		for i := 0; i < op.offset; i++ {
+			_, ok, err := op.input.Next()
+			if err != nil || !ok {
+				return nil, false, err
+			}
+		}
+		op.skipped = true
+	}
+
+	row, ok, err := op.input.Next()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	op.returned++
+	return row, true, nil
+}
+
+func (op *limitOperator) Close() error {
+	return op.input.Close()
+}
+
+// expressionName возвращает имя колонки результата для выражения
+func expressionName(expression *ast.Expression) string {
+	if expression.Alias != nil {
+		return expression.Alias.Value
+	}
+
+	switch expression.Kind {
+	case ast.LiteralKind:
+		if expression.Literal.Kind == lex.IdentifierToken {
+			_, name := splitColumnName(expression.Literal.Value)
+			return name
+		}
+	case ast.TypedLiteralKind:
+		return expression.DataType.Value
+	case ast.FunctionCallKind:
+		return expression.FunctionCall.Name.Value
+	case ast.AggregateKind:
+		return expression.Aggregate.Name.Value
+	case ast.WindowKind:
+		return expression.Window.Name.Value
+	case ast.ExistsKind:
+		return "exists"
+	case ast.CaseKind:
+		return "case"
+	case ast.CastKind:
+		// Приведение колонки (в том числе многократное) называется как колонка, приведение литерала - как целевой тип
+		operand := expression.Cast.Operand
+		for operand.Kind == ast.CastKind {
+			operand = operand.Cast.Operand
+		}
+		if name := expressionName(operand); name != "?column?" && operand.Kind != ast.TypedLiteralKind {
+			return name
+		}
+		return strings.ToLower(expression.Cast.DataType.Value)
+	case ast.SubqueryKind:
+		// Подзапрос-значение называется так же, как его единственная колонка
+		if selected := expression.Subquery.Select.FirstQuery().SelectedColumns; len(selected) == 1 {
+			return expressionName(selected[0])
+		}
+	}
+
+	return "?column?"
+}
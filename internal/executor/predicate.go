@@ -0,0 +1,231 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// likeEscape символ, после которого символы шаблона LIKE % и _ теряют специальное значение
+const likeEscape = '\\'
+
+// predicateResultType проверяет типы операнда и аргументов предиката. Результат предиката всегда BOOLEAN
+func (e *executor) predicateResultType(expression *ast.Expression, columns []ResultColumn) (disk_manager.DataType, error) {
+	predicate := expression.Predicate
+
+	operandType, err := e.inferExpressionType(predicate.Operand, columns)
+	if err != nil {
+		return unknownType, err
+	}
+
+	for _, argument := range predicate.Arguments {
+		argumentType, err := e.inferExpressionType(argument, columns)
+		if err != nil {
+			return unknownType, err
+		}
+
+		if expression.Kind == ast.LikeKind {
+			if !isLikeOperandType(operandType) || !isLikeOperandType(argumentType) {
+				return unknownType, fmt.Errorf("operator does not exist: %s %s %s", operandType, likeOperatorName(predicate), argumentType)
+			}
+			continue
+		}
+		if _, ok := comparisonType(operandType, argumentType); !ok {
+			return unknownType, fmt.Errorf("cannot compare %s with %s", operandType, argumentType)
+		}
+	}
+
+	return disk_manager.BOOLEAN_TYPE, nil
+}
+
+// isLikeOperandType проверяет, что значение типа можно сравнивать с шаблоном LIKE
+func isLikeOperandType(dataType disk_manager.DataType) bool {
+	return disk_manager.IsTextType(dataType) || dataType == unknownType
+}
+
+// likeOperatorName возвращает имя оператора сравнения с шаблоном для сообщений об ошибках
+func likeOperatorName(predicate *ast.PredicateExpression) string {
+	if predicate.CaseInsensitive {
+		return "ILIKE"
+	}
+	return "LIKE"
+}
+
+// evaluatePredicate вычисляет предикат LIKE, IN (список), BETWEEN или IS NULL.
+// Кроме IS NULL, результат NULL, если значение предиката нельзя определить из-за NULL операндов
+func (e *executor) evaluatePredicate(expression *ast.Expression, scope *rowScope) (disk_manager.DataCell, error) {
+	predicate := expression.Predicate
+
+	operand, err := e.evaluateExpression(predicate.Operand, scope)
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+
+	arguments := make([]disk_manager.DataCell, 0, len(predicate.Arguments))
+	for _, argument := range predicate.Arguments {
+		cell, err := e.evaluateExpression(argument, scope)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		arguments = append(arguments, cell)
+	}
+
+	var result, known bool
+	switch expression.Kind {
+	case ast.IsNullKind:
+		result, known = operand.IsNull, true
+	case ast.LikeKind:
+		result, known, err = evaluateLike(operand, arguments[0], predicate.CaseInsensitive)
+	case ast.InListKind:
+		result, known, err = evaluateInList(operand, arguments)
+	case ast.BetweenKind:
+		result, known, err = evaluateBetween(operand, arguments[0], arguments[1])
+	default:
+		return disk_manager.DataCell{}, fmt.Errorf("unsupported predicate: %s", expression.Kind)
+	}
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+
+	if !known {
+		return nullCell(disk_manager.BOOLEAN_TYPE), nil
+	}
+	return disk_manager.DataCell{DataType: disk_manager.BOOLEAN_TYPE, Data: result != predicate.Negated}, nil
+}
+
+// evaluateLike сравнивает значение с шаблоном LIKE. Второе значение false, если один из операндов NULL
+func evaluateLike(value, pattern disk_manager.DataCell, caseInsensitive bool) (bool, bool, error) {
+	if value.IsNull || pattern.IsNull {
+		return false, false, nil
+	}
+
+	text, patternText := concatText(value), concatText(pattern)
+	if caseInsensitive {
+		text, patternText = strings.ToLower(text), strings.ToLower(patternText)
+	}
+
+	matched, err := matchLike(text, patternText)
+	return matched, true, err
+}
+
+// evaluateInList проверяет вхождение значения в список. Если совпадения нет, но в списке есть NULL
+// (или само значение NULL), результат неизвестен
+func evaluateInList(value disk_manager.DataCell, values []disk_manager.DataCell) (bool, bool, error) {
+	if value.IsNull {
+		return false, false, nil
+	}
+
+	known := true
+	for _, candidate := range values {
+		if candidate.IsNull {
+			known = false
+			continue
+		}
+
+		result, err := compareCells(value, candidate)
+		if err != nil {
+			return false, false, err
+		}
+		if result == 0 {
+			return true, true, nil
+		}
+	}
+
+	return false, known, nil
+}
+
+// evaluateBetween проверяет low <= value AND value <= high. Если одна из проверок ложна,
+// результат ложен даже при NULL в другой границе
+func evaluateBetween(value, low, high disk_manager.DataCell) (bool, bool, error) {
+	if value.IsNull {
+		return false, false, nil
+	}
+
+	known := true
+	for i, bound := range []disk_manager.DataCell{low, high} {
+		if bound.IsNull {
+			known = false
+			continue
+		}
+
+		result, err := compareCells(value, bound)
+		if err != nil {
+			return false, false, err
+		}
+		if (i == 0 && result < 0) || (i == 1 && result > 0) {
+			return false, true, nil
+		}
+	}
+
+	return true, known, nil
+}
+
+// matchLike сравнивает строку с шаблоном LIKE: % - любая последовательность символов, _ - ровно один символ,
+// \ экранирует следующий символ. При несовпадении после % сравнение продолжается с последнего %,
+// поэтому время работы не растет экспоненциально от количества % в шаблоне
+func matchLike(text, pattern string) (bool, error) {
+	if err := checkLikePattern(pattern); err != nil {
+		return false, err
+	}
+
+	textPos, patternPos := 0, 0
+	// Позиция в шаблоне после последнего % и позиция в строке, с которой этот % сейчас сопоставлен
+	starPattern, starText := -1, 0
+
+	for textPos < len(text) {
+		if patternPos < len(pattern) {
+			symbol, size := utf8.DecodeRuneInString(pattern[patternPos:])
+			switch symbol {
+			case '%':
+				patternPos += size
+				starPattern, starText = patternPos, textPos
+				continue
+			case '_':
+				_, textSize := utf8.DecodeRuneInString(text[textPos:])
+				textPos += textSize
+				patternPos += size
+				continue
+			case likeEscape:
+				escaped, escapedSize := utf8.DecodeRuneInString(pattern[patternPos+size:])
+				symbol, size = escaped, size+escapedSize
+			}
+
+			textSymbol, textSize := utf8.DecodeRuneInString(text[textPos:])
+			if textSymbol == symbol {
+				textPos += textSize
+				patternPos += size
+				continue
+			}
+		}
+
+		// Несовпадение: последний % забирает еще один символ строки
+		if starPattern < 0 {
+			return false, nil
+		}
+		_, textSize := utf8.DecodeRuneInString(text[starText:])
+		starText += textSize
+		textPos, patternPos = starText, starPattern
+	}
+
+	// Строка закончилась, в шаблоне могут остаться только %
+	for _, symbol := range pattern[patternPos:] {
+		if symbol != '%' {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// checkLikePattern проверяет, что шаблон LIKE не заканчивается символом экранирования
+func checkLikePattern(pattern string) error {
+	escaped := false
+	for _, symbol := range pattern {
+		escaped = !escaped && symbol == likeEscape
+	}
+	if escaped {
+		return fmt.Errorf("LIKE pattern must not end with escape character")
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package executor
+
+import (
+	"custom-database/internal/buffer_bool"
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Имя, по которому в ON CONFLICT DO UPDATE доступны значения вставляемой строки: EXCLUDED.column
+const EXCLUDED_TABLE = "excluded"
+
+// keyEntry значение первичного ключа, которое уже есть в таблице или вставляется текущим INSERT
+type keyEntry struct {
+	row      disk_manager.Row
+	location *rowLocation // Положение строки в таблице, nil для строки, вставляемой текущим INSERT
+	touched  bool         // Строка вставлена или обновлена текущим INSERT
+}
+
+// insertPlan изменения таблицы, которые INSERT применяет только после проверки всех строк
+type insertPlan struct {
+	deletes []*keyEntry        // Строки, замененные ON CONFLICT DO UPDATE
+	inserts []disk_manager.Row // Новые строки и новые версии обновленных строк
+	index   *keyIndex          // Индекс первичного ключа, который обновляется вместе с таблицей. nil - ключа нет
+}
+
+// keyIndex индекс первичного ключа таблицы в памяти: закодированное значение ключа -> положение строки.
+// Индекс не хранится на диске и строится одним чтением таблицы после запуска или изменения ее формата
+type keyIndex struct {
+	keyColumns []int
+	locations  map[string]rowLocation
+}
+
+// key кодирует значение первичного ключа строки
+func (index *keyIndex) key(row disk_manager.Row) string {
+	return encodeGroupKey(keyCells(row, index.keyColumns))
+}
+
+// tableKeyIndex возвращает индекс первичного ключа таблицы, при первом обращении строит его по строкам таблицы
+func (e *executor) tableKeyIndex(tableName string, metaInfo *buffer_bool.MetaInfo, keyColumns []int) (*keyIndex, error) {
+	if index, ok := e.keyIndexes[tableName]; ok && slices.Equal(index.keyColumns, keyColumns) {
+		return index, nil
+	}
+
+	index := &keyIndex{keyColumns: keyColumns, locations: map[string]rowLocation{}}
+	err := e.scanTableRows(tableName, metaInfo, func(location rowLocation, row disk_manager.Row) error {
+		index.locations[index.key(row)] = location
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	e.keyIndexes[tableName] = index
+	return index, nil
+}
+
+// dropKeyIndex удаляет индекс первичного ключа таблицы, если строки таблицы изменены в обход индекса
+func (e *executor) dropKeyIndex(tableName string) {
+	delete(e.keyIndexes, tableName)
+}
+
+// primaryKeyColumns возвращает индексы колонок первичного ключа таблицы
+func primaryKeyColumns(columns []disk_manager.ColumnInfo) []int {
+	var keyColumns []int
+	for i, column := range columns {
+		if column.IsPrimaryKey == 1 {
+			keyColumns = append(keyColumns, i)
+		}
+	}
+	return keyColumns
+}
+
+// keyCells возвращает значения колонок ключа строки
+func keyCells(row disk_manager.Row, keyColumns []int) disk_manager.Row {
+	key := make(disk_manager.Row, 0, len(keyColumns))
+	for _, index := range keyColumns {
+		key = append(key, row[index])
+	}
+	return key
+}
+
+// duplicateKeyError ошибка нарушения первичного ключа в виде (id, name)=(1, John)
+func duplicateKeyError(tableName string, columns []disk_manager.ColumnInfo, keyColumns []int, row disk_manager.Row) error {
+	names := make([]string, 0, len(keyColumns))
+	values := make([]string, 0, len(keyColumns))
+	for _, index := range keyColumns {
+		names = append(names, columns[index].ColumnName)
+		values = append(values, row[index].String())
+	}
+	return fmt.Errorf("duplicate key value (%s)=(%s) violates primary key of table %s",
+		strings.Join(names, ", "), strings.Join(values, ", "), tableName)
+}
+
+// checkConflictTarget проверяет, что колонки ON CONFLICT (column, ...) совпадают с первичным ключом таблицы
+func checkConflictTarget(onConflict *ast.OnConflictClause, columns []disk_manager.ColumnInfo, keyColumns []int) error {
+	if onConflict == nil || len(onConflict.Columns) == 0 {
+		return nil
+	}
+
+	target := make(map[string]bool, len(onConflict.Columns))
+	for _, column := range onConflict.Columns {
+		target[column.Value] = true
+	}
+
+	matches := len(target) == len(keyColumns)
+	for _, index := range keyColumns {
+		if !target[columns[index].ColumnName] {
+			matches = false
+		}
+	}
+	if !matches {
+		return fmt.Errorf("there is no unique or exclusion constraint matching the ON CONFLICT specification")
+	}
+	return nil
+}
+
+// planKeyedInsert решает для каждой вставляемой строки, что с ней делать: вставить, пропустить (DO NOTHING)
+// или обновить конфликтующую строку (DO UPDATE). Конфликты ищутся в индексе первичного ключа
+// и среди строк, которые вставляет или обновляет этот же INSERT
+func (e *executor) planKeyedInsert(stmt *ast.InsertStatement, metaInfo *buffer_bool.MetaInfo, keyColumns []int, rows []disk_manager.Row) (*insertPlan, error) {
+	tableName := stmt.Table.Value
+	columns := metaInfo.MetaData.Columns
+
+	index, err := e.tableKeyIndex(tableName, metaInfo, keyColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	// keys значения ключа, измененные текущим INSERT. nil - ключ освобожден обновлением строки
+	keys := make(map[string]*keyEntry)
+	lookup := func(key string) (*keyEntry, error) {
+		if entry, ok := keys[key]; ok {
+			return entry, nil
+		}
+		location, ok := index.locations[key]
+		if !ok {
+			return nil, nil
+		}
+		row, err := e.readRow(tableName, metaInfo, location)
+		if err != nil {
+			return nil, err
+		}
+		return &keyEntry{row: row, location: &location}, nil
+	}
+
+	plan := &insertPlan{index: index}
+	for _, row := range rows {
+		key := index.key(row)
+		existing, err := lookup(key)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			keys[key] = &keyEntry{row: row, touched: true}
+			plan.inserts = append(plan.inserts, row)
+			continue
+		}
+
+		switch {
+		case stmt.OnConflict == nil:
+			return nil, duplicateKeyError(tableName, columns, keyColumns, row)
+		case stmt.OnConflict.Action == ast.DoNothingAction:
+			continue
+		case existing.touched:
+			return nil, fmt.Errorf("ON CONFLICT DO UPDATE command cannot affect row a second time")
+		}
+
+		updated, err := e.conflictUpdateRow(stmt, metaInfo, existing.row, row)
+		if err != nil {
+			return nil, err
+		}
+
+		// Обновление может изменить сам ключ, новое значение тоже должно быть уникальным
+		updatedKey := index.key(updated)
+		if updatedKey != key {
+			other, err := lookup(updatedKey)
+			if err != nil {
+				return nil, err
+			}
+			if other != nil {
+				return nil, duplicateKeyError(tableName, columns, keyColumns, updated)
+			}
+			keys[key] = nil
+		}
+		keys[updatedKey] = &keyEntry{row: updated, touched: true}
+
+		plan.deletes = append(plan.deletes, existing)
+		plan.inserts = append(plan.inserts, updated)
+	}
+
+	return plan, nil
+}
+
+// conflictUpdateRow вычисляет новую версию существующей строки по присваиваниям DO UPDATE SET.
+// Колонки существующей строки доступны по имени таблицы, колонки вставляемой - как EXCLUDED.column
+func (e *executor) conflictUpdateRow(stmt *ast.InsertStatement, metaInfo *buffer_bool.MetaInfo, existing, excluded disk_manager.Row) (disk_manager.Row, error) {
+	columns := metaInfo.MetaData.Columns
+	scope := &rowScope{
+		columns: append(tableColumns(metaInfo, stmt.Table.Value), tableColumns(metaInfo, EXCLUDED_TABLE)...),
+		row:     append(append(disk_manager.Row{}, existing...), excluded...),
+	}
+
+	// Все значения вычисляются по старой версии строки, как в UPDATE
+	updated := append(disk_manager.Row{}, existing...)
+	for _, assignment := range stmt.OnConflict.Updates {
+		index := -1
+		for i, column := range columns {
+			if column.ColumnName == assignment.Column.Value {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			return nil, fmt.Errorf("column %s of table %s does not exist", assignment.Column.Value, stmt.Table.Value)
+		}
+
+		value, err := e.evaluateExpression(assignment.Value, scope)
+		if err != nil {
+			return nil, err
+		}
+		cell, err := e.fitToColumn(value, columns[index])
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", columns[index].ColumnName, err)
+		}
+		if cell.IsNull && columns[index].IsNullable == 0 {
+			return nil, fmt.Errorf("column %s does not allow NULL values", columns[index].ColumnName)
+		}
+		updated[index] = cell
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,175 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"fmt"
+)
+
+// ========================== Set Operation ==========================
+
+// setOperationOperator объединяет, пересекает или вычитает результаты двух запросов (UNION, INTERSECT, EXCEPT).
+// Строки сравниваются по ключу encodeGroupKey, поэтому NULL равен NULL.
+// UNION читает сначала левый, затем правый вход; без ALL повторы отбрасываются по hash таблице возвращенных строк.
+// INTERSECT и EXCEPT сначала читают правый вход целиком и считают в hash таблице, сколько раз встречается
+// каждая строка, затем проверяют по ней строки левого входа
+type setOperationOperator struct {
+	kind    ast.SetOperationKind
+	all     bool
+	left    operator
+	right   operator
+	columns []ResultColumn
+
+	started  bool
+	leftDone bool                // UNION: строки левого входа закончились, читается правый
+	seen     map[string]struct{} // UNION: уже возвращенные строки
+	counts   map[string]int      // INTERSECT, EXCEPT: сколько раз строка встречается в правом входе
+}
+
+// newSetOperationOperator создает операцию над результатами запросов. Колонки результата называются
+// как колонки левого запроса, тип каждой колонки - общий тип колонок обоих запросов
+func newSetOperationOperator(operation *ast.SetOperation, left, right operator) (*setOperationOperator, error) {
+	leftColumns, rightColumns := left.Columns(), right.Columns()
+	if len(leftColumns) != len(rightColumns) {
+		return nil, fmt.Errorf("each %s query must have the same number of columns", operation.Kind)
+	}
+
+	columns := make([]ResultColumn, 0, len(leftColumns))
+	for i, column := range leftColumns {
+		dataType, ok := setOperationType(column.DataType, rightColumns[i].DataType)
+		if !ok {
+			return nil, fmt.Errorf("%s types %s and %s cannot be matched", operation.Kind, column.DataType, rightColumns[i].DataType)
+		}
+		columns = append(columns, ResultColumn{Name: column.Name, DataType: dataType})
+	}
+
+	return &setOperationOperator{
+		kind:    operation.Kind,
+		all:     operation.All,
+		left:    left,
+		right:   right,
+		columns: columns,
+		seen:    map[string]struct{}{},
+		counts:  map[string]int{},
+	}, nil
+}
+
+// setOperationType возвращает общий тип колонок двух запросов. Числа приводятся к INT, если обе колонки INT,
+// иначе к DECIMAL; строковые типы - к TEXT; NULL без типа принимает тип другой колонки
+func setOperationType(left, right disk_manager.DataType) (disk_manager.DataType, bool) {
+	if left == unknownType && right == unknownType {
+		return disk_manager.TEXT_TYPE, true
+	}
+	if left == disk_manager.JSON_TYPE && right == disk_manager.JSON_TYPE {
+		return left, true
+	}
+	return comparisonType(left, right)
+}
+
+func (op *setOperationOperator) Columns() []ResultColumn {
+	return op.columns
+}
+
+func (op *setOperationOperator) Next() (disk_manager.Row, bool, error) {
+	if op.kind == ast.UnionOperation {
+		return op.nextUnion()
+	}
+
+	if !op.started {
+		op.started = true
+		for {
+			row, ok, err := op.readRow(op.right)
+			if err != nil {
+				return nil, false, err
+			}
+			if !ok {
+				break
+			}
+			op.counts[encodeGroupKey(row)]++
+		}
+	}
+
+	for {
+		row, ok, err := op.readRow(op.left)
+		if err != nil || !ok {
+			return nil, false, err
+		}
+
+		key := encodeGroupKey(row)
+		count := op.counts[key]
+		switch {
+		case op.kind == ast.IntersectOperation && count > 0:
+			// Без ALL строка возвращается один раз, с ALL - столько раз, сколько она есть в обоих входах
+			op.counts[key]--
+			if !op.all {
+				op.counts[key] = 0
+			}
+			return row, true, nil
+		case op.kind == ast.ExceptOperation && count > 0 && op.all:
+			// Каждая строка правого входа вычитает одно повторение строки левого
+			op.counts[key]--
+		case op.kind == ast.ExceptOperation && count == 0:
+			// Без ALL возвращенная строка больше не попадает в результат, как если бы она была в правом входе
+			if !op.all {
+				op.counts[key] = 1
+			}
+			return row, true, nil
+		}
+	}
+}
+
+// nextUnion возвращает строки левого, затем правого входа, без ALL - только те, что еще не возвращались
+func (op *setOperationOperator) nextUnion() (disk_manager.Row, bool, error) {
+	for {
+		input := op.left
+		if op.leftDone {
+			input = op.right
+		}
+
+		row, ok, err := op.readRow(input)
+		if err != nil {
+			return nil, false, err
+		}
+		if !ok {
+			if op.leftDone {
+				return nil, false, nil
+			}
+			op.leftDone = true
+			continue
+		}
+
+		if !op.all {
+			key := encodeGroupKey(row)
+			if _, ok := op.seen[key]; ok {
+				continue
+			}
+			op.seen[key] = struct{}{}
+		}
+		return row, true, nil
+	}
+}
+
+// readRow читает строку входа и приводит ее значения к типам колонок результата
+func (op *setOperationOperator) readRow(input operator) (disk_manager.Row, bool, error) {
+	row, ok, err := input.Next()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	result := make(disk_manager.Row, len(row))
+	for i, cell := range row {
+		if result[i], err = coerceCell(cell, op.columns[i].DataType); err != nil {
+			return nil, false, err
+		}
+	}
+	return result, true, nil
+}
+
+func (op *setOperationOperator) Close() error {
+	op.seen, op.counts = nil, nil
+	leftErr := op.left.Close()
+	if err := op.right.Close(); err != nil {
+		return err
+	}
+	return leftErr
+}
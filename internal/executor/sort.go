@@ -0,0 +1,389 @@
+package executor
+
+import (
+	"container/heap"
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"fmt"
+	"sort"
+)
+
+// sortKey ключ сортировки: выражение, направление и положение NULL
+type sortKey struct {
+	expression *ast.Expression
+	descending bool
+	nullsFirst bool
+}
+
+// ========================== Sort ==========================
+
+// sortOperator сортирует строки входного оператора (ORDER BY).
+// Пока строки помещаются в WorkMemory, сортировка выполняется в памяти. Иначе отсортированные
+// порции (runs) выгружаются во временные файлы и затем сливаются k-way слиянием.
+// Если известно, что нужны только первые limit строк (ORDER BY ... LIMIT), в памяти держится
+// ограниченный heap из limit строк вместо полной сортировки.
+// Сортировка устойчивая: строки с равными ключами идут в порядке входа
+type sortOperator struct {
+	executor *executor
+	input    operator
+	keys     []sortKey
+	columns  []ResultColumn // Колонки входа, к которым в строках дописаны значения ключей
+	limit    int            // Сколько первых строк нужно, 0 - все строки
+
+	sorted     bool
+	rows       []disk_manager.Row // Строки, отсортированные в памяти
+	rowIndex   int
+	memoryUsed int            // Размер строк, накопленных в памяти
+	runs       []*spillFile   // Отсортированные порции во временных файлах
+	merge      *sortItemsHeap // Текущие строки порций при слиянии
+	err        error          // Ошибка сравнения, возникшая внутри sort/heap
+}
+
+func newSortOperator(executor *executor, input operator, keys []sortKey) (*sortOperator, error) {
+	columns := append([]ResultColumn{}, input.Columns()...)
+	for _, key := range keys {
+		dataType, err := executor.inferExpressionType(key.expression, input.Columns())
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := comparisonType(dataType, dataType); !ok && dataType != unknownType {
+			return nil, fmt.Errorf("could not identify an ordering operator for type %s", dataType)
+		}
+
+		columns = append(columns, ResultColumn{Name: "?sort_key?", DataType: dataType})
+	}
+
+	return &sortOperator{
+		executor: executor,
+		input:    input,
+		keys:     keys,
+		columns:  columns,
+	}, nil
+}
+
+func (op *sortOperator) Columns() []ResultColumn {
+	return op.input.Columns()
+}
+
+func (op *sortOperator) Next() (disk_manager.Row, bool, error) {
+	if !op.sorted {
+		if err := op.sort(); err != nil {
+			return nil, false, err
+		}
+		op.sorted = true
+	}
+
+	var row disk_manager.Row
+	if op.merge != nil {
+		next, ok, err := op.nextMerged()
+		if err != nil || !ok {
+			return nil, false, err
+		}
+		row = next
+	} else {
+		if op.rowIndex >= len(op.rows) {
+			return nil, false, nil
+		}
+		row = op.rows[op.rowIndex]
+		op.rowIndex++
+	}
+
+	// Отбрасываем значения ключей сортировки
+	return row[:len(op.input.Columns())], true, nil
+}
+
+func (op *sortOperator) Close() error {
+	op.rows = nil
+	op.merge = nil
+
+	var closeErr error
+	for _, run := range op.runs {
+		if err := run.Close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+	op.runs = nil
+
+	if err := op.input.Close(); err != nil && closeErr == nil {
+		closeErr = err
+	}
+	return closeErr
+}
+
+// sort читает весь вход, сортируя его порциями размером не больше WorkMemory
+func (op *sortOperator) sort() error {
+	if op.limit > 0 {
+		done, err := op.sortTopN()
+		if err != nil || done {
+			return err
+		}
+		// Первые limit строк не поместились в память - продолжаем внешней сортировкой
+	}
+
+	for {
+		row, ok, err := op.input.Next()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+
+		row, err = op.appendKeys(row)
+		if err != nil {
+			return err
+		}
+		op.rows = append(op.rows, row)
+		op.memoryUsed += int(row.GetSize())
+
+		if op.memoryUsed > op.executor.config.WorkMemory {
+			if err := op.spillRun(); err != nil {
+				return err
+			}
+			op.memoryUsed = 0
+		}
+	}
+
+	// Все строки поместились в память - сливать нечего
+	if len(op.runs) == 0 {
+		return op.sortRows(op.rows)
+	}
+
+	if len(op.rows) > 0 {
+		if err := op.spillRun(); err != nil {
+			return err
+		}
+	}
+	return op.startMerge()
+}
+
+// sortTopN читает вход, оставляя в max-heap только limit наименьших строк.
+// Возвращает false, если эти строки не поместились в WorkMemory: тогда они переносятся в op.rows
+// в порядке входа, и сортировка продолжается обычным способом (отброшенные строки в результат не попали бы)
+func (op *sortOperator) sortTopN() (bool, error) {
+	top := &sortItemsHeap{op: op, reverse: true}
+	for order := 0; ; order++ {
+		row, ok, err := op.input.Next()
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			break
+		}
+
+		row, err = op.appendKeys(row)
+		if err != nil {
+			return false, err
+		}
+		item := sortItem{row: row, order: order}
+
+		if top.Len() < op.limit {
+			heap.Push(top, item)
+			op.memoryUsed += int(row.GetSize())
+		} else if top.compareItems(item, top.items[0]) < 0 {
+			// Новая строка меньше наибольшей из оставленных - заменяем ее
+			op.memoryUsed += int(row.GetSize()) - int(top.items[0].row.GetSize())
+			top.items[0] = item
+			heap.Fix(top, 0)
+		}
+		if op.err != nil {
+			return false, op.err
+		}
+
+		if op.memoryUsed > op.executor.config.WorkMemory {
+			sort.Slice(top.items, func(i, j int) bool {
+				return top.items[i].order < top.items[j].order
+			})
+			for _, item := range top.items {
+				op.rows = append(op.rows, item.row)
+			}
+			return false, nil
+		}
+	}
+
+	sort.Slice(top.items, func(i, j int) bool {
+		return top.compareItems(top.items[i], top.items[j]) < 0
+	})
+	for _, item := range top.items {
+		op.rows = append(op.rows, item.row)
+	}
+	return true, op.err
+}
+
+// appendKeys вычисляет значения ключей сортировки и дописывает их в конец строки
+func (op *sortOperator) appendKeys(row disk_manager.Row) (disk_manager.Row, error) {
+	scope := &rowScope{
+		columns: op.input.Columns(),
+		row:     row,
+	}
+
+	result := make(disk_manager.Row, 0, len(op.columns))
+	result = append(result, row...)
+	for _, key := range op.keys {
+		cell, err := op.executor.evaluateExpression(key.expression, scope)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, cell)
+	}
+
+	return result, nil
+}
+
+// sortRows устойчиво сортирует строки в памяти
+func (op *sortOperator) sortRows(rows []disk_manager.Row) error {
+	sort.SliceStable(rows, func(i, j int) bool {
+		return op.compareRows(rows[i], rows[j]) < 0
+	})
+	return op.err
+}
+
+// spillRun сортирует накопленные строки и выгружает их во временный файл
+func (op *sortOperator) spillRun() error {
+	if err := op.sortRows(op.rows); err != nil {
+		return err
+	}
+
+	run, err := newSpillFile(op.executor.config.TempDir, op.columns)
+	if err != nil {
+		return err
+	}
+	op.runs = append(op.runs, run)
+
+	for _, row := range op.rows {
+		if err := run.WriteRow(row); err != nil {
+			return err
+		}
+	}
+
+	op.rows = nil
+	return nil
+}
+
+// compareRows сравнивает строки по ключам сортировки с учетом направления и положения NULL
+func (op *sortOperator) compareRows(left, right disk_manager.Row) int {
+	offset := len(op.input.Columns())
+	for i, key := range op.keys {
+		a, b := left[offset+i], right[offset+i]
+
+		var result int
+		switch {
+		case a.IsNull && b.IsNull:
+			result = 0
+		case a.IsNull || b.IsNull:
+			// NULLS FIRST/LAST не зависит от направления сортировки
+			if a.IsNull == key.nullsFirst {
+				return -1
+			}
+			return 1
+		default:
+			compared, err := compareCells(a, b)
+			if err != nil {
+				if op.err == nil {
+					op.err = err
+				}
+				return 0
+			}
+			result = compared
+			if key.descending {
+				result = -result
+			}
+		}
+
+		if result != 0 {
+			return result
+		}
+	}
+
+	return 0
+}
+
+// ========================== Heap ==========================
+
+// sortItem строка вместе с порядковым номером (номер порции при слиянии или номер строки при top-N).
+// При равенстве ключей раньше идет элемент с меньшим номером, поэтому сортировка остается устойчивой
+type sortItem struct {
+	row   disk_manager.Row
+	order int
+}
+
+// sortItemsHeap heap строк по ключам сортировки: min-heap для слияния порций, max-heap (reverse) для top-N
+type sortItemsHeap struct {
+	items   []sortItem
+	op      *sortOperator
+	reverse bool
+}
+
+// compareItems сравнивает элементы по ключам сортировки, а при равенстве - по порядковому номеру
+func (h *sortItemsHeap) compareItems(left, right sortItem) int {
+	if result := h.op.compareRows(left.row, right.row); result != 0 {
+		return result
+	}
+	return compareOrdered(left.order, right.order)
+}
+
+func (h *sortItemsHeap) Len() int { return len(h.items) }
+
+func (h *sortItemsHeap) Less(i, j int) bool {
+	if h.reverse {
+		return h.compareItems(h.items[i], h.items[j]) > 0
+	}
+	return h.compareItems(h.items[i], h.items[j]) < 0
+}
+
+func (h *sortItemsHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *sortItemsHeap) Push(x any) { h.items = append(h.items, x.(sortItem)) }
+
+func (h *sortItemsHeap) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+// ========================== K-way merge ==========================
+
+// startMerge открывает все порции на чтение и кладет в heap их первые строки
+func (op *sortOperator) startMerge() error {
+	op.merge = &sortItemsHeap{op: op}
+	for i, run := range op.runs {
+		if err := run.Rewind(); err != nil {
+			return err
+		}
+
+		row, ok, err := run.ReadRow()
+		if err != nil {
+			return err
+		}
+		if ok {
+			op.merge.items = append(op.merge.items, sortItem{row: row, order: i})
+		}
+	}
+
+	heap.Init(op.merge)
+	return op.err
+}
+
+// nextMerged возвращает минимальную строку среди порций и подтягивает следующую строку из той же порции
+func (op *sortOperator) nextMerged() (disk_manager.Row, bool, error) {
+	if op.merge.Len() == 0 {
+		return nil, false, nil
+	}
+
+	item := op.merge.items[0]
+	row, ok, err := op.runs[item.order].ReadRow()
+	if err != nil {
+		return nil, false, err
+	}
+	if ok {
+		op.merge.items[0] = sortItem{row: row, order: item.order}
+		heap.Fix(op.merge, 0)
+	} else {
+		heap.Pop(op.merge)
+	}
+
+	if op.err != nil {
+		return nil, false, op.err
+	}
+	return item.row, true, nil
+}
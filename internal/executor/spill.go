@@ -0,0 +1,149 @@
+package executor
+
+import (
+	"bufio"
+	"custom-database/internal/disk_manager"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// spillFile временный файл, в который операторы выгружают строки, не поместившиеся в память.
+// Каждая строка записывается как: длина (4 байта) + null bitmap + данные ячеек в формате страниц таблиц
+type spillFile struct {
+	file    *os.File
+	writer  *bufio.Writer
+	reader  *bufio.Reader
+	columns []disk_manager.ColumnInfo
+	rows    int // Количество записанных строк
+	size    int // Количество записанных байт
+}
+
+// newSpillFile создает временный файл для строк с указанными колонками
+func newSpillFile(tempDir string, columns []ResultColumn) (*spillFile, error) {
+	file, err := os.CreateTemp(tempDir, "spill-*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	columnInfos := make([]disk_manager.ColumnInfo, 0, len(columns))
+	for _, column := range columns {
+		columnInfos = append(columnInfos, disk_manager.ColumnInfo{DataType: column.DataType})
+	}
+
+	return &spillFile{
+		file:    file,
+		writer:  bufio.NewWriter(file),
+		columns: columnInfos,
+	}, nil
+}
+
+// nullBitmapSize размер null bitmap строки в байтах
+func (f *spillFile) nullBitmapSize() int {
+	return (len(f.columns) + 7) / 8
+}
+
+// WriteRow дописывает строку в конец файла
+func (f *spillFile) WriteRow(row disk_manager.Row) error {
+	nullBitmap := make([]byte, f.nullBitmapSize())
+	data := make([]byte, 0, row.GetSize())
+	for i, cell := range row {
+		if cell.IsNull {
+			nullBitmap[i/8] |= 1 << (i % 8)
+			continue
+		}
+		data = append(data, cell.SerializeData()...)
+	}
+
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint32(header, uint32(len(nullBitmap)+len(data)))
+	for _, chunk := range [][]byte{header, nullBitmap, data} {
+		if _, err := f.writer.Write(chunk); err != nil {
+			return fmt.Errorf("failed to write temporary file: %w", err)
+		}
+	}
+
+	f.rows++
+	f.size += len(header) + len(nullBitmap) + len(data)
+	return nil
+}
+
+// Rewind завершает запись и переводит файл в режим чтения с начала
+func (f *spillFile) Rewind() error {
+	if err := f.writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind temporary file: %w", err)
+	}
+
+	f.reader = bufio.NewReader(f.file)
+	return nil
+}
+
+// ReadRow читает следующую строку, false - если строки закончились
+func (f *spillFile) ReadRow() (disk_manager.Row, bool, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(f.reader, header); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read temporary file: %w", err)
+	}
+
+	data := make([]byte, binary.BigEndian.Uint32(header))
+	if _, err := io.ReadFull(f.reader, data); err != nil {
+		return nil, false, fmt.Errorf("failed to read temporary file: %w", err)
+	}
+
+	bitmapSize := f.nullBitmapSize()
+	row, err := disk_manager.ConvertRawTupleToRow(disk_manager.RawTuple{
+		NullBitmap: data[:bitmapSize],
+		Data:       data[bitmapSize:],
+	}, f.columns)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return row, true, nil
+}
+
+// Close закрывает и удаляет временный файл
+func (f *spillFile) Close() error {
+	closeErr := f.file.Close()
+	if err := os.Remove(f.file.Name()); err != nil {
+		return err
+	}
+	return closeErr
+}
+
+// ========================== Spill Scan ==========================
+
+// spillScanOperator читает строки временного файла как вход другого оператора.
+// Close удаляет файл
+type spillScanOperator struct {
+	file    *spillFile
+	columns []ResultColumn
+}
+
+// newSpillScanOperator переводит файл в режим чтения и возвращает оператор, читающий его с начала
+func newSpillScanOperator(file *spillFile, columns []ResultColumn) (*spillScanOperator, error) {
+	if err := file.Rewind(); err != nil {
+		return nil, err
+	}
+	return &spillScanOperator{file: file, columns: columns}, nil
+}
+
+func (op *spillScanOperator) Columns() []ResultColumn {
+	return op.columns
+}
+
+func (op *spillScanOperator) Next() (disk_manager.Row, bool, error) {
+	return op.file.ReadRow()
+}
+
+func (op *spillScanOperator) Close() error {
+	return op.file.Close()
+}
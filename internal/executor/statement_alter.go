@@ -0,0 +1,185 @@
+package executor
+
+import (
+	"custom-database/internal/buffer_bool"
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"fmt"
+)
+
+// executeAlterTable изменяет таблицу. RENAME и изменения ограничений колонки меняют только мета-файл.
+// ADD COLUMN и DROP COLUMN меняют формат строк, поэтому таблица переписывается целиком:
+// все строки читаются и пересчитываются до изменения таблицы, затем страницы таблицы удаляются
+// и строки записываются заново уже с новым списком колонок
+func (e *executor) executeAlterTable(stmt *ast.AlterTableStatement) error {
+	tableName := stmt.Table.Value
+	metaInfo, err := e.readMetaInfo(tableName)
+	if err != nil {
+		return err
+	}
+
+	switch stmt.Action {
+	case ast.RenameTableAction:
+		if stmt.NewName.Value == SYSTEM_FUNCTIONS_VIEW {
+			return fmt.Errorf("table %s already exists", stmt.NewName.Value)
+		}
+		e.dropKeyIndex(tableName)
+		return e.bufferPool.RenameTable(tableName, stmt.NewName.Value)
+	case ast.AddColumnAction:
+		return e.addColumn(stmt, metaInfo)
+	}
+
+	// Изменяем копию списка колонок, чтобы при ошибке метаинформация в кэше осталась прежней
+	columns := append([]disk_manager.ColumnInfo(nil), metaInfo.MetaData.Columns...)
+	index := columnIndex(columns, stmt.Column.Value)
+	if index == -1 {
+		return fmt.Errorf("column %s of table %s does not exist", stmt.Column.Value, tableName)
+	}
+	column := &columns[index]
+
+	switch stmt.Action {
+	case ast.DropColumnAction:
+		return e.dropColumn(tableName, metaInfo, index)
+
+	case ast.RenameColumnAction:
+		if columnIndex(columns, stmt.NewName.Value) != -1 {
+			return fmt.Errorf("column %s of table %s already exists", stmt.NewName.Value, tableName)
+		}
+		if err := checkColumnName(stmt.NewName.Value); err != nil {
+			return err
+		}
+		column.ColumnName = stmt.NewName.Value
+		column.ColumnNameLength = uint32(len(stmt.NewName.Value))
+
+	case ast.SetNotNullAction:
+		err := e.scanTableRows(tableName, metaInfo, func(_ rowLocation, row disk_manager.Row) error {
+			if row[index].IsNull {
+				return fmt.Errorf("column %s of table %s contains null values", column.ColumnName, tableName)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		column.IsNullable = 0
+
+	case ast.DropNotNullAction:
+		if column.IsPrimaryKey == 1 {
+			return fmt.Errorf("column %s is in a primary key", column.ColumnName)
+		}
+		column.IsNullable = 1
+
+	case ast.SetDefaultAction:
+		if column.Default, err = e.columnDefault(stmt.Default, *column); err != nil {
+			return err
+		}
+
+	case ast.DropDefaultAction:
+		column.Default = nil
+
+	default:
+		return fmt.Errorf("unsupported ALTER TABLE action: %s", stmt.Action)
+	}
+
+	metaInfo.MetaData.Columns = columns
+	return e.bufferPool.WriteMetaInfo(tableName)
+}
+
+// addColumn добавляет колонку в конец таблицы. Существующие строки получают значение по умолчанию или NULL
+func (e *executor) addColumn(stmt *ast.AlterTableStatement, metaInfo *buffer_bool.MetaInfo) error {
+	tableName := stmt.Table.Value
+	added := stmt.Added
+	if columnIndex(metaInfo.MetaData.Columns, added.Name.Value) != -1 {
+		return fmt.Errorf("column %s of table %s already exists", added.Name.Value, tableName)
+	}
+
+	column, err := e.columnInfoFromDefinition(added.Name, added.Datatype, added.Parameters, added.NotNull, added.Default)
+	if err != nil {
+		return err
+	}
+	value := defaultCell(column)
+
+	var rows []disk_manager.Row
+	err = e.scanTableRows(tableName, metaInfo, func(_ rowLocation, row disk_manager.Row) error {
+		if value.IsNull && column.IsNullable == 0 {
+			return fmt.Errorf("column %s of table %s contains null values", column.ColumnName, tableName)
+		}
+		rows = append(rows, append(row, value))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	columns := append(append([]disk_manager.ColumnInfo(nil), metaInfo.MetaData.Columns...), column)
+	return e.rewriteTable(tableName, metaInfo, columns, rows)
+}
+
+// dropColumn удаляет колонку таблицы. Удаление колонки первичного ключа удаляет весь первичный ключ
+func (e *executor) dropColumn(tableName string, metaInfo *buffer_bool.MetaInfo, index int) error {
+	if len(metaInfo.MetaData.Columns) == 1 {
+		return fmt.Errorf("cannot drop the only column of table %s", tableName)
+	}
+
+	columns := make([]disk_manager.ColumnInfo, 0, len(metaInfo.MetaData.Columns)-1)
+	dropsKey := metaInfo.MetaData.Columns[index].IsPrimaryKey == 1
+	for i, column := range metaInfo.MetaData.Columns {
+		if i == index {
+			continue
+		}
+		if dropsKey {
+			column.IsPrimaryKey = 0
+		}
+		columns = append(columns, column)
+	}
+
+	var rows []disk_manager.Row
+	err := e.scanTableRows(tableName, metaInfo, func(_ rowLocation, row disk_manager.Row) error {
+		rows = append(rows, append(row[:index:index], row[index+1:]...))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.rewriteTable(tableName, metaInfo, columns, rows)
+}
+
+// rewriteTable удаляет все страницы таблицы и записывает строки заново с новым списком колонок.
+// Мета-файл записывается до вставки строк: новые страницы создаются уже с новыми колонками
+func (e *executor) rewriteTable(tableName string, metaInfo *buffer_bool.MetaInfo, columns []disk_manager.ColumnInfo, rows []disk_manager.Row) error {
+	// Строки меняют положение, а ключ может измениться вместе с колонками: индекс строится заново при следующем INSERT
+	e.dropKeyIndex(tableName)
+	for _, row := range rows {
+		if size := row.GetSize(); size > MAX_ROW_SIZE {
+			return fmt.Errorf("row size %d exceeds maximum %d bytes", size, MAX_ROW_SIZE)
+		}
+	}
+
+	if err := e.bufferPool.TruncateTable(tableName); err != nil {
+		return err
+	}
+
+	metaInfo.MetaData.Columns = columns
+	metaInfo.MetaData.Header.ColumnCount = uint32(len(columns))
+	if err := e.bufferPool.WriteMetaInfo(tableName); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if _, err := e.insertRow(tableName, metaInfo, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// columnIndex возвращает индекс колонки с указанным именем или -1, если такой колонки нет
+func columnIndex(columns []disk_manager.ColumnInfo, name string) int {
+	for i, column := range columns {
+		if column.ColumnName == name {
+			return i
+		}
+	}
+	return -1
+}
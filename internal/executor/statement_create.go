@@ -0,0 +1,142 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"custom-database/internal/parser/lex"
+	"fmt"
+	"strconv"
+)
+
+// executeCreateTable создает таблицу с колонками из CREATE TABLE statement.
+// С IF NOT EXISTS существующая таблица не меняется, а вместо ошибки возвращается сообщение
+func (e *executor) executeCreateTable(stmt *ast.CreateTableStatement) (*Result, error) {
+	exists, err := e.tableExists(stmt.Table.Value)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		if stmt.IfNotExists {
+			return noticeResult("table %s already exists, skipping", stmt.Table.Value), nil
+		}
+		return nil, fmt.Errorf("table %s already exists", stmt.Table.Value)
+	}
+
+	primaryKey := map[string]bool{}
+	for _, column := range stmt.PrimaryKey {
+		primaryKey[column.Value] = true
+	}
+
+	columns := make([]disk_manager.ColumnInfo, 0, len(*stmt.Columns))
+	for _, column := range *stmt.Columns {
+		columnInfo, err := e.columnInfoFromDefinition(column.Name, column.Datatype, column.Parameters, column.NotNull, column.Default)
+		if err != nil {
+			return nil, err
+		}
+
+		// Колонки первичного ключа не могут содержать NULL
+		if column.PrimaryKey || primaryKey[column.Name.Value] {
+			columnInfo.IsPrimaryKey = 1
+			columnInfo.IsNullable = 0
+		}
+
+		columns = append(columns, columnInfo)
+	}
+
+	return nil, e.bufferPool.CreateTable(stmt.Table.Value, columns)
+}
+
+// columnInfoFromDefinition описывает колонку из определения CREATE TABLE или ALTER TABLE ADD COLUMN:
+// тип с параметрами, NOT NULL и значение по умолчанию
+func (e *executor) columnInfoFromDefinition(name lex.Token, datatype lex.Token, parameters []*lex.Token, notNull bool, defaultValue *ast.Expression) (disk_manager.ColumnInfo, error) {
+	if err := checkColumnName(name.Value); err != nil {
+		return disk_manager.ColumnInfo{}, err
+	}
+	dataType, err := dataTypeFromToken(datatype)
+	if err != nil {
+		return disk_manager.ColumnInfo{}, err
+	}
+
+	columnInfo := disk_manager.ColumnInfo{
+		ColumnNameLength: uint32(len(name.Value)),
+		ColumnName:       name.Value,
+		DataType:         dataType,
+		IsNullable:       1,
+	}
+	if notNull {
+		columnInfo.IsNullable = 0
+	}
+
+	if err := applyTypeParameters(&columnInfo, parameters); err != nil {
+		return disk_manager.ColumnInfo{}, fmt.Errorf("column %s: %w", name.Value, err)
+	}
+
+	if defaultValue != nil {
+		if columnInfo.Default, err = e.columnDefault(defaultValue, columnInfo); err != nil {
+			return disk_manager.ColumnInfo{}, err
+		}
+	}
+
+	return columnInfo, nil
+}
+
+// checkColumnName проверяет, что имя колонки помещается в мета-файл. Более длинное имя обрезалось бы при записи
+// и после перезапуска колонка была бы недоступна по исходному имени
+func checkColumnName(name string) error {
+	if len(name) > disk_manager.COLUMN_NAME_MAX_LENGTH {
+		return fmt.Errorf("column name too long: %d bytes, maximum %d", len(name), disk_manager.COLUMN_NAME_MAX_LENGTH)
+	}
+	return nil
+}
+
+// columnDefault вычисляет значение по умолчанию колонки. Оно вычисляется один раз, когда задается,
+// и хранится в мета-файле уже приведенным к типу колонки. DEFAULT NULL означает отсутствие значения по умолчанию
+func (e *executor) columnDefault(expr *ast.Expression, column disk_manager.ColumnInfo) (*disk_manager.DataCell, error) {
+	value, err := e.evaluateExpression(expr, nil)
+	if err != nil {
+		return nil, err
+	}
+	cell, err := e.fitToColumn(value, column)
+	if err != nil {
+		return nil, fmt.Errorf("column %s: %w", column.ColumnName, err)
+	}
+	if cell.IsNull {
+		return nil, nil
+	}
+	return &cell, nil
+}
+
+// applyTypeParameters заполняет параметры типа колонки: DECIMAL(precision, scale), VARCHAR(n), CHAR(n)
+func applyTypeParameters(columnInfo *disk_manager.ColumnInfo, parameters []*lex.Token) error {
+	values := make([]uint32, 0, len(parameters))
+	for _, parameter := range parameters {
+		value, err := strconv.ParseUint(parameter.Value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid type parameter: %s", parameter.Value)
+		}
+		values = append(values, uint32(value))
+	}
+
+	switch columnInfo.DataType {
+	case disk_manager.DECIMAL_TYPE:
+		if len(values) > 0 {
+			columnInfo.Precision = values[0]
+		}
+		if len(values) > 1 {
+			columnInfo.Scale = values[1]
+		}
+	case disk_manager.VARCHAR_TYPE, disk_manager.CHAR_TYPE:
+		if len(values) > 0 {
+			columnInfo.Length = values[0]
+		} else if columnInfo.DataType == disk_manager.CHAR_TYPE {
+			// CHAR без длины означает CHAR(1)
+			columnInfo.Length = 1
+		}
+	default:
+		if len(values) > 0 {
+			return fmt.Errorf("type %s does not accept parameters", columnInfo.DataType)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package executor
+
+import (
+	"custom-database/internal/parser/ast"
+	"fmt"
+)
+
+// executeDropTable удаляет таблицу. С IF EXISTS вместо ошибки для отсутствующей таблицы возвращается сообщение
+func (e *executor) executeDropTable(stmt *ast.DropTableStatement) (*Result, error) {
+	if stmt.IfExists {
+		exists, err := e.tableExists(stmt.Table.Value)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return noticeResult("table %s does not exist, skipping", stmt.Table.Value), nil
+		}
+	}
+
+	if _, err := e.readMetaInfo(stmt.Table.Value); err != nil {
+		return nil, err
+	}
+
+	e.dropKeyIndex(stmt.Table.Value)
+	return nil, e.bufferPool.DropTable(stmt.Table.Value)
+}
+
+// tableExists проверяет, есть ли таблица с указанным именем. Системное представление тоже считается таблицей
+func (e *executor) tableExists(tableName string) (bool, error) {
+	if tableName == SYSTEM_FUNCTIONS_VIEW {
+		return true, nil
+	}
+
+	metaInfo, err := e.bufferPool.ReadMetaInfo(tableName)
+	if err != nil {
+		return false, err
+	}
+	return metaInfo != nil, nil
+}
+
+// noticeResult возвращает результат без строк с одним сообщением для пользователя
+func noticeResult(format string, args ...any) *Result {
+	return &Result{Notices: []string{fmt.Sprintf(format, args...)}}
+}
@@ -0,0 +1,229 @@
+package executor
+
+import (
+	"custom-database/internal/buffer_bool"
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"fmt"
+)
+
+// executeInsert вычисляет строки INSERT statement'а (VALUES или результат запроса) и записывает их в таблицу.
+// Сначала вычисляются и проверяются все строки и конфликты по первичному ключу (ON CONFLICT),
+// поэтому ошибка в одной строке не оставляет в таблице часть строк.
+// С RETURNING возвращает результат, вычисленный по записанным строкам, без него - nil
+func (e *executor) executeInsert(stmt *ast.InsertStatement) (*Result, error) {
+	tableName := stmt.Table.Value
+	metaInfo, err := e.readMetaInfo(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := insertTargets(stmt, metaInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	sources, width, err := e.insertSourceRows(stmt)
+	if err != nil {
+		return nil, err
+	}
+	if width != len(targets) {
+		if len(stmt.Columns) == 0 {
+			return nil, fmt.Errorf("INSERT has %d values, but table %s has %d columns", width, tableName, len(targets))
+		}
+		return nil, fmt.Errorf("INSERT has %d values, but %d target columns", width, len(targets))
+	}
+
+	rows := make([]disk_manager.Row, 0, len(sources))
+	for _, source := range sources {
+		row, err := e.buildInsertRow(metaInfo.MetaData.Columns, targets, source)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	plan := &insertPlan{inserts: rows}
+	keyColumns := primaryKeyColumns(metaInfo.MetaData.Columns)
+	if len(keyColumns) > 0 {
+		if err := checkConflictTarget(stmt.OnConflict, metaInfo.MetaData.Columns, keyColumns); err != nil {
+			return nil, err
+		}
+		if plan, err = e.planKeyedInsert(stmt, metaInfo, keyColumns, rows); err != nil {
+			return nil, err
+		}
+	} else if stmt.OnConflict != nil && len(stmt.OnConflict.Columns) > 0 {
+		// Без первичного ключа конфликтов не бывает, но явно указанный ключ должен существовать
+		return nil, fmt.Errorf("there is no unique or exclusion constraint matching the ON CONFLICT specification")
+	}
+
+	// RETURNING вычисляется до записи: ошибка в нем не оставляет в таблице часть строк,
+	// а подзапросы RETURNING видят таблицу такой, какой она была до INSERT
+	var result *Result
+	if stmt.Returning != nil {
+		if result, err = e.insertReturning(stmt, metaInfo, plan.inserts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := e.applyInsertPlan(tableName, metaInfo, plan); err != nil {
+		// Индекс мог не успеть учесть часть записанных строк, он будет построен заново
+		e.dropKeyIndex(tableName)
+		return nil, err
+	}
+	return result, nil
+}
+
+// applyInsertPlan записывает изменения INSERT в таблицу и в индекс первичного ключа
+func (e *executor) applyInsertPlan(tableName string, metaInfo *buffer_bool.MetaInfo, plan *insertPlan) error {
+	for _, deleted := range plan.deletes {
+		if err := e.deleteRow(tableName, metaInfo, *deleted.location); err != nil {
+			return err
+		}
+		if plan.index != nil {
+			delete(plan.index.locations, plan.index.key(deleted.row))
+		}
+	}
+	for _, row := range plan.inserts {
+		location, err := e.insertRow(tableName, metaInfo, row)
+		if err != nil {
+			return err
+		}
+		if plan.index != nil {
+			plan.index.locations[plan.index.key(row)] = location
+		}
+	}
+	return nil
+}
+
+// insertTargets возвращает индексы колонок таблицы, в которые по порядку вставляются значения
+func insertTargets(stmt *ast.InsertStatement, metaInfo *buffer_bool.MetaInfo) ([]int, error) {
+	columns := metaInfo.MetaData.Columns
+	if len(stmt.Columns) == 0 {
+		targets := make([]int, len(columns))
+		for i := range columns {
+			targets[i] = i
+		}
+		return targets, nil
+	}
+
+	targets := make([]int, 0, len(stmt.Columns))
+	for _, name := range stmt.Columns {
+		index := -1
+		for i, column := range columns {
+			if column.ColumnName == name.Value {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			return nil, fmt.Errorf("column %s of table %s does not exist", name.Value, stmt.Table.Value)
+		}
+		targets = append(targets, index)
+	}
+	return targets, nil
+}
+
+// insertSourceRows вычисляет вставляемые значения: строки VALUES или все строки запроса INSERT ... SELECT,
+// и возвращает количество значений в строке. Результат запроса читается целиком до записи,
+// поэтому запрос может читать ту же таблицу
+func (e *executor) insertSourceRows(stmt *ast.InsertStatement) ([]disk_manager.Row, int, error) {
+	if stmt.Select != nil {
+		result, err := e.executeSelect(stmt.Select)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Rows, len(result.Columns), nil
+	}
+
+	// Валидатор проверил, что во всех строках VALUES одинаковое количество значений
+	rows := make([]disk_manager.Row, 0, len(stmt.Values))
+	for _, values := range stmt.Values {
+		row := make(disk_manager.Row, 0, len(values))
+		for _, value := range values {
+			cell, err := e.evaluateExpression(value, nil)
+			if err != nil {
+				return nil, 0, err
+			}
+			row = append(row, cell)
+		}
+		rows = append(rows, row)
+	}
+	return rows, len(stmt.Values[0]), nil
+}
+
+// buildInsertRow раскладывает значения по колонкам таблицы.
+// Колонки, которых нет в списке, получают значение по умолчанию или NULL
+func (e *executor) buildInsertRow(columns []disk_manager.ColumnInfo, targets []int, values disk_manager.Row) (disk_manager.Row, error) {
+	row := make(disk_manager.Row, len(columns))
+	for i, column := range columns {
+		row[i] = defaultCell(column)
+	}
+	for i, target := range targets {
+		// Приводим значение к типу колонки (например, строку '2024-01-31' к DATE, 1.005 к DECIMAL(10,2))
+		cell, err := e.fitToColumn(values[i], columns[target])
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", columns[target].ColumnName, err)
+		}
+		row[target] = cell
+	}
+
+	for i, cell := range row {
+		if cell.IsNull && columns[i].IsNullable == 0 {
+			return nil, fmt.Errorf("column %s does not allow NULL values", columns[i].ColumnName)
+		}
+	}
+	return row, nil
+}
+
+// defaultCell возвращает значение по умолчанию колонки или NULL, если его нет
+func defaultCell(column disk_manager.ColumnInfo) disk_manager.DataCell {
+	if column.Default != nil {
+		return *column.Default
+	}
+	return nullCell(column.DataType)
+}
+
+// insertReturning вычисляет список RETURNING по записываемым строкам: вставляемым и новым версиям строк,
+// обновленных ON CONFLICT DO UPDATE. Строки, пропущенные DO NOTHING, в результат не попадают
+func (e *executor) insertReturning(stmt *ast.InsertStatement, metaInfo *buffer_bool.MetaInfo, rows []disk_manager.Row) (*Result, error) {
+	input := &rowsOperator{columns: tableColumns(metaInfo, stmt.Table.Value), rows: rows}
+
+	// RETURNING * и table.* раскрываются в колонки таблицы, как в списке SELECT
+	returning, err := expandSelectList(&ast.SelectStatement{SelectedColumns: stmt.Returning}, input.Columns(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	projection, err := newProjectionOperator(e, input, returning)
+	if err != nil {
+		return nil, err
+	}
+	defer projection.Close()
+
+	result := &Result{Columns: projection.Columns(), Rows: []disk_manager.Row{}}
+	for {
+		row, ok, err := projection.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result, nil
+}
+
+// tableColumns описывает колонки таблицы, доступные по имени qualifier как qualifier.column
+func tableColumns(metaInfo *buffer_bool.MetaInfo, qualifier string) []ResultColumn {
+	columns := make([]ResultColumn, 0, len(metaInfo.MetaData.Columns))
+	for _, column := range metaInfo.MetaData.Columns {
+		columns = append(columns, ResultColumn{
+			Name:     column.ColumnName,
+			DataType: column.DataType,
+			Table:    qualifier,
+		})
+	}
+	return columns
+}
@@ -0,0 +1,574 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"custom-database/internal/parser/lex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// executeSelect строит план выполнения SELECT statement'а и собирает все строки результата
+func (e *executor) executeSelect(stmt *ast.SelectStatement) (result *Result, err error) {
+	if stmt.With != nil {
+		e.defineCommonTables(stmt.With)
+		defer func() {
+			if dropErr := e.dropWorktables(); dropErr != nil && err == nil {
+				result, err = nil, dropErr
+			}
+		}()
+	}
+
+	plan, err := e.buildSelectPlan(stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer plan.Close()
+
+	rows := []disk_manager.Row{}
+	for {
+		row, ok, err := plan.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		rows = append(rows, row)
+	}
+
+	return &Result{
+		Columns: plan.Columns(),
+		Rows:    rows,
+	}, nil
+}
+
+// buildSelectPlan строит дерево операторов:
+// сканирование таблиц и соединения (FROM, JOIN) -> фильтрация (WHERE) -> группировка (GROUP BY) -> фильтрация групп (HAVING) ->
+// оконные функции -> удаление повторов (DISTINCT) -> сортировка (ORDER BY) -> проекция -> LIMIT/OFFSET.
+// Составной запрос строится buildSetOperationPlan
+func (e *executor) buildSelectPlan(stmt *ast.SelectStatement) (operator, error) {
+	limit, offset, err := rowLimits(stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	if stmt.SetOperation != nil {
+		return e.buildSetOperationPlan(stmt, limit, offset)
+	}
+
+	plan, size, err := e.buildFromPlan(stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	if stmt.Where != nil {
+		plan, err = e.buildWhere(plan, size, stmt.Where)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// SELECT * в запросе с группировкой или DISTINCT и table.* - это колонки входа
+	grouped := isGroupedQuery(stmt)
+	selected, err := expandSelectList(stmt, plan.Columns(), grouped)
+	if err != nil {
+		return nil, err
+	}
+
+	if grouped {
+		expressions := append([]*ast.Expression{}, selected...)
+		if stmt.Having != nil {
+			expressions = append(expressions, stmt.Having)
+		}
+		for _, item := range stmt.OrderBy {
+			expressions = append(expressions, item.Expression)
+		}
+
+		plan, err = newHashAggregateOperator(e, plan, distinctGroupBy(stmt.GroupBy, plan.Columns()), collectAggregates(expressions))
+		if err != nil {
+			return nil, err
+		}
+
+		if stmt.Having != nil {
+			if err := e.checkCondition(stmt.Having, plan.Columns(), "HAVING"); err != nil {
+				return nil, err
+			}
+			plan = newFilterOperator(e, plan, stmt.Having)
+		}
+	}
+
+	// Оконные функции вычисляются после группировки и HAVING, но до ORDER BY: их значения доступны для сортировки
+	expressions := append([]*ast.Expression{}, selected...)
+	for _, item := range stmt.OrderBy {
+		expressions = append(expressions, item.Expression)
+	}
+	if windows := collectWindows(expressions); len(windows) > 0 {
+		if plan, err = e.buildWindowPlan(plan, windows); err != nil {
+			return nil, err
+		}
+	}
+
+	// DISTINCT - группировка по колонкам результата без агрегатных функций. ORDER BY ссылается на колонки результата,
+	// поэтому сортируются уже различные строки
+	if stmt.Distinct {
+		plan, err = newHashAggregateOperator(e, plan, distinctGroupBy(selected, plan.Columns()), nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(stmt.OrderBy) > 0 {
+		keys, err := resolveSortKeys(stmt.OrderBy, selected, plan.Columns())
+		if err != nil {
+			return nil, err
+		}
+
+		sort, err := newSortOperator(e, plan, keys)
+		if err != nil {
+			return nil, err
+		}
+		// Для ORDER BY ... LIMIT достаточно найти первые offset + limit строк
+		if limit > 0 {
+			sort.limit = offset + limit
+		}
+		plan = sort
+	}
+
+	plan, err = newProjectionOperator(e, plan, selected)
+	if err != nil {
+		return nil, err
+	}
+
+	if stmt.Limit != nil || stmt.Offset != nil {
+		plan = newLimitOperator(plan, limit, offset)
+	}
+
+	return plan, nil
+}
+
+// buildSetOperationPlan строит план составного запроса: планы обеих частей -> операция над результатами (UNION, INTERSECT, EXCEPT) ->
+// сортировка (ORDER BY) по колонкам результата -> LIMIT/OFFSET
+func (e *executor) buildSetOperationPlan(stmt *ast.SelectStatement, limit, offset int) (operator, error) {
+	left, err := e.buildSelectPlan(stmt.SetOperation.Left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := e.buildSelectPlan(stmt.SetOperation.Right)
+	if err != nil {
+		left.Close()
+		return nil, err
+	}
+
+	operation, err := newSetOperationOperator(stmt.SetOperation, left, right)
+	if err != nil {
+		left.Close()
+		right.Close()
+		return nil, err
+	}
+	var plan operator = operation
+
+	if len(stmt.OrderBy) > 0 {
+		keys, err := resolveSortKeys(stmt.OrderBy, nil, plan.Columns())
+		if err != nil {
+			plan.Close()
+			return nil, err
+		}
+
+		sort, err := newSortOperator(e, plan, keys)
+		if err != nil {
+			plan.Close()
+			return nil, err
+		}
+		if limit > 0 {
+			sort.limit = offset + limit
+		}
+		plan = sort
+	}
+
+	if stmt.Limit != nil || stmt.Offset != nil {
+		plan = newLimitOperator(plan, limit, offset)
+	}
+
+	return plan, nil
+}
+
+// buildFromPlan строит план для FROM: первая таблица (или подзапрос), к которой по очереди
+// присоединяются остальные. Способ каждого соединения выбирается по размерам сторон.
+// Возвращает также оценку объема данных, которые читает план
+func (e *executor) buildFromPlan(stmt *ast.SelectStatement) (operator, int, error) {
+	plan, size, err := e.buildFromItem(stmt.Table, stmt.Subquery, stmt.Alias)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, join := range stmt.Joins {
+		right, rightSize, err := e.buildFromItem(join.Table, join.Subquery, join.Alias)
+		if err != nil {
+			plan.Close()
+			return nil, 0, err
+		}
+
+		plan, err = e.buildJoin(plan, right, join, size, rightSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		size += rightSize
+	}
+
+	return plan, size, nil
+}
+
+// buildWhere строит фильтрацию строк по условию WHERE. Условия, объединенные AND, без подзапросов
+// проверяются первыми, а подзапросы [NOT] EXISTS и IN по возможности заменяются соединениями (buildSemiJoin).
+// planSize - оценка объема данных, которые читает plan
+func (e *executor) buildWhere(plan operator, planSize int, where *ast.Expression) (operator, error) {
+	if err := e.checkCondition(where, plan.Columns(), "WHERE"); err != nil {
+		return nil, err
+	}
+
+	var filters, subqueries []*ast.Expression
+	for _, conjunct := range splitConjuncts(where) {
+		if conjunct.Kind == ast.ExistsKind || conjunct.Kind == ast.InKind {
+			subqueries = append(subqueries, conjunct)
+		} else {
+			filters = append(filters, conjunct)
+		}
+	}
+	if condition := joinConjuncts(filters); condition != nil {
+		plan = newFilterOperator(e, plan, condition)
+	}
+
+	var rest []*ast.Expression
+	for _, conjunct := range subqueries {
+		join, ok, err := e.buildSemiJoin(plan, planSize, conjunct)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			plan = join
+			continue
+		}
+		rest = append(rest, conjunct)
+	}
+	if condition := joinConjuncts(rest); condition != nil {
+		plan = newFilterOperator(e, plan, condition)
+	}
+
+	return plan, nil
+}
+
+// buildFromItem строит сканирование таблицы или план подзапроса в FROM и оценивает их объем
+func (e *executor) buildFromItem(table lex.Token, subquery *ast.SelectStatement, alias *lex.Token) (operator, int, error) {
+	if subquery != nil {
+		plan, err := e.buildSelectPlan(subquery)
+		if err != nil {
+			return nil, 0, err
+		}
+		return newDerivedTableOperator(plan, alias.Value), e.estimateSelectSize(subquery), nil
+	}
+	qualifier := table.Value
+	if alias != nil {
+		qualifier = alias.Value
+	}
+	if e.isSystemView(table.Value) {
+		return e.newSystemFunctionsOperator(qualifier), 0, nil
+	}
+	if common := e.lookupCommonTable(table.Value); common != nil {
+		return e.buildCommonTableScan(common, qualifier)
+	}
+
+	scan, err := e.buildTableScan(table, alias)
+	if err != nil {
+		return nil, 0, err
+	}
+	return scan, scan.estimatedSize(), nil
+}
+
+// estimateSelectSize оценивает объем данных, которые читает запрос: сумма объемов таблиц и подзапросов в FROM
+func (e *executor) estimateSelectSize(stmt *ast.SelectStatement) int {
+	if stmt.SetOperation != nil {
+		return e.estimateSelectSize(stmt.SetOperation.Left) + e.estimateSelectSize(stmt.SetOperation.Right)
+	}
+
+	size := e.estimateFromItemSize(stmt.Table, stmt.Subquery)
+	for _, join := range stmt.Joins {
+		size += e.estimateFromItemSize(join.Table, join.Subquery)
+	}
+	return size
+}
+
+// estimateFromItemSize оценивает объем таблицы по заголовку файла данных или объем подзапроса
+func (e *executor) estimateFromItemSize(table lex.Token, subquery *ast.SelectStatement) int {
+	if subquery != nil {
+		return e.estimateSelectSize(subquery)
+	}
+
+	name := table.Value
+	if common := e.lookupCommonTable(table.Value); common != nil {
+		// Рекурсивная часть читает строки предыдущей итерации, остальные ссылки - результат запроса WITH
+		name = common.working
+		if name == "" {
+			if err := e.startCommonTable(common); err != nil {
+				return 0
+			}
+			name = common.table
+		}
+	}
+	metaInfo, err := e.readMetaInfo(name)
+	if err != nil {
+		return 0
+	}
+	return int(metaInfo.DataHeaders.PagesCount) * disk_manager.PAGE_SIZE
+}
+
+// buildTableScan создает сканирование таблицы.
+// Колонки доступны через псевдоним, если он указан, иначе через имя таблицы
+func (e *executor) buildTableScan(table lex.Token, alias *lex.Token) (*tableScanOperator, error) {
+	metaInfo, err := e.readMetaInfo(table.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	qualifier := table.Value
+	if alias != nil {
+		qualifier = alias.Value
+	}
+	return newTableScanOperator(e.bufferPool, table.Value, qualifier, metaInfo), nil
+}
+
+// checkCondition проверяет, что условие WHERE или HAVING имеет тип BOOLEAN
+func (e *executor) checkCondition(condition *ast.Expression, columns []ResultColumn, clause string) error {
+	conditionType, err := e.inferExpressionType(condition, columns)
+	if err != nil {
+		return err
+	}
+	if conditionType != disk_manager.BOOLEAN_TYPE {
+		return fmt.Errorf("argument of %s must be type BOOLEAN, not %s", clause, conditionType)
+	}
+
+	return nil
+}
+
+// isGroupedQuery проверяет, нужна ли запросу группировка: есть GROUP BY, HAVING или агрегатные функции
+func isGroupedQuery(stmt *ast.SelectStatement) bool {
+	if len(stmt.GroupBy) > 0 || stmt.Having != nil {
+		return true
+	}
+
+	for _, expression := range stmt.SelectedColumns {
+		if expression.ContainsAggregate() {
+			return true
+		}
+	}
+	for _, item := range stmt.OrderBy {
+		if item.Expression.ContainsAggregate() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// collectAggregates собирает различные вызовы агрегатных функций из выражений
+func collectAggregates(expressions []*ast.Expression) []*ast.Expression {
+	aggregates := []*ast.Expression{}
+
+	var collect func(expression *ast.Expression)
+	collect = func(expression *ast.Expression) {
+		if expression.Kind != ast.AggregateKind {
+			for _, child := range expression.Children() {
+				collect(child)
+			}
+			return
+		}
+
+		for _, aggregate := range aggregates {
+			if aggregate.Equals(expression) {
+				return
+			}
+		}
+		aggregates = append(aggregates, expression)
+	}
+
+	for _, expression := range expressions {
+		collect(expression)
+	}
+	return aggregates
+}
+
+// expandSelectList заменяет * и table.* в списке SELECT на колонки входа. SELECT * (пустой список)
+// раскрывается только в запросе с группировкой или DISTINCT, иначе проекция возвращает строки входа как есть
+func expandSelectList(stmt *ast.SelectStatement, columns []ResultColumn, grouped bool) ([]*ast.Expression, error) {
+	var groupBy []*ast.Expression
+	if grouped {
+		groupBy = stmt.GroupBy
+	}
+
+	if len(stmt.SelectedColumns) == 0 {
+		if !grouped && !stmt.Distinct {
+			return stmt.SelectedColumns, nil
+		}
+		return expandStar(columns, "", grouped, groupBy)
+	}
+
+	selected := make([]*ast.Expression, 0, len(stmt.SelectedColumns))
+	for _, expression := range stmt.SelectedColumns {
+		if expression.Kind != ast.StarKind {
+			selected = append(selected, expression)
+			continue
+		}
+
+		table := ""
+		if expression.Literal != nil {
+			table = expression.Literal.Value
+		}
+		expressions, err := expandStar(columns, table, grouped, groupBy)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, expressions...)
+	}
+
+	return selected, nil
+}
+
+// expandStar заменяет * (table пустое) или table.* на список колонок входа.
+// В запросе с группировкой проверяет, что все колонки указаны в GROUP BY
+func expandStar(columns []ResultColumn, table string, grouped bool, groupBy []*ast.Expression) ([]*ast.Expression, error) {
+	expressions := make([]*ast.Expression, 0, len(columns))
+	for i, column := range columns {
+		if table != "" && !strings.EqualFold(column.Table, table) {
+			continue
+		}
+
+		// Колонка уточняется таблицей, чтобы одноименные колонки разных таблиц не были неоднозначны
+		name := column.Name
+		if column.Table != "" {
+			name = column.Table + "." + column.Name
+		}
+		expression := &ast.Expression{
+			Literal: &lex.Token{Value: name, Kind: lex.IdentifierToken},
+			Kind:    ast.LiteralKind,
+		}
+
+		inGroupBy := false
+		for _, groupExpression := range groupBy {
+			if isColumnReference(groupExpression) {
+				index, err := findColumn(columns, groupExpression.Literal.Value)
+				inGroupBy = inGroupBy || (err == nil && index == i)
+			}
+		}
+		if grouped && !inGroupBy {
+			return nil, fmt.Errorf("column %s must appear in the GROUP BY clause or be used in an aggregate function", column.Name)
+		}
+
+		expressions = append(expressions, expression)
+	}
+
+	if table != "" && len(expressions) == 0 {
+		return nil, fmt.Errorf("missing FROM-clause entry for table %s", table)
+	}
+	return expressions, nil
+}
+
+// distinctGroupBy убирает из GROUP BY повторы: одинаковые выражения и ссылки на одну и ту же колонку (name и u.name)
+func distinctGroupBy(groupBy []*ast.Expression, columns []ResultColumn) []*ast.Expression {
+	result := make([]*ast.Expression, 0, len(groupBy))
+	seen := map[int]bool{}
+	for _, expression := range groupBy {
+		if isColumnReference(expression) {
+			if index, err := findColumn(columns, expression.Literal.Value); err == nil {
+				if seen[index] {
+					continue
+				}
+				seen[index] = true
+			}
+		}
+
+		duplicate := false
+		for _, previous := range result {
+			duplicate = duplicate || previous.Equals(expression)
+		}
+		if !duplicate {
+			result = append(result, expression)
+		}
+	}
+
+	return result
+}
+
+// rowLimits возвращает значения LIMIT и OFFSET. Если LIMIT не указан, limit равен -1
+func rowLimits(stmt *ast.SelectStatement) (int, int, error) {
+	limit, offset := -1, 0
+
+	if stmt.Limit != nil {
+		value, err := strconv.Atoi(stmt.Limit.Value)
+		if err != nil || value < 0 {
+			return 0, 0, fmt.Errorf("LIMIT must be a non-negative integer, got: %s", stmt.Limit.Value)
+		}
+		limit = value
+	}
+
+	if stmt.Offset != nil {
+		value, err := strconv.Atoi(stmt.Offset.Value)
+		if err != nil || value < 0 {
+			return 0, 0, fmt.Errorf("OFFSET must be a non-negative integer, got: %s", stmt.Offset.Value)
+		}
+		offset = value
+	}
+
+	return limit, offset, nil
+}
+
+// resolveSortKeys строит ключи сортировки из ORDER BY.
+// Целое число в ORDER BY означает номер колонки в списке SELECT (начиная с 1), пустой список SELECT - это SELECT *
+func resolveSortKeys(orderBy []*ast.OrderByItem, selected []*ast.Expression, columns []ResultColumn) ([]sortKey, error) {
+	keys := make([]sortKey, 0, len(orderBy))
+	for _, item := range orderBy {
+		expression := item.Expression
+
+		// Псевдоним колонки результата (AS alias) ссылается на выражение из списка SELECT
+		if isColumnReference(expression) {
+			for _, column := range selected {
+				if column.Alias != nil && column.Alias.Value == expression.Literal.Value {
+					expression = column
+					break
+				}
+			}
+		}
+
+		if expression.Kind == ast.LiteralKind && expression.Literal.Kind == lex.NumericToken {
+			position, err := strconv.Atoi(expression.Literal.Value)
+			if err != nil {
+				return nil, fmt.Errorf("non-integer constant in ORDER BY: %s", expression.Literal.Value)
+			}
+
+			selectedCount := len(selected)
+			if selectedCount == 0 {
+				selectedCount = len(columns)
+			}
+			if position < 1 || position > selectedCount {
+				return nil, fmt.Errorf("ORDER BY position %d is not in select list", position)
+			}
+
+			if len(selected) > 0 {
+				expression = selected[position-1]
+			} else {
+				expression = &ast.Expression{
+					Literal: &lex.Token{Value: columns[position-1].Name, Kind: lex.IdentifierToken},
+					Kind:    ast.LiteralKind,
+				}
+			}
+		}
+
+		keys = append(keys, sortKey{
+			expression: expression,
+			descending: item.Descending,
+			nullsFirst: item.NullsFirst,
+		})
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,23 @@
+package executor
+
+import "custom-database/internal/parser/ast"
+
+// executeTruncateTable удаляет все строки таблицы вместе с ее страницами, колонки таблицы сохраняются.
+// RESTART IDENTITY также сбрасывает счетчик RowID в мета-файле
+func (e *executor) executeTruncateTable(stmt *ast.TruncateTableStatement) error {
+	tableName := stmt.Table.Value
+	metaInfo, err := e.readMetaInfo(tableName)
+	if err != nil {
+		return err
+	}
+
+	e.dropKeyIndex(tableName)
+	if err := e.bufferPool.TruncateTable(tableName); err != nil {
+		return err
+	}
+
+	if stmt.RestartIdentity {
+		metaInfo.MetaData.Header.NextRowID = 0
+	}
+	return e.bufferPool.WriteMetaInfo(tableName)
+}
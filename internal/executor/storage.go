@@ -0,0 +1,234 @@
+package executor
+
+import (
+	"custom-database/internal/buffer_bool"
+	"custom-database/internal/disk_manager"
+	"fmt"
+)
+
+// Максимальный размер строки, которая помещается на пустую страницу вместе со слотом
+const MAX_ROW_SIZE = disk_manager.PAGE_SIZE - disk_manager.PAGE_HEADER_SIZE - disk_manager.SLOT_SIZE
+
+// readMetaInfo возвращает метаинформацию таблицы из buffer pool
+func (e *executor) readMetaInfo(tableName string) (*buffer_bool.MetaInfo, error) {
+	metaInfo, err := e.bufferPool.ReadMetaInfo(tableName)
+	if err != nil {
+		return nil, err
+	}
+	if metaInfo == nil {
+		return nil, fmt.Errorf("table %s not found", tableName)
+	}
+
+	return metaInfo, nil
+}
+
+// tablePageID возвращает идентификатор страницы таблицы для buffer pool
+func tablePageID(metaInfo *buffer_bool.MetaInfo, pageNumber uint32) disk_manager.PageID {
+	return disk_manager.PageID{
+		FileID:     metaInfo.FileID.FileID,
+		PageNumber: pageNumber,
+	}
+}
+
+// readPageRows читает живые (не удаленные) строки страницы.
+// Строки копируются, поэтому страница освобождается (unpin) сразу после чтения
+func readPageRows(bufferPool buffer_bool.BufferPoolInterface, tableName string, metaInfo *buffer_bool.MetaInfo, pageNumber uint32) ([]disk_manager.Row, error) {
+	pageID := tablePageID(metaInfo, pageNumber)
+
+	frame, err := bufferPool.GetPage(tableName, pageID)
+	if err != nil {
+		return nil, err
+	}
+	defer bufferPool.Unpin(tableName, pageID)
+
+	page := frame.Page
+	rows := make([]disk_manager.Row, 0, len(page.Rows))
+	for i, row := range page.Rows {
+		if i < len(page.Slots) && page.Slots[i].Flags != 0 {
+			continue
+		}
+		rows = append(rows, append(disk_manager.Row(nil), row...))
+	}
+
+	return rows, nil
+}
+
+// insertRow записывает строку в первую страницу таблицы, где для нее хватает места, и возвращает ее положение.
+// Если такой страницы нет, в таблицу добавляется новая страница
+func (e *executor) insertRow(tableName string, metaInfo *buffer_bool.MetaInfo, row disk_manager.Row) (rowLocation, error) {
+	return e.insertRowFrom(tableName, metaInfo, row, 0)
+}
+
+// appendRow записывает строку в последнюю страницу таблицы или в новую страницу, не занимая свободное место
+// предыдущих страниц. Строки хранятся в порядке добавления, поэтому чтение таблицы можно продолжить с места,
+// где оно остановилось
+func (e *executor) appendRow(tableName string, metaInfo *buffer_bool.MetaInfo, row disk_manager.Row) error {
+	_, err := e.insertRowFrom(tableName, metaInfo, row, len(metaInfo.PageDirectory.Entries)-1)
+	return err
+}
+
+// insertRowFrom записывает строку в первую страницу таблицы, начиная со страницы с индексом first, где для нее хватает места
+func (e *executor) insertRowFrom(tableName string, metaInfo *buffer_bool.MetaInfo, row disk_manager.Row, first int) (rowLocation, error) {
+	rowSize := row.GetSize()
+	if rowSize > MAX_ROW_SIZE {
+		return rowLocation{}, fmt.Errorf("row size %d exceeds maximum %d bytes", rowSize, MAX_ROW_SIZE)
+	}
+
+	// Ищем страницу со свободным местом для строки и ее слота
+	entryIndex := -1
+	for i := max(first, 0); i < len(metaInfo.PageDirectory.Entries); i++ {
+		entry := metaInfo.PageDirectory.Entries[i]
+		if entry.Flags == 0 && entry.FreeSpace >= rowSize+disk_manager.SLOT_SIZE {
+			entryIndex = i
+			break
+		}
+	}
+
+	var frame *buffer_bool.BufferFrame
+	var err error
+	if entryIndex == -1 {
+		frame, err = e.addPage(tableName, metaInfo)
+		entryIndex = len(metaInfo.PageDirectory.Entries) - 1
+	} else {
+		frame, err = e.bufferPool.GetPage(tableName, tablePageID(metaInfo, metaInfo.PageDirectory.Entries[entryIndex].PageID))
+	}
+	if err != nil {
+		return rowLocation{}, err
+	}
+
+	// Подменяем страницу целиком, чтобы background worker никогда не видел ее частично измененной
+	page := insertRowIntoPage(frame.Page, row)
+	frame.Page = page
+
+	e.bufferPool.MarkDirty(tableName, frame.PageID)
+	e.bufferPool.Unpin(tableName, frame.PageID)
+
+	// Обновляем метаинформацию таблицы
+	metaInfo.PageDirectory.Entries[entryIndex].FreeSpace = page.Header.Upper - page.Header.Lower
+	metaInfo.DataHeaders.RecordCount++
+
+	location := rowLocation{pageNumber: metaInfo.PageDirectory.Entries[entryIndex].PageID, slot: len(page.Rows) - 1}
+	return location, e.bufferPool.WriteMetaInfo(tableName)
+}
+
+// addPage добавляет в таблицу новую пустую страницу и регистрирует ее в page directory
+func (e *executor) addPage(tableName string, metaInfo *buffer_bool.MetaInfo) (*buffer_bool.BufferFrame, error) {
+	pageNumber := metaInfo.DataHeaders.PagesCount + 1
+
+	frame, err := e.bufferPool.AddNewPage(tableName, tablePageID(metaInfo, pageNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	// DiskManager уже увеличил счетчик страниц в data файле, синхронизируем кэш
+	metaInfo.DataHeaders.PagesCount = pageNumber
+
+	metaInfo.PageDirectory.Entries = append(metaInfo.PageDirectory.Entries, disk_manager.PageDirectoryEntry{
+		PageID:    pageNumber,
+		FreeSpace: disk_manager.PAGE_SIZE - disk_manager.PAGE_HEADER_SIZE,
+		Flags:     0,
+	})
+	metaInfo.PageDirectory.Header.PageCount++
+	metaInfo.PageDirectory.Header.NextPageID = pageNumber + 1
+
+	return frame, nil
+}
+
+// insertRowIntoPage возвращает копию страницы с добавленной строкой.
+// Данные строки размещаются с конца страницы (Upper), слот - после заголовка (Lower)
+func insertRowIntoPage(page *disk_manager.Page, row disk_manager.Row) *disk_manager.Page {
+	rowSize := row.GetSize()
+
+	header := page.Header
+	header.Upper -= rowSize
+	slot := disk_manager.PageSlot{
+		Offset: header.Upper,
+		Length: rowSize,
+		Flags:  0,
+	}
+	header.Lower += disk_manager.SLOT_SIZE
+	header.RecordCount++
+
+	slots := make([]disk_manager.PageSlot, 0, len(page.Slots)+1)
+	slots = append(slots, page.Slots...)
+	rows := make([]disk_manager.Row, 0, len(page.Rows)+1)
+	rows = append(rows, page.Rows...)
+
+	return &disk_manager.Page{
+		Header:  header,
+		Slots:   append(slots, slot),
+		Rows:    append(rows, row),
+		Columns: page.Columns,
+	}
+}
+
+// rowLocation положение строки в таблице: номер страницы и индекс слота на странице
+type rowLocation struct {
+	pageNumber uint32
+	slot       int
+}
+
+// scanTableRows вызывает visit для каждой живой строки таблицы вместе с ее положением
+func (e *executor) scanTableRows(tableName string, metaInfo *buffer_bool.MetaInfo, visit func(location rowLocation, row disk_manager.Row) error) error {
+	for _, entry := range metaInfo.PageDirectory.Entries {
+		if entry.Flags != 0 {
+			continue
+		}
+
+		pageID := tablePageID(metaInfo, entry.PageID)
+		frame, err := e.bufferPool.GetPage(tableName, pageID)
+		if err != nil {
+			return err
+		}
+		page := frame.Page
+		e.bufferPool.Unpin(tableName, pageID)
+
+		for i, row := range page.Rows {
+			if i < len(page.Slots) && page.Slots[i].Flags != 0 {
+				continue
+			}
+			if err := visit(rowLocation{pageNumber: entry.PageID, slot: i}, append(disk_manager.Row(nil), row...)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// readRow читает строку таблицы по ее положению
+func (e *executor) readRow(tableName string, metaInfo *buffer_bool.MetaInfo, location rowLocation) (disk_manager.Row, error) {
+	pageID := tablePageID(metaInfo, location.pageNumber)
+	frame, err := e.bufferPool.GetPage(tableName, pageID)
+	if err != nil {
+		return nil, err
+	}
+	defer e.bufferPool.Unpin(tableName, pageID)
+
+	page := frame.Page
+	if location.slot >= len(page.Rows) || (location.slot < len(page.Slots) && page.Slots[location.slot].Flags != 0) {
+		return nil, fmt.Errorf("row %d on page %d of table %s not found", location.slot, location.pageNumber, tableName)
+	}
+	return append(disk_manager.Row(nil), page.Rows[location.slot]...), nil
+}
+
+// deleteRow помечает слот строки удаленным. Место строки на странице не освобождается
+func (e *executor) deleteRow(tableName string, metaInfo *buffer_bool.MetaInfo, location rowLocation) error {
+	pageID := tablePageID(metaInfo, location.pageNumber)
+	frame, err := e.bufferPool.GetPage(tableName, pageID)
+	if err != nil {
+		return err
+	}
+
+	// Как и при вставке, подменяем страницу целиком
+	page := *frame.Page
+	page.Slots = append([]disk_manager.PageSlot(nil), frame.Page.Slots...)
+	page.Slots[location.slot].Flags = 1
+	frame.Page = &page
+
+	e.bufferPool.MarkDirty(tableName, pageID)
+	e.bufferPool.Unpin(tableName, pageID)
+
+	metaInfo.DataHeaders.RecordCount--
+	return e.bufferPool.WriteMetaInfo(tableName)
+}
@@ -0,0 +1,106 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// textFunction создает функцию одного строкового аргумента: upper, lower
+func textFunction(apply func(string) string) func([]disk_manager.DataCell) (disk_manager.DataCell, error) {
+	return func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+		if hasNullArgument(arguments) {
+			return nullCell(disk_manager.TEXT_TYPE), nil
+		}
+		return disk_manager.DataCell{DataType: disk_manager.TEXT_TYPE, Data: apply(arguments[0].Data.(string))}, nil
+	}
+}
+
+// length возвращает количество символов строки или количество байт BYTEA
+func length(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+	if hasNullArgument(arguments) {
+		return nullCell(disk_manager.INT_32_TYPE), nil
+	}
+
+	if data, ok := arguments[0].Data.([]byte); ok {
+		return disk_manager.DataCell{DataType: disk_manager.INT_32_TYPE, Data: int32(len(data))}, nil
+	}
+	return disk_manager.DataCell{DataType: disk_manager.INT_32_TYPE, Data: int32(utf8.RuneCountInString(arguments[0].Data.(string)))}, nil
+}
+
+// substr возвращает подстроку substr(string, start [, count]). Символы нумеруются с 1,
+// start может быть меньше 1: тогда count отсчитывается от этой позиции, как в PostgreSQL
+func substr(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+	if hasNullArgument(arguments) {
+		return nullCell(disk_manager.TEXT_TYPE), nil
+	}
+
+	runes := []rune(arguments[0].Data.(string))
+	start := int64(arguments[1].Data.(int32))
+	end := int64(len(runes)) + 1
+	if len(arguments) == 3 {
+		count := int64(arguments[2].Data.(int32))
+		if count < 0 {
+			return disk_manager.DataCell{}, fmt.Errorf("negative substring length not allowed")
+		}
+		end = min(end, start+count)
+	}
+	start = max(start, 1)
+
+	if start >= end {
+		return disk_manager.DataCell{DataType: disk_manager.TEXT_TYPE, Data: ""}, nil
+	}
+	return disk_manager.DataCell{DataType: disk_manager.TEXT_TYPE, Data: string(runes[start-1 : end-1])}, nil
+}
+
+// trim удаляет символы из начала и конца строки: пробелы или символы второго аргумента
+func trim(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+	if hasNullArgument(arguments) {
+		return nullCell(disk_manager.TEXT_TYPE), nil
+	}
+
+	characters := " "
+	if len(arguments) == 2 {
+		characters = arguments[1].Data.(string)
+	}
+	return disk_manager.DataCell{DataType: disk_manager.TEXT_TYPE, Data: strings.Trim(arguments[0].Data.(string), characters)}, nil
+}
+
+// replace заменяет все вхождения подстроки. Пустая подстрока не заменяется
+func replace(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+	if hasNullArgument(arguments) {
+		return nullCell(disk_manager.TEXT_TYPE), nil
+	}
+
+	value, from, to := arguments[0].Data.(string), arguments[1].Data.(string), arguments[2].Data.(string)
+	if from == "" {
+		return disk_manager.DataCell{DataType: disk_manager.TEXT_TYPE, Data: value}, nil
+	}
+	return disk_manager.DataCell{DataType: disk_manager.TEXT_TYPE, Data: strings.ReplaceAll(value, from, to)}, nil
+}
+
+// concat склеивает текстовые представления аргументов. В отличие от ||, NULL пропускаются
+func concat(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+	var builder strings.Builder
+	for _, argument := range arguments {
+		if !argument.IsNull {
+			builder.WriteString(concatText(argument))
+		}
+	}
+	return disk_manager.DataCell{DataType: disk_manager.TEXT_TYPE, Data: builder.String()}, nil
+}
+
+// position возвращает номер символа, с которого подстрока первый раз входит в строку, или 0
+func position(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+	if hasNullArgument(arguments) {
+		return nullCell(disk_manager.INT_32_TYPE), nil
+	}
+
+	value := arguments[1].Data.(string)
+	index := strings.Index(value, arguments[0].Data.(string))
+	if index < 0 {
+		return disk_manager.DataCell{DataType: disk_manager.INT_32_TYPE, Data: int32(0)}, nil
+	}
+	return disk_manager.DataCell{DataType: disk_manager.INT_32_TYPE, Data: int32(utf8.RuneCountInString(value[:index]) + 1)}, nil
+}
@@ -0,0 +1,429 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"custom-database/internal/parser/lex"
+	"errors"
+	"fmt"
+)
+
+// Соединения, которые планировщик строит вместо повторного выполнения подзапроса (декорреляция)
+const (
+	semiJoin ast.JoinKind = "SEMI" // Строки левой стороны, для которых есть пара (EXISTS, IN)
+	antiJoin ast.JoinKind = "ANTI" // Строки левой стороны, для которых нет пары (NOT EXISTS)
+)
+
+// outerScope строка внешнего запроса, доступная подзапросу.
+// referenced - подзапрос ссылался на колонки этой строки, т.е. он коррелирован
+type outerScope struct {
+	scope      *rowScope
+	referenced bool
+}
+
+// subqueryState описание подзапроса и результат некоррелированного подзапроса.
+// Некоррелированный подзапрос выполняется один раз за statement, коррелированный - для каждой строки внешнего запроса
+type subqueryState struct {
+	columns    []ResultColumn
+	correlated bool
+	keyType    disk_manager.DataType // Тип, к которому приводятся значения при сравнении в IN
+
+	evaluated bool
+	value     disk_manager.DataCell // Значение скалярного подзапроса
+	exists    bool                  // Подзапрос вернул хотя бы одну строку
+	values    map[string]struct{}   // Значения подзапроса IN, кроме NULL
+	hasNull   bool                  // Среди значений подзапроса IN есть NULL
+}
+
+// checkSingleColumn проверяет, что подзапрос-значение возвращает одну колонку
+func (s *subqueryState) checkSingleColumn() error {
+	if len(s.columns) != 1 {
+		return errors.New("subquery must return only one column")
+	}
+	return nil
+}
+
+// planSubquery строит план подзапроса, чтобы узнать колонки результата и коррелирован ли он.
+// columns - колонки строки внешнего запроса, на которые может ссылаться подзапрос
+func (e *executor) planSubquery(stmt *ast.SelectStatement, columns []ResultColumn) (*subqueryState, error) {
+	if state, ok := e.subqueries[stmt]; ok {
+		return state, nil
+	}
+
+	state := &subqueryState{}
+	correlated, err := e.withOuterScope(&rowScope{columns: columns}, func() error {
+		plan, err := e.buildSelectPlan(stmt)
+		if err != nil {
+			return err
+		}
+		state.columns = plan.Columns()
+		return plan.Close()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	state.correlated = correlated
+	e.subqueries[stmt] = state
+	return state, nil
+}
+
+// planInSubquery строит план подзапроса IN и выводит общий тип операнда и значений подзапроса
+func (e *executor) planInSubquery(subquery *ast.SubqueryExpression, columns []ResultColumn) (*subqueryState, error) {
+	state, err := e.planSubquery(subquery.Select, columns)
+	if err != nil {
+		return nil, err
+	}
+	if err := state.checkSingleColumn(); err != nil {
+		return nil, err
+	}
+	if state.keyType != unknownType {
+		return state, nil
+	}
+
+	operandType, err := e.inferExpressionType(subquery.Operand, columns)
+	if err != nil {
+		return nil, err
+	}
+	keyType, ok := comparisonType(operandType, state.columns[0].DataType)
+	if !ok {
+		return nil, fmt.Errorf("cannot compare %s with %s", operandType, state.columns[0].DataType)
+	}
+	// Обе стороны NULL без типа - значения все равно не сравниваются
+	if keyType == unknownType {
+		keyType = disk_manager.TEXT_TYPE
+	}
+	state.keyType = keyType
+
+	return state, nil
+}
+
+// withOuterScope выполняет run, пока строка scope доступна подзапросу как строка внешнего запроса.
+// Возвращает, ссылался ли подзапрос на колонки этой строки
+func (e *executor) withOuterScope(scope *rowScope, run func() error) (bool, error) {
+	if scope == nil {
+		scope = &rowScope{}
+	}
+	outer := &outerScope{scope: scope}
+
+	e.outerScopes = append(e.outerScopes, outer)
+	err := run()
+	e.outerScopes = e.outerScopes[:len(e.outerScopes)-1]
+
+	return outer.referenced, err
+}
+
+// runSubquery выполняет подзапрос для строки внешнего запроса, передавая строки результата в consume.
+// consume возвращает false, когда следующие строки не нужны
+func (e *executor) runSubquery(stmt *ast.SelectStatement, scope *rowScope, consume func(row disk_manager.Row) (bool, error)) error {
+	_, err := e.withOuterScope(scope, func() error {
+		plan, err := e.buildSelectPlan(stmt)
+		if err != nil {
+			return err
+		}
+		defer plan.Close()
+
+		for {
+			row, ok, err := plan.Next()
+			if err != nil || !ok {
+				return err
+			}
+			more, err := consume(row)
+			if err != nil || !more {
+				return err
+			}
+		}
+	})
+	return err
+}
+
+// scopeColumns возвращает колонки строки, nil для вычислений без строки (VALUES)
+func scopeColumns(scope *rowScope) []ResultColumn {
+	if scope == nil {
+		return nil
+	}
+	return scope.columns
+}
+
+// evaluateScalarSubquery вычисляет подзапрос-значение: без строк результат NULL, больше одной строки - ошибка
+func (e *executor) evaluateScalarSubquery(stmt *ast.SelectStatement, scope *rowScope) (disk_manager.DataCell, error) {
+	state, err := e.planSubquery(stmt, scopeColumns(scope))
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+	if err := state.checkSingleColumn(); err != nil {
+		return disk_manager.DataCell{}, err
+	}
+	if state.evaluated {
+		return state.value, nil
+	}
+
+	value := nullCell(state.columns[0].DataType)
+	found := false
+	err = e.runSubquery(stmt, scope, func(row disk_manager.Row) (bool, error) {
+		if found {
+			return false, errors.New("more than one row returned by a subquery used as an expression")
+		}
+		value, found = row[0], true
+		return true, nil
+	})
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+
+	if !state.correlated {
+		state.value, state.evaluated = value, true
+	}
+	return value, nil
+}
+
+// evaluateExists проверяет, возвращает ли подзапрос хотя бы одну строку. Строки после первой не читаются
+func (e *executor) evaluateExists(stmt *ast.SelectStatement, scope *rowScope) (bool, error) {
+	state, err := e.planSubquery(stmt, scopeColumns(scope))
+	if err != nil {
+		return false, err
+	}
+	if state.evaluated {
+		return state.exists, nil
+	}
+
+	exists := false
+	err = e.runSubquery(stmt, scope, func(disk_manager.Row) (bool, error) {
+		exists = true
+		return false, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if !state.correlated {
+		state.exists, state.evaluated = exists, true
+	}
+	return exists, nil
+}
+
+// evaluateInSubquery вычисляет x [NOT] IN (SELECT ...) по правилам SQL: если пары нет,
+// а операнд или одно из значений подзапроса равно NULL, результат NULL.
+// Значения некоррелированного подзапроса собираются в hash таблицу один раз
+func (e *executor) evaluateInSubquery(subquery *ast.SubqueryExpression, scope *rowScope) (disk_manager.DataCell, error) {
+	state, err := e.planInSubquery(subquery, scopeColumns(scope))
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+
+	operand, err := e.evaluateExpression(subquery.Operand, scope)
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+	operand, err = coerceCell(operand, state.keyType)
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+	key := encodeGroupKey(disk_manager.Row{operand})
+
+	values, hasNull := state.values, state.hasNull
+	found := false
+	if !state.evaluated {
+		values, hasNull = map[string]struct{}{}, false
+		err = e.runSubquery(subquery.Select, scope, func(row disk_manager.Row) (bool, error) {
+			cell, err := coerceCell(row[0], state.keyType)
+			if err != nil {
+				return false, err
+			}
+			if cell.IsNull {
+				hasNull = true
+				return true, nil
+			}
+
+			value := encodeGroupKey(disk_manager.Row{cell})
+			// Коррелированному подзапросу достаточно найти пару
+			if state.correlated && !operand.IsNull && value == key {
+				found = true
+				return false, nil
+			}
+			values[value] = struct{}{}
+			return true, nil
+		})
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+
+		if !state.correlated {
+			state.values, state.hasNull, state.evaluated = values, hasNull, true
+		}
+	}
+
+	if !found && !operand.IsNull {
+		_, found = values[key]
+	}
+
+	switch {
+	case found:
+		return disk_manager.DataCell{DataType: disk_manager.BOOLEAN_TYPE, Data: !subquery.Negated}, nil
+	case len(values) == 0 && !hasNull:
+		// Пустой подзапрос: IN ложно, NOT IN истинно даже для NULL
+		return disk_manager.DataCell{DataType: disk_manager.BOOLEAN_TYPE, Data: subquery.Negated}, nil
+	case operand.IsNull || hasNull:
+		return nullCell(disk_manager.BOOLEAN_TYPE), nil
+	}
+	return disk_manager.DataCell{DataType: disk_manager.BOOLEAN_TYPE, Data: subquery.Negated}, nil
+}
+
+// ========================== Decorrelation ==========================
+
+// buildSemiJoin заменяет условие WHERE вида [NOT] EXISTS (SELECT ...) или x IN (SELECT ...) соединением,
+// чтобы подзапрос выполнялся один раз, а не для каждой строки. false - если условие так заменить нельзя,
+// и подзапрос выполняется повторно для каждой строки.
+// NOT IN не заменяется: из-за NULL значений в подзапросе он не равносилен anti join.
+// planSize - оценка объема данных, которые читает plan
+func (e *executor) buildSemiJoin(plan operator, planSize int, condition *ast.Expression) (operator, bool, error) {
+	switch {
+	case condition.Kind == ast.ExistsKind:
+		return e.buildExistsJoin(plan, planSize, condition.Subquery)
+	case condition.Kind == ast.InKind && !condition.Subquery.Negated:
+		return e.buildInJoin(plan, planSize, condition.Subquery)
+	}
+	return nil, false, nil
+}
+
+// buildExistsJoin заменяет коррелированный [NOT] EXISTS semi (anti) join'ом. Подзапрос должен быть простым
+// (без группировки, LIMIT и OFFSET), а его WHERE - равенством колонок подзапроса и колонок внешнего запроса,
+// возможно вместе с условиями на колонки подзапроса через AND:
+// EXISTS (SELECT ... FROM posts WHERE posts.user_id = users.id AND posts.likes > 10)
+func (e *executor) buildExistsJoin(plan operator, planSize int, subquery *ast.SubqueryExpression) (operator, bool, error) {
+	stmt := subquery.Select
+	state, err := e.planSubquery(stmt, plan.Columns())
+	if err != nil {
+		return nil, false, err
+	}
+	if !state.correlated || stmt.Where == nil || isGroupedQuery(stmt) || stmt.Limit != nil || stmt.Offset != nil {
+		return nil, false, nil
+	}
+
+	right, rightSize, err := e.buildFromPlan(stmt)
+	if err != nil {
+		// Например, ON подзапроса ссылается на внешний запрос - остается повторное выполнение
+		return nil, false, nil
+	}
+
+	keys, rest, ok, err := e.splitEquiJoinKeys(stmt.Where, plan.Columns(), right.Columns())
+	if err != nil || !ok || !referencesOuterOnly(keys.leftKey, right.Columns()) {
+		right.Close()
+		return nil, false, err
+	}
+	// Остальные условия фильтруют строки подзапроса до соединения
+	for _, conjunct := range rest {
+		if !referencesOnly(conjunct, right.Columns()) {
+			right.Close()
+			return nil, false, nil
+		}
+	}
+	if condition := joinConjuncts(rest); condition != nil {
+		right = newFilterOperator(e, right, condition)
+	}
+
+	kind := semiJoin
+	if subquery.Negated {
+		kind = antiJoin
+	}
+	join, err := e.buildEquiJoin(newSemiJoinBase(e, kind, plan, right), keys, planSize, rightSize)
+	return join, err == nil, err
+}
+
+// buildInJoin заменяет x IN (SELECT ...) без корреляции semi join'ом с результатом подзапроса
+func (e *executor) buildInJoin(plan operator, planSize int, subquery *ast.SubqueryExpression) (operator, bool, error) {
+	state, err := e.planInSubquery(subquery, plan.Columns())
+	if err != nil {
+		return nil, false, err
+	}
+	if state.correlated || !referencesOnly(subquery.Operand, plan.Columns()) {
+		return nil, false, nil
+	}
+
+	operandType, err := e.inferExpressionType(subquery.Operand, plan.Columns())
+	if err != nil {
+		return nil, false, err
+	}
+	right, err := e.buildSelectPlan(subquery.Select)
+	if err != nil {
+		return nil, false, err
+	}
+
+	column := right.Columns()[0]
+	keys := equiJoin{
+		leftKey: subquery.Operand,
+		rightKey: &ast.Expression{
+			Kind:    ast.LiteralKind,
+			Literal: &lex.Token{Kind: lex.IdentifierToken, Value: column.Name},
+		},
+		keyType: state.keyType,
+		ordered: orderedKeys(operandType, column.DataType, state.keyType),
+	}
+	join, err := e.buildEquiJoin(newSemiJoinBase(e, semiJoin, plan, right), keys, planSize, e.estimateSelectSize(subquery.Select))
+	return join, err == nil, err
+}
+
+// newSemiJoinBase создает semi или anti join: результат состоит из колонок левой стороны
+func newSemiJoinBase(e *executor, kind ast.JoinKind, left, right operator) joinBase {
+	return joinBase{
+		executor: e,
+		kind:     kind,
+		left:     left,
+		right:    right,
+		columns:  left.Columns(),
+	}
+}
+
+// referencesOuterOnly проверяет, что ключ внешнего запроса не ссылается на колонки подзапроса:
+// колонка с тем же именем в подзапросе скрыла бы колонку внешнего запроса
+func referencesOuterOnly(expression *ast.Expression, innerColumns []ResultColumn) bool {
+	outer := true
+
+	var walk func(expression *ast.Expression)
+	walk = func(expression *ast.Expression) {
+		if isColumnReference(expression) {
+			if len(matchColumns(innerColumns, expression.Literal.Value)) > 0 {
+				outer = false
+			}
+			return
+		}
+		for _, child := range expression.Children() {
+			walk(child)
+		}
+	}
+	walk(expression)
+
+	return outer
+}
+
+// ========================== Derived Table ==========================
+
+// derivedTableOperator подзапрос в FROM. Колонки результата подзапроса доступны через псевдоним
+type derivedTableOperator struct {
+	input   operator
+	columns []ResultColumn
+}
+
+func newDerivedTableOperator(input operator, alias string) *derivedTableOperator {
+	columns := make([]ResultColumn, 0, len(input.Columns()))
+	for _, column := range input.Columns() {
+		columns = append(columns, ResultColumn{Name: column.Name, DataType: column.DataType, Table: alias})
+	}
+
+	return &derivedTableOperator{
+		input:   input,
+		columns: columns,
+	}
+}
+
+func (op *derivedTableOperator) Columns() []ResultColumn {
+	return op.columns
+}
+
+func (op *derivedTableOperator) Next() (disk_manager.Row, bool, error) {
+	return op.input.Next()
+}
+
+func (op *derivedTableOperator) Close() error {
+	return op.input.Close()
+}
@@ -0,0 +1,139 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/functions"
+	"fmt"
+)
+
+// USER_AGGREGATE_STATE_SIZE примерный объем памяти состояния пользовательской агрегатной функции:
+// само состояние executor'у не известно
+const USER_AGGREGATE_STATE_SIZE = 64
+
+// RegisterScalarFunc регистрирует скалярную функцию с одной сигнатурой
+func (e *executor) RegisterScalarFunc(
+	name string,
+	argumentTypes []disk_manager.DataType,
+	returnType disk_manager.DataType,
+	fn functions.ScalarFunc,
+) error {
+	if fn == nil {
+		return fmt.Errorf("function %s: implementation is nil", name)
+	}
+
+	return e.functions.Register(functions.Function{
+		Name:       name,
+		Kind:       functions.ScalarKind,
+		Signatures: []functions.Signature{{Arguments: argumentTypes, Result: returnType}},
+		Scalar:     fn,
+	})
+}
+
+// RegisterAggregateFunc регистрирует агрегатную функцию одного аргумента
+func (e *executor) RegisterAggregateFunc(
+	name string,
+	argumentType disk_manager.DataType,
+	returnType disk_manager.DataType,
+	init functions.AggregateInitFunc,
+	step functions.AggregateStepFunc,
+	final functions.AggregateFinalFunc,
+) error {
+	if init == nil || step == nil || final == nil {
+		return fmt.Errorf("function %s: init, step and final must be set", name)
+	}
+
+	return e.functions.Register(functions.Function{
+		Name:       name,
+		Kind:       functions.AggregateKind,
+		Signatures: []functions.Signature{{Arguments: []disk_manager.DataType{argumentType}, Result: returnType}},
+		Aggregate:  &functions.AggregateCallbacks{Init: init, Step: step, Final: final},
+	})
+}
+
+// Functions возвращает реестр функций executor'а
+func (e *executor) Functions() *functions.Registry {
+	return e.functions
+}
+
+// userScalarFunction оборачивает реализацию пользовательской скалярной функции: результат проверяется
+// на соответствие типу из сигнатуры
+func userScalarFunction(function *functions.Function) builtinFunction {
+	returnType := function.Signatures[0].Result
+	return builtinFunction{
+		call: func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error) {
+			result, err := function.Scalar(arguments)
+			if err != nil {
+				return disk_manager.DataCell{}, err
+			}
+			return checkFunctionResult(function.Name, result, returnType)
+		},
+	}
+}
+
+// userAggregateFunction описывает пользовательскую агрегатную функцию так же, как встроенные
+func userAggregateFunction(function *functions.Function) aggregateFunction {
+	signature := function.Signatures[0]
+	return aggregateFunction{
+		returnType: func(argumentType disk_manager.DataType) (disk_manager.DataType, error) {
+			if !functions.AcceptsType(signature.Parameter(0), argumentType) {
+				return unknownType, fmt.Errorf("function %s(%s) does not exist", function.Name, argumentType)
+			}
+			return signature.Result, nil
+		},
+		newState: func(disk_manager.DataType) aggregateState {
+			return &userAggregateState{
+				function:  function,
+				parameter: signature.Parameter(0),
+				state:     function.Aggregate.Init(),
+			}
+		},
+	}
+}
+
+// checkFunctionResult приводит результат пользовательской функции к объявленному типу
+func checkFunctionResult(name string, result disk_manager.DataCell, returnType disk_manager.DataType) (disk_manager.DataCell, error) {
+	if result.IsNull {
+		return nullCell(returnType), nil
+	}
+
+	cell, err := coerceCell(result, returnType)
+	if err != nil {
+		return disk_manager.DataCell{}, fmt.Errorf("function %s returned value of type %s instead of %s", name, result.DataType, returnType)
+	}
+	return cell, nil
+}
+
+// userAggregateState состояние пользовательской агрегатной функции для одной группы
+type userAggregateState struct {
+	function  *functions.Function
+	parameter disk_manager.DataType
+	state     any
+}
+
+func (s *userAggregateState) add(cell disk_manager.DataCell) error {
+	if s.parameter != functions.AnyType {
+		var err error
+		if cell, err = coerceCell(cell, s.parameter); err != nil {
+			return err
+		}
+	}
+
+	state, err := s.function.Aggregate.Step(s.state, cell)
+	if err != nil {
+		return err
+	}
+	s.state = state
+	return nil
+}
+
+func (s *userAggregateState) result() (disk_manager.DataCell, error) {
+	result, err := s.function.Aggregate.Final(s.state)
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+	return checkFunctionResult(s.function.Name, result, s.function.Signatures[0].Result)
+}
+
+func (s *userAggregateState) size() int {
+	return USER_AGGREGATE_STATE_SIZE
+}
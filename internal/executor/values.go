@@ -0,0 +1,598 @@
+package executor
+
+import (
+	"bytes"
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/lex"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// unknownType тип литерала NULL, который еще не приведен ни к одному типу
+const unknownType disk_manager.DataType = 0
+
+// DIVISION_MIN_SCALE минимальный scale результата деления точных чисел, как в PostgreSQL
+const DIVISION_MIN_SCALE = 16
+
+// dataTypeFromToken возвращает тип данных по токену типа из SQL (INT, TEXT, DATE...)
+func dataTypeFromToken(token lex.Token) (disk_manager.DataType, error) {
+	switch lex.Keyword(strings.ToLower(token.Value)) {
+	case lex.IntKeyword:
+		return disk_manager.INT_32_TYPE, nil
+	case lex.TextKeyword:
+		return disk_manager.TEXT_TYPE, nil
+	case lex.DateKeyword:
+		return disk_manager.DATE_TYPE, nil
+	case lex.TimestampKeyword:
+		return disk_manager.TIMESTAMP_TYPE, nil
+	case lex.IntervalKeyword:
+		return disk_manager.INTERVAL_TYPE, nil
+	case lex.DecimalKeyword, lex.NumericKeyword:
+		return disk_manager.DECIMAL_TYPE, nil
+	case lex.VarcharKeyword:
+		return disk_manager.VARCHAR_TYPE, nil
+	case lex.CharKeyword:
+		return disk_manager.CHAR_TYPE, nil
+	case lex.ByteaKeyword:
+		return disk_manager.BYTEA_TYPE, nil
+	case lex.JsonKeyword:
+		return disk_manager.JSON_TYPE, nil
+	default:
+		return unknownType, fmt.Errorf("unsupported data type: %s", token.Value)
+	}
+}
+
+// nullCell возвращает NULL указанного типа
+func nullCell(dataType disk_manager.DataType) disk_manager.DataCell {
+	return disk_manager.DataCell{
+		DataType: dataType,
+		IsNull:   true,
+	}
+}
+
+// literalToCell переводит литерал (число, строку, NULL) в значение.
+// Целые числа, которые помещаются в int32, имеют тип INT, остальные числа - DECIMAL
+func literalToCell(token *lex.Token) (disk_manager.DataCell, error) {
+	switch token.Kind {
+	case lex.NumericToken:
+		if value, err := strconv.ParseInt(token.Value, 10, 32); err == nil {
+			return disk_manager.DataCell{DataType: disk_manager.INT_32_TYPE, Data: int32(value)}, nil
+		}
+
+		decimal, err := disk_manager.ParseDecimal(token.Value)
+		if err != nil {
+			return disk_manager.DataCell{}, fmt.Errorf("invalid numeric literal: %s", token.Value)
+		}
+		return disk_manager.DataCell{DataType: disk_manager.DECIMAL_TYPE, Data: decimal}, nil
+	case lex.StringToken:
+		return disk_manager.DataCell{DataType: disk_manager.TEXT_TYPE, Data: unescapeString(token.Value)}, nil
+	case lex.HexStringToken:
+		data, err := hex.DecodeString(token.Value)
+		if err != nil {
+			return disk_manager.DataCell{}, fmt.Errorf("invalid hexadecimal literal: X'%s'", token.Value)
+		}
+		return disk_manager.DataCell{DataType: disk_manager.BYTEA_TYPE, Data: data}, nil
+	case lex.NullToken:
+		return nullCell(unknownType), nil
+	default:
+		return disk_manager.DataCell{}, fmt.Errorf("unexpected literal: %s", token.Value)
+	}
+}
+
+// unescapeString убирает экранирование кавычек в строковом литерале ('it”s' -> it's)
+func unescapeString(value string) string {
+	return strings.ReplaceAll(value, "''", "'")
+}
+
+// parseTextAs парсит текстовое значение как значение указанного типа
+func parseTextAs(value string, dataType disk_manager.DataType) (disk_manager.DataCell, error) {
+	switch dataType {
+	case disk_manager.TEXT_TYPE, disk_manager.VARCHAR_TYPE, disk_manager.CHAR_TYPE:
+		return disk_manager.DataCell{DataType: dataType, Data: value}, nil
+	case disk_manager.INT_32_TYPE:
+		number, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+		if err != nil {
+			return disk_manager.DataCell{}, fmt.Errorf("invalid input syntax for type INT: %q", value)
+		}
+		return disk_manager.DataCell{DataType: dataType, Data: int32(number)}, nil
+	case disk_manager.DATE_TYPE:
+		date, err := disk_manager.ParseDate(value)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		return disk_manager.DataCell{DataType: dataType, Data: date}, nil
+	case disk_manager.TIMESTAMP_TYPE:
+		timestamp, err := disk_manager.ParseTimestamp(value)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		return disk_manager.DataCell{DataType: dataType, Data: timestamp}, nil
+	case disk_manager.INTERVAL_TYPE:
+		interval, err := disk_manager.ParseInterval(value)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		return disk_manager.DataCell{DataType: dataType, Data: interval}, nil
+	case disk_manager.DECIMAL_TYPE:
+		decimal, err := disk_manager.ParseDecimal(value)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		return disk_manager.DataCell{DataType: dataType, Data: decimal}, nil
+	case disk_manager.BOOLEAN_TYPE:
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "t", "true", "yes", "on", "1":
+			return disk_manager.DataCell{DataType: dataType, Data: true}, nil
+		case "f", "false", "no", "off", "0":
+			return disk_manager.DataCell{DataType: dataType, Data: false}, nil
+		}
+		return disk_manager.DataCell{}, fmt.Errorf("invalid input syntax for type BOOLEAN: %q", value)
+	case disk_manager.BYTEA_TYPE:
+		data, err := parseByteaText(value)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		return disk_manager.DataCell{DataType: dataType, Data: data}, nil
+	case disk_manager.JSON_TYPE:
+		document, err := normalizeJSON(value)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		return disk_manager.DataCell{DataType: dataType, Data: document}, nil
+	default:
+		return disk_manager.DataCell{}, fmt.Errorf("cannot convert text to %s", dataType)
+	}
+}
+
+// parseByteaText переводит строку в двоичные данные.
+// Строка вида '\xDEADBEEF' разбирается как hex, остальные строки записываются как есть
+func parseByteaText(value string) ([]byte, error) {
+	if !strings.HasPrefix(value, `\x`) {
+		return []byte(value), nil
+	}
+
+	data, err := hex.DecodeString(value[2:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid hexadecimal data for type BYTEA: %q", value)
+	}
+	return data, nil
+}
+
+// coerceCell неявно приводит значение к типу колонки при записи.
+// Допускаются: NULL к любому типу, строки друг к другу, строка к дате/времени/интервалу/DECIMAL/BYTEA/JSON, JSON к строке,
+// DATE <-> TIMESTAMP, INT <-> DECIMAL. Ограничения длины VARCHAR(n)/CHAR(n) проверяет fitToColumn
+func coerceCell(cell disk_manager.DataCell, dataType disk_manager.DataType) (disk_manager.DataCell, error) {
+	if cell.IsNull {
+		return nullCell(dataType), nil
+	}
+	if cell.DataType == dataType {
+		return cell, nil
+	}
+
+	switch {
+	case cell.DataType == disk_manager.CHAR_TYPE && disk_manager.IsTextType(dataType):
+		// При приведении CHAR к другим строковым типам хвостовые пробелы отбрасываются
+		return disk_manager.DataCell{DataType: dataType, Data: strings.TrimRight(cell.Data.(string), " ")}, nil
+	case disk_manager.IsTextType(cell.DataType) && disk_manager.IsTextType(dataType):
+		return disk_manager.DataCell{DataType: dataType, Data: cell.Data.(string)}, nil
+	case disk_manager.IsTextType(cell.DataType) &&
+		(isTemporalType(dataType) || dataType == disk_manager.DECIMAL_TYPE ||
+			dataType == disk_manager.BYTEA_TYPE || dataType == disk_manager.JSON_TYPE):
+		return parseTextAs(cell.Data.(string), dataType)
+	case cell.DataType == disk_manager.JSON_TYPE && disk_manager.IsTextType(dataType):
+		return disk_manager.DataCell{DataType: dataType, Data: cell.Data.(string)}, nil
+	case cell.DataType == disk_manager.INT_32_TYPE && dataType == disk_manager.DECIMAL_TYPE:
+		return disk_manager.DataCell{DataType: dataType, Data: disk_manager.NewDecimalFromInt(int64(cell.Data.(int32)))}, nil
+	case cell.DataType == disk_manager.DECIMAL_TYPE && dataType == disk_manager.INT_32_TYPE:
+		decimal := cell.Data.(disk_manager.Decimal)
+		if !decimal.IsInt32() {
+			return disk_manager.DataCell{}, fmt.Errorf("integer out of range")
+		}
+		return disk_manager.DataCell{DataType: dataType, Data: int32(decimal.Rescale(0).Unscaled.Int64())}, nil
+	case cell.DataType == disk_manager.DATE_TYPE && dataType == disk_manager.TIMESTAMP_TYPE:
+		return disk_manager.DataCell{DataType: dataType, Data: cell.Data.(time.Time)}, nil
+	case cell.DataType == disk_manager.TIMESTAMP_TYPE && dataType == disk_manager.DATE_TYPE:
+		timestamp := cell.Data.(time.Time)
+		date := time.Date(timestamp.Year(), timestamp.Month(), timestamp.Day(), 0, 0, 0, 0, time.UTC)
+		return disk_manager.DataCell{DataType: dataType, Data: date}, nil
+	}
+
+	return disk_manager.DataCell{}, fmt.Errorf("cannot use value of type %s as %s", cell.DataType, dataType)
+}
+
+// isTemporalType проверяет, является ли тип датой, временем или интервалом
+func isTemporalType(dataType disk_manager.DataType) bool {
+	return dataType == disk_manager.DATE_TYPE ||
+		dataType == disk_manager.TIMESTAMP_TYPE ||
+		dataType == disk_manager.INTERVAL_TYPE
+}
+
+// isNumericType проверяет, является ли тип числовым
+func isNumericType(dataType disk_manager.DataType) bool {
+	return dataType == disk_manager.INT_32_TYPE || dataType == disk_manager.DECIMAL_TYPE
+}
+
+// comparisonType возвращает общий тип, к которому приводятся операнды сравнения
+func comparisonType(left, right disk_manager.DataType) (disk_manager.DataType, bool) {
+	switch {
+	case left == disk_manager.JSON_TYPE || right == disk_manager.JSON_TYPE:
+		// Для JSON нет операторов сравнения
+		return unknownType, false
+	case disk_manager.IsTextType(left) && disk_manager.IsTextType(right):
+		// Строки сравниваются как TEXT, у CHAR хвостовые пробелы не учитываются
+		return disk_manager.TEXT_TYPE, true
+	case left == right:
+		return left, true
+	case left == unknownType:
+		return right, true
+	case right == unknownType:
+		return left, true
+	case disk_manager.IsTextType(left) && isTemporalType(right):
+		return right, true
+	case disk_manager.IsTextType(right) && isTemporalType(left):
+		return left, true
+	case disk_manager.IsTextType(left) && right == disk_manager.BYTEA_TYPE:
+		return right, true
+	case disk_manager.IsTextType(right) && left == disk_manager.BYTEA_TYPE:
+		return left, true
+	case isNumericType(left) && isNumericType(right):
+		return disk_manager.DECIMAL_TYPE, true
+	case left == disk_manager.DATE_TYPE && right == disk_manager.TIMESTAMP_TYPE,
+		left == disk_manager.TIMESTAMP_TYPE && right == disk_manager.DATE_TYPE:
+		return disk_manager.TIMESTAMP_TYPE, true
+	}
+
+	return unknownType, false
+}
+
+// compareCells сравнивает два не NULL значения: -1 если left < right, 0 если равны, 1 если left > right
+func compareCells(left, right disk_manager.DataCell) (int, error) {
+	dataType, ok := comparisonType(left.DataType, right.DataType)
+	if !ok {
+		return 0, fmt.Errorf("cannot compare %s with %s", left.DataType, right.DataType)
+	}
+
+	left, err := coerceCell(left, dataType)
+	if err != nil {
+		return 0, err
+	}
+	right, err = coerceCell(right, dataType)
+	if err != nil {
+		return 0, err
+	}
+
+	switch dataType {
+	case disk_manager.INT_32_TYPE:
+		return compareOrdered(left.Data.(int32), right.Data.(int32)), nil
+	case disk_manager.TEXT_TYPE:
+		return strings.Compare(left.Data.(string), right.Data.(string)), nil
+	case disk_manager.DATE_TYPE, disk_manager.TIMESTAMP_TYPE:
+		return left.Data.(time.Time).Compare(right.Data.(time.Time)), nil
+	case disk_manager.INTERVAL_TYPE:
+		return left.Data.(disk_manager.Interval).Compare(right.Data.(disk_manager.Interval)), nil
+	case disk_manager.BOOLEAN_TYPE:
+		return compareOrdered(boolToInt(left.Data.(bool)), boolToInt(right.Data.(bool))), nil
+	case disk_manager.DECIMAL_TYPE:
+		return left.Data.(disk_manager.Decimal).Compare(right.Data.(disk_manager.Decimal)), nil
+	case disk_manager.BYTEA_TYPE:
+		return bytes.Compare(left.Data.([]byte), right.Data.([]byte)), nil
+	}
+
+	return 0, fmt.Errorf("cannot compare values of type %s", dataType)
+}
+
+func compareOrdered[T int | int32 | int64](left, right T) int {
+	switch {
+	case left < right:
+		return -1
+	case left > right:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// arithmeticResultType возвращает тип результата арифметической операции или конкатенации над операндами указанных типов
+func arithmeticResultType(operator lex.MathOperator, left, right disk_manager.DataType) (disk_manager.DataType, error) {
+	if operator == lex.ConcatOperator {
+		return concatResultType(left, right)
+	}
+
+	// NULL без типа принимает тип второго операнда
+	if left == unknownType {
+		left = right
+	}
+	if right == unknownType {
+		right = left
+	}
+
+	switch {
+	case left == disk_manager.INT_32_TYPE && right == disk_manager.INT_32_TYPE:
+		return disk_manager.INT_32_TYPE, nil
+	case isNumericType(left) && isNumericType(right):
+		return disk_manager.DECIMAL_TYPE, nil
+	}
+
+	switch operator {
+	case lex.PlusOperator, lex.MinusOperator:
+		return additiveResultType(operator, left, right)
+	case lex.MultiplyOperator:
+		// Интервал умножается на число
+		if (left == disk_manager.INTERVAL_TYPE && isNumericType(right)) || (isNumericType(left) && right == disk_manager.INTERVAL_TYPE) {
+			return disk_manager.INTERVAL_TYPE, nil
+		}
+	case lex.DivideOperator:
+		if left == disk_manager.INTERVAL_TYPE && isNumericType(right) {
+			return disk_manager.INTERVAL_TYPE, nil
+		}
+	}
+
+	return unknownType, fmt.Errorf("operator does not exist: %s %s %s", left, operator, right)
+}
+
+// additiveResultType возвращает тип результата + или - над датами, временем и интервалами
+func additiveResultType(operator lex.MathOperator, left, right disk_manager.DataType) (disk_manager.DataType, error) {
+	plus := operator == lex.PlusOperator
+	switch {
+	case left == disk_manager.DATE_TYPE && right == disk_manager.INT_32_TYPE,
+		plus && left == disk_manager.INT_32_TYPE && right == disk_manager.DATE_TYPE:
+		return disk_manager.DATE_TYPE, nil
+	case !plus && left == disk_manager.DATE_TYPE && right == disk_manager.DATE_TYPE:
+		return disk_manager.INT_32_TYPE, nil
+	case (left == disk_manager.DATE_TYPE || left == disk_manager.TIMESTAMP_TYPE) && right == disk_manager.INTERVAL_TYPE,
+		plus && left == disk_manager.INTERVAL_TYPE && (right == disk_manager.DATE_TYPE || right == disk_manager.TIMESTAMP_TYPE):
+		return disk_manager.TIMESTAMP_TYPE, nil
+	case !plus && left == disk_manager.TIMESTAMP_TYPE && right == disk_manager.TIMESTAMP_TYPE:
+		return disk_manager.INTERVAL_TYPE, nil
+	case left == disk_manager.INTERVAL_TYPE && right == disk_manager.INTERVAL_TYPE:
+		return disk_manager.INTERVAL_TYPE, nil
+	}
+
+	return unknownType, fmt.Errorf("operator does not exist: %s %s %s", left, operator, right)
+}
+
+// concatResultType возвращает тип результата конкатенации ||: если один из операндов - строка,
+// другой приводится к строке, BYTEA || BYTEA - BYTEA. NULL без типа считается строкой
+func concatResultType(left, right disk_manager.DataType) (disk_manager.DataType, error) {
+	switch {
+	case left == disk_manager.BYTEA_TYPE && (right == disk_manager.BYTEA_TYPE || right == unknownType),
+		left == unknownType && right == disk_manager.BYTEA_TYPE:
+		return disk_manager.BYTEA_TYPE, nil
+	case left == unknownType || right == unknownType || disk_manager.IsTextType(left) || disk_manager.IsTextType(right):
+		return disk_manager.TEXT_TYPE, nil
+	}
+
+	return unknownType, fmt.Errorf("operator does not exist: %s %s %s", left, lex.ConcatOperator, right)
+}
+
+// applyArithmetic выполняет арифметическую операцию или конкатенацию над двумя не NULL значениями
+func applyArithmetic(operator lex.MathOperator, left, right disk_manager.DataCell) (disk_manager.DataCell, error) {
+	resultType, err := arithmeticResultType(operator, left.DataType, right.DataType)
+	if err != nil {
+		return disk_manager.DataCell{}, err
+	}
+
+	switch operator {
+	case lex.ConcatOperator:
+		if resultType == disk_manager.BYTEA_TYPE {
+			data := append(append([]byte{}, left.Data.([]byte)...), right.Data.([]byte)...)
+			return disk_manager.DataCell{DataType: resultType, Data: data}, nil
+		}
+		return disk_manager.DataCell{DataType: resultType, Data: concatText(left) + concatText(right)}, nil
+	case lex.MultiplyOperator, lex.DivideOperator, lex.ModuloOperator:
+		return applyMultiplicative(operator, resultType, left, right)
+	}
+
+	plus := operator == lex.PlusOperator
+	// Для коммутативного сложения приводим операнды к порядку (значение, смещение)
+	if plus && (right.DataType == disk_manager.DATE_TYPE || right.DataType == disk_manager.TIMESTAMP_TYPE) {
+		left, right = right, left
+	}
+
+	switch {
+	case left.DataType == disk_manager.INT_32_TYPE && right.DataType == disk_manager.INT_32_TYPE:
+		a, b := int64(left.Data.(int32)), int64(right.Data.(int32))
+		result := a + b
+		if !plus {
+			result = a - b
+		}
+		if result > int64(^uint32(0)>>1) || result < -int64(^uint32(0)>>1)-1 {
+			return disk_manager.DataCell{}, fmt.Errorf("integer out of range")
+		}
+		return disk_manager.DataCell{DataType: resultType, Data: int32(result)}, nil
+
+	case resultType == disk_manager.DECIMAL_TYPE:
+		// Смешанные INT и DECIMAL вычисляются точно в DECIMAL
+		left, err = coerceCell(left, disk_manager.DECIMAL_TYPE)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		right, err = coerceCell(right, disk_manager.DECIMAL_TYPE)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+
+		a, b := left.Data.(disk_manager.Decimal), right.Data.(disk_manager.Decimal)
+		if plus {
+			return disk_manager.DataCell{DataType: resultType, Data: a.Add(b)}, nil
+		}
+		return disk_manager.DataCell{DataType: resultType, Data: a.Sub(b)}, nil
+
+	case left.DataType == disk_manager.DATE_TYPE && right.DataType == disk_manager.INT_32_TYPE:
+		days := int(right.Data.(int32))
+		if !plus {
+			days = -days
+		}
+		return disk_manager.DataCell{DataType: resultType, Data: left.Data.(time.Time).AddDate(0, 0, days)}, nil
+
+	case left.DataType == disk_manager.DATE_TYPE && right.DataType == disk_manager.DATE_TYPE:
+		days := disk_manager.DateToDays(left.Data.(time.Time)) - disk_manager.DateToDays(right.Data.(time.Time))
+		return disk_manager.DataCell{DataType: resultType, Data: days}, nil
+
+	case right.DataType == disk_manager.INTERVAL_TYPE && left.DataType != disk_manager.INTERVAL_TYPE:
+		interval := right.Data.(disk_manager.Interval)
+		if !plus {
+			interval = interval.Negate()
+		}
+		return disk_manager.DataCell{DataType: resultType, Data: interval.AddTo(left.Data.(time.Time))}, nil
+
+	case left.DataType == disk_manager.TIMESTAMP_TYPE && right.DataType == disk_manager.TIMESTAMP_TYPE:
+		// time.Time.Sub ограничена ~292 годами (time.Duration), поэтому разность считается в микросекундах
+		microseconds := left.Data.(time.Time).UnixMicro() - right.Data.(time.Time).UnixMicro()
+		interval := disk_manager.Interval{
+			Days:         int32(microseconds / disk_manager.MICROSECONDS_PER_DAY),
+			Microseconds: microseconds % disk_manager.MICROSECONDS_PER_DAY,
+		}
+		return disk_manager.DataCell{DataType: resultType, Data: interval}, nil
+
+	case left.DataType == disk_manager.INTERVAL_TYPE && right.DataType == disk_manager.INTERVAL_TYPE:
+		interval := right.Data.(disk_manager.Interval)
+		if !plus {
+			interval = interval.Negate()
+		}
+		return disk_manager.DataCell{DataType: resultType, Data: left.Data.(disk_manager.Interval).Add(interval)}, nil
+	}
+
+	return disk_manager.DataCell{}, fmt.Errorf("operator does not exist: %s %s %s", left.DataType, operator, right.DataType)
+}
+
+// concatText возвращает значение операнда конкатенации как строку. У CHAR хвостовые пробелы отбрасываются
+func concatText(cell disk_manager.DataCell) string {
+	if disk_manager.IsTextType(cell.DataType) {
+		text, _ := coerceCell(cell, disk_manager.TEXT_TYPE)
+		return text.Data.(string)
+	}
+	return cell.String()
+}
+
+// applyMultiplicative выполняет *, / или % над двумя не NULL значениями. Деление целых чисел отбрасывает дробную часть
+func applyMultiplicative(operator lex.MathOperator, resultType disk_manager.DataType, left, right disk_manager.DataCell) (disk_manager.DataCell, error) {
+	switch {
+	case left.DataType == disk_manager.INT_32_TYPE && right.DataType == disk_manager.INT_32_TYPE:
+		a, b := int64(left.Data.(int32)), int64(right.Data.(int32))
+		if operator != lex.MultiplyOperator && b == 0 {
+			return disk_manager.DataCell{}, fmt.Errorf("division by zero")
+		}
+
+		var result int64
+		switch operator {
+		case lex.MultiplyOperator:
+			result = a * b
+		case lex.DivideOperator:
+			result = a / b
+		case lex.ModuloOperator:
+			result = a % b
+		}
+		if result > int64(^uint32(0)>>1) || result < -int64(^uint32(0)>>1)-1 {
+			return disk_manager.DataCell{}, fmt.Errorf("integer out of range")
+		}
+		return disk_manager.DataCell{DataType: resultType, Data: int32(result)}, nil
+
+	case resultType == disk_manager.DECIMAL_TYPE:
+		left, err := coerceCell(left, disk_manager.DECIMAL_TYPE)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		right, err = coerceCell(right, disk_manager.DECIMAL_TYPE)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+
+		a, b := left.Data.(disk_manager.Decimal), right.Data.(disk_manager.Decimal)
+		var result disk_manager.Decimal
+		switch operator {
+		case lex.MultiplyOperator:
+			result = a.Mul(b)
+		case lex.DivideOperator:
+			result, err = a.Div(b, max(a.Scale, b.Scale, DIVISION_MIN_SCALE))
+		case lex.ModuloOperator:
+			result, err = a.Mod(b)
+		}
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		return disk_manager.DataCell{DataType: resultType, Data: result}, nil
+
+	case resultType == disk_manager.INTERVAL_TYPE:
+		// Для коммутативного умножения приводим операнды к порядку (интервал, число)
+		if right.DataType == disk_manager.INTERVAL_TYPE {
+			left, right = right, left
+		}
+		factor := float64(0)
+		if right.DataType == disk_manager.INT_32_TYPE {
+			factor = float64(right.Data.(int32))
+		} else {
+			factor = right.Data.(disk_manager.Decimal).Float64()
+		}
+
+		if operator == lex.DivideOperator {
+			if factor == 0 {
+				return disk_manager.DataCell{}, fmt.Errorf("division by zero")
+			}
+			factor = 1 / factor
+		}
+		return disk_manager.DataCell{DataType: resultType, Data: left.Data.(disk_manager.Interval).Multiply(factor)}, nil
+	}
+
+	return disk_manager.DataCell{}, fmt.Errorf("operator does not exist: %s %s %s", left.DataType, operator, right.DataType)
+}
+
+// fitToColumn приводит значение к типу колонки и проверяет ограничения типа:
+// округляет DECIMAL до scale колонки и проверяет precision, проверяет длину VARCHAR(n) и дополняет пробелами CHAR(n)
+func (e *executor) fitToColumn(cell disk_manager.DataCell, column disk_manager.ColumnInfo) (disk_manager.DataCell, error) {
+	cell, err := coerceCell(cell, column.DataType)
+	if err != nil || cell.IsNull {
+		return cell, err
+	}
+
+	switch column.DataType {
+	case disk_manager.DECIMAL_TYPE:
+		decimal, err := cell.Data.(disk_manager.Decimal).FitTo(column.Precision, column.Scale)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		cell.Data = decimal
+	case disk_manager.VARCHAR_TYPE, disk_manager.CHAR_TYPE:
+		value, err := e.fitToLength(cell.Data.(string), column)
+		if err != nil {
+			return disk_manager.DataCell{}, err
+		}
+		cell.Data = value
+	}
+
+	return cell, nil
+}
+
+// fitToLength проверяет длину строки для VARCHAR(n)/CHAR(n) (в символах, а не в байтах).
+// Лишние пробелы в конце обрезаются всегда, остальные лишние символы - только в нестрогом режиме.
+// Значения CHAR(n) дополняются пробелами до длины n
+func (e *executor) fitToLength(value string, column disk_manager.ColumnInfo) (string, error) {
+	if column.Length == 0 {
+		return value, nil
+	}
+
+	runes := []rune(value)
+	if uint32(len(runes)) > column.Length {
+		overflow := string(runes[column.Length:])
+		if e.config.StrictMode && strings.TrimRight(overflow, " ") != "" {
+			return "", fmt.Errorf("value too long for type %s(%d)", column.DataType, column.Length)
+		}
+		runes = runes[:column.Length]
+	}
+
+	if column.DataType == disk_manager.CHAR_TYPE && uint32(len(runes)) < column.Length {
+		return string(runes) + strings.Repeat(" ", int(column.Length)-len(runes)), nil
+	}
+
+	return string(runes), nil
+}
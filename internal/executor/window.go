@@ -0,0 +1,533 @@
+package executor
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// windowFrameBounds границы рамки строки: индексы первой и последней строки партиции, start > end - пустая рамка
+type windowFrameBounds struct {
+	start int
+	end   int
+}
+
+// windowPartition строки одной партиции в порядке ORDER BY окна
+type windowPartition struct {
+	rows      []disk_manager.Row
+	peerStart []int // Индекс первой строки, равной строке i по ORDER BY
+	peerEnd   []int // Индекс последней строки, равной строке i по ORDER BY
+}
+
+// ========================== Window ==========================
+
+// windowOperator вычисляет оконные функции с одинаковым окном (PARTITION BY, ORDER BY и рамкой).
+// Вход отсортирован по выражениям PARTITION BY, затем ORDER BY, поэтому строки партиции идут подряд:
+// оператор читает партицию целиком, вычисляет функции для каждой ее строки и возвращает строки по одной.
+// Строки результата: колонки входа, затем значения оконных функций
+type windowOperator struct {
+	executor *executor
+	input    operator
+	window   *ast.WindowExpression // Окно, общее для всех функций оператора
+	windows  []*ast.Expression
+	frame    [2]ast.FrameBound
+	offsets  [2]int // Количество строк для n PRECEDING и n FOLLOWING
+	columns  []ResultColumn
+
+	pending    disk_manager.Row // Первая строка следующей партиции
+	pendingKey string
+	done       bool
+	rows       []disk_manager.Row // Строки текущей партиции со значениями функций
+	rowIndex   int
+}
+
+// buildWindowPlan добавляет к плану вычисление оконных функций. Функции с одинаковым окном
+// вычисляет один windowOperator по одной сортировке входа
+func (e *executor) buildWindowPlan(plan operator, windows []*ast.Expression) (operator, error) {
+	for len(windows) > 0 {
+		window := windows[0].Window
+		same, rest := []*ast.Expression{}, []*ast.Expression{}
+		for _, expression := range windows {
+			if expression.Window.SameWindow(window) {
+				same = append(same, expression)
+			} else {
+				rest = append(rest, expression)
+			}
+		}
+		windows = rest
+
+		keys := make([]sortKey, 0, len(window.PartitionBy)+len(window.OrderBy))
+		for _, expression := range window.PartitionBy {
+			keys = append(keys, sortKey{expression: expression})
+		}
+		for _, item := range window.OrderBy {
+			keys = append(keys, sortKey{expression: item.Expression, descending: item.Descending, nullsFirst: item.NullsFirst})
+		}
+		// Без PARTITION BY и ORDER BY все строки - одна партиция в порядке входа
+		if len(keys) > 0 {
+			sort, err := newSortOperator(e, plan, keys)
+			if err != nil {
+				plan.Close()
+				return nil, err
+			}
+			plan = sort
+		}
+
+		operator, err := newWindowOperator(e, plan, same)
+		if err != nil {
+			plan.Close()
+			return nil, err
+		}
+		plan = operator
+	}
+
+	return plan, nil
+}
+
+func newWindowOperator(executor *executor, input operator, windows []*ast.Expression) (*windowOperator, error) {
+	window := windows[0].Window
+	op := &windowOperator{
+		executor: executor,
+		input:    input,
+		window:   window,
+		windows:  windows,
+		columns:  append([]ResultColumn{}, input.Columns()...),
+	}
+
+	// Рамка по умолчанию: с ORDER BY - до последней строки, равной текущей, без ORDER BY - вся партиция
+	op.frame = [2]ast.FrameBound{{Kind: ast.UnboundedPreceding}, {Kind: ast.UnboundedFollowing}}
+	if window.Frame != nil {
+		op.frame = [2]ast.FrameBound{window.Frame.Start, window.Frame.End}
+	}
+	for i, bound := range op.frame {
+		if bound.Offset == nil {
+			continue
+		}
+		offset, err := strconv.Atoi(bound.Offset.Value)
+		if err != nil || offset < 0 {
+			return nil, fmt.Errorf("frame offset must be a non-negative integer")
+		}
+		op.offsets[i] = offset
+	}
+
+	for _, expression := range windows {
+		dataType, err := executor.inferExpressionType(expression, input.Columns())
+		if err != nil {
+			return nil, err
+		}
+		op.columns = append(op.columns, ResultColumn{Name: expressionName(expression), DataType: dataType, Expression: expression})
+	}
+
+	return op, nil
+}
+
+// windowResultType проверяет аргументы оконной функции и возвращает тип ее результата
+func (e *executor) windowResultType(window *ast.WindowExpression, columns []ResultColumn) (disk_manager.DataType, error) {
+	name := strings.ToLower(window.Name.Value)
+	if window.Distinct {
+		return unknownType, fmt.Errorf("DISTINCT is not implemented for window functions")
+	}
+
+	switch name {
+	case "row_number", "rank", "dense_rank":
+		if len(window.Arguments) > 0 {
+			return unknownType, fmt.Errorf("window function %s expects no arguments", window.Name.Value)
+		}
+		return disk_manager.INT_32_TYPE, nil
+
+	case "lag", "lead":
+		if len(window.Arguments) < 1 || len(window.Arguments) > 3 {
+			return unknownType, fmt.Errorf("window function %s expects 1 to 3 arguments", window.Name.Value)
+		}
+		valueType, err := e.inferExpressionType(window.Arguments[0], columns)
+		if err != nil {
+			return unknownType, err
+		}
+		if len(window.Arguments) > 1 {
+			offsetType, err := e.inferExpressionType(window.Arguments[1], columns)
+			if err != nil {
+				return unknownType, err
+			}
+			if offsetType != unknownType && !isNumericType(offsetType) {
+				return unknownType, fmt.Errorf("window function %s offset must be a number, got %s", window.Name.Value, offsetType)
+			}
+		}
+		if len(window.Arguments) > 2 {
+			defaultType, err := e.inferExpressionType(window.Arguments[2], columns)
+			if err != nil {
+				return unknownType, err
+			}
+			dataType, ok := setOperationType(valueType, defaultType)
+			if !ok {
+				return unknownType, fmt.Errorf("window function %s types %s and %s cannot be matched", window.Name.Value, valueType, defaultType)
+			}
+			return dataType, nil
+		}
+		return valueType, nil
+
+	case "first_value":
+		if len(window.Arguments) != 1 {
+			return unknownType, fmt.Errorf("window function %s expects exactly 1 argument", window.Name.Value)
+		}
+		return e.inferExpressionType(window.Arguments[0], columns)
+	}
+
+	return e.aggregateResultType(&ast.AggregateExpression{
+		Name:      window.Name,
+		Arguments: window.Arguments,
+		Star:      window.Star,
+	}, columns)
+}
+
+func (op *windowOperator) Columns() []ResultColumn {
+	return op.columns
+}
+
+func (op *windowOperator) Next() (disk_manager.Row, bool, error) {
+	for op.rowIndex >= len(op.rows) {
+		if op.done && op.pending == nil {
+			return nil, false, nil
+		}
+
+		partition, err := op.readPartition()
+		if err != nil {
+			return nil, false, err
+		}
+		if err := op.compute(partition); err != nil {
+			return nil, false, err
+		}
+		op.rows, op.rowIndex = partition.rows, 0
+	}
+
+	row := op.rows[op.rowIndex]
+	op.rows[op.rowIndex] = nil
+	op.rowIndex++
+	return row, true, nil
+}
+
+func (op *windowOperator) Close() error {
+	op.rows, op.pending = nil, nil
+	return op.input.Close()
+}
+
+// readPartition читает строки входа, пока значения PARTITION BY не изменятся.
+// Первая строка следующей партиции остается в pending
+func (op *windowOperator) readPartition() (*windowPartition, error) {
+	partition := &windowPartition{}
+	key := op.pendingKey
+	if op.pending != nil {
+		partition.rows = append(partition.rows, op.pending)
+		op.pending = nil
+	}
+
+	for !op.done {
+		row, ok, err := op.input.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			op.done = true
+			break
+		}
+
+		rowKey, err := op.encodeValues(op.window.PartitionBy, row)
+		if err != nil {
+			return nil, err
+		}
+		if len(partition.rows) == 0 {
+			key = rowKey
+		} else if rowKey != key {
+			op.pending, op.pendingKey = row, rowKey
+			break
+		}
+		partition.rows = append(partition.rows, row)
+	}
+
+	// Строки, равные по ORDER BY (peers), идут подряд
+	orderBy := make([]*ast.Expression, 0, len(op.window.OrderBy))
+	for _, item := range op.window.OrderBy {
+		orderBy = append(orderBy, item.Expression)
+	}
+	partition.peerStart = make([]int, len(partition.rows))
+	partition.peerEnd = make([]int, len(partition.rows))
+	previous := ""
+	for i, row := range partition.rows {
+		key, err := op.encodeValues(orderBy, row)
+		if err != nil {
+			return nil, err
+		}
+		partition.peerStart[i] = i
+		if i > 0 && key == previous {
+			partition.peerStart[i] = partition.peerStart[i-1]
+		}
+		previous = key
+	}
+	for i := len(partition.rows) - 1; i >= 0; i-- {
+		partition.peerEnd[i] = i
+		if i+1 < len(partition.rows) && partition.peerStart[i+1] == partition.peerStart[i] {
+			partition.peerEnd[i] = partition.peerEnd[i+1]
+		}
+	}
+
+	return partition, nil
+}
+
+// encodeValues вычисляет выражения для строки входа и кодирует их значения в ключ для сравнения
+func (op *windowOperator) encodeValues(expressions []*ast.Expression, row disk_manager.Row) (string, error) {
+	values, err := op.evaluate(expressions, row)
+	if err != nil {
+		return "", err
+	}
+	return encodeGroupKey(values), nil
+}
+
+// evaluate вычисляет выражения для строки входа
+func (op *windowOperator) evaluate(expressions []*ast.Expression, row disk_manager.Row) (disk_manager.Row, error) {
+	scope := &rowScope{columns: op.input.Columns(), row: row}
+	values := make(disk_manager.Row, 0, len(expressions))
+	for _, expression := range expressions {
+		cell, err := op.executor.evaluateExpression(expression, scope)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, cell)
+	}
+	return values, nil
+}
+
+// frameBounds возвращает рамку строки i партиции
+func (op *windowOperator) frameBounds(partition *windowPartition, i int) windowFrameBounds {
+	last := len(partition.rows) - 1
+	bounds := [2]int{}
+	for j, bound := range op.frame {
+		switch bound.Kind {
+		case ast.UnboundedPreceding:
+			bounds[j] = 0
+		case ast.OffsetPreceding:
+			bounds[j] = i - op.offsets[j]
+		case ast.CurrentRow:
+			bounds[j] = i
+		case ast.OffsetFollowing:
+			bounds[j] = i + op.offsets[j]
+		case ast.UnboundedFollowing:
+			bounds[j] = last
+		}
+	}
+
+	// Рамка по умолчанию с ORDER BY заканчивается последней строкой, равной текущей
+	if op.window.Frame == nil && len(op.window.OrderBy) > 0 {
+		bounds[1] = partition.peerEnd[i]
+	}
+	return windowFrameBounds{start: max(bounds[0], 0), end: min(bounds[1], last)}
+}
+
+// compute вычисляет значения оконных функций для строк партиции и дописывает их к строкам
+func (op *windowOperator) compute(partition *windowPartition) error {
+	inputCount := len(op.input.Columns())
+	for i, row := range partition.rows {
+		partition.rows[i] = append(row[:inputCount:inputCount], make(disk_manager.Row, len(op.windows))...)
+	}
+
+	for j, expression := range op.windows {
+		values, err := op.computeFunction(expression.Window, op.columns[inputCount+j].DataType, partition)
+		if err != nil {
+			return err
+		}
+		for i, value := range values {
+			if value, err = coerceCell(value, op.columns[inputCount+j].DataType); err != nil {
+				return err
+			}
+			partition.rows[i][inputCount+j] = value
+		}
+	}
+
+	return nil
+}
+
+// computeFunction вычисляет оконную функцию для каждой строки партиции
+func (op *windowOperator) computeFunction(window *ast.WindowExpression, resultType disk_manager.DataType, partition *windowPartition) ([]disk_manager.DataCell, error) {
+	rows := partition.rows
+	values := make([]disk_manager.DataCell, len(rows))
+
+	switch strings.ToLower(window.Name.Value) {
+	case "row_number":
+		for i := range rows {
+			values[i] = disk_manager.DataCell{DataType: disk_manager.INT_32_TYPE, Data: int32(i + 1)}
+		}
+		return values, nil
+
+	case "rank":
+		for i := range rows {
+			values[i] = disk_manager.DataCell{DataType: disk_manager.INT_32_TYPE, Data: int32(partition.peerStart[i] + 1)}
+		}
+		return values, nil
+
+	case "dense_rank":
+		rank := 0
+		for i := range rows {
+			if partition.peerStart[i] == i {
+				rank++
+			}
+			values[i] = disk_manager.DataCell{DataType: disk_manager.INT_32_TYPE, Data: int32(rank)}
+		}
+		return values, nil
+
+	case "lag", "lead":
+		return op.computeOffset(window, resultType, partition)
+
+	case "first_value":
+		arguments, err := op.arguments(window, rows)
+		if err != nil {
+			return nil, err
+		}
+		for i := range rows {
+			values[i] = nullCell(resultType)
+			if bounds := op.frameBounds(partition, i); bounds.start <= bounds.end {
+				values[i] = arguments[bounds.start]
+			}
+		}
+		return values, nil
+	}
+
+	return op.computeAggregate(window, resultType, partition)
+}
+
+// computeOffset вычисляет lag(value, offset, default) и lead(value, offset, default): значение строки,
+// отстоящей от текущей на offset строк назад или вперед, или default, если такой строки в партиции нет
+func (op *windowOperator) computeOffset(window *ast.WindowExpression, resultType disk_manager.DataType, partition *windowPartition) ([]disk_manager.DataCell, error) {
+	rows := partition.rows
+	values := make([]disk_manager.DataCell, len(rows))
+	arguments, err := op.arguments(window, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	direction := -1
+	if strings.ToLower(window.Name.Value) == "lead" {
+		direction = 1
+	}
+
+	for i, row := range rows {
+		offset := 1
+		if len(window.Arguments) > 1 {
+			cell, err := op.executor.evaluateExpression(window.Arguments[1], &rowScope{columns: op.input.Columns(), row: row})
+			if err != nil {
+				return nil, err
+			}
+			if cell.IsNull {
+				values[i] = nullCell(resultType)
+				continue
+			}
+			if cell, err = coerceCell(cell, disk_manager.INT_32_TYPE); err != nil {
+				return nil, err
+			}
+			offset = int(cell.Data.(int32))
+		}
+
+		if target := i + direction*offset; target >= 0 && target < len(rows) {
+			values[i] = arguments[target]
+			continue
+		}
+
+		values[i] = nullCell(resultType)
+		if len(window.Arguments) > 2 {
+			if values[i], err = op.executor.evaluateExpression(window.Arguments[2], &rowScope{columns: op.input.Columns(), row: row}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// computeAggregate вычисляет агрегатную функцию по рамке каждой строки.
+// Пока рамки начинаются с первой строки партиции и не сужаются (рамка по умолчанию, UNBOUNDED PRECEDING),
+// состояние агрегата накапливается по мере роста рамки, иначе рамка агрегируется заново
+func (op *windowOperator) computeAggregate(window *ast.WindowExpression, resultType disk_manager.DataType, partition *windowPartition) ([]disk_manager.DataCell, error) {
+	function, err := op.executor.lookupAggregate(window.Name.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := partition.rows
+	values := make([]disk_manager.DataCell, len(rows))
+	arguments := make([]disk_manager.DataCell, len(rows))
+	if window.Star {
+		// count(*) учитывает каждую строку
+		for i := range arguments {
+			arguments[i] = disk_manager.DataCell{DataType: disk_manager.BOOLEAN_TYPE, Data: true}
+		}
+	} else if arguments, err = op.arguments(window, rows); err != nil {
+		return nil, err
+	}
+
+	var state aggregateState
+	added := 0 // Сколько первых строк партиции учтено в state
+	for i := range rows {
+		bounds := op.frameBounds(partition, i)
+		if state == nil || bounds.start != 0 || bounds.end+1 < added {
+			state, added = function.newState(resultType), bounds.start
+		}
+
+		for ; added <= bounds.end; added++ {
+			if arguments[added].IsNull {
+				continue
+			}
+			if err := state.add(arguments[added]); err != nil {
+				return nil, err
+			}
+		}
+
+		if values[i], err = state.result(); err != nil {
+			return nil, err
+		}
+		// Состояние рамки, начинающейся не с первой строки, не переиспользуется
+		if bounds.start != 0 {
+			state = nil
+		}
+	}
+
+	return values, nil
+}
+
+// arguments вычисляет первый аргумент функции для каждой строки партиции
+func (op *windowOperator) arguments(window *ast.WindowExpression, rows []disk_manager.Row) ([]disk_manager.DataCell, error) {
+	values := make([]disk_manager.DataCell, 0, len(rows))
+	for _, row := range rows {
+		cell, err := op.executor.evaluateExpression(window.Arguments[0], &rowScope{columns: op.input.Columns(), row: row})
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, cell)
+	}
+	return values, nil
+}
+
+// collectWindows собирает различные вызовы оконных функций из выражений
+func collectWindows(expressions []*ast.Expression) []*ast.Expression {
+	windows := []*ast.Expression{}
+
+	var collect func(expression *ast.Expression)
+	collect = func(expression *ast.Expression) {
+		if expression.Kind != ast.WindowKind {
+			for _, child := range expression.Children() {
+				collect(child)
+			}
+			return
+		}
+
+		for _, window := range windows {
+			if window.Equals(expression) {
+				return
+			}
+		}
+		windows = append(windows, expression)
+	}
+
+	for _, expression := range expressions {
+		collect(expression)
+	}
+	return windows
+}
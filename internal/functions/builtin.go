@@ -0,0 +1,80 @@
+package functions
+
+import "custom-database/internal/disk_manager"
+
+// Короткие имена типов для таблицы сигнатур
+const (
+	intType       = disk_manager.INT_32_TYPE
+	textType      = disk_manager.TEXT_TYPE
+	dateType      = disk_manager.DATE_TYPE
+	timestampType = disk_manager.TIMESTAMP_TYPE
+	intervalType  = disk_manager.INTERVAL_TYPE
+	decimalType   = disk_manager.DECIMAL_TYPE
+	byteaType     = disk_manager.BYTEA_TYPE
+	jsonType      = disk_manager.JSON_TYPE
+)
+
+// signature создает сигнатуру с фиксированным количеством аргументов
+func signature(result disk_manager.DataType, arguments ...disk_manager.DataType) Signature {
+	return Signature{Arguments: arguments, Result: result}
+}
+
+// variadic создает сигнатуру, последний аргумент которой может повторяться
+func variadic(result disk_manager.DataType, arguments ...disk_manager.DataType) Signature {
+	return Signature{Arguments: arguments, Variadic: true, Result: result}
+}
+
+// builtinSignatures сигнатуры встроенных скалярных функций. Реализации находятся в executor'е
+var builtinSignatures = map[string][]Signature{
+	// Дата и время
+	"now":        {signature(timestampType)},
+	"date_trunc": {signature(timestampType, textType, timestampType), signature(timestampType, textType, dateType)},
+	"extract": {
+		signature(decimalType, textType, timestampType),
+		signature(decimalType, textType, dateType),
+		signature(decimalType, textType, intervalType),
+	},
+
+	// Строки
+	"upper":    {signature(textType, textType)},
+	"lower":    {signature(textType, textType)},
+	"length":   {signature(intType, textType), signature(intType, byteaType)},
+	"substr":   {signature(textType, textType, intType), signature(textType, textType, intType, intType)},
+	"trim":     {signature(textType, textType), signature(textType, textType, textType)},
+	"replace":  {signature(textType, textType, textType, textType)},
+	"concat":   {variadic(textType, AnyType)},
+	"position": {signature(intType, textType, textType)},
+
+	// Математика
+	"abs":    {signature(intType, intType), signature(decimalType, decimalType)},
+	"round":  {signature(decimalType, decimalType), signature(decimalType, decimalType, intType)},
+	"floor":  {signature(decimalType, decimalType)},
+	"ceil":   {signature(decimalType, decimalType)},
+	"mod":    {signature(intType, intType, intType), signature(decimalType, decimalType, decimalType)},
+	"power":  {signature(decimalType, decimalType, decimalType)},
+	"sqrt":   {signature(decimalType, decimalType)},
+	"random": {signature(decimalType)},
+
+	// Условные выражения
+	"coalesce": {variadic(AnyElementType, AnyElementType)},
+	"nullif":   {signature(AnyElementType, AnyElementType, AnyElementType)},
+
+	// BYTEA
+	"encode": {signature(textType, byteaType, textType)},
+	"decode": {signature(byteaType, textType, textType)},
+
+	// JSON
+	"json_extract":      {signature(jsonType, jsonType, textType)},
+	"json_array_length": {signature(intType, jsonType)},
+	"json_typeof":       {signature(textType, jsonType)},
+}
+
+// builtinAggregateSignatures сигнатуры встроенных агрегатных функций, нужны для каталога функций.
+// Типы аргументов агрегатных функций проверяет executor
+var builtinAggregateSignatures = map[string][]Signature{
+	"count": {signature(intType), signature(intType, AnyType)},
+	"sum":   {signature(decimalType, decimalType), signature(intervalType, intervalType)},
+	"avg":   {signature(decimalType, decimalType), signature(intervalType, intervalType)},
+	"min":   {signature(AnyElementType, AnyElementType)},
+	"max":   {signature(AnyElementType, AnyElementType)},
+}
@@ -0,0 +1,277 @@
+package functions
+
+import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/parser/ast"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Псевдотипы аргументов сигнатур. Значения не пересекаются с типами колонок disk_manager
+const (
+	// AnyType аргумент любого типа (concat)
+	AnyType disk_manager.DataType = 1000 + iota
+	// AnyElementType аргумент любого типа, тип результата вычисляется по типам аргументов при вызове (coalesce, nullif)
+	AnyElementType
+)
+
+// unknownType тип NULL без типа, подходит для аргумента любого типа
+const unknownType disk_manager.DataType = 0
+
+// Kind вид функции
+type Kind string
+
+const (
+	ScalarKind    Kind = "scalar"    // Вычисляется для каждой строки
+	AggregateKind Kind = "aggregate" // Вычисляется по всем строкам группы
+)
+
+// ScalarFunc реализация пользовательской скалярной функции. Аргументы приведены к типам сигнатуры,
+// NULL передаются в функцию как есть
+type ScalarFunc func(arguments []disk_manager.DataCell) (disk_manager.DataCell, error)
+
+// AggregateInitFunc создает начальное состояние пользовательской агрегатной функции для новой группы
+type AggregateInitFunc func() any
+
+// AggregateStepFunc учитывает очередное значение группы (NULL пропускаются) и возвращает новое состояние
+type AggregateStepFunc func(state any, value disk_manager.DataCell) (any, error)
+
+// AggregateFinalFunc возвращает результат агрегатной функции по состоянию. Может вызываться несколько раз
+// для одного состояния (оконные функции), поэтому не должна его изменять
+type AggregateFinalFunc func(state any) (disk_manager.DataCell, error)
+
+// AggregateCallbacks реализация пользовательской агрегатной функции
+type AggregateCallbacks struct {
+	Init  AggregateInitFunc
+	Step  AggregateStepFunc
+	Final AggregateFinalFunc
+}
+
+// Signature сигнатура функции: типы аргументов и тип результата
+type Signature struct {
+	Arguments []disk_manager.DataType // Типы аргументов
+	Variadic  bool                    // Последний аргумент может повторяться
+	Result    disk_manager.DataType   // Тип результата
+}
+
+// Function функция с одной или несколькими сигнатурами (перегрузками)
+type Function struct {
+	Name       string      // Имя функции в нижнем регистре
+	Kind       Kind        // Скалярная или агрегатная
+	Builtin    bool        // Встроенная функция, реализация находится в executor'е
+	Signatures []Signature // Сигнатуры в порядке выбора: побеждает первая подходящая
+
+	Scalar    ScalarFunc          // Реализация пользовательской скалярной функции
+	Aggregate *AggregateCallbacks // Реализация пользовательской агрегатной функции
+}
+
+// Registry реестр функций, которые можно вызывать из SQL. Общий для парсера и executor'а,
+// поэтому зарегистрированная функция сразу проверяется валидатором и вычисляется executor'ом
+type Registry struct {
+	mutex     sync.RWMutex
+	functions map[string]*Function
+}
+
+// functionNamePattern допустимое имя пользовательской функции
+var functionNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// NewRegistry создает реестр со встроенными функциями
+func NewRegistry() *Registry {
+	registry := &Registry{functions: map[string]*Function{}}
+	for name, signatures := range builtinSignatures {
+		registry.functions[name] = &Function{Name: name, Kind: ScalarKind, Builtin: true, Signatures: signatures}
+	}
+	for name, signatures := range builtinAggregateSignatures {
+		registry.functions[name] = &Function{Name: name, Kind: AggregateKind, Builtin: true, Signatures: signatures}
+	}
+
+	return registry
+}
+
+// Register добавляет пользовательскую функцию. Имя не должно совпадать с именем существующей функции,
+// типы аргументов и результата - типы колонок (для аргументов допустим также AnyType)
+func (r *Registry) Register(function Function) error {
+	function.Name = strings.ToLower(function.Name)
+	if !functionNamePattern.MatchString(function.Name) {
+		return fmt.Errorf("invalid function name: %q", function.Name)
+	}
+	if ast.IsWindowFunction(function.Name) {
+		return fmt.Errorf("function %s already exists", function.Name)
+	}
+
+	for _, signature := range function.Signatures {
+		for _, argument := range signature.Arguments {
+			if argument != AnyType && !isColumnType(argument) {
+				return fmt.Errorf("function %s: unsupported argument type %s", function.Name, TypeName(argument))
+			}
+		}
+		if !isColumnType(signature.Result) {
+			return fmt.Errorf("function %s: unsupported return type %s", function.Name, TypeName(signature.Result))
+		}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.functions[function.Name]; ok {
+		return fmt.Errorf("function %s already exists", function.Name)
+	}
+	function.Builtin = false
+	r.functions[function.Name] = &function
+	return nil
+}
+
+// Functions возвращает все функции реестра в порядке имен
+func (r *Registry) Functions() []*Function {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	result := make([]*Function, 0, len(r.functions))
+	for _, function := range r.functions {
+		result = append(result, function)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// Lookup ищет функцию по имени (без учета регистра)
+func (r *Registry) Lookup(name string) (*Function, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	function, ok := r.functions[strings.ToLower(name)]
+	return function, ok
+}
+
+// AcceptsCount проверяет, что у функции есть сигнатура с указанным количеством аргументов
+func (f *Function) AcceptsCount(count int) bool {
+	for _, signature := range f.Signatures {
+		if signature.AcceptsCount(count) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve выбирает первую сигнатуру, которая принимает аргументы указанных типов
+func (f *Function) Resolve(argumentTypes []disk_manager.DataType) (Signature, bool) {
+	for _, signature := range f.Signatures {
+		if signature.Accepts(argumentTypes) {
+			return signature, true
+		}
+	}
+	return Signature{}, false
+}
+
+// ArgumentCounts описывает допустимое количество аргументов для сообщений об ошибках:
+// "no arguments", "1 argument", "2 or 3 arguments", "at least 1 argument"
+func (f *Function) ArgumentCounts() string {
+	counts := map[int]bool{}
+	minVariadic := -1
+	for _, signature := range f.Signatures {
+		if signature.Variadic {
+			if minVariadic < 0 || len(signature.Arguments) < minVariadic {
+				minVariadic = len(signature.Arguments)
+			}
+			continue
+		}
+		counts[len(signature.Arguments)] = true
+	}
+	if minVariadic >= 0 {
+		return "at least " + pluralArguments(minVariadic)
+	}
+
+	sorted := make([]int, 0, len(counts))
+	for count := range counts {
+		sorted = append(sorted, count)
+	}
+	sort.Ints(sorted)
+	if len(sorted) == 1 && sorted[0] == 0 {
+		return "no arguments"
+	}
+
+	names := make([]string, 0, len(sorted))
+	for _, count := range sorted[:len(sorted)-1] {
+		names = append(names, fmt.Sprint(count))
+	}
+	names = append(names, pluralArguments(sorted[len(sorted)-1]))
+	return strings.Join(names, " or ")
+}
+
+// pluralArguments форматирует количество аргументов: "1 argument", "2 arguments"
+func pluralArguments(count int) string {
+	if count == 1 {
+		return "1 argument"
+	}
+	return fmt.Sprintf("%d arguments", count)
+}
+
+// Parameter возвращает тип параметра сигнатуры для аргумента с указанным номером (с учетом повторения последнего)
+func (s Signature) Parameter(index int) disk_manager.DataType {
+	if index >= len(s.Arguments) {
+		return s.Arguments[len(s.Arguments)-1]
+	}
+	return s.Arguments[index]
+}
+
+// Accepts проверяет, что сигнатура принимает аргументы указанных типов
+func (s Signature) Accepts(argumentTypes []disk_manager.DataType) bool {
+	if !s.AcceptsCount(len(argumentTypes)) {
+		return false
+	}
+	for i, argumentType := range argumentTypes {
+		if !AcceptsType(s.Parameter(i), argumentType) {
+			return false
+		}
+	}
+	return true
+}
+
+// AcceptsCount проверяет количество аргументов
+func (s Signature) AcceptsCount(count int) bool {
+	if s.Variadic {
+		return count >= len(s.Arguments)
+	}
+	return count == len(s.Arguments)
+}
+
+// AcceptsType проверяет, что значение типа argument можно передать в параметр типа parameter:
+// NULL без типа подходит любому параметру, INT неявно приводится к DECIMAL, строки - к JSON,
+// а там, где допустим TEXT, допустимы и остальные строковые типы
+func AcceptsType(parameter, argument disk_manager.DataType) bool {
+	switch {
+	case argument == unknownType, parameter == AnyType, parameter == AnyElementType, parameter == argument:
+		return true
+	case parameter == disk_manager.TEXT_TYPE, parameter == disk_manager.JSON_TYPE:
+		return disk_manager.IsTextType(argument)
+	case parameter == disk_manager.DECIMAL_TYPE:
+		return argument == disk_manager.INT_32_TYPE
+	}
+	return false
+}
+
+// TypeName возвращает SQL название типа, в том числе псевдотипа
+func TypeName(dataType disk_manager.DataType) string {
+	switch dataType {
+	case AnyType:
+		return "ANY"
+	case AnyElementType:
+		return "ANYELEMENT"
+	}
+	return dataType.String()
+}
+
+// isColumnType проверяет, что тип - один из типов колонок
+func isColumnType(dataType disk_manager.DataType) bool {
+	switch dataType {
+	case disk_manager.INT_32_TYPE, disk_manager.TEXT_TYPE, disk_manager.DATE_TYPE, disk_manager.TIMESTAMP_TYPE,
+		disk_manager.INTERVAL_TYPE, disk_manager.BOOLEAN_TYPE, disk_manager.DECIMAL_TYPE, disk_manager.VARCHAR_TYPE,
+		disk_manager.CHAR_TYPE, disk_manager.BYTEA_TYPE, disk_manager.JSON_TYPE:
+		return true
+	}
+	return false
+}
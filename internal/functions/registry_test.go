@@ -0,0 +1,107 @@
+package functions
+
+import (
+	"custom-database/internal/disk_manager"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("lookup ignores case", func(t *testing.T) {
+		registry := NewRegistry()
+
+		function, ok := registry.Lookup("UPPER")
+		_, unknown := registry.Lookup("foo")
+
+		require.True(t, ok)
+		require.Equal(t, "upper", function.Name)
+		require.False(t, unknown)
+	})
+
+	t.Run("resolve picks the first matching overload", func(t *testing.T) {
+		function, _ := NewRegistry().Lookup("abs")
+
+		intSignature, intOk := function.Resolve([]disk_manager.DataType{disk_manager.INT_32_TYPE})
+		decimalSignature, decimalOk := function.Resolve([]disk_manager.DataType{disk_manager.DECIMAL_TYPE})
+		_, textOk := function.Resolve([]disk_manager.DataType{disk_manager.TEXT_TYPE})
+
+		require.True(t, intOk)
+		require.Equal(t, disk_manager.INT_32_TYPE, intSignature.Result)
+		require.True(t, decimalOk)
+		require.Equal(t, disk_manager.DECIMAL_TYPE, decimalSignature.Result)
+		require.False(t, textOk)
+	})
+
+	t.Run("implicit conversions of arguments", func(t *testing.T) {
+		require.True(t, AcceptsType(disk_manager.DECIMAL_TYPE, disk_manager.INT_32_TYPE))
+		require.True(t, AcceptsType(disk_manager.TEXT_TYPE, disk_manager.CHAR_TYPE))
+		require.True(t, AcceptsType(disk_manager.JSON_TYPE, disk_manager.VARCHAR_TYPE))
+		require.True(t, AcceptsType(disk_manager.DATE_TYPE, unknownType))
+		require.True(t, AcceptsType(AnyType, disk_manager.BYTEA_TYPE))
+		require.False(t, AcceptsType(disk_manager.INT_32_TYPE, disk_manager.DECIMAL_TYPE))
+		require.False(t, AcceptsType(disk_manager.TEXT_TYPE, disk_manager.INT_32_TYPE))
+	})
+
+	t.Run("variadic signature repeats the last argument", func(t *testing.T) {
+		function, _ := NewRegistry().Lookup("concat")
+
+		_, ok := function.Resolve([]disk_manager.DataType{disk_manager.INT_32_TYPE, disk_manager.TEXT_TYPE, disk_manager.DATE_TYPE})
+
+		require.True(t, ok)
+		require.False(t, function.AcceptsCount(0))
+		require.Equal(t, "at least 1 argument", function.ArgumentCounts())
+	})
+
+	t.Run("argument counts of overloads", func(t *testing.T) {
+		registry := NewRegistry()
+		substr, _ := registry.Lookup("substr")
+		now, _ := registry.Lookup("now")
+		upper, _ := registry.Lookup("upper")
+
+		require.Equal(t, "2 or 3 arguments", substr.ArgumentCounts())
+		require.Equal(t, "no arguments", now.ArgumentCounts())
+		require.Equal(t, "1 argument", upper.ArgumentCounts())
+	})
+	t.Run("register adds a user function", func(t *testing.T) {
+		registry := NewRegistry()
+
+		err := registry.Register(Function{
+			Name:       "Double",
+			Kind:       ScalarKind,
+			Builtin:    true,
+			Signatures: []Signature{signature(intType, intType)},
+		})
+		function, ok := registry.Lookup("double")
+		duplicate := registry.Register(Function{Name: "DOUBLE", Kind: ScalarKind})
+
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "double", function.Name)
+		require.False(t, function.Builtin)
+		require.EqualError(t, duplicate, "function double already exists")
+	})
+
+	t.Run("register checks types of the signature", func(t *testing.T) {
+		registry := NewRegistry()
+
+		anyArgument := registry.Register(Function{Name: "f", Signatures: []Signature{signature(intType, AnyType)}})
+		anyResult := registry.Register(Function{Name: "g", Signatures: []Signature{signature(AnyType, intType)}})
+		unknownArgument := registry.Register(Function{Name: "h", Signatures: []Signature{signature(intType, AnyElementType)}})
+
+		require.NoError(t, anyArgument)
+		require.EqualError(t, anyResult, "function g: unsupported return type ANY")
+		require.EqualError(t, unknownArgument, "function h: unsupported argument type ANYELEMENT")
+	})
+
+	t.Run("functions are sorted by name", func(t *testing.T) {
+		registry := NewRegistry()
+		require.NoError(t, registry.Register(Function{Name: "aaa", Kind: AggregateKind, Signatures: []Signature{signature(intType, intType)}}))
+
+		list := registry.Functions()
+
+		require.Equal(t, "aaa", list[0].Name)
+		require.Equal(t, AggregateKind, list[0].Kind)
+		require.Len(t, list, len(builtinSignatures)+len(builtinAggregateSignatures)+1)
+	})
+}
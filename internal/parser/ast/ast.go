@@ -72,6 +72,19 @@ func parseStatement(tokens []*lex.Token, initialPointer uint) (*AstStatement, ui
 		}, newCursor, true
 	}
 
+	// Пробуем парсить SELECT statement с общими табличными выражениями (WITH ... SELECT)
+	if with, newCursor, ok := parseWithClause(tokens, pointer); ok {
+		selectStmt, newCursor, ok := parseSelectStatement(tokens, newCursor)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		selectStmt.With = with
+		return &AstStatement{
+			Kind:            SelectKind,
+			SelectStatement: selectStmt,
+		}, newCursor, true
+	}
+
 	// Пробуем парсить INSERT statement
 	if insertStmt, newCursor, ok := parseInsertStatement(tokens, pointer); ok {
 		return &AstStatement{
@@ -96,5 +109,21 @@ func parseStatement(tokens []*lex.Token, initialPointer uint) (*AstStatement, ui
 		}, newCursor, true
 	}
 
+	// Пробуем парсить ALTER TABLE statement
+	if alterStmt, newCursor, ok := parseAlterTableStatement(tokens, pointer); ok {
+		return &AstStatement{
+			Kind:                AlterTableKind,
+			AlterTableStatement: alterStmt,
+		}, newCursor, true
+	}
+
+	// Пробуем парсить TRUNCATE TABLE statement
+	if truncateStmt, newCursor, ok := parseTruncateTableStatement(tokens, pointer); ok {
+		return &AstStatement{
+			Kind:                   TruncateTableKind,
+			TruncateTableStatement: truncateStmt,
+		}, newCursor, true
+	}
+
 	return nil, initialPointer, false
 }
@@ -0,0 +1,233 @@
+package ast
+
+import "custom-database/internal/parser/lex"
+
+// aggregateFunctions имена агрегатных функций
+var aggregateFunctions = map[string]bool{
+	"count": true,
+	"sum":   true,
+	"avg":   true,
+	"min":   true,
+	"max":   true,
+}
+
+// IsAggregateFunction проверяет, является ли функция с указанным именем агрегатной
+func IsAggregateFunction(name string) bool {
+	return aggregateFunctions[name]
+}
+
+// windowFunctions имена функций, которые вычисляются только как оконные (с OVER)
+var windowFunctions = map[string]bool{
+	"row_number":  true,
+	"rank":        true,
+	"dense_rank":  true,
+	"lag":         true,
+	"lead":        true,
+	"first_value": true,
+}
+
+// IsWindowFunction проверяет, является ли функция с указанным именем оконной
+func IsWindowFunction(name string) bool {
+	return windowFunctions[name]
+}
+
+// Equals сравнивает выражения по структуре: a + 1 в SELECT и a + 1 в GROUP BY - одно и то же выражение
+func (expression *Expression) Equals(other *Expression) bool {
+	if expression == nil || other == nil {
+		return expression == other
+	}
+	if expression.Kind != other.Kind {
+		return false
+	}
+
+	switch expression.Kind {
+	case LiteralKind:
+		return expression.Literal.Equals(other.Literal)
+	case TypedLiteralKind:
+		return expression.Literal.Equals(other.Literal) && expression.DataType.Equals(other.DataType)
+	case BinaryKind:
+		return expression.Binary.Operator.Equals(&other.Binary.Operator) &&
+			expression.Binary.A.Equals(other.Binary.A) &&
+			expression.Binary.B.Equals(other.Binary.B)
+	case FunctionCallKind:
+		return expression.FunctionCall.Name.Equals(&other.FunctionCall.Name) &&
+			expressionListsEqual(expression.FunctionCall.Arguments, other.FunctionCall.Arguments)
+	case AggregateKind:
+		return expression.Aggregate.Name.Equals(&other.Aggregate.Name) &&
+			expression.Aggregate.Distinct == other.Aggregate.Distinct &&
+			expression.Aggregate.Star == other.Aggregate.Star &&
+			expressionListsEqual(expression.Aggregate.Arguments, other.Aggregate.Arguments)
+	case WindowKind:
+		return expression.Window.Equals(other.Window)
+	case LikeKind, InListKind, BetweenKind, IsNullKind:
+		return expression.Predicate.CaseInsensitive == other.Predicate.CaseInsensitive &&
+			expression.Predicate.Negated == other.Predicate.Negated &&
+			expression.Predicate.Operand.Equals(other.Predicate.Operand) &&
+			expressionListsEqual(expression.Predicate.Arguments, other.Predicate.Arguments)
+	case CaseKind:
+		return expression.Case.Equals(other.Case)
+	case LogicalKind:
+		return expression.Logical.Operator == other.Logical.Operator &&
+			expressionListsEqual(expression.Logical.Operands, other.Logical.Operands)
+	case CastKind:
+		return expression.Cast.Operand.Equals(other.Cast.Operand) &&
+			expression.Cast.DataType.Equals(&other.Cast.DataType) &&
+			tokenListsEqual(expression.Cast.Parameters, other.Cast.Parameters)
+	case SubqueryKind, ExistsKind, InKind:
+		// Подзапросы равны, только если это один и тот же подзапрос
+		return expression.Subquery.Select == other.Subquery.Select &&
+			expression.Subquery.Negated == other.Subquery.Negated &&
+			expression.Subquery.Operand.Equals(other.Subquery.Operand)
+	}
+
+	return false
+}
+
+// Equals сравнивает выражения CASE: значение простой формы, ветки WHEN и ELSE
+func (caseExpression *CaseExpression) Equals(other *CaseExpression) bool {
+	if !caseExpression.Operand.Equals(other.Operand) || !caseExpression.Else.Equals(other.Else) ||
+		len(caseExpression.Whens) != len(other.Whens) {
+		return false
+	}
+	for i, when := range caseExpression.Whens {
+		if !when.Condition.Equals(other.Whens[i].Condition) || !when.Result.Equals(other.Whens[i].Result) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals сравнивает вызовы оконных функций: функцию, аргументы и окно
+func (window *WindowExpression) Equals(other *WindowExpression) bool {
+	if !window.Name.Equals(&other.Name) ||
+		window.Star != other.Star || window.Distinct != other.Distinct ||
+		!expressionListsEqual(window.Arguments, other.Arguments) {
+		return false
+	}
+	return window.SameWindow(other)
+}
+
+// SameWindow проверяет, что у вызовов одинаковые PARTITION BY, ORDER BY и рамка:
+// такие функции вычисляются по одной сортировке строк
+func (window *WindowExpression) SameWindow(other *WindowExpression) bool {
+	if !expressionListsEqual(window.PartitionBy, other.PartitionBy) || len(window.OrderBy) != len(other.OrderBy) {
+		return false
+	}
+	for i, item := range window.OrderBy {
+		otherItem := other.OrderBy[i]
+		if !item.Expression.Equals(otherItem.Expression) ||
+			item.Descending != otherItem.Descending || item.NullsFirst != otherItem.NullsFirst {
+			return false
+		}
+	}
+
+	if window.Frame == nil || other.Frame == nil {
+		return window.Frame == other.Frame
+	}
+	return window.Frame.Start.Equals(other.Frame.Start) && window.Frame.End.Equals(other.Frame.End)
+}
+
+// Equals сравнивает границы рамки окна
+func (bound FrameBound) Equals(other FrameBound) bool {
+	if bound.Kind != other.Kind {
+		return false
+	}
+	if bound.Offset == nil || other.Offset == nil {
+		return bound.Offset == other.Offset
+	}
+	return bound.Offset.Equals(other.Offset)
+}
+
+// expressionListsEqual сравнивает списки выражений поэлементно
+func expressionListsEqual(a, b []*Expression) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equals(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenListsEqual сравнивает списки токенов поэлементно
+func tokenListsEqual(a, b []*lex.Token) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equals(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Children возвращает непосредственные подвыражения выражения
+func (expression *Expression) Children() []*Expression {
+	switch expression.Kind {
+	case BinaryKind:
+		return []*Expression{expression.Binary.A, expression.Binary.B}
+	case FunctionCallKind:
+		return expression.FunctionCall.Arguments
+	case AggregateKind:
+		return expression.Aggregate.Arguments
+	case WindowKind:
+		children := append([]*Expression{}, expression.Window.Arguments...)
+		children = append(children, expression.Window.PartitionBy...)
+		for _, item := range expression.Window.OrderBy {
+			children = append(children, item.Expression)
+		}
+		return children
+	case LikeKind, InListKind, BetweenKind, IsNullKind:
+		return append([]*Expression{expression.Predicate.Operand}, expression.Predicate.Arguments...)
+	case CaseKind:
+		var children []*Expression
+		if expression.Case.Operand != nil {
+			children = append(children, expression.Case.Operand)
+		}
+		for _, when := range expression.Case.Whens {
+			children = append(children, when.Condition, when.Result)
+		}
+		if expression.Case.Else != nil {
+			children = append(children, expression.Case.Else)
+		}
+		return children
+	case CastKind:
+		return []*Expression{expression.Cast.Operand}
+	case LogicalKind:
+		return expression.Logical.Operands
+	case InKind:
+		// Выражения подзапроса относятся к подзапросу, а не к этому выражению
+		return []*Expression{expression.Subquery.Operand}
+	}
+
+	return nil
+}
+
+// ContainsAggregate проверяет, есть ли в выражении вызов агрегатной функции
+func (expression *Expression) ContainsAggregate() bool {
+	if expression.Kind == AggregateKind {
+		return true
+	}
+	for _, child := range expression.Children() {
+		if child.ContainsAggregate() {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsWindow проверяет, есть ли в выражении вызов оконной функции
+func (expression *Expression) ContainsWindow() bool {
+	if expression.Kind == WindowKind {
+		return true
+	}
+	for _, child := range expression.Children() {
+		if child.ContainsWindow() {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package ast
 import (
 	"custom-database/internal/parser/lex"
 	"fmt"
+	"strings"
 )
 
 // parseExpressions парсит список выражений, разделенных запятыми
@@ -46,15 +47,269 @@ func parseExpressions(tokens []*lex.Token, initialPointer uint, delimiters []lex
 	}
 }
 
-// parseExpression парсит одно выражение (идентификатор, число, строка, NULL)
+// parseColumnNames парсит список имен колонок в скобках: (column, ...)
+func parseColumnNames(tokens []*lex.Token, initialPointer uint) ([]lex.Token, uint, bool) {
+	pointer := initialPointer
+	columns := []lex.Token{}
+
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.LeftparenSymbol)) {
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	for {
+		column, newCursor, ok := parseToken(tokens, pointer, lex.IdentifierToken)
+		if !ok {
+			helpMessage(tokens, pointer, "Expected column name")
+			return nil, initialPointer, false
+		}
+		columns = append(columns, *column)
+		pointer = newCursor
+
+		if !expectToken(tokens, pointer, tokenFromSymbol(lex.CommaSymbol)) {
+			break
+		}
+		pointer++
+	}
+
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.RightparenSymbol)) {
+		helpMessage(tokens, pointer, "Expected right paren after column names")
+		return nil, initialPointer, false
+	}
+
+	return columns, pointer + 1, true
+}
+
+// parseExpression парсит одно выражение с учетом приоритета бинарных и логических операторов
+// Пример: created_at >= TIMESTAMP '2024-01-01' - INTERVAL '1 day' AND NOT deleted
 func parseExpression(tokens []*lex.Token, initialPointer uint, _ lex.Token) (*Expression, uint, bool) {
+	return parseBinaryExpression(tokens, initialPointer, 0)
+}
+
+// Приоритеты логических операторов и сравнений: OR связывает слабее AND, AND - слабее NOT,
+// а NOT - слабее сравнений, поэтому NOT a = b AND c означает (NOT (a = b)) AND c
+const (
+	orPrecedence         uint = 1
+	andPrecedence        uint = 2
+	comparisonPrecedence uint = 4
+)
+
+// binaryOperatorPrecedence возвращает приоритет бинарного оператора (чем больше, тем раньше вычисляется)
+func binaryOperatorPrecedence(token *lex.Token) (uint, bool) {
+	// * лексер возвращает как символ
+	if token.Kind == lex.SymbolToken && token.Value == string(lex.AsteriskSymbol) {
+		return 7, true
+	}
+	if token.Kind == lex.KeywordToken {
+		switch lex.Keyword(token.Value) {
+		case lex.OrKeyword:
+			return orPrecedence, true
+		case lex.AndKeyword:
+			return andPrecedence, true
+		}
+		return 0, false
+	}
+	if token.Kind != lex.MathOperatorToken {
+		return 0, false
+	}
+
+	switch lex.MathOperator(token.Value) {
+	case lex.EqualOperator, lex.NotEqualOperator,
+		lex.LessThanOperator, lex.GreaterThanOperator,
+		lex.LessOrEqualOperator, lex.GreaterOrEqualOperator:
+		return comparisonPrecedence, true
+	case lex.ConcatOperator:
+		return 5, true
+	case lex.PlusOperator, lex.MinusOperator:
+		return 6, true
+	case lex.DivideOperator, lex.ModuloOperator:
+		return 7, true
+	case lex.JsonGetOperator, lex.JsonGetTextOperator, lex.JsonPathOperator, lex.JsonPathTextOperator:
+		return 8, true
+	default:
+		return 0, false
+	}
+}
+
+// parseBinaryExpression парсит цепочку бинарных операторов, приоритет которых выше minPrecedence
+func parseBinaryExpression(tokens []*lex.Token, initialPointer uint, minPrecedence uint) (*Expression, uint, bool) {
 	pointer := initialPointer
 
+	// Парсим левый операнд
+	left, newCursor, ok := parseNotExpression(tokens, pointer)
+	if !ok {
+		return nil, initialPointer, false
+	}
+	pointer = newCursor
+
+	// Пока следующий токен - оператор с достаточным приоритетом, собираем бинарное выражение
+	for pointer < uint(len(tokens)) {
+		// Предикаты [NOT] IN, [NOT] LIKE, [NOT] BETWEEN и IS [NOT] NULL имеют приоритет операторов сравнения
+		if isPredicateStart(tokens, pointer) {
+			if minPrecedence >= comparisonPrecedence {
+				break
+			}
+			predicate, newCursor, ok := parsePredicate(tokens, pointer, left)
+			if !ok {
+				return nil, initialPointer, false
+			}
+			left = predicate
+			pointer = newCursor
+			continue
+		}
+
+		operator := tokens[pointer]
+		precedence, ok := binaryOperatorPrecedence(operator)
+		if !ok || precedence <= minPrecedence {
+			break
+		}
+
+		// Правый операнд парсим с приоритетом текущего оператора (левая ассоциативность)
+		right, newCursor, ok := parseBinaryExpression(tokens, pointer+1, precedence)
+		if !ok {
+			helpMessage(tokens, pointer+1, "Expected expression after operator")
+			return nil, initialPointer, false
+		}
+		pointer = newCursor
+
+		if operator.Kind == lex.KeywordToken {
+			left = &Expression{
+				Logical: &LogicalExpression{Operator: lex.Keyword(operator.Value), Operands: []*Expression{left, right}},
+				Kind:    LogicalKind,
+			}
+			continue
+		}
+		left = &Expression{
+			Binary: &BinaryExpression{
+				A:        left,
+				B:        right,
+				Operator: lex.Token{Value: operator.Value, Kind: lex.MathOperatorToken},
+			},
+			Kind: BinaryKind,
+		}
+	}
+
+	return left, pointer, true
+}
+
+// parseNotExpression парсит операнд бинарного оператора, возможно с логическим отрицанием: NOT operand.
+// Отрицание относится к операнду вместе со сравнениями и предикатами, но не с AND и OR
+func parseNotExpression(tokens []*lex.Token, initialPointer uint) (*Expression, uint, bool) {
+	pointer := initialPointer
+
+	// NOT EXISTS (SELECT ...) парсит parseExists
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.NotKeyword)) && !expectToken(tokens, pointer+1, tokenFromKeyword(lex.ExistsKeyword)) {
+		operand, newCursor, ok := parseBinaryExpression(tokens, pointer+1, andPrecedence)
+		if !ok {
+			helpMessage(tokens, pointer+1, "Expected expression after NOT")
+			return nil, initialPointer, false
+		}
+		return &Expression{
+			Logical: &LogicalExpression{Operator: lex.NotKeyword, Operands: []*Expression{operand}},
+			Kind:    LogicalKind,
+		}, newCursor, true
+	}
+
+	operand, newCursor, ok := parsePrimaryExpression(tokens, pointer)
+	if !ok {
+		return nil, initialPointer, false
+	}
+
+	// Приведение типа operand::type связывает сильнее любого бинарного оператора
+	return parseCastSuffix(tokens, newCursor, operand)
+}
+
+// parsePrimaryExpression парсит операнд выражения (идентификатор, литерал, вызов функции, выражение в скобках)
+func parsePrimaryExpression(tokens []*lex.Token, initialPointer uint) (*Expression, uint, bool) {
+	pointer := initialPointer
+
+	// Скалярный подзапрос: (SELECT ...)
+	if subquery, newCursor, ok := parseSubquery(tokens, pointer); ok {
+		return &Expression{
+			Subquery: &SubqueryExpression{Select: subquery},
+			Kind:     SubqueryKind,
+		}, newCursor, true
+	}
+
+	// [NOT] EXISTS (SELECT ...)
+	if exists, newCursor, ok := parseExists(tokens, pointer); ok {
+		return exists, newCursor, true
+	}
+
+	// Выражение в скобках
+	if expectToken(tokens, pointer, tokenFromSymbol(lex.LeftparenSymbol)) {
+		expression, newCursor, ok := parseExpression(tokens, pointer+1, tokenFromSymbol(lex.RightparenSymbol))
+		if !ok {
+			return nil, initialPointer, false
+		}
+		pointer = newCursor
+
+		if !expectToken(tokens, pointer, tokenFromSymbol(lex.RightparenSymbol)) {
+			helpMessage(tokens, pointer, "Expected right paren")
+			return nil, initialPointer, false
+		}
+
+		return expression, pointer + 1, true
+	}
+
+	// Отрицательное число: -42
+	if expectToken(tokens, pointer, tokenFromMathOperator(lex.MinusOperator)) {
+		if number, newCursor, ok := parseToken(tokens, pointer+1, lex.NumericToken); ok {
+			return &Expression{
+				Literal: &lex.Token{
+					Value: string(lex.MinusOperator) + number.Value,
+					Kind:  lex.NumericToken,
+				},
+				Kind: LiteralKind,
+			}, newCursor, true
+		}
+
+		// Унарный минус перед остальными операндами (-id, -(a + b)) связывает сильнее бинарных операторов
+		// и записывается как -1 * operand, что подходит и для чисел, и для интервалов
+		operand, newCursor, ok := parsePrimaryExpression(tokens, pointer+1)
+		if !ok {
+			helpMessage(tokens, pointer+1, "Expected expression after unary minus")
+			return nil, initialPointer, false
+		}
+		if operand, newCursor, ok = parseCastSuffix(tokens, newCursor, operand); !ok {
+			return nil, initialPointer, false
+		}
+		return &Expression{
+			Binary: &BinaryExpression{
+				A:        &Expression{Literal: &lex.Token{Value: "-1", Kind: lex.NumericToken}, Kind: LiteralKind},
+				B:        operand,
+				Operator: lex.Token{Value: string(lex.MultiplyOperator), Kind: lex.MathOperatorToken},
+			},
+			Kind: BinaryKind,
+		}, newCursor, true
+	}
+
+	// CASE [operand] WHEN ... THEN ... [ELSE ...] END
+	if caseExpression, newCursor, ok := parseCase(tokens, pointer); ok {
+		return caseExpression, newCursor, true
+	}
+
+	// CAST(operand AS type)
+	if cast, newCursor, ok := parseCast(tokens, pointer); ok {
+		return cast, newCursor, true
+	}
+
+	// Литерал с указанием типа: DATE '2024-01-31', TIMESTAMP '...', INTERVAL '1 day'
+	if typedLiteral, newCursor, ok := parseTypedLiteral(tokens, pointer); ok {
+		return typedLiteral, newCursor, true
+	}
+
+	// Вызов функции: name(arguments...)
+	if functionCall, newCursor, ok := parseFunctionCall(tokens, pointer); ok {
+		return functionCall, newCursor, true
+	}
+
 	// Пробуем парсить различные типы токенов как выражения
 	validKinds := []lex.TokenKind{
 		lex.IdentifierToken, // Имя колонки
 		lex.NumericToken,    // Число
 		lex.StringToken,     // Строка
+		lex.HexStringToken,  // Шестнадцатеричная строка X'...'
 		lex.NullToken,       // NULL
 	}
 
@@ -70,6 +325,682 @@ func parseExpression(tokens []*lex.Token, initialPointer uint, _ lex.Token) (*Ex
 	return nil, initialPointer, false
 }
 
+// parseExists парсит проверку существования строк подзапроса: [NOT] EXISTS (SELECT ...)
+func parseExists(tokens []*lex.Token, initialPointer uint) (*Expression, uint, bool) {
+	pointer := initialPointer
+
+	negated := expectToken(tokens, pointer, tokenFromKeyword(lex.NotKeyword))
+	if negated {
+		pointer++
+	}
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.ExistsKeyword)) {
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	subquery, newCursor, ok := parseSubquery(tokens, pointer)
+	if !ok {
+		helpMessage(tokens, pointer, "Expected subquery after EXISTS")
+		return nil, initialPointer, false
+	}
+
+	return &Expression{
+		Subquery: &SubqueryExpression{Select: subquery, Negated: negated},
+		Kind:     ExistsKind,
+	}, newCursor, true
+}
+
+// predicateKeywords ключевые слова, с которых начинается предикат после операнда (возможно, после NOT)
+var predicateKeywords = []lex.Keyword{
+	lex.InKeyword,
+	lex.LikeKeyword,
+	lex.IlikeKeyword,
+	lex.BetweenKeyword,
+}
+
+// isPredicateStart проверяет, что с позиции pointer начинается предикат: [NOT] IN|LIKE|ILIKE|BETWEEN или IS
+func isPredicateStart(tokens []*lex.Token, pointer uint) bool {
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.IsKeyword)) {
+		return true
+	}
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.NotKeyword)) {
+		pointer++
+	}
+	for _, keyword := range predicateKeywords {
+		if expectToken(tokens, pointer, tokenFromKeyword(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePredicate парсит предикат над operand:
+// [NOT] IN (SELECT ...), [NOT] IN (a, b, ...), [NOT] LIKE|ILIKE pattern, [NOT] BETWEEN a AND b, IS [NOT] NULL
+func parsePredicate(tokens []*lex.Token, initialPointer uint, operand *Expression) (*Expression, uint, bool) {
+	pointer := initialPointer
+
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.IsKeyword)) {
+		return parseIsNull(tokens, initialPointer, operand)
+	}
+
+	predicate := &PredicateExpression{Operand: operand}
+	predicate.Negated = expectToken(tokens, pointer, tokenFromKeyword(lex.NotKeyword))
+	if predicate.Negated {
+		pointer++
+	}
+
+	var kind ExpressionKind
+	switch {
+	case expectToken(tokens, pointer, tokenFromKeyword(lex.InKeyword)):
+		pointer++
+
+		// x [NOT] IN (SELECT ...)
+		if subquery, newCursor, ok := parseSubquery(tokens, pointer); ok {
+			return &Expression{
+				Subquery: &SubqueryExpression{Select: subquery, Operand: operand, Negated: predicate.Negated},
+				Kind:     InKind,
+			}, newCursor, true
+		}
+
+		if !expectToken(tokens, pointer, tokenFromSymbol(lex.LeftparenSymbol)) {
+			helpMessage(tokens, pointer, "Expected subquery or list of values after IN")
+			return nil, initialPointer, false
+		}
+		values, newCursor, ok := parseExpressions(tokens, pointer+1, []lex.Token{tokenFromSymbol(lex.RightparenSymbol)})
+		if !ok || len(*values) == 0 {
+			helpMessage(tokens, pointer+1, "Expected list of values after IN")
+			return nil, initialPointer, false
+		}
+		predicate.Arguments = *values
+		pointer = newCursor + 1
+		kind = InListKind
+
+	case expectToken(tokens, pointer, tokenFromKeyword(lex.LikeKeyword)),
+		expectToken(tokens, pointer, tokenFromKeyword(lex.IlikeKeyword)):
+		predicate.CaseInsensitive = tokens[pointer].Value == string(lex.IlikeKeyword)
+		pointer++
+
+		// Шаблон связывает сильнее сравнения: x LIKE 'a' || '%'
+		pattern, newCursor, ok := parseBinaryExpression(tokens, pointer, comparisonPrecedence)
+		if !ok {
+			helpMessage(tokens, pointer, "Expected pattern after LIKE")
+			return nil, initialPointer, false
+		}
+		predicate.Arguments = []*Expression{pattern}
+		pointer = newCursor
+		kind = LikeKind
+
+	case expectToken(tokens, pointer, tokenFromKeyword(lex.BetweenKeyword)):
+		pointer++
+
+		low, newCursor, ok := parseBinaryExpression(tokens, pointer, comparisonPrecedence)
+		if !ok {
+			helpMessage(tokens, pointer, "Expected lower bound after BETWEEN")
+			return nil, initialPointer, false
+		}
+		pointer = newCursor
+
+		if !expectToken(tokens, pointer, tokenFromKeyword(lex.AndKeyword)) {
+			helpMessage(tokens, pointer, "Expected AND in BETWEEN")
+			return nil, initialPointer, false
+		}
+		pointer++
+
+		high, newCursor, ok := parseBinaryExpression(tokens, pointer, comparisonPrecedence)
+		if !ok {
+			helpMessage(tokens, pointer, "Expected upper bound after AND")
+			return nil, initialPointer, false
+		}
+		predicate.Arguments = []*Expression{low, high}
+		pointer = newCursor
+		kind = BetweenKind
+
+	default:
+		helpMessage(tokens, pointer, "Expected IN, LIKE, ILIKE or BETWEEN after NOT")
+		return nil, initialPointer, false
+	}
+
+	return &Expression{
+		Predicate: predicate,
+		Kind:      kind,
+	}, pointer, true
+}
+
+// parseIsNull парсит проверку на NULL: operand IS [NOT] NULL
+func parseIsNull(tokens []*lex.Token, initialPointer uint, operand *Expression) (*Expression, uint, bool) {
+	pointer := initialPointer
+
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.IsKeyword)) {
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	negated := expectToken(tokens, pointer, tokenFromKeyword(lex.NotKeyword))
+	if negated {
+		pointer++
+	}
+
+	if _, newCursor, ok := parseToken(tokens, pointer, lex.NullToken); ok {
+		return &Expression{
+			Predicate: &PredicateExpression{Operand: operand, Negated: negated},
+			Kind:      IsNullKind,
+		}, newCursor, true
+	}
+
+	helpMessage(tokens, pointer, "Expected NULL after IS")
+	return nil, initialPointer, false
+}
+
+// parseCase парсит условное выражение: CASE [operand] WHEN a THEN x [WHEN b THEN y ...] [ELSE z] END
+func parseCase(tokens []*lex.Token, initialPointer uint) (*Expression, uint, bool) {
+	pointer := initialPointer
+
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.CaseKeyword)) {
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	caseExpression := &CaseExpression{}
+
+	// Простая форма: CASE operand WHEN value THEN ...
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.WhenKeyword)) {
+		operand, newCursor, ok := parseExpression(tokens, pointer, tokenFromKeyword(lex.WhenKeyword))
+		if !ok {
+			helpMessage(tokens, pointer, "Expected expression or WHEN after CASE")
+			return nil, initialPointer, false
+		}
+		caseExpression.Operand = operand
+		pointer = newCursor
+	}
+
+	for expectToken(tokens, pointer, tokenFromKeyword(lex.WhenKeyword)) {
+		pointer++
+
+		condition, newCursor, ok := parseExpression(tokens, pointer, tokenFromKeyword(lex.ThenKeyword))
+		if !ok {
+			helpMessage(tokens, pointer, "Expected expression after WHEN")
+			return nil, initialPointer, false
+		}
+		pointer = newCursor
+
+		if !expectToken(tokens, pointer, tokenFromKeyword(lex.ThenKeyword)) {
+			helpMessage(tokens, pointer, "Expected THEN")
+			return nil, initialPointer, false
+		}
+		pointer++
+
+		result, newCursor, ok := parseExpression(tokens, pointer, tokenFromKeyword(lex.WhenKeyword))
+		if !ok {
+			helpMessage(tokens, pointer, "Expected expression after THEN")
+			return nil, initialPointer, false
+		}
+		pointer = newCursor
+
+		caseExpression.Whens = append(caseExpression.Whens, &CaseWhen{Condition: condition, Result: result})
+	}
+
+	if len(caseExpression.Whens) == 0 {
+		helpMessage(tokens, pointer, "Expected WHEN in CASE")
+		return nil, initialPointer, false
+	}
+
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.ElseKeyword)) {
+		pointer++
+
+		elseResult, newCursor, ok := parseExpression(tokens, pointer, tokenFromKeyword(lex.EndKeyword))
+		if !ok {
+			helpMessage(tokens, pointer, "Expected expression after ELSE")
+			return nil, initialPointer, false
+		}
+		caseExpression.Else = elseResult
+		pointer = newCursor
+	}
+
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.EndKeyword)) {
+		helpMessage(tokens, pointer, "Expected END after CASE")
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	return &Expression{
+		Case: caseExpression,
+		Kind: CaseKind,
+	}, pointer, true
+}
+
+// parseCast парсит приведение типа: CAST(operand AS type)
+func parseCast(tokens []*lex.Token, initialPointer uint) (*Expression, uint, bool) {
+	pointer := initialPointer
+
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.CastKeyword)) {
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.LeftparenSymbol)) {
+		helpMessage(tokens, pointer, "Expected left paren after CAST")
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	operand, newCursor, ok := parseExpression(tokens, pointer, tokenFromKeyword(lex.AsKeyword))
+	if !ok {
+		helpMessage(tokens, pointer, "Expected expression in CAST")
+		return nil, initialPointer, false
+	}
+	pointer = newCursor
+
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.AsKeyword)) {
+		helpMessage(tokens, pointer, "Expected AS in CAST")
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	cast, newCursor, ok := parseCastType(tokens, pointer, operand)
+	if !ok {
+		return nil, initialPointer, false
+	}
+	pointer = newCursor
+
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.RightparenSymbol)) {
+		helpMessage(tokens, pointer, "Expected right paren after CAST type")
+		return nil, initialPointer, false
+	}
+
+	return cast, pointer + 1, true
+}
+
+// parseCastSuffix парсит приведения типа после операнда: operand::type[::type ...].
+// Если приведения нет, возвращает operand без изменений
+func parseCastSuffix(tokens []*lex.Token, initialPointer uint, operand *Expression) (*Expression, uint, bool) {
+	pointer := initialPointer
+
+	for expectToken(tokens, pointer, tokenFromSymbol(lex.DoubleColonSymbol)) {
+		cast, newCursor, ok := parseCastType(tokens, pointer+1, operand)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		operand = cast
+		pointer = newCursor
+	}
+
+	return operand, pointer, true
+}
+
+// parseCastType парсит целевой тип приведения с необязательными параметрами: DECIMAL(10, 2)
+func parseCastType(tokens []*lex.Token, initialPointer uint, operand *Expression) (*Expression, uint, bool) {
+	pointer := initialPointer
+
+	dataType, newCursor, ok := parseDataType(tokens, pointer)
+	// BOOLEAN не зарезервирован и бывает только типом приведения, поэтому его нет среди типов колонок
+	if !ok && expectWord(tokens, pointer, string(lex.BooleanKeyword)) {
+		booleanType := tokenFromKeyword(lex.BooleanKeyword)
+		dataType, newCursor, ok = &booleanType, pointer+1, true
+	}
+	if !ok {
+		helpMessage(tokens, pointer, "Expected type name")
+		return nil, initialPointer, false
+	}
+	pointer = newCursor
+
+	parameters, newCursor, ok := parseTypeParameters(tokens, pointer)
+	if !ok {
+		return nil, initialPointer, false
+	}
+	pointer = newCursor
+
+	return &Expression{
+		Cast: &CastExpression{Operand: operand, DataType: *dataType, Parameters: parameters},
+		Kind: CastKind,
+	}, pointer, true
+}
+
+// typedLiteralKeywords типы, которые можно указать перед строковым литералом.
+// Эти слова не зарезервированы, поэтому приходят из лексера как идентификаторы
+var typedLiteralKeywords = []lex.Keyword{
+	lex.DateKeyword,
+	lex.TimestampKeyword,
+	lex.IntervalKeyword,
+}
+
+// parseTypedLiteral парсит литерал с указанием типа: DATE '2024-01-31'.
+// Если после имени типа нет строки, это не литерал, а колонка с таким именем
+func parseTypedLiteral(tokens []*lex.Token, initialPointer uint) (*Expression, uint, bool) {
+	pointer := initialPointer
+
+	for _, keyword := range typedLiteralKeywords {
+		if !expectWord(tokens, pointer, string(keyword)) {
+			continue
+		}
+
+		literal, newCursor, ok := parseToken(tokens, pointer+1, lex.StringToken)
+		if !ok {
+			return nil, initialPointer, false
+		}
+
+		dataType := tokenFromKeyword(keyword)
+		return &Expression{
+			Literal:  literal,
+			DataType: &dataType,
+			Kind:     TypedLiteralKind,
+		}, newCursor, true
+	}
+
+	return nil, initialPointer, false
+}
+
+// parseDataType парсит имя типа данных. Незарезервированные типы (DATE, TIMESTAMP, INTERVAL)
+// приходят как идентификаторы и возвращаются в виде ключевого слова, как и остальные типы
+func parseDataType(tokens []*lex.Token, initialPointer uint) (*lex.Token, uint, bool) {
+	if dataType, newCursor, ok := parseToken(tokens, initialPointer, lex.KeywordToken); ok {
+		return dataType, newCursor, true
+	}
+
+	for _, keyword := range typedLiteralKeywords {
+		if expectWord(tokens, initialPointer, string(keyword)) {
+			dataType := tokenFromKeyword(keyword)
+			return &dataType, initialPointer + 1, true
+		}
+	}
+
+	return nil, initialPointer, false
+}
+
+// parseFunctionCall парсит вызов функции: now(), date_trunc('day', created_at), extract(year FROM created_at),
+// position('a' IN name)
+func parseFunctionCall(tokens []*lex.Token, initialPointer uint) (*Expression, uint, bool) {
+	pointer := initialPointer
+
+	// Имя функции и открывающая скобка
+	name, newCursor, ok := parseToken(tokens, pointer, lex.IdentifierToken)
+	if !ok || !expectToken(tokens, newCursor, tokenFromSymbol(lex.LeftparenSymbol)) {
+		return nil, initialPointer, false
+	}
+	pointer = newCursor + 1
+
+	// Агрегатные функции: count(*), sum([DISTINCT] x)
+	if IsAggregateFunction(name.Value) {
+		aggregate, newCursor, ok := parseAggregateCall(tokens, initialPointer, name, pointer)
+		if !ok || !expectToken(tokens, newCursor, tokenFromKeyword(lex.OverKeyword)) {
+			return aggregate, newCursor, ok
+		}
+
+		// Агрегатная функция с OVER вычисляется как оконная: sum(x) OVER (ORDER BY y)
+		return parseWindow(tokens, initialPointer, &WindowExpression{
+			Name:      *name,
+			Arguments: aggregate.Aggregate.Arguments,
+			Star:      aggregate.Aggregate.Star,
+			Distinct:  aggregate.Aggregate.Distinct,
+		}, newCursor)
+	}
+
+	var arguments []*Expression
+	if name.Value == "extract" {
+		// EXTRACT(field FROM source) - поле передается первым аргументом как строка
+		field, newCursor, ok := parseToken(tokens, pointer, lex.IdentifierToken)
+		if !ok {
+			helpMessage(tokens, pointer, "Expected field name in extract")
+			return nil, initialPointer, false
+		}
+		pointer = newCursor
+
+		if !expectToken(tokens, pointer, tokenFromKeyword(lex.FromKeyword)) {
+			helpMessage(tokens, pointer, "Expected FROM in extract")
+			return nil, initialPointer, false
+		}
+		pointer++
+
+		source, newCursor, ok := parseExpression(tokens, pointer, tokenFromSymbol(lex.RightparenSymbol))
+		if !ok {
+			helpMessage(tokens, pointer, "Expected expression in extract")
+			return nil, initialPointer, false
+		}
+		pointer = newCursor
+
+		arguments = []*Expression{
+			{Literal: &lex.Token{Value: field.Value, Kind: lex.StringToken}, Kind: LiteralKind},
+			source,
+		}
+	} else if positionArguments, newCursor, ok := parsePositionArguments(tokens, name, pointer); ok {
+		pointer = newCursor
+		arguments = positionArguments
+	} else {
+		// Аргументы функции через запятую
+		expressions, newCursor, ok := parseExpressions(tokens, pointer, []lex.Token{tokenFromSymbol(lex.RightparenSymbol)})
+		if !ok {
+			return nil, initialPointer, false
+		}
+		pointer = newCursor
+		arguments = *expressions
+	}
+
+	// Ожидаем закрывающую скобку
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.RightparenSymbol)) {
+		helpMessage(tokens, pointer, "Expected right paren")
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.OverKeyword)) {
+		return parseWindow(tokens, initialPointer, &WindowExpression{Name: *name, Arguments: arguments}, pointer)
+	}
+
+	return &Expression{
+		FunctionCall: &FunctionCallExpression{
+			Name:      *name,
+			Arguments: arguments,
+		},
+		Kind: FunctionCallKind,
+	}, pointer, true
+}
+
+// parsePositionArguments парсит аргументы position(substring IN string) после открывающей скобки.
+// Операнды не могут содержать сравнений и предикатов, поэтому IN не считается началом предиката
+func parsePositionArguments(tokens []*lex.Token, name *lex.Token, pointer uint) ([]*Expression, uint, bool) {
+	if name.Value != "position" {
+		return nil, pointer, false
+	}
+
+	substring, newCursor, ok := parseBinaryExpression(tokens, pointer, comparisonPrecedence)
+	if !ok || !expectToken(tokens, newCursor, tokenFromKeyword(lex.InKeyword)) {
+		return nil, pointer, false
+	}
+
+	source, newCursor, ok := parseBinaryExpression(tokens, newCursor+1, comparisonPrecedence)
+	if !ok {
+		helpMessage(tokens, newCursor+1, "Expected expression in position")
+		return nil, pointer, false
+	}
+	return []*Expression{substring, source}, newCursor, true
+}
+
+// parseAggregateCall парсит аргументы агрегатной функции после открывающей скобки:
+// count(*), count(DISTINCT x), sum(x)
+func parseAggregateCall(tokens []*lex.Token, initialPointer uint, name *lex.Token, pointer uint) (*Expression, uint, bool) {
+	aggregate := &AggregateExpression{Name: *name}
+
+	if expectToken(tokens, pointer, tokenFromSymbol(lex.AsteriskSymbol)) {
+		aggregate.Star = true
+		pointer++
+	} else {
+		if expectToken(tokens, pointer, tokenFromKeyword(lex.DistinctKeyword)) {
+			aggregate.Distinct = true
+			pointer++
+		}
+
+		expressions, newCursor, ok := parseExpressions(tokens, pointer, []lex.Token{tokenFromSymbol(lex.RightparenSymbol)})
+		if !ok {
+			return nil, initialPointer, false
+		}
+		pointer = newCursor
+		aggregate.Arguments = *expressions
+	}
+
+	// Ожидаем закрывающую скобку
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.RightparenSymbol)) {
+		helpMessage(tokens, pointer, "Expected right paren")
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	return &Expression{
+		Aggregate: aggregate,
+		Kind:      AggregateKind,
+	}, pointer, true
+}
+
+// parseWindow парсит окно вызова оконной функции: OVER ([PARTITION BY expr, ...] [ORDER BY ...] [ROWS ...])
+func parseWindow(tokens []*lex.Token, initialPointer uint, window *WindowExpression, pointer uint) (*Expression, uint, bool) {
+	// Ожидаем OVER (
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.OverKeyword)) {
+		return nil, initialPointer, false
+	}
+	pointer++
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.LeftparenSymbol)) {
+		helpMessage(tokens, pointer, "Expected left paren after OVER")
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	// Парсим PARTITION BY (опционально)
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.PartitionKeyword)) {
+		pointer++
+		if !expectToken(tokens, pointer, tokenFromKeyword(lex.ByKeyword)) {
+			helpMessage(tokens, pointer, "Expected BY after PARTITION")
+			return nil, initialPointer, false
+		}
+		pointer++
+
+		partitionBy, newCursor, ok := parseExpressions(tokens, pointer, []lex.Token{
+			tokenFromKeyword(lex.OrderKeyword),
+			tokenFromKeyword(lex.RowsKeyword),
+			tokenFromSymbol(lex.RightparenSymbol),
+		})
+		if !ok || len(*partitionBy) == 0 {
+			helpMessage(tokens, pointer, "Expected PARTITION BY expressions")
+			return nil, initialPointer, false
+		}
+		window.PartitionBy = *partitionBy
+		pointer = newCursor
+	}
+
+	// Парсим ORDER BY (опционально)
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.OrderKeyword)) {
+		orderBy, newCursor, ok := parseOrderBy(tokens, pointer)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		window.OrderBy = orderBy
+		pointer = newCursor
+	}
+
+	// Парсим рамку ROWS (опционально)
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.RowsKeyword)) {
+		frame, newCursor, ok := parseWindowFrame(tokens, pointer)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		window.Frame = frame
+		pointer = newCursor
+	}
+
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.RightparenSymbol)) {
+		helpMessage(tokens, pointer, "Expected right paren after window definition")
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	return &Expression{
+		Window: window,
+		Kind:   WindowKind,
+	}, pointer, true
+}
+
+// parseWindowFrame парсит рамку окна: ROWS BETWEEN start AND end или ROWS start
+func parseWindowFrame(tokens []*lex.Token, initialPointer uint) (*WindowFrame, uint, bool) {
+	pointer := initialPointer
+
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.RowsKeyword)) {
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	between := expectToken(tokens, pointer, tokenFromKeyword(lex.BetweenKeyword))
+	if between {
+		pointer++
+	}
+
+	start, newCursor, ok := parseFrameBound(tokens, pointer)
+	if !ok {
+		return nil, initialPointer, false
+	}
+	pointer = newCursor
+
+	// Без BETWEEN рамка заканчивается текущей строкой
+	frame := &WindowFrame{Start: start, End: FrameBound{Kind: CurrentRow}}
+	if !between {
+		return frame, pointer, true
+	}
+
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.AndKeyword)) {
+		helpMessage(tokens, pointer, "Expected AND in frame definition")
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	end, newCursor, ok := parseFrameBound(tokens, pointer)
+	if !ok {
+		return nil, initialPointer, false
+	}
+	frame.End = end
+
+	return frame, newCursor, true
+}
+
+// parseFrameBound парсит границу рамки: UNBOUNDED PRECEDING|FOLLOWING, CURRENT ROW, n PRECEDING|FOLLOWING
+func parseFrameBound(tokens []*lex.Token, initialPointer uint) (FrameBound, uint, bool) {
+	pointer := initialPointer
+
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.CurrentKeyword)) {
+		if !expectToken(tokens, pointer+1, tokenFromKeyword(lex.RowKeyword)) {
+			helpMessage(tokens, pointer+1, "Expected ROW after CURRENT")
+			return FrameBound{}, initialPointer, false
+		}
+		return FrameBound{Kind: CurrentRow}, pointer + 2, true
+	}
+
+	bound := FrameBound{}
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.UnboundedKeyword)) {
+		pointer++
+	} else {
+		offset, newCursor, ok := parseToken(tokens, pointer, lex.NumericToken)
+		if !ok {
+			helpMessage(tokens, pointer, "Expected frame bound")
+			return FrameBound{}, initialPointer, false
+		}
+		bound.Offset = offset
+		pointer = newCursor
+	}
+
+	switch {
+	case expectToken(tokens, pointer, tokenFromKeyword(lex.PrecedingKeyword)):
+		bound.Kind = OffsetPreceding
+		if bound.Offset == nil {
+			bound.Kind = UnboundedPreceding
+		}
+	case expectToken(tokens, pointer, tokenFromKeyword(lex.FollowingKeyword)):
+		bound.Kind = OffsetFollowing
+		if bound.Offset == nil {
+			bound.Kind = UnboundedFollowing
+		}
+	default:
+		helpMessage(tokens, pointer, "Expected PRECEDING or FOLLOWING")
+		return FrameBound{}, initialPointer, false
+	}
+
+	return bound, pointer + 1, true
+}
+
 // tokenFromKeyword создает токен из ключевого слова
 func tokenFromKeyword(k lex.Keyword) lex.Token {
 	return lex.Token{
@@ -86,6 +1017,14 @@ func tokenFromSymbol(s lex.Symbol) lex.Token {
 	}
 }
 
+// tokenFromMathOperator создает токен из математического оператора
+func tokenFromMathOperator(o lex.MathOperator) lex.Token {
+	return lex.Token{
+		Kind:  lex.MathOperatorToken,
+		Value: string(o),
+	}
+}
+
 // parseToken парсит токен определенного типа
 func parseToken(tokens []*lex.Token, initialPointer uint, kind lex.TokenKind) (*lex.Token, uint, bool) {
 	pointer := initialPointer
@@ -112,6 +1051,17 @@ func expectToken(tokens []*lex.Token, pointer uint, expected lex.Token) bool {
 	return expected.Equals(tokens[pointer])
 }
 
+// expectWord проверяет, что токен - идентификатор word (без учета регистра). Так распознаются слова,
+// которые не сделаны ключевыми, чтобы их можно было использовать как имена колонок (key, nothing)
+func expectWord(tokens []*lex.Token, pointer uint, word string) bool {
+	if pointer >= uint(len(tokens)) {
+		return false
+	}
+
+	token := tokens[pointer]
+	return token.Kind == lex.IdentifierToken && strings.EqualFold(token.Value, word)
+}
+
 // helpMessage выводит сообщение об ошибке парсинга
 func helpMessage(tokens []*lex.Token, pointer uint, msg string) {
 	var current *lex.Token
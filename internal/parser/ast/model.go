@@ -11,57 +11,325 @@ type Ast struct {
 type AstStatmentKind string
 
 const (
-	SelectKind      AstStatmentKind = "SELECT"       // SELECT запрос
-	CreateTableKind AstStatmentKind = "CREATE_TABLE" // CREATE TABLE запрос
-	InsertKind      AstStatmentKind = "INSERT"       // INSERT запрос
-	DropTableKind   AstStatmentKind = "DROP_TABLE"   // DROP TABLE запрос
+	SelectKind        AstStatmentKind = "SELECT"         // SELECT запрос
+	CreateTableKind   AstStatmentKind = "CREATE_TABLE"   // CREATE TABLE запрос
+	InsertKind        AstStatmentKind = "INSERT"         // INSERT запрос
+	DropTableKind     AstStatmentKind = "DROP_TABLE"     // DROP TABLE запрос
+	AlterTableKind    AstStatmentKind = "ALTER_TABLE"    // ALTER TABLE запрос
+	TruncateTableKind AstStatmentKind = "TRUNCATE_TABLE" // TRUNCATE TABLE запрос
 )
 
 // AstStatement представляет один SQL statement
 type AstStatement struct {
 	Kind AstStatmentKind // Тип statement'а
 
-	SelectStatement      *SelectStatement      // SELECT FROM statement
-	CreateTableStatement *CreateTableStatement // CREATE TABLE statement
-	InsertStatement      *InsertStatement      // INSERT INTO statement
-	DropTableStatement   *DropTableStatement   // DROP TABLE statement
+	SelectStatement        *SelectStatement        // SELECT FROM statement
+	CreateTableStatement   *CreateTableStatement   // CREATE TABLE statement
+	InsertStatement        *InsertStatement        // INSERT INTO statement
+	DropTableStatement     *DropTableStatement     // DROP TABLE statement
+	AlterTableStatement    *AlterTableStatement    // ALTER TABLE statement
+	TruncateTableStatement *TruncateTableStatement // TRUNCATE TABLE statement
 }
 
 // ExpressionKind тип для определения вида выражения
 type ExpressionKind string
 
 const (
-	LiteralKind ExpressionKind = "LITERAL" // Литеральное значение (строка, число, NULL)
-	// Могут быть и другие типы выражений (BINARY, FUNCTION_CALL, AGGREGATE_FUNCTION), но они не используются в текущей реализации
+	LiteralKind      ExpressionKind = "LITERAL"            // Литеральное значение (строка, число, NULL)
+	TypedLiteralKind ExpressionKind = "TYPED_LITERAL"      // Литерал с указанием типа (DATE '2024-01-31')
+	BinaryKind       ExpressionKind = "BINARY"             // Бинарное выражение (a + b, a = b)
+	FunctionCallKind ExpressionKind = "FUNCTION_CALL"      // Вызов функции (now(), date_trunc('day', ts))
+	AggregateKind    ExpressionKind = "AGGREGATE_FUNCTION" // Агрегатная функция (count(*), sum(x), count(DISTINCT x))
+	SubqueryKind     ExpressionKind = "SUBQUERY"           // Скалярный подзапрос ((SELECT max(id) FROM users))
+	ExistsKind       ExpressionKind = "EXISTS"             // Проверка существования строк ([NOT] EXISTS (SELECT ...))
+	InKind           ExpressionKind = "IN"                 // Проверка вхождения в результат подзапроса (x [NOT] IN (SELECT ...))
+	WindowKind       ExpressionKind = "WINDOW_FUNCTION"    // Оконная функция (row_number() OVER (...), sum(x) OVER (...))
+	StarKind         ExpressionKind = "STAR"               // Все колонки в списке SELECT: * вместе с другими колонками или users.* (Literal - имя таблицы)
+	LikeKind         ExpressionKind = "LIKE"               // Сравнение с шаблоном (x [NOT] LIKE 'a%', x [NOT] ILIKE 'a%')
+	InListKind       ExpressionKind = "IN_LIST"            // Проверка вхождения в список значений (x [NOT] IN (1, 2, 3))
+	BetweenKind      ExpressionKind = "BETWEEN"            // Проверка диапазона (x [NOT] BETWEEN a AND b)
+	IsNullKind       ExpressionKind = "IS_NULL"            // Проверка на NULL (x IS [NOT] NULL)
+	CaseKind         ExpressionKind = "CASE"               // Условное выражение (CASE [x] WHEN ... THEN ... ELSE ... END)
+	CastKind         ExpressionKind = "CAST"               // Приведение типа (CAST(x AS type), x::type)
+	LogicalKind      ExpressionKind = "LOGICAL"            // Логическое выражение (a AND b, a OR b, NOT a)
 )
 
 // Expression представляет выражение в SQL (колонка, значение и т.д.)
 type Expression struct {
-	Literal *lex.Token // Литеральное значение (строка, число, NULL)
-	Kind    ExpressionKind
+	Literal      *lex.Token              // Литеральное значение (строка, число, NULL)
+	DataType     *lex.Token              // Тип литерала для TYPED_LITERAL (DATE, TIMESTAMP, INTERVAL)
+	Binary       *BinaryExpression       // Бинарное выражение
+	FunctionCall *FunctionCallExpression // Вызов функции
+	Aggregate    *AggregateExpression    // Агрегатная функция
+	Subquery     *SubqueryExpression     // Подзапрос (SUBQUERY, EXISTS, IN)
+	Window       *WindowExpression       // Оконная функция
+	Predicate    *PredicateExpression    // Предикат (LIKE, IN_LIST, BETWEEN, IS_NULL)
+	Case         *CaseExpression         // Условное выражение CASE
+	Cast         *CastExpression         // Приведение типа
+	Logical      *LogicalExpression      // Логическое выражение (AND, OR, NOT)
+	Alias        *lex.Token              // Имя колонки результата (SELECT expr AS alias), nil если не указано
+	Kind         ExpressionKind
+}
+
+// BinaryExpression представляет бинарное выражение: A Operator B
+type BinaryExpression struct {
+	A        *Expression // Левый операнд
+	B        *Expression // Правый операнд
+	Operator lex.Token   // Оператор (=, !=, <, >, <=, >=, +, -, *, /, %, ||, ->)
+}
+
+// LogicalExpression представляет логическое выражение: A AND B, A OR B или NOT A.
+// Значение вычисляется по трехзначной логике: NULL означает неизвестное значение
+type LogicalExpression struct {
+	Operator lex.Keyword   // AND, OR или NOT
+	Operands []*Expression // Операнды: два для AND и OR, один для NOT
+}
+
+// SubqueryExpression представляет подзапрос в выражении: (SELECT ...), [NOT] EXISTS (SELECT ...), x [NOT] IN (SELECT ...).
+// Подзапрос может ссылаться на колонки внешнего запроса (коррелированный подзапрос)
+type SubqueryExpression struct {
+	Select  *SelectStatement // Подзапрос
+	Operand *Expression      // Левая часть IN, nil для скалярного подзапроса и EXISTS
+	Negated bool             // NOT EXISTS, NOT IN
+}
+
+// PredicateExpression представляет предикат над операндом: x [NOT] LIKE|ILIKE pattern, x [NOT] IN (a, b, ...),
+// x [NOT] BETWEEN a AND b, x IS [NOT] NULL
+type PredicateExpression struct {
+	Operand         *Expression   // Проверяемое выражение
+	Arguments       []*Expression // Шаблон LIKE, значения списка IN или границы BETWEEN, пустой список для IS NULL
+	CaseInsensitive bool          // ILIKE
+	Negated         bool          // NOT LIKE, NOT IN, NOT BETWEEN, IS NOT NULL
+}
+
+// CaseExpression представляет условное выражение. В простой форме CASE x WHEN a THEN ... значение x
+// сравнивается со значениями WHEN, в поисковой форме CASE WHEN condition THEN ... проверяются условия
+type CaseExpression struct {
+	Operand *Expression // Сравниваемое значение простой формы, nil для поисковой формы
+	Whens   []*CaseWhen // Ветки WHEN в порядке проверки
+	Else    *Expression // Результат ELSE, nil - NULL
+}
+
+// CaseWhen представляет ветку CASE: WHEN condition THEN result
+type CaseWhen struct {
+	Condition *Expression // Условие (поисковая форма) или значение для сравнения (простая форма)
+	Result    *Expression // Результат ветки
+}
+
+// CastExpression представляет приведение типа: CAST(operand AS type) или operand::type
+type CastExpression struct {
+	Operand    *Expression  // Приводимое выражение
+	DataType   lex.Token    // Целевой тип
+	Parameters []*lex.Token // Параметры целевого типа, например DECIMAL(10, 2)
+}
+
+// FunctionCallExpression представляет вызов функции: name(arguments...)
+type FunctionCallExpression struct {
+	Name      lex.Token     // Имя функции
+	Arguments []*Expression // Аргументы функции
+}
+
+// AggregateExpression представляет вызов агрегатной функции: name([DISTINCT] argument) или count(*)
+type AggregateExpression struct {
+	Name      lex.Token     // Имя функции (count, sum, avg, min, max)
+	Arguments []*Expression // Аргументы функции, пустой список для count(*)
+	Distinct  bool          // Агрегируются только различные значения (DISTINCT)
+	Star      bool          // count(*) - считаются все строки
+}
+
+// WindowExpression представляет вызов оконной функции: name(arguments) OVER ([PARTITION BY ...] [ORDER BY ...] [ROWS ...]).
+// Функция вычисляется для каждой строки по строкам ее партиции, не объединяя строки в группы
+type WindowExpression struct {
+	Name        lex.Token      // Имя функции (row_number, rank, lag, sum, ...)
+	Arguments   []*Expression  // Аргументы функции
+	Star        bool           // count(*) OVER (...)
+	Distinct    bool           // count(DISTINCT x) OVER (...) - не поддерживается, сохраняется для проверки
+	PartitionBy []*Expression  // Выражения, по которым строки делятся на партиции, пустой список - одна партиция
+	OrderBy     []*OrderByItem // Порядок строк в партиции, пустой список если не указан
+	Frame       *WindowFrame   // Рамка (ROWS ...), nil - рамка по умолчанию
+}
+
+// FrameBoundKind тип границы рамки окна
+type FrameBoundKind string
+
+const (
+	UnboundedPreceding FrameBoundKind = "UNBOUNDED PRECEDING" // Первая строка партиции
+	OffsetPreceding    FrameBoundKind = "PRECEDING"           // n строк до текущей
+	CurrentRow         FrameBoundKind = "CURRENT ROW"         // Текущая строка
+	OffsetFollowing    FrameBoundKind = "FOLLOWING"           // n строк после текущей
+	UnboundedFollowing FrameBoundKind = "UNBOUNDED FOLLOWING" // Последняя строка партиции
+)
+
+// FrameBound граница рамки окна
+type FrameBound struct {
+	Kind   FrameBoundKind
+	Offset *lex.Token // Количество строк для n PRECEDING и n FOLLOWING, иначе nil
+}
+
+// WindowFrame рамка окна: ROWS BETWEEN start AND end или ROWS start (тогда end - CURRENT ROW).
+// По умолчанию рамка - от начала партиции до текущей строки и равных ей по ORDER BY строк,
+// а без ORDER BY - вся партиция
+type WindowFrame struct {
+	Start FrameBound
+	End   FrameBound
 }
 
 type CreateTableStatement struct {
-	Table   lex.Token            // Имя таблицы
-	Columns *[]*columnDefinition // Определения колонок
+	Table       lex.Token            // Имя таблицы
+	Columns     *[]*columnDefinition // Определения колонок
+	PrimaryKey  []lex.Token          // Колонки первичного ключа из ограничения PRIMARY KEY (column, ...), пустой список если его нет
+	IfNotExists bool                 // CREATE TABLE IF NOT EXISTS: существующая таблица - не ошибка
 }
 
 // columnDefinition представляет определение колонки в CREATE TABLE
 type columnDefinition struct {
-	Name     lex.Token // Имя колонки
-	Datatype lex.Token // Тип данных колонки
+	Name       lex.Token    // Имя колонки
+	Datatype   lex.Token    // Тип данных колонки
+	Parameters []*lex.Token // Параметры типа данных, например DECIMAL(10, 2)
+	PrimaryKey bool         // Колонка объявлена первичным ключом: id INT PRIMARY KEY
+	NotNull    bool         // Колонка не допускает NULL: id INT NOT NULL
+	Default    *Expression  // Значение по умолчанию: hits INT DEFAULT 0, nil если не указано
 }
 
 type DropTableStatement struct {
-	Table lex.Token // Имя таблицы
+	Table    lex.Token // Имя таблицы
+	IfExists bool      // DROP TABLE IF EXISTS: отсутствующая таблица - не ошибка
+}
+
+// TruncateTableStatement TRUNCATE TABLE table [RESTART IDENTITY]
+type TruncateTableStatement struct {
+	Table           lex.Token // Имя таблицы
+	RestartIdentity bool      // RESTART IDENTITY: сбросить счетчик RowID таблицы
+}
+
+// AlterTableAction действие ALTER TABLE
+type AlterTableAction string
+
+const (
+	AddColumnAction    AlterTableAction = "ADD COLUMN"                 // ADD [COLUMN] column type ...
+	DropColumnAction   AlterTableAction = "DROP COLUMN"                // DROP [COLUMN] column
+	RenameColumnAction AlterTableAction = "RENAME COLUMN"              // RENAME [COLUMN] column TO new_name
+	RenameTableAction  AlterTableAction = "RENAME TO"                  // RENAME TO new_name
+	SetNotNullAction   AlterTableAction = "ALTER COLUMN SET NOT NULL"  // ALTER [COLUMN] column SET NOT NULL
+	DropNotNullAction  AlterTableAction = "ALTER COLUMN DROP NOT NULL" // ALTER [COLUMN] column DROP NOT NULL
+	SetDefaultAction   AlterTableAction = "ALTER COLUMN SET DEFAULT"   // ALTER [COLUMN] column SET DEFAULT expr
+	DropDefaultAction  AlterTableAction = "ALTER COLUMN DROP DEFAULT"  // ALTER [COLUMN] column DROP DEFAULT
+)
+
+// AlterTableStatement ALTER TABLE table action
+type AlterTableStatement struct {
+	Table   lex.Token         // Имя таблицы
+	Action  AlterTableAction  // Действие
+	Column  lex.Token         // Изменяемая колонка, пустая для ADD COLUMN и RENAME TO
+	NewName lex.Token         // Новое имя колонки (RENAME COLUMN) или таблицы (RENAME TO)
+	Added   *columnDefinition // Определение новой колонки для ADD COLUMN, nil для остальных действий
+	Default *Expression       // Новое значение по умолчанию для SET DEFAULT
 }
 
 type InsertStatement struct {
-	Table  lex.Token      // Имя таблицы
-	Values *[]*Expression // Значения для вставки
+	Table   lex.Token        // Имя таблицы
+	Columns []lex.Token      // Колонки, в которые вставляются значения, пустой список - все колонки таблицы по порядку
+	Values  [][]*Expression  // Строки VALUES, пустой список для INSERT ... SELECT
+	Select  *SelectStatement // Запрос, строки которого вставляются (INSERT ... SELECT), nil для VALUES
+
+	OnConflict *OnConflictClause // Действие при конфликте по первичному ключу, nil если не указано
+	Returning  []*Expression     // Список RETURNING (как список SELECT, может содержать * и table.*), nil если не указан
+}
+
+// ConflictAction действие INSERT ... ON CONFLICT
+type ConflictAction string
+
+const (
+	DoNothingAction ConflictAction = "DO NOTHING" // Строка, конфликтующая с существующей, пропускается
+	DoUpdateAction  ConflictAction = "DO UPDATE"  // Существующая строка обновляется
+)
+
+// OnConflictClause ON CONFLICT [(column, ...)] DO NOTHING | DO UPDATE SET column = expr, ...
+type OnConflictClause struct {
+	Columns []lex.Token    // Колонки ключа, по которому ищется конфликт, пустой список - любой ключ (только DO NOTHING)
+	Action  ConflictAction // Действие при конфликте
+	Updates []*Assignment  // Присваивания DO UPDATE SET. Значения вставляемой строки доступны как EXCLUDED.column
+}
+
+// Assignment присваивание column = expr
+type Assignment struct {
+	Column lex.Token   // Изменяемая колонка
+	Value  *Expression // Новое значение
 }
 
 type SelectStatement struct {
-	Table           lex.Token     // Имя таблицы
-	SelectedColumns []*Expression // Выбранные колонки
+	With            *WithClause      // Общие табличные выражения (WITH), nil если не указаны
+	Table           lex.Token        // Имя первой таблицы в FROM
+	Subquery        *SelectStatement // Подзапрос вместо первой таблицы (FROM (SELECT ...) alias), тогда Table пустой
+	Alias           *lex.Token       // Псевдоним первой таблицы, nil если не указан
+	Joins           []*JoinClause    // Присоединяемые таблицы (JOIN), пустой список если их нет
+	Distinct        bool             // Повторяющиеся строки результата удаляются (SELECT DISTINCT)
+	SelectedColumns []*Expression    // Выбранные колонки
+	Where           *Expression      // Условие фильтрации (WHERE), nil если не указано
+	GroupBy         []*Expression    // Выражения группировки (GROUP BY), пустой список если не указаны
+	Having          *Expression      // Условие фильтрации групп (HAVING), nil если не указано
+	OrderBy         []*OrderByItem   // Сортировка (ORDER BY), пустой список если не указана
+	Limit           *lex.Token       // Максимальное количество строк (LIMIT), nil если не указано
+	Offset          *lex.Token       // Сколько строк пропустить (OFFSET), nil если не указано
+	SetOperation    *SetOperation    // Составной запрос (UNION, INTERSECT, EXCEPT), тогда остальные поля, кроме WITH, ORDER BY, LIMIT и OFFSET, пустые
+}
+
+// SetOperationKind тип операции над результатами запросов
+type SetOperationKind string
+
+const (
+	UnionOperation     SetOperationKind = "UNION"     // Строки обоих запросов
+	IntersectOperation SetOperationKind = "INTERSECT" // Строки, которые есть в результатах обоих запросов
+	ExceptOperation    SetOperationKind = "EXCEPT"    // Строки левого запроса, которых нет в правом
+)
+
+// SetOperation представляет составной запрос: Left UNION|INTERSECT|EXCEPT [ALL] Right.
+// Без ALL повторяющиеся строки результата удаляются
+type SetOperation struct {
+	Kind  SetOperationKind
+	All   bool             // Повторяющиеся строки не удаляются (ALL)
+	Left  *SelectStatement // Левый запрос, может быть составным
+	Right *SelectStatement // Правый запрос, может быть составным
+}
+
+// WithClause представляет общие табличные выражения: WITH [RECURSIVE] name [(columns)] AS (SELECT ...), ...
+type WithClause struct {
+	Recursive bool                     // WITH RECURSIVE - запрос может ссылаться на самого себя
+	Queries   []*CommonTableExpression // Именованные запросы в порядке объявления
+}
+
+// CommonTableExpression представляет именованный запрос WITH: name [(columns)] AS (SELECT ...).
+// Рекурсивный запрос - это UNION [ALL], левая часть которого нерекурсивная, а правая ссылается на name
+// и выполняется, пока добавляет строки
+type CommonTableExpression struct {
+	Name    lex.Token        // Имя, по которому на запрос ссылаются в FROM
+	Columns []lex.Token      // Имена колонок результата, пустой список - имена колонок запроса
+	Select  *SelectStatement // Запрос
+}
+
+// JoinKind тип соединения таблиц
+type JoinKind string
+
+const (
+	InnerJoin JoinKind = "INNER" // [INNER] JOIN - только совпавшие пары строк
+	LeftJoin  JoinKind = "LEFT"  // LEFT [OUTER] JOIN - плюс строки левой таблицы без пары
+	RightJoin JoinKind = "RIGHT" // RIGHT [OUTER] JOIN - плюс строки правой таблицы без пары
+	FullJoin  JoinKind = "FULL"  // FULL [OUTER] JOIN - плюс строки обеих таблиц без пары
+	CrossJoin JoinKind = "CROSS" // CROSS JOIN или FROM a, b - все пары строк
+)
+
+// JoinClause представляет присоединение таблицы: [INNER|LEFT|RIGHT|FULL|CROSS] JOIN table [[AS] alias] [ON condition]
+type JoinClause struct {
+	Kind      JoinKind
+	Table     lex.Token        // Имя присоединяемой таблицы
+	Subquery  *SelectStatement // Подзапрос вместо таблицы (JOIN (SELECT ...) alias), тогда Table пустой
+	Alias     *lex.Token       // Псевдоним таблицы, nil если не указан
+	Condition *Expression      // Условие соединения (ON), nil для CROSS JOIN
+}
+
+// OrderByItem представляет один элемент ORDER BY: expression [ASC|DESC] [NULLS FIRST|LAST]
+type OrderByItem struct {
+	Expression *Expression // Выражение, по которому сортируются строки (или номер колонки SELECT)
+	Descending bool        // Сортировка по убыванию (DESC)
+	NullsFirst bool        // NULL идут первыми. По умолчанию NULL больше любого значения: NULLS LAST для ASC, NULLS FIRST для DESC
 }
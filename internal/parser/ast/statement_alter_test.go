@@ -0,0 +1,206 @@
+package ast
+
+import (
+	"custom-database/internal/parser/lex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAlterTableStatement(t *testing.T) {
+	t.Run("valid ALTER TABLE ADD COLUMN statement", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "alter"},
+			{Kind: lex.KeywordToken, Value: "table"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "add"},
+			{Kind: lex.KeywordToken, Value: "column"},
+			{Kind: lex.IdentifierToken, Value: "name"},
+			{Kind: lex.KeywordToken, Value: "varchar"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.NumericToken, Value: "20"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "default"},
+			{Kind: lex.StringToken, Value: "unknown"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseAlterTableStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(12), pointer)
+		require.Equal(t, "users", result.Table.Value)
+		require.Equal(t, AddColumnAction, result.Action)
+		require.Equal(t, "name", result.Added.Name.Value)
+		require.Equal(t, "varchar", result.Added.Datatype.Value)
+		require.Len(t, result.Added.Parameters, 1)
+		require.Equal(t, "unknown", result.Added.Default.Literal.Value)
+	})
+
+	t.Run("valid ALTER TABLE DROP statement without COLUMN", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "alter"},
+			{Kind: lex.KeywordToken, Value: "table"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "drop"},
+			{Kind: lex.IdentifierToken, Value: "name"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseAlterTableStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(5), pointer)
+		require.Equal(t, DropColumnAction, result.Action)
+		require.Equal(t, "name", result.Column.Value)
+	})
+
+	t.Run("valid ALTER TABLE RENAME COLUMN statement", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "alter"},
+			{Kind: lex.KeywordToken, Value: "table"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "rename"},
+			{Kind: lex.KeywordToken, Value: "column"},
+			{Kind: lex.IdentifierToken, Value: "name"},
+			{Kind: lex.KeywordToken, Value: "to"},
+			{Kind: lex.IdentifierToken, Value: "title"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseAlterTableStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(8), pointer)
+		require.Equal(t, RenameColumnAction, result.Action)
+		require.Equal(t, "name", result.Column.Value)
+		require.Equal(t, "title", result.NewName.Value)
+	})
+
+	t.Run("valid ALTER TABLE RENAME TO statement", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "alter"},
+			{Kind: lex.KeywordToken, Value: "table"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "rename"},
+			{Kind: lex.KeywordToken, Value: "to"},
+			{Kind: lex.IdentifierToken, Value: "members"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseAlterTableStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(6), pointer)
+		require.Equal(t, RenameTableAction, result.Action)
+		require.Equal(t, "members", result.NewName.Value)
+	})
+
+	t.Run("valid ALTER COLUMN SET NOT NULL and SET DEFAULT statements", func(t *testing.T) {
+		notNullTokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "alter"},
+			{Kind: lex.KeywordToken, Value: "table"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "alter"},
+			{Kind: lex.KeywordToken, Value: "column"},
+			{Kind: lex.IdentifierToken, Value: "name"},
+			{Kind: lex.KeywordToken, Value: "set"},
+			{Kind: lex.KeywordToken, Value: "not"},
+			{Kind: lex.NullToken, Value: "null"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+		defaultTokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "alter"},
+			{Kind: lex.KeywordToken, Value: "table"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "alter"},
+			{Kind: lex.IdentifierToken, Value: "level"},
+			{Kind: lex.KeywordToken, Value: "set"},
+			{Kind: lex.KeywordToken, Value: "default"},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.MathOperatorToken, Value: "+"},
+			{Kind: lex.NumericToken, Value: "2"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		notNull, notNullPointer, notNullOk := parseAlterTableStatement(notNullTokens, 0)
+		setDefault, defaultPointer, defaultOk := parseAlterTableStatement(defaultTokens, 0)
+
+		require.True(t, notNullOk)
+		require.Equal(t, uint(9), notNullPointer)
+		require.Equal(t, SetNotNullAction, notNull.Action)
+		require.Equal(t, "name", notNull.Column.Value)
+
+		require.True(t, defaultOk)
+		require.Equal(t, uint(10), defaultPointer)
+		require.Equal(t, SetDefaultAction, setDefault.Action)
+		require.Equal(t, "level", setDefault.Column.Value)
+		require.Equal(t, BinaryKind, setDefault.Default.Kind)
+	})
+
+	t.Run("valid ALTER COLUMN DROP NOT NULL and DROP DEFAULT statements", func(t *testing.T) {
+		notNullTokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "alter"},
+			{Kind: lex.KeywordToken, Value: "table"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "alter"},
+			{Kind: lex.IdentifierToken, Value: "name"},
+			{Kind: lex.KeywordToken, Value: "drop"},
+			{Kind: lex.KeywordToken, Value: "not"},
+			{Kind: lex.NullToken, Value: "null"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+		defaultTokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "alter"},
+			{Kind: lex.KeywordToken, Value: "table"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "alter"},
+			{Kind: lex.IdentifierToken, Value: "level"},
+			{Kind: lex.KeywordToken, Value: "drop"},
+			{Kind: lex.KeywordToken, Value: "default"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		notNull, _, notNullOk := parseAlterTableStatement(notNullTokens, 0)
+		dropDefault, defaultPointer, defaultOk := parseAlterTableStatement(defaultTokens, 0)
+
+		require.True(t, notNullOk)
+		require.Equal(t, DropNotNullAction, notNull.Action)
+		require.True(t, defaultOk)
+		require.Equal(t, uint(7), defaultPointer)
+		require.Equal(t, DropDefaultAction, dropDefault.Action)
+	})
+
+	t.Run("invalid ALTER TABLE statement - missing action", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "alter"},
+			{Kind: lex.KeywordToken, Value: "table"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseAlterTableStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+		require.Nil(t, result)
+	})
+
+	t.Run("invalid ALTER TABLE statement - RENAME COLUMN without TO", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "alter"},
+			{Kind: lex.KeywordToken, Value: "table"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "rename"},
+			{Kind: lex.IdentifierToken, Value: "name"},
+			{Kind: lex.IdentifierToken, Value: "title"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseAlterTableStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+		require.Nil(t, result)
+	})
+}
@@ -45,6 +45,67 @@ func TestParseCreateTableStatement(t *testing.T) {
 		require.Equal(t, "timestamp", (*result.Columns)[3].Datatype.Value)
 	})
 
+	t.Run("valid CREATE TABLE statement with PRIMARY KEY", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "create"},
+			{Kind: lex.KeywordToken, Value: "table"},
+			{Kind: lex.IdentifierToken, Value: "pairs"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.IdentifierToken, Value: "a"},
+			{Kind: lex.KeywordToken, Value: "int"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "b"},
+			{Kind: lex.KeywordToken, Value: "int"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.KeywordToken, Value: "primary"},
+			{Kind: lex.IdentifierToken, Value: "key"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.IdentifierToken, Value: "a"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "b"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseCreateTableStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(18), pointer)
+		require.Len(t, *result.Columns, 2)
+		require.False(t, (*result.Columns)[0].PrimaryKey)
+		require.Len(t, result.PrimaryKey, 2)
+		require.Equal(t, "a", result.PrimaryKey[0].Value)
+		require.Equal(t, "b", result.PrimaryKey[1].Value)
+	})
+
+	t.Run("valid CREATE TABLE statement with column PRIMARY KEY", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "create"},
+			{Kind: lex.KeywordToken, Value: "table"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "int"},
+			{Kind: lex.KeywordToken, Value: "primary"},
+			{Kind: lex.IdentifierToken, Value: "key"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "name"},
+			{Kind: lex.KeywordToken, Value: "text"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseCreateTableStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(12), pointer)
+		require.Len(t, *result.Columns, 2)
+		require.True(t, (*result.Columns)[0].PrimaryKey)
+		require.False(t, (*result.Columns)[1].PrimaryKey)
+		require.Empty(t, result.PrimaryKey)
+	})
+
 	t.Run("invalid CREATE statement - missing TABLE keyword", func(t *testing.T) {
 		tokens := []*lex.Token{
 			{Kind: lex.KeywordToken, Value: "create"},
@@ -138,4 +199,94 @@ func TestParseColumnDefinitions(t *testing.T) {
 		require.Equal(t, uint(0), pointer)
 		require.Nil(t, cols)
 	})
+
+	t.Run("valid column definitions with type parameters", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.IdentifierToken, Value: "price"},
+			{Kind: lex.KeywordToken, Value: "decimal"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.NumericToken, Value: "10"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.NumericToken, Value: "2"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "amount"},
+			{Kind: lex.KeywordToken, Value: "numeric"},
+			{Kind: lex.SymbolToken, Value: ")"},
+		}
+		endDelimiter := lex.Token{Kind: lex.SymbolToken, Value: ")"}
+
+		cols, pointer, ok := parseColumnDefinitions(tokens, 0, endDelimiter)
+
+		require.True(t, ok)
+		require.Equal(t, uint(10), pointer)
+		require.Len(t, *cols, 2)
+		require.Equal(t, "decimal", (*cols)[0].Datatype.Value)
+		require.Len(t, (*cols)[0].Parameters, 2)
+		require.Equal(t, "10", (*cols)[0].Parameters[0].Value)
+		require.Equal(t, "2", (*cols)[0].Parameters[1].Value)
+		require.Len(t, (*cols)[1].Parameters, 0)
+	})
+
+	t.Run("valid column definitions with NOT NULL and DEFAULT", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.IdentifierToken, Value: "level"},
+			{Kind: lex.KeywordToken, Value: "int"},
+			{Kind: lex.KeywordToken, Value: "default"},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.KeywordToken, Value: "not"},
+			{Kind: lex.NullToken, Value: "null"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "name"},
+			{Kind: lex.KeywordToken, Value: "text"},
+			{Kind: lex.KeywordToken, Value: "not"},
+			{Kind: lex.NullToken, Value: "null"},
+			{Kind: lex.SymbolToken, Value: ")"},
+		}
+		endDelimiter := lex.Token{Kind: lex.SymbolToken, Value: ")"}
+
+		cols, pointer, ok := parseColumnDefinitions(tokens, 0, endDelimiter)
+
+		require.True(t, ok)
+		require.Equal(t, uint(11), pointer)
+		require.Len(t, *cols, 2)
+		require.True(t, (*cols)[0].NotNull)
+		require.NotNil(t, (*cols)[0].Default)
+		require.Equal(t, "1", (*cols)[0].Default.Literal.Value)
+		require.True(t, (*cols)[1].NotNull)
+		require.Nil(t, (*cols)[1].Default)
+	})
+
+	t.Run("invalid column definition - DEFAULT without value", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.IdentifierToken, Value: "level"},
+			{Kind: lex.KeywordToken, Value: "int"},
+			{Kind: lex.KeywordToken, Value: "default"},
+			{Kind: lex.SymbolToken, Value: ")"},
+		}
+		endDelimiter := lex.Token{Kind: lex.SymbolToken, Value: ")"}
+
+		cols, pointer, ok := parseColumnDefinitions(tokens, 0, endDelimiter)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+		require.Nil(t, cols)
+	})
+
+	t.Run("invalid column definition - unclosed type parameters", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.IdentifierToken, Value: "price"},
+			{Kind: lex.KeywordToken, Value: "decimal"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.NumericToken, Value: "10"},
+			{Kind: lex.NumericToken, Value: "2"},
+		}
+		endDelimiter := lex.Token{Kind: lex.SymbolToken, Value: ")"}
+
+		cols, pointer, ok := parseColumnDefinitions(tokens, 0, endDelimiter)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+		require.Nil(t, cols)
+	})
 }
@@ -29,10 +29,229 @@ func TestParseInsertStatement(t *testing.T) {
 		require.True(t, ok)
 		require.Equal(t, uint(11), pointer)
 		require.Equal(t, "users", result.Table.Value)
-		require.Len(t, *result.Values, 3)
-		require.Equal(t, "1", (*result.Values)[0].Literal.Value)
-		require.Equal(t, "John", (*result.Values)[1].Literal.Value)
-		require.Equal(t, "null", (*result.Values)[2].Literal.Value)
+		require.Len(t, result.Values[0], 3)
+		require.Equal(t, "1", result.Values[0][0].Literal.Value)
+		require.Equal(t, "John", result.Values[0][1].Literal.Value)
+		require.Equal(t, "null", result.Values[0][2].Literal.Value)
+	})
+
+	t.Run("valid INSERT statement with column list and several rows", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "insert"},
+			{Kind: lex.KeywordToken, Value: "into"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.IdentifierToken, Value: "name"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "values"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.StringToken, Value: "John"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.StringToken, Value: "Ann"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.NumericToken, Value: "2"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseInsertStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(20), pointer)
+		require.Len(t, result.Columns, 2)
+		require.Equal(t, "name", result.Columns[0].Value)
+		require.Equal(t, "id", result.Columns[1].Value)
+		require.Len(t, result.Values, 2)
+		require.Equal(t, "John", result.Values[0][0].Literal.Value)
+		require.Equal(t, "2", result.Values[1][1].Literal.Value)
+		require.Nil(t, result.Select)
+	})
+
+	t.Run("valid INSERT ... SELECT statement", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "insert"},
+			{Kind: lex.KeywordToken, Value: "into"},
+			{Kind: lex.IdentifierToken, Value: "archive"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseInsertStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(10), pointer)
+		require.Equal(t, "archive", result.Table.Value)
+		require.Len(t, result.Columns, 1)
+		require.Empty(t, result.Values)
+		require.NotNil(t, result.Select)
+		require.Equal(t, "users", result.Select.Table.Value)
+	})
+
+	t.Run("valid INSERT statement with ON CONFLICT DO NOTHING", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "insert"},
+			{Kind: lex.KeywordToken, Value: "into"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "values"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "on"},
+			{Kind: lex.KeywordToken, Value: "conflict"},
+			{Kind: lex.KeywordToken, Value: "do"},
+			{Kind: lex.IdentifierToken, Value: "nothing"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseInsertStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(11), pointer)
+		require.NotNil(t, result.OnConflict)
+		require.Equal(t, DoNothingAction, result.OnConflict.Action)
+		require.Empty(t, result.OnConflict.Columns)
+		require.Empty(t, result.OnConflict.Updates)
+	})
+
+	t.Run("valid INSERT statement with ON CONFLICT DO UPDATE", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "insert"},
+			{Kind: lex.KeywordToken, Value: "into"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "values"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.StringToken, Value: "John"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "on"},
+			{Kind: lex.KeywordToken, Value: "conflict"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "do"},
+			{Kind: lex.KeywordToken, Value: "update"},
+			{Kind: lex.KeywordToken, Value: "set"},
+			{Kind: lex.IdentifierToken, Value: "name"},
+			{Kind: lex.MathOperatorToken, Value: "="},
+			{Kind: lex.IdentifierToken, Value: "excluded.name"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseInsertStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(20), pointer)
+		require.NotNil(t, result.OnConflict)
+		require.Equal(t, DoUpdateAction, result.OnConflict.Action)
+		require.Len(t, result.OnConflict.Columns, 1)
+		require.Equal(t, "id", result.OnConflict.Columns[0].Value)
+		require.Len(t, result.OnConflict.Updates, 1)
+		require.Equal(t, "name", result.OnConflict.Updates[0].Column.Value)
+		require.Equal(t, "excluded.name", result.OnConflict.Updates[0].Value.Literal.Value)
+	})
+
+	t.Run("valid INSERT ... SELECT statement with RETURNING", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "insert"},
+			{Kind: lex.KeywordToken, Value: "into"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.KeywordToken, Value: "returning"},
+			{Kind: lex.SymbolToken, Value: "*"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "as"},
+			{Kind: lex.IdentifierToken, Value: "key"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseInsertStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(11), pointer)
+		require.NotNil(t, result.Select)
+		require.Len(t, result.Select.SelectedColumns, 1)
+		require.Len(t, result.Returning, 2)
+		require.Equal(t, StarKind, result.Returning[0].Kind)
+		require.Equal(t, "id", result.Returning[1].Literal.Value)
+		require.Equal(t, "key", result.Returning[1].Alias.Value)
+	})
+
+	t.Run("invalid INSERT statement - empty RETURNING list", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "insert"},
+			{Kind: lex.KeywordToken, Value: "into"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "values"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "returning"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseInsertStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+		require.Nil(t, result)
+	})
+
+	t.Run("invalid INSERT statement - ON CONFLICT without action", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "insert"},
+			{Kind: lex.KeywordToken, Value: "into"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "values"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "on"},
+			{Kind: lex.KeywordToken, Value: "conflict"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseInsertStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+		require.Nil(t, result)
+	})
+
+	t.Run("invalid INSERT statement - missing comma between rows", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "insert"},
+			{Kind: lex.KeywordToken, Value: "into"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "values"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.NumericToken, Value: "2"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseInsertStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+		require.Nil(t, result)
 	})
 
 	t.Run("invalid INSERT statement - missing INTO keyword", func(t *testing.T) {
@@ -111,4 +111,900 @@ func TestParseSelectStatement(t *testing.T) {
 		require.Equal(t, uint(0), pointer)
 		require.Nil(t, result)
 	})
+
+	t.Run("valid SELECT statement with position IN syntax", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "position"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.StringToken, Value: "a"},
+			{Kind: lex.MathOperatorToken, Value: "||"},
+			{Kind: lex.StringToken, Value: "b"},
+			{Kind: lex.KeywordToken, Value: "in"},
+			{Kind: lex.IdentifierToken, Value: "name"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(11), pointer)
+		require.Len(t, result.SelectedColumns, 1)
+		call := result.SelectedColumns[0]
+		require.Equal(t, FunctionCallKind, call.Kind)
+		require.Equal(t, "position", call.FunctionCall.Name.Value)
+		// IN внутри position разделяет аргументы, а не начинает предикат
+		require.Len(t, call.FunctionCall.Arguments, 2)
+		require.Equal(t, BinaryKind, call.FunctionCall.Arguments[0].Kind)
+		require.Equal(t, "name", call.FunctionCall.Arguments[1].Literal.Value)
+	})
+
+	t.Run("valid SELECT statement with WHERE and temporal expressions", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "extract"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.IdentifierToken, Value: "year"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "created"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "where"},
+			{Kind: lex.IdentifierToken, Value: "created"},
+			{Kind: lex.MathOperatorToken, Value: ">="},
+			{Kind: lex.IdentifierToken, Value: "date"},
+			{Kind: lex.StringToken, Value: "2024-01-31"},
+			{Kind: lex.MathOperatorToken, Value: "-"},
+			{Kind: lex.IdentifierToken, Value: "interval"},
+			{Kind: lex.StringToken, Value: "1 day"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(17), pointer)
+		require.Len(t, result.SelectedColumns, 1)
+		require.Equal(t, FunctionCallKind, result.SelectedColumns[0].Kind)
+		require.Equal(t, "extract", result.SelectedColumns[0].FunctionCall.Name.Value)
+		require.Len(t, result.SelectedColumns[0].FunctionCall.Arguments, 2)
+		require.Equal(t, "year", result.SelectedColumns[0].FunctionCall.Arguments[0].Literal.Value)
+
+		require.NotNil(t, result.Where)
+		require.Equal(t, BinaryKind, result.Where.Kind)
+		require.Equal(t, ">=", result.Where.Binary.Operator.Value)
+		require.Equal(t, "created", result.Where.Binary.A.Literal.Value)
+
+		// Арифметика имеет больший приоритет, чем сравнение
+		right := result.Where.Binary.B
+		require.Equal(t, BinaryKind, right.Kind)
+		require.Equal(t, "-", right.Binary.Operator.Value)
+		require.Equal(t, TypedLiteralKind, right.Binary.A.Kind)
+		require.Equal(t, "date", right.Binary.A.DataType.Value)
+		require.Equal(t, "2024-01-31", right.Binary.A.Literal.Value)
+		require.Equal(t, "interval", right.Binary.B.DataType.Value)
+	})
+
+	t.Run("valid SELECT statement with ORDER BY", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "order"},
+			{Kind: lex.KeywordToken, Value: "by"},
+			{Kind: lex.IdentifierToken, Value: "name"},
+			{Kind: lex.KeywordToken, Value: "desc"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "age"},
+			{Kind: lex.MathOperatorToken, Value: "+"},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.KeywordToken, Value: "nulls"},
+			{Kind: lex.IdentifierToken, Value: "first"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(16), pointer)
+		require.Len(t, result.OrderBy, 3)
+
+		require.Equal(t, "name", result.OrderBy[0].Expression.Literal.Value)
+		require.True(t, result.OrderBy[0].Descending)
+		require.True(t, result.OrderBy[0].NullsFirst)
+
+		require.Equal(t, BinaryKind, result.OrderBy[1].Expression.Kind)
+		require.False(t, result.OrderBy[1].Descending)
+		require.True(t, result.OrderBy[1].NullsFirst)
+
+		require.Equal(t, "1", result.OrderBy[2].Expression.Literal.Value)
+		require.False(t, result.OrderBy[2].Descending)
+		require.False(t, result.OrderBy[2].NullsFirst)
+	})
+
+	t.Run("invalid SELECT statement - NULLS without FIRST or LAST", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "order"},
+			{Kind: lex.KeywordToken, Value: "by"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "nulls"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+		require.Nil(t, result)
+	})
+
+	t.Run("valid SELECT statement with LIMIT and OFFSET", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "order"},
+			{Kind: lex.KeywordToken, Value: "by"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "limit"},
+			{Kind: lex.NumericToken, Value: "10"},
+			{Kind: lex.KeywordToken, Value: "offset"},
+			{Kind: lex.NumericToken, Value: "20"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(11), pointer)
+		require.Len(t, result.OrderBy, 1)
+		require.Equal(t, "10", result.Limit.Value)
+		require.Equal(t, "20", result.Offset.Value)
+	})
+
+	t.Run("invalid SELECT statement - LIMIT without number", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "limit"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+		require.Nil(t, result)
+	})
+
+	t.Run("valid SELECT statement with aggregates, GROUP BY and HAVING", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "region"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "count"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.SymbolToken, Value: "*"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "count"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.KeywordToken, Value: "distinct"},
+			{Kind: lex.IdentifierToken, Value: "qty"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "sales"},
+			{Kind: lex.KeywordToken, Value: "group"},
+			{Kind: lex.KeywordToken, Value: "by"},
+			{Kind: lex.IdentifierToken, Value: "region"},
+			{Kind: lex.KeywordToken, Value: "having"},
+			{Kind: lex.IdentifierToken, Value: "sum"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.IdentifierToken, Value: "amount"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.MathOperatorToken, Value: ">"},
+			{Kind: lex.NumericToken, Value: "10"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(25), pointer)
+		require.Len(t, result.SelectedColumns, 3)
+
+		countAll := result.SelectedColumns[1]
+		require.Equal(t, AggregateKind, countAll.Kind)
+		require.True(t, countAll.Aggregate.Star)
+		require.Empty(t, countAll.Aggregate.Arguments)
+
+		countDistinct := result.SelectedColumns[2]
+		require.Equal(t, AggregateKind, countDistinct.Kind)
+		require.True(t, countDistinct.Aggregate.Distinct)
+		require.Equal(t, "qty", countDistinct.Aggregate.Arguments[0].Literal.Value)
+
+		require.Len(t, result.GroupBy, 1)
+		require.Equal(t, "region", result.GroupBy[0].Literal.Value)
+		require.Equal(t, BinaryKind, result.Having.Kind)
+		require.Equal(t, AggregateKind, result.Having.Binary.A.Kind)
+		require.True(t, result.Having.ContainsAggregate())
+		require.False(t, result.GroupBy[0].ContainsAggregate())
+	})
+
+	t.Run("valid SELECT statement with JOINs and aliases", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "u.name"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "p.title"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.IdentifierToken, Value: "u"},
+			{Kind: lex.KeywordToken, Value: "left"},
+			{Kind: lex.KeywordToken, Value: "outer"},
+			{Kind: lex.KeywordToken, Value: "join"},
+			{Kind: lex.IdentifierToken, Value: "posts"},
+			{Kind: lex.KeywordToken, Value: "as"},
+			{Kind: lex.IdentifierToken, Value: "p"},
+			{Kind: lex.KeywordToken, Value: "on"},
+			{Kind: lex.IdentifierToken, Value: "u.id"},
+			{Kind: lex.MathOperatorToken, Value: "="},
+			{Kind: lex.IdentifierToken, Value: "p.user_id"},
+			{Kind: lex.KeywordToken, Value: "cross"},
+			{Kind: lex.KeywordToken, Value: "join"},
+			{Kind: lex.IdentifierToken, Value: "tags"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "labels"},
+			{Kind: lex.KeywordToken, Value: "where"},
+			{Kind: lex.IdentifierToken, Value: "u.id"},
+			{Kind: lex.MathOperatorToken, Value: ">"},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(26), pointer)
+		require.Equal(t, "users", result.Table.Value)
+		require.Equal(t, "u", result.Alias.Value)
+		require.Len(t, result.Joins, 3)
+
+		require.Equal(t, LeftJoin, result.Joins[0].Kind)
+		require.Equal(t, "posts", result.Joins[0].Table.Value)
+		require.Equal(t, "p", result.Joins[0].Alias.Value)
+		require.Equal(t, "u.id", result.Joins[0].Condition.Binary.A.Literal.Value)
+		require.Equal(t, "p.user_id", result.Joins[0].Condition.Binary.B.Literal.Value)
+
+		require.Equal(t, CrossJoin, result.Joins[1].Kind)
+		require.Equal(t, "tags", result.Joins[1].Table.Value)
+		require.Nil(t, result.Joins[1].Alias)
+		require.Nil(t, result.Joins[1].Condition)
+
+		require.Equal(t, CrossJoin, result.Joins[2].Kind)
+		require.Equal(t, "labels", result.Joins[2].Table.Value)
+		require.NotNil(t, result.Where)
+	})
+
+	t.Run("invalid SELECT statement - JOIN without ON", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.SymbolToken, Value: "*"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "inner"},
+			{Kind: lex.KeywordToken, Value: "join"},
+			{Kind: lex.IdentifierToken, Value: "posts"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		_, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+	})
+
+	t.Run("valid SELECT statement with derived table and NOT IN subquery", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "name"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.SymbolToken, Value: "*"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.IdentifierToken, Value: "u"},
+			{Kind: lex.KeywordToken, Value: "where"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "not"},
+			{Kind: lex.KeywordToken, Value: "in"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "user_id"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "posts"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(20), pointer)
+		require.Empty(t, result.Table.Value)
+		require.Equal(t, "users", result.Subquery.Table.Value)
+		require.Equal(t, "u", result.Alias.Value)
+
+		require.Equal(t, InKind, result.Where.Kind)
+		require.True(t, result.Where.Subquery.Negated)
+		require.Equal(t, "id", result.Where.Subquery.Operand.Literal.Value)
+		require.Equal(t, "posts", result.Where.Subquery.Select.Table.Value)
+	})
+
+	t.Run("valid SELECT statement with scalar and EXISTS subqueries", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "count"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.SymbolToken, Value: "*"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "posts"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.IdentifierToken, Value: "u"},
+			{Kind: lex.KeywordToken, Value: "where"},
+			{Kind: lex.KeywordToken, Value: "not"},
+			{Kind: lex.KeywordToken, Value: "exists"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.SymbolToken, Value: "*"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "posts"},
+			{Kind: lex.IdentifierToken, Value: "p"},
+			{Kind: lex.KeywordToken, Value: "where"},
+			{Kind: lex.IdentifierToken, Value: "p.user_id"},
+			{Kind: lex.MathOperatorToken, Value: "="},
+			{Kind: lex.IdentifierToken, Value: "u.id"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(27), pointer)
+		require.Len(t, result.SelectedColumns, 1)
+		require.Equal(t, SubqueryKind, result.SelectedColumns[0].Kind)
+		require.Equal(t, AggregateKind, result.SelectedColumns[0].Subquery.Select.SelectedColumns[0].Kind)
+
+		require.Equal(t, ExistsKind, result.Where.Kind)
+		require.True(t, result.Where.Subquery.Negated)
+		require.Equal(t, "p", result.Where.Subquery.Select.Alias.Value)
+		require.Equal(t, "u.id", result.Where.Subquery.Select.Where.Binary.B.Literal.Value)
+	})
+
+	t.Run("invalid SELECT statement - subquery without right parenthesis", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.SymbolToken, Value: "*"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "where"},
+			{Kind: lex.KeywordToken, Value: "exists"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.SymbolToken, Value: "*"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "posts"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		_, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+	})
+	t.Run("valid SELECT statement with set operations - INTERSECT binds tighter", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "a"},
+			{Kind: lex.KeywordToken, Value: "union"},
+			{Kind: lex.KeywordToken, Value: "all"},
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "b"},
+			{Kind: lex.KeywordToken, Value: "intersect"},
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "c"},
+			{Kind: lex.KeywordToken, Value: "except"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "d"},
+			{Kind: lex.KeywordToken, Value: "limit"},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "order"},
+			{Kind: lex.KeywordToken, Value: "by"},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(27), pointer)
+		require.Len(t, result.OrderBy, 1)
+
+		// (a UNION ALL (b INTERSECT c)) EXCEPT (d LIMIT 1)
+		except := result.SetOperation
+		require.Equal(t, ExceptOperation, except.Kind)
+		require.False(t, except.All)
+		require.Equal(t, "d", except.Right.Table.Value)
+		require.Equal(t, "1", except.Right.Limit.Value)
+
+		union := except.Left.SetOperation
+		require.Equal(t, UnionOperation, union.Kind)
+		require.True(t, union.All)
+		require.Equal(t, "a", union.Left.Table.Value)
+
+		intersect := union.Right.SetOperation
+		require.Equal(t, IntersectOperation, intersect.Kind)
+		require.Equal(t, "b", intersect.Left.Table.Value)
+		require.Equal(t, "c", intersect.Right.Table.Value)
+		require.Equal(t, "a", result.FirstQuery().Table.Value)
+	})
+
+	t.Run("invalid SELECT statement - ORDER BY before UNION", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "a"},
+			{Kind: lex.KeywordToken, Value: "order"},
+			{Kind: lex.KeywordToken, Value: "by"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "union"},
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "b"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		_, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+	})
+
+	t.Run("valid SELECT statement with window functions", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "sum"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.IdentifierToken, Value: "salary"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "over"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.KeywordToken, Value: "partition"},
+			{Kind: lex.KeywordToken, Value: "by"},
+			{Kind: lex.IdentifierToken, Value: "dept"},
+			{Kind: lex.KeywordToken, Value: "order"},
+			{Kind: lex.KeywordToken, Value: "by"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "desc"},
+			{Kind: lex.KeywordToken, Value: "rows"},
+			{Kind: lex.KeywordToken, Value: "between"},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.KeywordToken, Value: "preceding"},
+			{Kind: lex.KeywordToken, Value: "and"},
+			{Kind: lex.KeywordToken, Value: "current"},
+			{Kind: lex.KeywordToken, Value: "row"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "row_number"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "over"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "emp"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(31), pointer)
+		require.Len(t, result.SelectedColumns, 2)
+
+		sum := result.SelectedColumns[0]
+		require.Equal(t, WindowKind, sum.Kind)
+		require.Equal(t, "sum", sum.Window.Name.Value)
+		require.Len(t, sum.Window.Arguments, 1)
+		require.Len(t, sum.Window.PartitionBy, 1)
+		require.Equal(t, "dept", sum.Window.PartitionBy[0].Literal.Value)
+		require.Len(t, sum.Window.OrderBy, 1)
+		require.True(t, sum.Window.OrderBy[0].Descending)
+		require.Equal(t, OffsetPreceding, sum.Window.Frame.Start.Kind)
+		require.Equal(t, "1", sum.Window.Frame.Start.Offset.Value)
+		require.Equal(t, CurrentRow, sum.Window.Frame.End.Kind)
+
+		rowNumber := result.SelectedColumns[1]
+		require.Equal(t, WindowKind, rowNumber.Kind)
+		require.Empty(t, rowNumber.Window.Arguments)
+		require.Empty(t, rowNumber.Window.PartitionBy)
+		require.Nil(t, rowNumber.Window.Frame)
+		require.False(t, rowNumber.Window.SameWindow(sum.Window))
+	})
+
+	t.Run("invalid SELECT statement - OVER without parentheses", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "rank"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "over"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "emp"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		_, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+	})
+
+	t.Run("valid SELECT statement with DISTINCT, table star and alias", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.KeywordToken, Value: "distinct"},
+			{Kind: lex.IdentifierToken, Value: "u.*"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.NumericToken, Value: "7"},
+			{Kind: lex.MathOperatorToken, Value: "-"},
+			{Kind: lex.NumericToken, Value: "2"},
+			{Kind: lex.SymbolToken, Value: "*"},
+			{Kind: lex.NumericToken, Value: "3"},
+			{Kind: lex.KeywordToken, Value: "as"},
+			{Kind: lex.IdentifierToken, Value: "x"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.IdentifierToken, Value: "u"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(14), pointer)
+		require.True(t, result.Distinct)
+		require.Len(t, result.SelectedColumns, 2)
+
+		star := result.SelectedColumns[0]
+		require.Equal(t, StarKind, star.Kind)
+		require.Equal(t, "u", star.Literal.Value)
+
+		// Умножение связывает сильнее вычитания: 7 - (2 * 3)
+		difference := result.SelectedColumns[1]
+		require.Equal(t, BinaryKind, difference.Kind)
+		require.Equal(t, "-", difference.Binary.Operator.Value)
+		require.Equal(t, "7", difference.Binary.A.Literal.Value)
+		require.Equal(t, BinaryKind, difference.Binary.B.Kind)
+		require.Equal(t, lex.Token{Kind: lex.MathOperatorToken, Value: "*"}, difference.Binary.B.Binary.Operator)
+		require.Equal(t, "x", difference.Alias.Value)
+	})
+
+	t.Run("invalid SELECT statement - alias without name", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "as"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		_, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+	})
+
+	t.Run("valid SELECT statement with LIKE, IN list, BETWEEN and IS NULL", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "name"},
+			{Kind: lex.KeywordToken, Value: "not"},
+			{Kind: lex.KeywordToken, Value: "ilike"},
+			{Kind: lex.StringToken, Value: "a%"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "in"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.NumericToken, Value: "2"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "between"},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.KeywordToken, Value: "and"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.MathOperatorToken, Value: "+"},
+			{Kind: lex.NumericToken, Value: "2"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "name"},
+			{Kind: lex.KeywordToken, Value: "is"},
+			{Kind: lex.KeywordToken, Value: "not"},
+			{Kind: lex.NullToken, Value: "null"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(28), pointer)
+		require.Len(t, result.SelectedColumns, 4)
+
+		like := result.SelectedColumns[0]
+		require.Equal(t, LikeKind, like.Kind)
+		require.True(t, like.Predicate.Negated)
+		require.True(t, like.Predicate.CaseInsensitive)
+		require.Equal(t, "a%", like.Predicate.Arguments[0].Literal.Value)
+
+		in := result.SelectedColumns[1]
+		require.Equal(t, InListKind, in.Kind)
+		require.False(t, in.Predicate.Negated)
+		require.Len(t, in.Predicate.Arguments, 2)
+
+		// Граница BETWEEN - арифметическое выражение, AND к нему не относится
+		between := result.SelectedColumns[2]
+		require.Equal(t, BetweenKind, between.Kind)
+		require.Equal(t, "1", between.Predicate.Arguments[0].Literal.Value)
+		require.Equal(t, BinaryKind, between.Predicate.Arguments[1].Kind)
+
+		isNull := result.SelectedColumns[3]
+		require.Equal(t, IsNullKind, isNull.Kind)
+		require.True(t, isNull.Predicate.Negated)
+		require.Empty(t, isNull.Predicate.Arguments)
+	})
+
+	t.Run("valid SELECT statement with AND, OR and NOT", func(t *testing.T) {
+		// WHERE NOT a = 1 OR b BETWEEN 1 AND 2 AND c > 3
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "a"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "t"},
+			{Kind: lex.KeywordToken, Value: "where"},
+			{Kind: lex.KeywordToken, Value: "not"},
+			{Kind: lex.IdentifierToken, Value: "a"},
+			{Kind: lex.MathOperatorToken, Value: "="},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.KeywordToken, Value: "or"},
+			{Kind: lex.IdentifierToken, Value: "b"},
+			{Kind: lex.KeywordToken, Value: "between"},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.KeywordToken, Value: "and"},
+			{Kind: lex.NumericToken, Value: "2"},
+			{Kind: lex.KeywordToken, Value: "and"},
+			{Kind: lex.IdentifierToken, Value: "c"},
+			{Kind: lex.MathOperatorToken, Value: ">"},
+			{Kind: lex.NumericToken, Value: "3"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(19), pointer)
+
+		// OR связывает слабее AND, NOT относится к сравнению целиком, AND внутри BETWEEN - граница диапазона
+		or := result.Where
+		require.Equal(t, LogicalKind, or.Kind)
+		require.Equal(t, lex.OrKeyword, or.Logical.Operator)
+		require.Len(t, or.Logical.Operands, 2)
+
+		not := or.Logical.Operands[0]
+		require.Equal(t, LogicalKind, not.Kind)
+		require.Equal(t, lex.NotKeyword, not.Logical.Operator)
+		require.Len(t, not.Logical.Operands, 1)
+		require.Equal(t, BinaryKind, not.Logical.Operands[0].Kind)
+
+		and := or.Logical.Operands[1]
+		require.Equal(t, LogicalKind, and.Kind)
+		require.Equal(t, lex.AndKeyword, and.Logical.Operator)
+		require.Equal(t, BetweenKind, and.Logical.Operands[0].Kind)
+		require.Equal(t, "2", and.Logical.Operands[0].Predicate.Arguments[1].Literal.Value)
+		require.Equal(t, BinaryKind, and.Logical.Operands[1].Kind)
+	})
+
+	t.Run("valid SELECT statement with unary minus", func(t *testing.T) {
+		// SELECT -(a + 1) * 2, -a FROM t
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.MathOperatorToken, Value: "-"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.IdentifierToken, Value: "a"},
+			{Kind: lex.MathOperatorToken, Value: "+"},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: "*"},
+			{Kind: lex.NumericToken, Value: "2"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.MathOperatorToken, Value: "-"},
+			{Kind: lex.IdentifierToken, Value: "a"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "t"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(14), pointer)
+		require.Len(t, result.SelectedColumns, 2)
+
+		// Унарный минус связывает сильнее умножения: (-1 * (a + 1)) * 2
+		product := result.SelectedColumns[0]
+		require.Equal(t, BinaryKind, product.Kind)
+		require.Equal(t, "*", product.Binary.Operator.Value)
+		require.Equal(t, "2", product.Binary.B.Literal.Value)
+
+		negated := product.Binary.A
+		require.Equal(t, BinaryKind, negated.Kind)
+		require.Equal(t, "*", negated.Binary.Operator.Value)
+		require.Equal(t, "-1", negated.Binary.A.Literal.Value)
+		require.Equal(t, "+", negated.Binary.B.Binary.Operator.Value)
+
+		column := result.SelectedColumns[1]
+		require.Equal(t, "-1", column.Binary.A.Literal.Value)
+		require.Equal(t, "a", column.Binary.B.Literal.Value)
+	})
+
+	t.Run("invalid SELECT statement - IS without NULL", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "is"},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		_, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+	})
+
+	t.Run("invalid SELECT statement - BETWEEN without AND", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "between"},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		_, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+	})
+
+	t.Run("valid SELECT statement with CASE and casts", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.KeywordToken, Value: "case"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "when"},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.KeywordToken, Value: "then"},
+			{Kind: lex.StringToken, Value: "one"},
+			{Kind: lex.KeywordToken, Value: "else"},
+			{Kind: lex.IdentifierToken, Value: "name"},
+			{Kind: lex.KeywordToken, Value: "end"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.KeywordToken, Value: "cast"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.IdentifierToken, Value: "price"},
+			{Kind: lex.KeywordToken, Value: "as"},
+			{Kind: lex.KeywordToken, Value: "decimal"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.NumericToken, Value: "5"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.NumericToken, Value: "2"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.MathOperatorToken, Value: "+"},
+			{Kind: lex.StringToken, Value: "1"},
+			{Kind: lex.SymbolToken, Value: "::"},
+			{Kind: lex.KeywordToken, Value: "int"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "items"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(30), pointer)
+		require.Len(t, result.SelectedColumns, 3)
+
+		caseExpression := result.SelectedColumns[0]
+		require.Equal(t, CaseKind, caseExpression.Kind)
+		require.Equal(t, "id", caseExpression.Case.Operand.Literal.Value)
+		require.Len(t, caseExpression.Case.Whens, 1)
+		require.Equal(t, "1", caseExpression.Case.Whens[0].Condition.Literal.Value)
+		require.Equal(t, "one", caseExpression.Case.Whens[0].Result.Literal.Value)
+		require.Equal(t, "name", caseExpression.Case.Else.Literal.Value)
+
+		cast := result.SelectedColumns[1]
+		require.Equal(t, CastKind, cast.Kind)
+		require.Equal(t, "decimal", cast.Cast.DataType.Value)
+		require.Len(t, cast.Cast.Parameters, 2)
+
+		// :: связывает сильнее сложения: id + ('1'::int)
+		sum := result.SelectedColumns[2]
+		require.Equal(t, BinaryKind, sum.Kind)
+		require.Equal(t, CastKind, sum.Binary.B.Kind)
+		require.Equal(t, "int", sum.Binary.B.Cast.DataType.Value)
+	})
+
+	t.Run("invalid SELECT statement - CASE without WHEN", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.KeywordToken, Value: "case"},
+			{Kind: lex.KeywordToken, Value: "else"},
+			{Kind: lex.NumericToken, Value: "1"},
+			{Kind: lex.KeywordToken, Value: "end"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "items"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		_, pointer, ok := parseSelectStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+	})
 }
@@ -0,0 +1,73 @@
+package ast
+
+import (
+	"custom-database/internal/parser/lex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTruncateTableStatement(t *testing.T) {
+	t.Run("valid TRUNCATE TABLE statement", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "truncate"},
+			{Kind: lex.KeywordToken, Value: "table"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseTruncateTableStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(3), pointer)
+		require.Equal(t, "users", result.Table.Value)
+		require.False(t, result.RestartIdentity)
+	})
+
+	t.Run("valid TRUNCATE statement without TABLE with RESTART IDENTITY", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "truncate"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "restart"},
+			{Kind: lex.KeywordToken, Value: "identity"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseTruncateTableStatement(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(4), pointer)
+		require.Equal(t, "users", result.Table.Value)
+		require.True(t, result.RestartIdentity)
+	})
+
+	t.Run("invalid TRUNCATE statement - RESTART without IDENTITY", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "truncate"},
+			{Kind: lex.KeywordToken, Value: "table"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "restart"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseTruncateTableStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+		require.Nil(t, result)
+	})
+
+	t.Run("invalid TRUNCATE statement - missing table name", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "truncate"},
+			{Kind: lex.KeywordToken, Value: "table"},
+			{Kind: lex.SymbolToken, Value: ";"},
+		}
+
+		result, pointer, ok := parseTruncateTableStatement(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+		require.Nil(t, result)
+	})
+}
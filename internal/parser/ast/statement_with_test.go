@@ -0,0 +1,81 @@
+package ast
+
+import (
+	"custom-database/internal/parser/lex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWithClause(t *testing.T) {
+
+	t.Run("valid WITH RECURSIVE clause with column list", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "with"},
+			{Kind: lex.KeywordToken, Value: "recursive"},
+			{Kind: lex.IdentifierToken, Value: "t"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.IdentifierToken, Value: "n"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "as"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "id"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.KeywordToken, Value: "union"},
+			{Kind: lex.KeywordToken, Value: "all"},
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.IdentifierToken, Value: "n"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "t"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.SymbolToken, Value: ","},
+			{Kind: lex.IdentifierToken, Value: "u"},
+			{Kind: lex.KeywordToken, Value: "as"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.SymbolToken, Value: "*"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "t"},
+			{Kind: lex.SymbolToken, Value: ")"},
+			{Kind: lex.KeywordToken, Value: "select"},
+		}
+
+		result, pointer, ok := parseWithClause(tokens, 0)
+
+		require.True(t, ok)
+		require.Equal(t, uint(28), pointer)
+		require.True(t, result.Recursive)
+		require.Len(t, result.Queries, 2)
+
+		require.Equal(t, "t", result.Queries[0].Name.Value)
+		require.Len(t, result.Queries[0].Columns, 1)
+		union := result.Queries[0].Select.SetOperation
+		require.Equal(t, UnionOperation, union.Kind)
+		require.True(t, union.All)
+		require.Equal(t, "users", union.Left.Table.Value)
+		require.Equal(t, "t", union.Right.Table.Value)
+
+		require.Equal(t, "u", result.Queries[1].Name.Value)
+		require.Nil(t, result.Queries[1].Select.SetOperation)
+	})
+
+	t.Run("invalid WITH clause - missing AS", func(t *testing.T) {
+		tokens := []*lex.Token{
+			{Kind: lex.KeywordToken, Value: "with"},
+			{Kind: lex.IdentifierToken, Value: "t"},
+			{Kind: lex.SymbolToken, Value: "("},
+			{Kind: lex.KeywordToken, Value: "select"},
+			{Kind: lex.SymbolToken, Value: "*"},
+			{Kind: lex.KeywordToken, Value: "from"},
+			{Kind: lex.IdentifierToken, Value: "users"},
+			{Kind: lex.SymbolToken, Value: ")"},
+		}
+
+		_, pointer, ok := parseWithClause(tokens, 0)
+
+		require.False(t, ok)
+		require.Equal(t, uint(0), pointer)
+	})
+}
@@ -0,0 +1,156 @@
+package ast
+
+import "custom-database/internal/parser/lex"
+
+// parseAlterTableStatement парсит ALTER TABLE statement:
+// ALTER TABLE table ADD [COLUMN] definition | DROP [COLUMN] column | RENAME [COLUMN] column TO name | RENAME TO name |
+// ALTER [COLUMN] column SET NOT NULL | DROP NOT NULL | SET DEFAULT expr | DROP DEFAULT
+func parseAlterTableStatement(tokens []*lex.Token, initialPointer uint) (*AlterTableStatement, uint, bool) {
+	pointer := initialPointer
+
+	// Ожидаем ключевые слова ALTER TABLE
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.AlterKeyword)) {
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.TableKeyword)) {
+		helpMessage(tokens, pointer, "Expected table")
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	// Парсим имя таблицы
+	tableName, newCursor, ok := parseToken(tokens, pointer, lex.IdentifierToken)
+	if !ok {
+		helpMessage(tokens, pointer, "Expected table name")
+		return nil, initialPointer, false
+	}
+	pointer = newCursor
+	stmt := &AlterTableStatement{Table: *tableName}
+
+	// Парсим действие
+	switch {
+	case expectToken(tokens, pointer, tokenFromKeyword(lex.AddKeyword)):
+		pointer = skipColumnKeyword(tokens, pointer+1)
+		added, newCursor, ok := parseColumnDefinition(tokens, pointer)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		stmt.Action = AddColumnAction
+		stmt.Added = added
+		pointer = newCursor
+
+	case expectToken(tokens, pointer, tokenFromKeyword(lex.DropKeyword)):
+		column, newCursor, ok := parseToken(tokens, skipColumnKeyword(tokens, pointer+1), lex.IdentifierToken)
+		if !ok {
+			helpMessage(tokens, pointer+1, "Expected column name")
+			return nil, initialPointer, false
+		}
+		stmt.Action = DropColumnAction
+		stmt.Column = *column
+		pointer = newCursor
+
+	case expectToken(tokens, pointer, tokenFromKeyword(lex.RenameKeyword)):
+		pointer++
+		stmt.Action = RenameTableAction
+		if !expectToken(tokens, pointer, tokenFromKeyword(lex.ToKeyword)) {
+			column, newCursor, ok := parseToken(tokens, skipColumnKeyword(tokens, pointer), lex.IdentifierToken)
+			if !ok {
+				helpMessage(tokens, pointer, "Expected column name or TO")
+				return nil, initialPointer, false
+			}
+			stmt.Action = RenameColumnAction
+			stmt.Column = *column
+			pointer = newCursor
+
+			if !expectToken(tokens, pointer, tokenFromKeyword(lex.ToKeyword)) {
+				helpMessage(tokens, pointer, "Expected TO")
+				return nil, initialPointer, false
+			}
+		}
+		pointer++
+
+		newName, newCursor, ok := parseToken(tokens, pointer, lex.IdentifierToken)
+		if !ok {
+			helpMessage(tokens, pointer, "Expected new name")
+			return nil, initialPointer, false
+		}
+		stmt.NewName = *newName
+		pointer = newCursor
+
+	case expectToken(tokens, pointer, tokenFromKeyword(lex.AlterKeyword)):
+		column, newCursor, ok := parseToken(tokens, skipColumnKeyword(tokens, pointer+1), lex.IdentifierToken)
+		if !ok {
+			helpMessage(tokens, pointer+1, "Expected column name")
+			return nil, initialPointer, false
+		}
+		stmt.Column = *column
+
+		newCursor, ok = parseAlterColumnAction(tokens, newCursor, stmt)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		pointer = newCursor
+
+	default:
+		helpMessage(tokens, pointer, "Expected ADD, DROP, RENAME or ALTER")
+		return nil, initialPointer, false
+	}
+
+	// Ожидаем точку с запятой
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.SemicolonSymbol)) {
+		helpMessage(tokens, pointer, "Expected semicolon")
+		return nil, initialPointer, false
+	}
+
+	return stmt, pointer, true
+}
+
+// parseAlterColumnAction парсит действие ALTER COLUMN: SET NOT NULL | DROP NOT NULL | SET DEFAULT expr | DROP DEFAULT
+func parseAlterColumnAction(tokens []*lex.Token, initialPointer uint, stmt *AlterTableStatement) (uint, bool) {
+	pointer := initialPointer
+
+	set := expectToken(tokens, pointer, tokenFromKeyword(lex.SetKeyword))
+	if !set && !expectToken(tokens, pointer, tokenFromKeyword(lex.DropKeyword)) {
+		helpMessage(tokens, pointer, "Expected SET or DROP")
+		return initialPointer, false
+	}
+	pointer++
+
+	switch {
+	case expectNotNull(tokens, pointer):
+		stmt.Action = DropNotNullAction
+		if set {
+			stmt.Action = SetNotNullAction
+		}
+		return pointer + 2, true
+
+	case expectToken(tokens, pointer, tokenFromKeyword(lex.DefaultKeyword)):
+		pointer++
+		if !set {
+			stmt.Action = DropDefaultAction
+			return pointer, true
+		}
+
+		value, newCursor, ok := parseExpression(tokens, pointer, tokenFromSymbol(lex.SemicolonSymbol))
+		if !ok {
+			helpMessage(tokens, pointer, "Expected default value")
+			return initialPointer, false
+		}
+		stmt.Action = SetDefaultAction
+		stmt.Default = value
+		return newCursor, true
+	}
+
+	helpMessage(tokens, pointer, "Expected NOT NULL or DEFAULT")
+	return initialPointer, false
+}
+
+// skipColumnKeyword пропускает необязательное ключевое слово COLUMN
+func skipColumnKeyword(tokens []*lex.Token, pointer uint) uint {
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.ColumnKeyword)) {
+		return pointer + 1
+	}
+	return pointer
+}
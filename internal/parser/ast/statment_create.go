@@ -18,6 +18,13 @@ func parseCreateTableStatement(tokens []*lex.Token, initialPointer uint) (*Creat
 	}
 	pointer++
 
+	// Парсим необязательное IF NOT EXISTS
+	ifNotExists, newCursor, ok := parseIfExists(tokens, pointer, true)
+	if !ok {
+		return nil, initialPointer, false
+	}
+	pointer = newCursor
+
 	// Парсим имя таблицы
 	tableName, newCursor, ok := parseToken(tokens, pointer, lex.IdentifierToken)
 	if !ok {
@@ -40,6 +47,21 @@ func parseCreateTableStatement(tokens []*lex.Token, initialPointer uint) (*Creat
 	}
 	pointer = newCursor
 
+	// После колонок может идти ограничение таблицы: PRIMARY KEY (column, ...)
+	var primaryKey []lex.Token
+	if expectToken(tokens, pointer, tokenFromSymbol(lex.CommaSymbol)) {
+		pointer++
+		if !expectPrimaryKey(tokens, pointer) {
+			helpMessage(tokens, pointer, "Expected PRIMARY KEY")
+			return nil, initialPointer, false
+		}
+		primaryKey, newCursor, ok = parseColumnNames(tokens, pointer+2)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		pointer = newCursor
+	}
+
 	// Ожидаем закрывающую скобку
 	if !expectToken(tokens, pointer, tokenFromSymbol(lex.RightparenSymbol)) {
 		helpMessage(tokens, pointer, "Expected right parenthesis")
@@ -54,8 +76,10 @@ func parseCreateTableStatement(tokens []*lex.Token, initialPointer uint) (*Creat
 	}
 
 	return &CreateTableStatement{
-		Table:   *tableName,
-		Columns: columns,
+		Table:       *tableName,
+		Columns:     columns,
+		PrimaryKey:  primaryKey,
+		IfNotExists: ifNotExists,
 	}, pointer, true
 }
 
@@ -83,31 +107,151 @@ func parseColumnDefinitions(tokens []*lex.Token, initialPointer uint, endDelimit
 				helpMessage(tokens, pointer, "Expected comma")
 				return nil, initialPointer, false
 			}
+			// Ограничения таблицы идут после колонок, их парсит parseCreateTableStatement
+			if expectPrimaryKey(tokens, pointer+1) {
+				break
+			}
 			pointer++
 		}
 
-		// Парсим имя колонки
-		columnName, newCursor, ok := parseToken(tokens, pointer, lex.IdentifierToken)
+		// Парсим определение колонки
+		columnDef, newCursor, ok := parseColumnDefinition(tokens, pointer)
 		if !ok {
-			helpMessage(tokens, pointer, "Expected column name")
 			return nil, initialPointer, false
 		}
 		pointer = newCursor
+		columnDefs = append(columnDefs, columnDef)
+	}
+
+	return &columnDefs, pointer, true
+}
+
+// parseColumnDefinition парсит определение одной колонки: name type [(parameters)] [constraints],
+// где ограничения колонки - PRIMARY KEY, NOT NULL и DEFAULT expr в любом порядке
+func parseColumnDefinition(tokens []*lex.Token, initialPointer uint) (*columnDefinition, uint, bool) {
+	pointer := initialPointer
+
+	// Парсим имя колонки
+	columnName, newCursor, ok := parseToken(tokens, pointer, lex.IdentifierToken)
+	if !ok {
+		helpMessage(tokens, pointer, "Expected column name")
+		return nil, initialPointer, false
+	}
+	pointer = newCursor
+
+	// Парсим тип данных колонки
+	columnType, newCursor, ok := parseDataType(tokens, pointer)
+	if !ok {
+		helpMessage(tokens, pointer, "Expected column type")
+		return nil, initialPointer, false
+	}
+	pointer = newCursor
+
+	// Парсим необязательные параметры типа данных: DECIMAL(10, 2)
+	parameters, newCursor, ok := parseTypeParameters(tokens, pointer)
+	if !ok {
+		return nil, initialPointer, false
+	}
+	pointer = newCursor
+
+	columnDef := &columnDefinition{
+		Name:       *columnName,
+		Datatype:   *columnType,
+		Parameters: parameters,
+	}
 
-		// Парсим тип данных колонки
-		columnType, newCursor, ok := parseToken(tokens, pointer, lex.KeywordToken)
+	// Парсим необязательные ограничения колонки
+	for {
+		switch {
+		case expectPrimaryKey(tokens, pointer):
+			columnDef.PrimaryKey = true
+			pointer += 2
+		case expectNotNull(tokens, pointer):
+			columnDef.NotNull = true
+			pointer += 2
+		case expectToken(tokens, pointer, tokenFromKeyword(lex.DefaultKeyword)):
+			value, newCursor, ok := parseExpression(tokens, pointer+1, tokenFromSymbol(lex.CommaSymbol))
+			if !ok {
+				helpMessage(tokens, pointer+1, "Expected default value")
+				return nil, initialPointer, false
+			}
+			columnDef.Default = value
+			pointer = newCursor
+		default:
+			return columnDef, pointer, true
+		}
+	}
+}
+
+// parseTypeParameters парсит необязательный список числовых параметров типа в скобках: (10, 2)
+func parseTypeParameters(tokens []*lex.Token, initialPointer uint) ([]*lex.Token, uint, bool) {
+	pointer := initialPointer
+
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.LeftparenSymbol)) {
+		return nil, initialPointer, true
+	}
+	pointer++
+
+	parameters := []*lex.Token{}
+	for {
+		if len(parameters) > 0 {
+			if expectToken(tokens, pointer, tokenFromSymbol(lex.RightparenSymbol)) {
+				break
+			}
+			if !expectToken(tokens, pointer, tokenFromSymbol(lex.CommaSymbol)) {
+				helpMessage(tokens, pointer, "Expected comma or right parenthesis")
+				return nil, initialPointer, false
+			}
+			pointer++
+		}
+
+		parameter, newCursor, ok := parseToken(tokens, pointer, lex.NumericToken)
 		if !ok {
-			helpMessage(tokens, pointer, "Expected column type")
+			helpMessage(tokens, pointer, "Expected type parameter")
 			return nil, initialPointer, false
 		}
 		pointer = newCursor
+		parameters = append(parameters, parameter)
+	}
+	pointer++
 
-		// Добавляем определение колонки
-		columnDefs = append(columnDefs, &columnDefinition{
-			Name:     *columnName,
-			Datatype: *columnType,
-		})
+	return parameters, pointer, true
+}
+
+// expectNotNull проверяет, что с позиции pointer идут слова NOT NULL
+func expectNotNull(tokens []*lex.Token, pointer uint) bool {
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.NotKeyword)) {
+		return false
 	}
+	_, _, ok := parseToken(tokens, pointer+1, lex.NullToken)
+	return ok
+}
 
-	return &columnDefs, pointer, true
+// expectPrimaryKey проверяет, что с позиции pointer идут слова PRIMARY KEY
+func expectPrimaryKey(tokens []*lex.Token, pointer uint) bool {
+	return expectToken(tokens, pointer, tokenFromKeyword(lex.PrimaryKeyword)) && expectWord(tokens, pointer+1, "key")
+}
+
+// parseIfExists парсит необязательное IF EXISTS (или IF NOT EXISTS, если not = true) перед именем таблицы
+// и возвращает, было ли оно указано
+func parseIfExists(tokens []*lex.Token, initialPointer uint, not bool) (bool, uint, bool) {
+	pointer := initialPointer
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.IfKeyword)) {
+		return false, initialPointer, true
+	}
+	pointer++
+
+	if not {
+		if !expectToken(tokens, pointer, tokenFromKeyword(lex.NotKeyword)) {
+			helpMessage(tokens, pointer, "Expected NOT")
+			return false, initialPointer, false
+		}
+		pointer++
+	}
+
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.ExistsKeyword)) {
+		helpMessage(tokens, pointer, "Expected EXISTS")
+		return false, initialPointer, false
+	}
+	return true, pointer + 1, true
 }
@@ -19,6 +19,13 @@ func parseDropTableStatement(tokens []*lex.Token, initialPointer uint) (*DropTab
 	}
 	pointer++
 
+	// Парсим необязательное IF EXISTS
+	ifExists, newCursor, ok := parseIfExists(tokens, pointer, false)
+	if !ok {
+		return nil, initialPointer, false
+	}
+	pointer = newCursor
+
 	// Парсим имя таблицы
 	tableName, newCursor, ok := parseToken(tokens, pointer, lex.IdentifierToken)
 	if !ok {
@@ -34,6 +41,7 @@ func parseDropTableStatement(tokens []*lex.Token, initialPointer uint) (*DropTab
 	}
 
 	return &DropTableStatement{
-		Table: *tableName,
+		Table:    *tableName,
+		IfExists: ifExists,
 	}, pointer, true
 }
@@ -2,7 +2,9 @@ package ast
 
 import "custom-database/internal/parser/lex"
 
-// parseInsertStatement парсит INSERT statement
+// parseInsertStatement парсит INSERT statement:
+// INSERT INTO table [(column, ...)] VALUES (...), (...) или INSERT INTO table [(column, ...)] [WITH ...] SELECT ...,
+// после строк могут идти ON CONFLICT и RETURNING
 func parseInsertStatement(tokens []*lex.Token, initialPointer uint) (*InsertStatement, uint, bool) {
 	pointer := initialPointer
 
@@ -26,13 +28,83 @@ func parseInsertStatement(tokens []*lex.Token, initialPointer uint) (*InsertStat
 		return nil, initialPointer, false
 	}
 	pointer = newCursor
+	stmt := &InsertStatement{Table: *tableName}
 
-	// Ожидаем ключевое слово VALUES
-	if !expectToken(tokens, pointer, tokenFromKeyword(lex.ValuesKeyword)) {
-		helpMessage(tokens, pointer, "Expected VALUES")
+	// Парсим необязательный список колонок
+	if expectToken(tokens, pointer, tokenFromSymbol(lex.LeftparenSymbol)) {
+		columns, newCursor, ok := parseColumnNames(tokens, pointer)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		stmt.Columns = columns
+		pointer = newCursor
+	}
+
+	// Строки могут браться из запроса
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.SelectKeyword)) ||
+		expectToken(tokens, pointer, tokenFromKeyword(lex.WithKeyword)) {
+		selectStmt, newCursor, ok := parseInsertSelect(tokens, pointer)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		stmt.Select = selectStmt
+		pointer = newCursor
+	} else {
+		// Ожидаем ключевое слово VALUES
+		if !expectToken(tokens, pointer, tokenFromKeyword(lex.ValuesKeyword)) {
+			helpMessage(tokens, pointer, "Expected VALUES or SELECT")
+			return nil, initialPointer, false
+		}
+		pointer++
+
+		// Парсим строки значений, разделенные запятыми
+		for {
+			values, newCursor, ok := parseValuesRow(tokens, pointer)
+			if !ok {
+				return nil, initialPointer, false
+			}
+			stmt.Values = append(stmt.Values, values)
+			pointer = newCursor
+
+			if !expectToken(tokens, pointer, tokenFromSymbol(lex.CommaSymbol)) {
+				break
+			}
+			pointer++
+		}
+	}
+
+	// Парсим необязательное ON CONFLICT
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.OnKeyword)) {
+		onConflict, newCursor, ok := parseOnConflict(tokens, pointer)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		stmt.OnConflict = onConflict
+		pointer = newCursor
+	}
+
+	// Парсим необязательный RETURNING
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.ReturningKeyword)) {
+		returning, newCursor, ok := parseSelectList(tokens, pointer+1)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		stmt.Returning = returning
+		pointer = newCursor
+	}
+
+	// Ожидаем точку с запятой
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.SemicolonSymbol)) {
+		helpMessage(tokens, pointer, "Expected semicolon")
 		return nil, initialPointer, false
 	}
-	pointer++
+
+	return stmt, pointer, true
+}
+
+// parseValuesRow парсит одну строку VALUES: (expression, ...)
+func parseValuesRow(tokens []*lex.Token, initialPointer uint) ([]*Expression, uint, bool) {
+	pointer := initialPointer
 
 	// Ожидаем открывающую скобку
 	if !expectToken(tokens, pointer, tokenFromSymbol(lex.LeftparenSymbol)) {
@@ -53,16 +125,117 @@ func parseInsertStatement(tokens []*lex.Token, initialPointer uint) (*InsertStat
 		helpMessage(tokens, pointer, "Expected right paren")
 		return nil, initialPointer, false
 	}
+
+	return *values, pointer + 1, true
+}
+
+// parseInsertSelect парсит запрос INSERT ... SELECT, в том числе с общими табличными выражениями
+func parseInsertSelect(tokens []*lex.Token, initialPointer uint) (*SelectStatement, uint, bool) {
+	pointer := initialPointer
+
+	with, newCursor, ok := parseWithClause(tokens, pointer)
+	if ok {
+		pointer = newCursor
+	}
+
+	selectStmt, newCursor, ok := parseSelectQuery(tokens, pointer)
+	if !ok {
+		return nil, initialPointer, false
+	}
+	selectStmt.With = with
+
+	return selectStmt, newCursor, true
+}
+
+// parseOnConflict парсит ON CONFLICT [(column, ...)] DO NOTHING | DO UPDATE SET column = expr, ...
+func parseOnConflict(tokens []*lex.Token, initialPointer uint) (*OnConflictClause, uint, bool) {
+	pointer := initialPointer
+	onConflict := &OnConflictClause{}
+
+	// Ожидаем ON CONFLICT
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.OnKeyword)) {
+		return nil, initialPointer, false
+	}
+	pointer++
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.ConflictKeyword)) {
+		helpMessage(tokens, pointer, "Expected CONFLICT")
+		return nil, initialPointer, false
+	}
 	pointer++
 
-	// Ожидаем точку с запятой
-	if !expectToken(tokens, pointer, tokenFromSymbol(lex.SemicolonSymbol)) {
-		helpMessage(tokens, pointer, "Expected semicolon")
+	// Парсим необязательный список колонок ключа
+	if expectToken(tokens, pointer, tokenFromSymbol(lex.LeftparenSymbol)) {
+		columns, newCursor, ok := parseColumnNames(tokens, pointer)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		onConflict.Columns = columns
+		pointer = newCursor
+	}
+
+	// Ожидаем DO NOTHING или DO UPDATE SET
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.DoKeyword)) {
+		helpMessage(tokens, pointer, "Expected DO")
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	if expectWord(tokens, pointer, "nothing") {
+		onConflict.Action = DoNothingAction
+		return onConflict, pointer + 1, true
+	}
+
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.UpdateKeyword)) {
+		helpMessage(tokens, pointer, "Expected NOTHING or UPDATE")
+		return nil, initialPointer, false
+	}
+	pointer++
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.SetKeyword)) {
+		helpMessage(tokens, pointer, "Expected SET")
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	onConflict.Action = DoUpdateAction
+	for {
+		assignment, newCursor, ok := parseAssignment(tokens, pointer)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		onConflict.Updates = append(onConflict.Updates, assignment)
+		pointer = newCursor
+
+		if !expectToken(tokens, pointer, tokenFromSymbol(lex.CommaSymbol)) {
+			break
+		}
+		pointer++
+	}
+
+	return onConflict, pointer, true
+}
+
+// parseAssignment парсит присваивание column = expr
+func parseAssignment(tokens []*lex.Token, initialPointer uint) (*Assignment, uint, bool) {
+	pointer := initialPointer
+
+	column, newCursor, ok := parseToken(tokens, pointer, lex.IdentifierToken)
+	if !ok {
+		helpMessage(tokens, pointer, "Expected column name")
+		return nil, initialPointer, false
+	}
+	pointer = newCursor
+
+	if !expectToken(tokens, pointer, tokenFromMathOperator(lex.EqualOperator)) {
+		helpMessage(tokens, pointer, "Expected =")
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	value, newCursor, ok := parseExpression(tokens, pointer, tokenFromSymbol(lex.CommaSymbol))
+	if !ok {
+		helpMessage(tokens, pointer, "Expected expression")
 		return nil, initialPointer, false
 	}
 
-	return &InsertStatement{
-		Table:  *tableName,
-		Values: values,
-	}, pointer, true
+	return &Assignment{Column: *column, Value: value}, newCursor, true
 }
@@ -2,10 +2,196 @@ package ast
 
 import (
 	"custom-database/internal/parser/lex"
+	"strings"
 )
 
-// parseSelectStatement парсит SELECT statement
+// parseSelectStatement парсит SELECT statement, завершенный точкой с запятой
 func parseSelectStatement(tokens []*lex.Token, initialPointer uint) (*SelectStatement, uint, bool) {
+	statement, pointer, ok := parseSelectQuery(tokens, initialPointer)
+	if !ok {
+		return nil, initialPointer, false
+	}
+
+	// Ожидаем точку с запятой
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.SemicolonSymbol)) {
+		helpMessage(tokens, pointer, "Expected semicolon")
+		return nil, initialPointer, false
+	}
+
+	return statement, pointer, true
+}
+
+// parseSubquery парсит подзапрос в скобках: (SELECT ...)
+func parseSubquery(tokens []*lex.Token, initialPointer uint) (*SelectStatement, uint, bool) {
+	pointer := initialPointer
+
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.LeftparenSymbol)) ||
+		!expectToken(tokens, pointer+1, tokenFromKeyword(lex.SelectKeyword)) {
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	statement, newCursor, ok := parseSelectQuery(tokens, pointer)
+	if !ok {
+		return nil, initialPointer, false
+	}
+	pointer = newCursor
+
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.RightparenSymbol)) {
+		helpMessage(tokens, pointer, "Expected right paren after subquery")
+		return nil, initialPointer, false
+	}
+
+	return statement, pointer + 1, true
+}
+
+// FirstQuery возвращает первый простой запрос составного запроса: имена колонок результата берутся из него
+func (stmt *SelectStatement) FirstQuery() *SelectStatement {
+	for stmt.SetOperation != nil {
+		stmt = stmt.SetOperation.Left
+	}
+	return stmt
+}
+
+// setOperationKeywords ключевые слова операций над результатами запросов
+var setOperationKeywords = []struct {
+	keyword lex.Keyword
+	kind    SetOperationKind
+}{
+	{lex.UnionKeyword, UnionOperation},
+	{lex.IntersectKeyword, IntersectOperation},
+	{lex.ExceptKeyword, ExceptOperation},
+}
+
+// parseSelectQuery парсит SELECT запрос без завершающей точки с запятой (statement или подзапрос):
+// простые запросы, соединенные UNION, INTERSECT и EXCEPT, и общие для результата ORDER BY, LIMIT и OFFSET
+func parseSelectQuery(tokens []*lex.Token, initialPointer uint) (*SelectStatement, uint, bool) {
+	statement, pointer, ok := parseSetOperation(tokens, initialPointer, false)
+	if !ok {
+		return nil, initialPointer, false
+	}
+
+	// ORDER BY, LIMIT и OFFSET относятся ко всему составному запросу.
+	// Если запрос целиком в скобках и они уже указаны внутри, повторно указать их нельзя
+	if statement.OrderBy != nil || statement.Limit != nil || statement.Offset != nil {
+		if expectToken(tokens, pointer, tokenFromKeyword(lex.OrderKeyword)) ||
+			expectToken(tokens, pointer, tokenFromKeyword(lex.LimitKeyword)) ||
+			expectToken(tokens, pointer, tokenFromKeyword(lex.OffsetKeyword)) {
+			helpMessage(tokens, pointer, "Multiple ORDER BY, LIMIT or OFFSET clauses are not allowed")
+			return nil, initialPointer, false
+		}
+		return statement, pointer, true
+	}
+
+	// Парсим ORDER BY clause (опционально)
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.OrderKeyword)) {
+		orderBy, newCursor, ok := parseOrderBy(tokens, pointer)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		statement.OrderBy = orderBy
+		pointer = newCursor
+	}
+
+	// Парсим LIMIT n (опционально)
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.LimitKeyword)) {
+		limit, newCursor, ok := parseToken(tokens, pointer+1, lex.NumericToken)
+		if !ok {
+			helpMessage(tokens, pointer+1, "Expected number after LIMIT")
+			return nil, initialPointer, false
+		}
+		statement.Limit = limit
+		pointer = newCursor
+	}
+
+	// Парсим OFFSET m (опционально)
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.OffsetKeyword)) {
+		offset, newCursor, ok := parseToken(tokens, pointer+1, lex.NumericToken)
+		if !ok {
+			helpMessage(tokens, pointer+1, "Expected number after OFFSET")
+			return nil, initialPointer, false
+		}
+		statement.Offset = offset
+		pointer = newCursor
+	}
+
+	return statement, pointer, true
+}
+
+// parseSetOperation парсит запросы, соединенные операциями над результатами: query {UNION|INTERSECT|EXCEPT [ALL|DISTINCT] query}.
+// INTERSECT выполняется раньше UNION и EXCEPT, операции одного приоритета - слева направо.
+// intersectOnly - парсить только цепочку INTERSECT (правый операнд UNION и EXCEPT)
+func parseSetOperation(tokens []*lex.Token, initialPointer uint, intersectOnly bool) (*SelectStatement, uint, bool) {
+	parseOperand := func(pointer uint) (*SelectStatement, uint, bool) {
+		if intersectOnly {
+			return parseSelectTerm(tokens, pointer)
+		}
+		return parseSetOperation(tokens, pointer, true)
+	}
+
+	left, pointer, ok := parseOperand(initialPointer)
+	if !ok {
+		return nil, initialPointer, false
+	}
+
+	for {
+		operation := &SetOperation{Left: left}
+		found := false
+		for _, setOperationKeyword := range setOperationKeywords {
+			if expectToken(tokens, pointer, tokenFromKeyword(setOperationKeyword.keyword)) {
+				operation.Kind = setOperationKeyword.kind
+				found = true
+				break
+			}
+		}
+		if !found || intersectOnly != (operation.Kind == IntersectOperation) {
+			return left, pointer, true
+		}
+		pointer++
+
+		if expectToken(tokens, pointer, tokenFromKeyword(lex.AllKeyword)) {
+			operation.All = true
+			pointer++
+		} else if expectToken(tokens, pointer, tokenFromKeyword(lex.DistinctKeyword)) {
+			pointer++
+		}
+
+		right, newCursor, ok := parseOperand(pointer)
+		if !ok {
+			helpMessage(tokens, pointer, "Expected SELECT after "+string(operation.Kind))
+			return nil, initialPointer, false
+		}
+		operation.Right = right
+		pointer = newCursor
+
+		left = &SelectStatement{
+			SelectedColumns: []*Expression{},
+			SetOperation:    operation,
+		}
+	}
+}
+
+// parseSelectTerm парсит операнд операции над результатами: простой SELECT или запрос в скобках,
+// у которого могут быть свои ORDER BY, LIMIT и OFFSET
+func parseSelectTerm(tokens []*lex.Token, initialPointer uint) (*SelectStatement, uint, bool) {
+	if !expectToken(tokens, initialPointer, tokenFromSymbol(lex.LeftparenSymbol)) {
+		return parseSimpleSelect(tokens, initialPointer)
+	}
+
+	statement, pointer, ok := parseSelectQuery(tokens, initialPointer+1)
+	if !ok {
+		return nil, initialPointer, false
+	}
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.RightparenSymbol)) {
+		helpMessage(tokens, pointer, "Expected right paren after query")
+		return nil, initialPointer, false
+	}
+
+	return statement, pointer + 1, true
+}
+
+// parseSimpleSelect парсит SELECT запрос без операций над результатами, ORDER BY, LIMIT и OFFSET
+func parseSimpleSelect(tokens []*lex.Token, initialPointer uint) (*SelectStatement, uint, bool) {
 	statement := &SelectStatement{
 		SelectedColumns: []*Expression{},
 	}
@@ -17,44 +203,379 @@ func parseSelectStatement(tokens []*lex.Token, initialPointer uint) (*SelectStat
 	}
 	pointer++
 
-	// Проверяем, не используется ли SELECT * (все колонки)
-	if expectToken(tokens, pointer, tokenFromSymbol(lex.AsteriskSymbol)) {
-		// SELECT * - выбираем все колонки
-		statement.SelectedColumns = []*Expression{}
+	// SELECT DISTINCT удаляет повторяющиеся строки, SELECT ALL - поведение по умолчанию
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.DistinctKeyword)) {
+		statement.Distinct = true
 		pointer++
-	} else {
-		// Парсим список конкретных колонок
-		expressions, newCursor, ok := parseExpressions(tokens, pointer, []lex.Token{
-			tokenFromKeyword(lex.FromKeyword),
+	} else if expectToken(tokens, pointer, tokenFromKeyword(lex.AllKeyword)) {
+		pointer++
+	}
+
+	// Парсим список колонок
+	expressions, newCursor, ok := parseSelectList(tokens, pointer)
+	if !ok {
+		return nil, initialPointer, false
+	}
+	pointer = newCursor
+	// SELECT * (все колонки) - пустой список
+	if len(expressions) != 1 || expressions[0].Kind != StarKind || expressions[0].Literal != nil {
+		statement.SelectedColumns = expressions
+	}
+
+	// Парсим FROM clause (опционально)
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.FromKeyword)) {
+		return statement, pointer, true
+	}
+	pointer++
+
+	// Парсим таблицу (или подзапрос) после FROM
+	table, subquery, alias, newCursor, ok := parseFromItem(tokens, pointer)
+	if !ok {
+		helpMessage(tokens, pointer, "Expected table name after FROM")
+		return nil, initialPointer, false
+	}
+	statement.Table = table
+	statement.Subquery = subquery
+	statement.Alias = alias
+	pointer = newCursor
+
+	// Парсим присоединяемые таблицы (JOIN или через запятую)
+	for {
+		join, newCursor, ok := parseJoinClause(tokens, pointer)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		if join == nil {
+			break
+		}
+		statement.Joins = append(statement.Joins, join)
+		pointer = newCursor
+	}
+
+	// Парсим WHERE clause (опционально)
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.WhereKeyword)) {
+		pointer++
+
+		where, newCursor, ok := parseExpression(tokens, pointer, tokenFromSymbol(lex.SemicolonSymbol))
+		if !ok {
+			helpMessage(tokens, pointer, "Expected WHERE condition")
+			return nil, initialPointer, false
+		}
+		statement.Where = where
+		pointer = newCursor
+	}
+
+	// Парсим GROUP BY clause (опционально)
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.GroupKeyword)) {
+		pointer++
+		if !expectToken(tokens, pointer, tokenFromKeyword(lex.ByKeyword)) {
+			helpMessage(tokens, pointer, "Expected BY after GROUP")
+			return nil, initialPointer, false
+		}
+		pointer++
+
+		groupBy, newCursor, ok := parseExpressions(tokens, pointer, []lex.Token{
+			tokenFromKeyword(lex.HavingKeyword),
+			tokenFromKeyword(lex.OrderKeyword),
+			tokenFromKeyword(lex.LimitKeyword),
+			tokenFromKeyword(lex.OffsetKeyword),
+			tokenFromKeyword(lex.UnionKeyword),
+			tokenFromKeyword(lex.IntersectKeyword),
+			tokenFromKeyword(lex.ExceptKeyword),
 			tokenFromSymbol(lex.SemicolonSymbol),
+			tokenFromSymbol(lex.RightparenSymbol),
 		})
+		if !ok || len(*groupBy) == 0 {
+			helpMessage(tokens, pointer, "Expected GROUP BY expressions")
+			return nil, initialPointer, false
+		}
+		statement.GroupBy = *groupBy
+		pointer = newCursor
+	}
+
+	// Парсим HAVING clause (опционально)
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.HavingKeyword)) {
+		pointer++
+
+		having, newCursor, ok := parseExpression(tokens, pointer, tokenFromSymbol(lex.SemicolonSymbol))
 		if !ok {
+			helpMessage(tokens, pointer, "Expected HAVING condition")
 			return nil, initialPointer, false
 		}
+		statement.Having = having
 		pointer = newCursor
-		statement.SelectedColumns = *expressions
 	}
 
-	// Парсим FROM clause (опционально)
-	if !expectToken(tokens, pointer, tokenFromKeyword(lex.FromKeyword)) {
-		return statement, pointer, true
+	return statement, pointer, true
+}
+
+// parseSelectList парсит список колонок SELECT до FROM или конца запроса
+func parseSelectList(tokens []*lex.Token, initialPointer uint) ([]*Expression, uint, bool) {
+	pointer := initialPointer
+	delimiters := []lex.Token{
+		tokenFromKeyword(lex.FromKeyword),
+		tokenFromKeyword(lex.UnionKeyword),
+		tokenFromKeyword(lex.IntersectKeyword),
+		tokenFromKeyword(lex.ExceptKeyword),
+		tokenFromSymbol(lex.SemicolonSymbol),
+		tokenFromSymbol(lex.RightparenSymbol),
+		// Список запроса INSERT ... SELECT без FROM
+		tokenFromKeyword(lex.OnKeyword),
+		tokenFromKeyword(lex.ReturningKeyword),
 	}
-	pointer++
 
-	// Парсим имя таблицы после FROM
-	tableName, newCursor, ok := parseToken(tokens, pointer, lex.IdentifierToken)
+	expressions := []*Expression{}
+	for {
+		if pointer >= uint(len(tokens)) {
+			return nil, initialPointer, false
+		}
+		for _, delimiter := range delimiters {
+			if delimiter.Equals(tokens[pointer]) && len(expressions) > 0 {
+				return expressions, pointer, true
+			}
+		}
+
+		// Если это не первая колонка, ожидаем запятую
+		if len(expressions) > 0 {
+			if !expectToken(tokens, pointer, tokenFromSymbol(lex.CommaSymbol)) {
+				helpMessage(tokens, pointer, "Expected comma")
+				return nil, initialPointer, false
+			}
+			pointer++
+		}
+
+		expression, newCursor, ok := parseSelectItem(tokens, pointer)
+		if !ok {
+			helpMessage(tokens, pointer, "Expected expression")
+			return nil, initialPointer, false
+		}
+		pointer = newCursor
+		expressions = append(expressions, expression)
+	}
+}
+
+// parseSelectItem парсит колонку списка SELECT: *, table.* или выражение с необязательным псевдонимом AS alias
+func parseSelectItem(tokens []*lex.Token, initialPointer uint) (*Expression, uint, bool) {
+	pointer := initialPointer
+
+	if expectToken(tokens, pointer, tokenFromSymbol(lex.AsteriskSymbol)) {
+		return &Expression{Kind: StarKind}, pointer + 1, true
+	}
+	// Лексер возвращает table.* одним идентификатором
+	if token, newCursor, ok := parseToken(tokens, pointer, lex.IdentifierToken); ok && strings.HasSuffix(token.Value, ".*") {
+		return &Expression{
+			Literal: &lex.Token{Value: strings.TrimSuffix(token.Value, ".*"), Kind: lex.IdentifierToken},
+			Kind:    StarKind,
+		}, newCursor, true
+	}
+
+	expression, pointer, ok := parseExpression(tokens, pointer, tokenFromSymbol(lex.CommaSymbol))
 	if !ok {
-		helpMessage(tokens, pointer, "Expected table name after FROM")
 		return nil, initialPointer, false
 	}
-	statement.Table = *tableName
+
+	// Псевдоним: expr AS alias
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.AsKeyword)) {
+		alias, newCursor, ok := parseToken(tokens, pointer+1, lex.IdentifierToken)
+		if !ok {
+			helpMessage(tokens, pointer+1, "Expected column alias after AS")
+			return nil, initialPointer, false
+		}
+		expression.Alias = alias
+		pointer = newCursor
+	}
+
+	return expression, pointer, true
+}
+
+// parseFromItem парсит элемент FROM или JOIN: имя таблицы или подзапрос в скобках с необязательным псевдонимом
+func parseFromItem(tokens []*lex.Token, initialPointer uint) (lex.Token, *SelectStatement, *lex.Token, uint, bool) {
+	pointer := initialPointer
+
+	var table lex.Token
+	subquery, newCursor, ok := parseSubquery(tokens, pointer)
+	if !ok {
+		tableName, newCursor, ok := parseToken(tokens, pointer, lex.IdentifierToken)
+		if !ok {
+			return lex.Token{}, nil, nil, initialPointer, false
+		}
+		table = *tableName
+		pointer = newCursor
+	} else {
+		pointer = newCursor
+	}
+
+	alias, newCursor, ok := parseTableAlias(tokens, pointer)
+	if !ok {
+		return lex.Token{}, nil, nil, initialPointer, false
+	}
+
+	return table, subquery, alias, newCursor, true
+}
+
+// parseTableAlias парсит необязательный псевдоним таблицы: [AS] alias.
+// Возвращает nil, если псевдоним не указан
+func parseTableAlias(tokens []*lex.Token, initialPointer uint) (*lex.Token, uint, bool) {
+	pointer := initialPointer
+
+	hasAs := expectToken(tokens, pointer, tokenFromKeyword(lex.AsKeyword))
+	if hasAs {
+		pointer++
+	}
+
+	alias, newCursor, ok := parseToken(tokens, pointer, lex.IdentifierToken)
+	if !ok {
+		if hasAs {
+			helpMessage(tokens, pointer, "Expected alias after AS")
+			return nil, initialPointer, false
+		}
+		return nil, initialPointer, true
+	}
+
+	return alias, newCursor, true
+}
+
+// joinKeywords ключевые слова, с которых начинается JOIN, и соответствующий тип соединения
+var joinKeywords = []struct {
+	keyword lex.Keyword
+	kind    JoinKind
+}{
+	{lex.JoinKeyword, InnerJoin},
+	{lex.InnerKeyword, InnerJoin},
+	{lex.LeftKeyword, LeftJoin},
+	{lex.RightKeyword, RightJoin},
+	{lex.FullKeyword, FullJoin},
+	{lex.CrossKeyword, CrossJoin},
+}
+
+// parseJoinClause парсит присоединение таблицы:
+// [INNER] JOIN t ON cond, LEFT|RIGHT|FULL [OUTER] JOIN t ON cond, CROSS JOIN t или , t.
+// Возвращает nil, если следующий токен не начинает JOIN
+func parseJoinClause(tokens []*lex.Token, initialPointer uint) (*JoinClause, uint, bool) {
+	pointer := initialPointer
+	join := &JoinClause{}
+
+	if expectToken(tokens, pointer, tokenFromSymbol(lex.CommaSymbol)) {
+		// FROM a, b - то же самое, что CROSS JOIN
+		join.Kind = CrossJoin
+		pointer++
+	} else {
+		found := false
+		for _, joinKeyword := range joinKeywords {
+			if expectToken(tokens, pointer, tokenFromKeyword(joinKeyword.keyword)) {
+				join.Kind = joinKeyword.kind
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, initialPointer, true
+		}
+
+		// После JOIN сразу идет таблица, после остальных ключевых слов - [OUTER] JOIN
+		if !expectToken(tokens, pointer, tokenFromKeyword(lex.JoinKeyword)) {
+			pointer++
+			if join.Kind == LeftJoin || join.Kind == RightJoin || join.Kind == FullJoin {
+				if expectToken(tokens, pointer, tokenFromKeyword(lex.OuterKeyword)) {
+					pointer++
+				}
+			}
+			if !expectToken(tokens, pointer, tokenFromKeyword(lex.JoinKeyword)) {
+				helpMessage(tokens, pointer, "Expected JOIN")
+				return nil, initialPointer, false
+			}
+		}
+		pointer++
+	}
+
+	table, subquery, alias, newCursor, ok := parseFromItem(tokens, pointer)
+	if !ok {
+		helpMessage(tokens, pointer, "Expected table name after JOIN")
+		return nil, initialPointer, false
+	}
+	join.Table = table
+	join.Subquery = subquery
+	join.Alias = alias
 	pointer = newCursor
 
-	// Ожидаем точку с запятой
-	if !expectToken(tokens, pointer, tokenFromSymbol(lex.SemicolonSymbol)) {
-		helpMessage(tokens, pointer, "Expected semicolon")
+	if join.Kind == CrossJoin {
+		return join, pointer, true
+	}
+
+	// Для остальных соединений обязательно условие ON
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.OnKeyword)) {
+		helpMessage(tokens, pointer, "Expected ON after joined table")
 		return nil, initialPointer, false
 	}
+	pointer++
 
-	return statement, pointer, true
+	condition, newCursor, ok := parseExpression(tokens, pointer, tokenFromSymbol(lex.SemicolonSymbol))
+	if !ok {
+		helpMessage(tokens, pointer, "Expected join condition")
+		return nil, initialPointer, false
+	}
+	join.Condition = condition
+
+	return join, newCursor, true
+}
+
+// parseOrderBy парсит ORDER BY expr [ASC|DESC] [NULLS FIRST|LAST], ...
+func parseOrderBy(tokens []*lex.Token, initialPointer uint) ([]*OrderByItem, uint, bool) {
+	pointer := initialPointer
+
+	// Ожидаем ключевые слова ORDER BY
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.OrderKeyword)) {
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.ByKeyword)) {
+		helpMessage(tokens, pointer, "Expected BY after ORDER")
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	items := []*OrderByItem{}
+	for {
+		expression, newCursor, ok := parseExpression(tokens, pointer, tokenFromSymbol(lex.CommaSymbol))
+		if !ok {
+			helpMessage(tokens, pointer, "Expected ORDER BY expression")
+			return nil, initialPointer, false
+		}
+		pointer = newCursor
+
+		item := &OrderByItem{Expression: expression}
+
+		// Направление сортировки (по умолчанию ASC)
+		if expectToken(tokens, pointer, tokenFromKeyword(lex.AscKeyword)) {
+			pointer++
+		} else if expectToken(tokens, pointer, tokenFromKeyword(lex.DescKeyword)) {
+			item.Descending = true
+			pointer++
+		}
+
+		// Положение NULL значений (по умолчанию NULL больше любого значения)
+		item.NullsFirst = item.Descending
+		if expectToken(tokens, pointer, tokenFromKeyword(lex.NullsKeyword)) {
+			pointer++
+			switch {
+			case expectWord(tokens, pointer, string(lex.FirstKeyword)):
+				item.NullsFirst = true
+			case expectWord(tokens, pointer, string(lex.LastKeyword)):
+				item.NullsFirst = false
+			default:
+				helpMessage(tokens, pointer, "Expected FIRST or LAST after NULLS")
+				return nil, initialPointer, false
+			}
+			pointer++
+		}
+
+		items = append(items, item)
+
+		// Следующий элемент через запятую
+		if !expectToken(tokens, pointer, tokenFromSymbol(lex.CommaSymbol)) {
+			return items, pointer, true
+		}
+		pointer++
+	}
 }
@@ -0,0 +1,46 @@
+package ast
+
+import "custom-database/internal/parser/lex"
+
+// parseTruncateTableStatement парсит TRUNCATE statement: TRUNCATE [TABLE] table [RESTART IDENTITY]
+func parseTruncateTableStatement(tokens []*lex.Token, initialPointer uint) (*TruncateTableStatement, uint, bool) {
+	pointer := initialPointer
+
+	// Ожидаем ключевое слово TRUNCATE
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.TruncateKeyword)) {
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	// Ключевое слово TABLE необязательно
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.TableKeyword)) {
+		pointer++
+	}
+
+	// Парсим имя таблицы
+	tableName, newCursor, ok := parseToken(tokens, pointer, lex.IdentifierToken)
+	if !ok {
+		helpMessage(tokens, pointer, "Expected table name")
+		return nil, initialPointer, false
+	}
+	pointer = newCursor
+	stmt := &TruncateTableStatement{Table: *tableName}
+
+	// Парсим необязательное RESTART IDENTITY
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.RestartKeyword)) {
+		if !expectToken(tokens, pointer+1, tokenFromKeyword(lex.IdentityKeyword)) {
+			helpMessage(tokens, pointer+1, "Expected identity")
+			return nil, initialPointer, false
+		}
+		stmt.RestartIdentity = true
+		pointer += 2
+	}
+
+	// Ожидаем точку с запятой
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.SemicolonSymbol)) {
+		helpMessage(tokens, pointer, "Expected semicolon")
+		return nil, initialPointer, false
+	}
+
+	return stmt, pointer, true
+}
@@ -0,0 +1,90 @@
+package ast
+
+import "custom-database/internal/parser/lex"
+
+// parseWithClause парсит общие табличные выражения перед SELECT:
+// WITH [RECURSIVE] name [(column, ...)] AS (SELECT ...), ...
+func parseWithClause(tokens []*lex.Token, initialPointer uint) (*WithClause, uint, bool) {
+	pointer := initialPointer
+	with := &WithClause{}
+
+	// Ожидаем ключевое слово WITH
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.WithKeyword)) {
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	if expectToken(tokens, pointer, tokenFromKeyword(lex.RecursiveKeyword)) {
+		with.Recursive = true
+		pointer++
+	}
+
+	for {
+		query, newCursor, ok := parseCommonTableExpression(tokens, pointer)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		with.Queries = append(with.Queries, query)
+		pointer = newCursor
+
+		// Запросы разделяются запятыми
+		if !expectToken(tokens, pointer, tokenFromSymbol(lex.CommaSymbol)) {
+			break
+		}
+		pointer++
+	}
+
+	return with, pointer, true
+}
+
+// parseCommonTableExpression парсит один именованный запрос: name [(column, ...)] AS (SELECT ...)
+func parseCommonTableExpression(tokens []*lex.Token, initialPointer uint) (*CommonTableExpression, uint, bool) {
+	pointer := initialPointer
+	query := &CommonTableExpression{}
+
+	// Парсим имя запроса
+	name, newCursor, ok := parseToken(tokens, pointer, lex.IdentifierToken)
+	if !ok {
+		helpMessage(tokens, pointer, "Expected WITH query name")
+		return nil, initialPointer, false
+	}
+	query.Name = *name
+	pointer = newCursor
+
+	// Парсим необязательный список имен колонок
+	if expectToken(tokens, pointer, tokenFromSymbol(lex.LeftparenSymbol)) {
+		columns, newCursor, ok := parseColumnNames(tokens, pointer)
+		if !ok {
+			return nil, initialPointer, false
+		}
+		query.Columns = columns
+		pointer = newCursor
+	}
+
+	// Ожидаем AS (
+	if !expectToken(tokens, pointer, tokenFromKeyword(lex.AsKeyword)) {
+		helpMessage(tokens, pointer, "Expected AS after WITH query name")
+		return nil, initialPointer, false
+	}
+	pointer++
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.LeftparenSymbol)) {
+		helpMessage(tokens, pointer, "Expected left paren before WITH query")
+		return nil, initialPointer, false
+	}
+	pointer++
+
+	selectStmt, newCursor, ok := parseSelectQuery(tokens, pointer)
+	if !ok {
+		helpMessage(tokens, pointer, "Expected SELECT in WITH query")
+		return nil, initialPointer, false
+	}
+	query.Select = selectStmt
+	pointer = newCursor
+
+	if !expectToken(tokens, pointer, tokenFromSymbol(lex.RightparenSymbol)) {
+		helpMessage(tokens, pointer, "Expected right paren after WITH query")
+		return nil, initialPointer, false
+	}
+
+	return query, pointer + 1, true
+}
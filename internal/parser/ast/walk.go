@@ -0,0 +1,86 @@
+package ast
+
+// WalkExpressions вызывает visit для каждого выражения statement'ов, включая подвыражения и выражения подзапросов.
+// Выражение посещается раньше своих подвыражений, поэтому visit может изменить его на месте
+func (ast *Ast) WalkExpressions(visit func(expression *Expression)) {
+	for _, statement := range ast.Statements {
+		switch statement.Kind {
+		case SelectKind:
+			statement.SelectStatement.WalkExpressions(visit)
+		case InsertKind:
+			for _, values := range statement.InsertStatement.Values {
+				walkExpressionList(values, visit)
+			}
+			statement.InsertStatement.Select.WalkExpressions(visit)
+			if statement.InsertStatement.OnConflict != nil {
+				for _, update := range statement.InsertStatement.OnConflict.Updates {
+					update.Value.Walk(visit)
+				}
+			}
+			walkExpressionList(statement.InsertStatement.Returning, visit)
+		case CreateTableKind:
+			for _, column := range *statement.CreateTableStatement.Columns {
+				column.Default.Walk(visit)
+			}
+		case AlterTableKind:
+			if statement.AlterTableStatement.Added != nil {
+				statement.AlterTableStatement.Added.Default.Walk(visit)
+			}
+			statement.AlterTableStatement.Default.Walk(visit)
+		}
+	}
+}
+
+// WalkExpressions вызывает visit для каждого выражения запроса, включая запросы WITH, подзапросы в FROM
+// и части составного запроса
+func (stmt *SelectStatement) WalkExpressions(visit func(expression *Expression)) {
+	if stmt == nil {
+		return
+	}
+
+	if stmt.With != nil {
+		for _, query := range stmt.With.Queries {
+			query.Select.WalkExpressions(visit)
+		}
+	}
+	if stmt.SetOperation != nil {
+		stmt.SetOperation.Left.WalkExpressions(visit)
+		stmt.SetOperation.Right.WalkExpressions(visit)
+	}
+
+	stmt.Subquery.WalkExpressions(visit)
+	for _, join := range stmt.Joins {
+		join.Subquery.WalkExpressions(visit)
+		join.Condition.Walk(visit)
+	}
+
+	walkExpressionList(stmt.SelectedColumns, visit)
+	stmt.Where.Walk(visit)
+	walkExpressionList(stmt.GroupBy, visit)
+	stmt.Having.Walk(visit)
+	for _, item := range stmt.OrderBy {
+		item.Expression.Walk(visit)
+	}
+}
+
+// Walk вызывает visit для выражения, затем для его подвыражений и выражений его подзапроса
+func (expression *Expression) Walk(visit func(expression *Expression)) {
+	if expression == nil {
+		return
+	}
+
+	visit(expression)
+	walkExpressionList(expression.Children(), visit)
+
+	switch expression.Kind {
+	case SubqueryKind, ExistsKind, InKind:
+		expression.Subquery.Select.WalkExpressions(visit)
+	}
+}
+
+// walkExpressionList обходит выражения списка по порядку
+func walkExpressionList(expressions []*Expression, visit func(expression *Expression)) {
+	for _, expression := range expressions {
+		expression.Walk(visit)
+	}
+}
@@ -5,20 +5,97 @@ type Keyword string
 
 const (
 	// Основные операторы SQL
-	CreateKeyword Keyword = "create" // CREATE TABLE
-	DropKeyword   Keyword = "drop"   // DROP TABLE
-	SelectKeyword Keyword = "select" // SELECT
-	InsertKeyword Keyword = "insert" // INSERT INTO
+	CreateKeyword   Keyword = "create"   // CREATE TABLE
+	DropKeyword     Keyword = "drop"     // DROP TABLE
+	SelectKeyword   Keyword = "select"   // SELECT
+	InsertKeyword   Keyword = "insert"   // INSERT INTO
+	AlterKeyword    Keyword = "alter"    // ALTER TABLE, ALTER COLUMN
+	TruncateKeyword Keyword = "truncate" // TRUNCATE TABLE
 
 	// Вспомогательные ключевые слова
-	FromKeyword   Keyword = "from"   // FROM table
-	TableKeyword  Keyword = "table"  // CREATE TABLE
-	IntoKeyword   Keyword = "into"   // INSERT INTO
-	ValuesKeyword Keyword = "values" // VALUES (...)
+	FromKeyword      Keyword = "from"      // FROM table
+	TableKeyword     Keyword = "table"     // CREATE TABLE
+	IntoKeyword      Keyword = "into"      // INSERT INTO
+	ValuesKeyword    Keyword = "values"    // VALUES (...)
+	WhereKeyword     Keyword = "where"     // WHERE condition
+	OrderKeyword     Keyword = "order"     // ORDER BY
+	ByKeyword        Keyword = "by"        // ORDER BY, GROUP BY
+	AscKeyword       Keyword = "asc"       // ORDER BY expr ASC
+	DescKeyword      Keyword = "desc"      // ORDER BY expr DESC
+	NullsKeyword     Keyword = "nulls"     // NULLS FIRST, NULLS LAST
+	FirstKeyword     Keyword = "first"     // NULLS FIRST
+	LastKeyword      Keyword = "last"      // NULLS LAST
+	LimitKeyword     Keyword = "limit"     // LIMIT n
+	OffsetKeyword    Keyword = "offset"    // OFFSET m
+	GroupKeyword     Keyword = "group"     // GROUP BY
+	HavingKeyword    Keyword = "having"    // HAVING condition
+	DistinctKeyword  Keyword = "distinct"  // SELECT DISTINCT, COUNT(DISTINCT expr)
+	AsKeyword        Keyword = "as"        // FROM users AS u, SELECT expr AS alias
+	JoinKeyword      Keyword = "join"      // JOIN table ON condition
+	InnerKeyword     Keyword = "inner"     // INNER JOIN
+	LeftKeyword      Keyword = "left"      // LEFT [OUTER] JOIN
+	RightKeyword     Keyword = "right"     // RIGHT [OUTER] JOIN
+	FullKeyword      Keyword = "full"      // FULL [OUTER] JOIN
+	OuterKeyword     Keyword = "outer"     // LEFT OUTER JOIN
+	CrossKeyword     Keyword = "cross"     // CROSS JOIN
+	OnKeyword        Keyword = "on"        // JOIN table ON condition
+	ExistsKeyword    Keyword = "exists"    // EXISTS (SELECT ...)
+	InKeyword        Keyword = "in"        // x IN (SELECT ...), x IN (1, 2, 3)
+	NotKeyword       Keyword = "not"       // NOT condition, NOT EXISTS, NOT IN, NOT LIKE, NOT BETWEEN, IS NOT NULL
+	WithKeyword      Keyword = "with"      // WITH name AS (SELECT ...)
+	RecursiveKeyword Keyword = "recursive" // WITH RECURSIVE
+	UnionKeyword     Keyword = "union"     // SELECT ... UNION SELECT ...
+	AllKeyword       Keyword = "all"       // UNION ALL
+	IntersectKeyword Keyword = "intersect" // SELECT ... INTERSECT SELECT ...
+	ExceptKeyword    Keyword = "except"    // SELECT ... EXCEPT SELECT ...
+	OverKeyword      Keyword = "over"      // row_number() OVER (...)
+	PartitionKeyword Keyword = "partition" // OVER (PARTITION BY ...)
+	RowsKeyword      Keyword = "rows"      // ROWS BETWEEN ... AND ...
+	RowKeyword       Keyword = "row"       // CURRENT ROW
+	BetweenKeyword   Keyword = "between"   // ROWS BETWEEN ... AND ..., x BETWEEN a AND b
+	AndKeyword       Keyword = "and"       // a AND b, ROWS BETWEEN ... AND ..., x BETWEEN a AND b
+	OrKeyword        Keyword = "or"        // a OR b
+	UnboundedKeyword Keyword = "unbounded" // UNBOUNDED PRECEDING, UNBOUNDED FOLLOWING
+	PrecedingKeyword Keyword = "preceding" // n PRECEDING
+	FollowingKeyword Keyword = "following" // n FOLLOWING
+	CurrentKeyword   Keyword = "current"   // CURRENT ROW
+	LikeKeyword      Keyword = "like"      // x [NOT] LIKE 'a%'
+	IlikeKeyword     Keyword = "ilike"     // x [NOT] ILIKE 'a%' - без учета регистра
+	IsKeyword        Keyword = "is"        // x IS [NOT] NULL
+	CaseKeyword      Keyword = "case"      // CASE WHEN ... THEN ... ELSE ... END
+	WhenKeyword      Keyword = "when"      // CASE WHEN condition THEN result
+	ThenKeyword      Keyword = "then"      // CASE WHEN condition THEN result
+	ElseKeyword      Keyword = "else"      // CASE ... ELSE result END
+	EndKeyword       Keyword = "end"       // CASE ... END
+	CastKeyword      Keyword = "cast"      // CAST(expr AS type)
+	PrimaryKeyword   Keyword = "primary"   // PRIMARY KEY
+	ConflictKeyword  Keyword = "conflict"  // INSERT ... ON CONFLICT
+	DoKeyword        Keyword = "do"        // ON CONFLICT DO NOTHING, ON CONFLICT DO UPDATE
+	UpdateKeyword    Keyword = "update"    // ON CONFLICT DO UPDATE
+	SetKeyword       Keyword = "set"       // DO UPDATE SET column = expr
+	ReturningKeyword Keyword = "returning" // INSERT ... RETURNING expr, ...
+	AddKeyword       Keyword = "add"       // ALTER TABLE ... ADD COLUMN
+	ColumnKeyword    Keyword = "column"    // ALTER TABLE ... ADD|DROP|RENAME|ALTER COLUMN
+	RenameKeyword    Keyword = "rename"    // ALTER TABLE ... RENAME [COLUMN]
+	ToKeyword        Keyword = "to"        // RENAME [COLUMN name] TO new_name
+	DefaultKeyword   Keyword = "default"   // column type DEFAULT expr, ALTER COLUMN ... SET|DROP DEFAULT
+	RestartKeyword   Keyword = "restart"   // TRUNCATE TABLE ... RESTART IDENTITY
+	IdentityKeyword  Keyword = "identity"  // TRUNCATE TABLE ... RESTART IDENTITY
+	IfKeyword        Keyword = "if"        // CREATE TABLE IF NOT EXISTS, DROP TABLE IF EXISTS
 
 	// Типы данных
-	IntKeyword  Keyword = "int"  // INTEGER
-	TextKeyword Keyword = "text" // TEXT
+	IntKeyword       Keyword = "int"       // INTEGER
+	TextKeyword      Keyword = "text"      // TEXT
+	DateKeyword      Keyword = "date"      // DATE, DATE '2024-01-31'
+	TimestampKeyword Keyword = "timestamp" // TIMESTAMP, TIMESTAMP '2024-01-31 10:00:00'
+	IntervalKeyword  Keyword = "interval"  // INTERVAL, INTERVAL '1 day'
+	DecimalKeyword   Keyword = "decimal"   // DECIMAL(p,s)
+	NumericKeyword   Keyword = "numeric"   // NUMERIC(p,s) - синоним DECIMAL
+	VarcharKeyword   Keyword = "varchar"   // VARCHAR(n)
+	CharKeyword      Keyword = "char"      // CHAR(n)
+	ByteaKeyword     Keyword = "bytea"     // BYTEA
+	JsonKeyword      Keyword = "json"      // JSON
+	BooleanKeyword   Keyword = "boolean"   // CAST(x AS BOOLEAN), x::boolean - только тип приведения, колонок BOOLEAN нет
 )
 
 // Keywords список всех ключевых слов для парсинга
@@ -28,25 +105,100 @@ var Keywords = []Keyword{
 	InsertKeyword,
 	CreateKeyword,
 	DropKeyword,
+	AlterKeyword,
+	TruncateKeyword,
 	// Вспомогательные ключевые слова
 	ValuesKeyword,
 	TableKeyword,
 	FromKeyword,
 	IntoKeyword,
+	WhereKeyword,
+	OrderKeyword,
+	ByKeyword,
+	AscKeyword,
+	DescKeyword,
+	NullsKeyword,
+	// FIRST и LAST не зарезервированы, чтобы их можно было использовать как имена колонок
+	LimitKeyword,
+	OffsetKeyword,
+	GroupKeyword,
+	HavingKeyword,
+	DistinctKeyword,
+	AsKeyword,
+	JoinKeyword,
+	InnerKeyword,
+	LeftKeyword,
+	RightKeyword,
+	FullKeyword,
+	OuterKeyword,
+	CrossKeyword,
+	OnKeyword,
+	ExistsKeyword,
+	InKeyword,
+	NotKeyword,
+	WithKeyword,
+	RecursiveKeyword,
+	UnionKeyword,
+	AllKeyword,
+	IntersectKeyword,
+	ExceptKeyword,
+	OverKeyword,
+	PartitionKeyword,
+	RowsKeyword,
+	RowKeyword,
+	BetweenKeyword,
+	AndKeyword,
+	OrKeyword,
+	UnboundedKeyword,
+	PrecedingKeyword,
+	FollowingKeyword,
+	CurrentKeyword,
+	LikeKeyword,
+	IlikeKeyword,
+	IsKeyword,
+	CaseKeyword,
+	WhenKeyword,
+	ThenKeyword,
+	ElseKeyword,
+	EndKeyword,
+	CastKeyword,
+	PrimaryKeyword,
+	ConflictKeyword,
+	DoKeyword,
+	UpdateKeyword,
+	SetKeyword,
+	ReturningKeyword,
+	AddKeyword,
+	ColumnKeyword,
+	RenameKeyword,
+	ToKeyword,
+	DefaultKeyword,
+	RestartKeyword,
+	IdentityKeyword,
+	IfKeyword,
 	// Типы данных
 	IntKeyword,
 	TextKeyword,
+	// DATE, TIMESTAMP и INTERVAL не зарезервированы: лексер возвращает их как идентификаторы,
+	// чтобы их можно было использовать как имена таблиц и колонок, а парсер распознает их по контексту
+	DecimalKeyword,
+	NumericKeyword,
+	VarcharKeyword,
+	CharKeyword,
+	ByteaKeyword,
+	JsonKeyword,
 }
 
 // Symbol тип для символов SQL
 type Symbol string
 
 const (
-	SemicolonSymbol  Symbol = ";" // Конец запроса
-	AsteriskSymbol   Symbol = "*" // SELECT *
-	CommaSymbol      Symbol = "," // Разделитель списков
-	LeftparenSymbol  Symbol = "("
-	RightparenSymbol Symbol = ")"
+	SemicolonSymbol   Symbol = ";" // Конец запроса
+	AsteriskSymbol    Symbol = "*" // SELECT *
+	CommaSymbol       Symbol = "," // Разделитель списков
+	LeftparenSymbol   Symbol = "("
+	RightparenSymbol  Symbol = ")"
+	DoubleColonSymbol Symbol = "::" // Приведение типа: expr::type
 )
 
 // symbols список всех символов для парсинга
@@ -56,6 +208,7 @@ var symbols = []Symbol{
 	RightparenSymbol,
 	SemicolonSymbol,
 	AsteriskSymbol,
+	DoubleColonSymbol,
 }
 
 // NullKeyword тип для ключевого слова NULL
@@ -69,10 +222,22 @@ const (
 type MathOperator string
 
 const (
-	EqualOperator       MathOperator = "="
-	NotEqualOperator    MathOperator = "!="
-	GreaterThanOperator MathOperator = ">"
-	LessThanOperator    MathOperator = "<"
+	EqualOperator          MathOperator = "="
+	NotEqualOperator       MathOperator = "!="
+	GreaterThanOperator    MathOperator = ">"
+	LessThanOperator       MathOperator = "<"
+	GreaterOrEqualOperator MathOperator = ">="
+	LessOrEqualOperator    MathOperator = "<="
+	PlusOperator           MathOperator = "+"
+	MinusOperator          MathOperator = "-"
+	MultiplyOperator       MathOperator = "*" // Лексер возвращает * как символ AsteriskSymbol, парсер выражений - как оператор умножения
+	DivideOperator         MathOperator = "/"
+	ModuloOperator         MathOperator = "%"
+	ConcatOperator         MathOperator = "||"  // Конкатенация строк
+	JsonGetOperator        MathOperator = "->"  // json -> 'key' или json -> 0, результат JSON
+	JsonGetTextOperator    MathOperator = "->>" // json ->> 'key', результат TEXT
+	JsonPathOperator       MathOperator = "#>"  // json #> '{a,0,b}', результат JSON
+	JsonPathTextOperator   MathOperator = "#>>" // json #>> '{a,0,b}', результат TEXT
 )
 
 // mathOperators список всех математических операторов для парсинга
@@ -81,4 +246,15 @@ var mathOperators = []MathOperator{
 	NotEqualOperator,
 	GreaterThanOperator,
 	LessThanOperator,
+	GreaterOrEqualOperator,
+	LessOrEqualOperator,
+	PlusOperator,
+	MinusOperator,
+	DivideOperator,
+	ModuloOperator,
+	ConcatOperator,
+	JsonGetOperator,
+	JsonGetTextOperator,
+	JsonPathOperator,
+	JsonPathTextOperator,
 }
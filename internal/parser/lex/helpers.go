@@ -36,6 +36,20 @@ func longestMatch(source string, startPointer uint, options []string) string {
 	return match
 }
 
+// isWordBoundary проверяет, что на указанной позиции заканчивается слово,
+// то есть следующий символ не может быть продолжением идентификатора
+func isWordBoundary(source string, pointer uint) bool {
+	if pointer >= uint(len(source)) {
+		return true
+	}
+
+	currentChar := source[pointer]
+	isAlphabetical := (currentChar >= 'A' && currentChar <= 'Z') || (currentChar >= 'a' && currentChar <= 'z')
+	isNumeric := currentChar >= '0' && currentChar <= '9'
+
+	return !isAlphabetical && !isNumeric && currentChar != '$' && currentChar != '_'
+}
+
 // maxOptionLength возвращает максимальную длину среди всех опций
 func maxOptionLength(options []string) int {
 	maxLen := 0
@@ -29,6 +29,7 @@ func (l *lex) Lex(source string) ([]*Token, error) {
 		lexSymbol,       // Символы (скобки, запятые и т.д.)
 		lexNull,         // NULL
 		lexMathOperator, // Математические операторы (=, <, >, !=)
+		lexHexString,    // Шестнадцатеричные строковые литералы (X'DEADBEEF')
 		lexString,       // Строковые литералы
 		lexNumeric,      // Числовые литералы
 		lexIdentifier,   // Идентификаторы (имена таблиц, колонок)
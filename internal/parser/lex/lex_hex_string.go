@@ -0,0 +1,22 @@
+package lex
+
+// lexHexString парсит шестнадцатеричные строковые литералы вида X'DEADBEEF' (для BYTEA)
+func lexHexString(source string, startPointer uint) (*Token, uint, bool) {
+	// Проверяем, что не вышли за пределы длинны sql запроса
+	if startPointer+1 >= uint(len(source)) {
+		return nil, startPointer, false
+	}
+
+	// Литерал начинается с префикса X или x, сразу за которым идет кавычка
+	if source[startPointer] != 'x' && source[startPointer] != 'X' {
+		return nil, startPointer, false
+	}
+
+	token, newPointer, ok := lexCharacterDelimited(source, startPointer+1, '\'')
+	if !ok {
+		return nil, startPointer, false
+	}
+
+	token.Kind = HexStringToken
+	return token, newPointer, true
+}
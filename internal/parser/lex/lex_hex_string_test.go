@@ -0,0 +1,54 @@
+package lex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexHexString(t *testing.T) {
+	t.Run("valid hex string", func(t *testing.T) {
+		input := "X'DEADBEEF'"
+		startPointer := uint(0)
+
+		got, newPointer, isValid := lexHexString(input, startPointer)
+
+		require.True(t, isValid)
+		require.Equal(t, "DEADBEEF", got.Value)
+		require.Equal(t, HexStringToken, got.Kind)
+		require.Equal(t, uint(11), newPointer)
+	})
+
+	t.Run("valid lower case prefix", func(t *testing.T) {
+		input := "x'00ff', 1"
+		startPointer := uint(0)
+
+		got, newPointer, isValid := lexHexString(input, startPointer)
+
+		require.True(t, isValid)
+		require.Equal(t, "00ff", got.Value)
+		require.Equal(t, uint(7), newPointer)
+	})
+
+	t.Run("identifier starting with x", func(t *testing.T) {
+		input := "xid"
+		startPointer := uint(0)
+
+		got, newPointer, isValid := lexHexString(input, startPointer)
+
+		require.False(t, isValid)
+		require.Nil(t, got)
+		require.Equal(t, startPointer, newPointer)
+	})
+
+	t.Run("unterminated hex string", func(t *testing.T) {
+		input := "X'DEAD"
+		startPointer := uint(0)
+
+		got, newPointer, isValid := lexHexString(input, startPointer)
+
+		require.False(t, isValid)
+		require.Nil(t, got)
+		require.Equal(t, startPointer, newPointer)
+	})
+}
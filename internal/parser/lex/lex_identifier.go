@@ -2,18 +2,47 @@ package lex
 
 import "strings"
 
-// lexIdentifier парсит идентификаторы (имена таблиц, колонок и т.д.)
+// lexIdentifier парсит идентификаторы (имена таблиц, колонок и т.д.).
+// Квалифицированное имя колонки (users.id, u."Name") возвращается одним токеном, части разделены точкой.
+// Все колонки таблицы в списке SELECT (users.*) - тоже один токен
 func lexIdentifier(source string, startPointer uint) (*Token, uint, bool) {
+	value, pointer, ok := lexIdentifierPart(source, startPointer)
+	if !ok {
+		return nil, startPointer, false
+	}
+
+	// Продолжаем, пока после точки идет следующая часть имени
+	for pointer+1 < uint(len(source)) && source[pointer] == '.' {
+		if source[pointer+1] == '*' {
+			value += ".*"
+			pointer += 2
+			break
+		}
+
+		part, newPointer, ok := lexIdentifierPart(source, pointer+1)
+		if !ok {
+			break
+		}
+		value += "." + part
+		pointer = newPointer
+	}
+
+	return &Token{
+		Value: value,
+		Kind:  IdentifierToken,
+	}, pointer, true
+}
+
+// lexIdentifierPart парсит одну часть идентификатора: имя в двойных кавычках или обычное имя
+func lexIdentifierPart(source string, startPointer uint) (string, uint, bool) {
 	// Проверяем, что не вышли за пределы длинны sql запроса
 	if startPointer >= uint(len(source)) {
-		return nil, startPointer, false
+		return "", startPointer, false
 	}
 
 	// Сначала проверяем, не является ли это идентификатором в двойных кавычках
 	if token, newPointer, ok := lexCharacterDelimited(source, startPointer, '"'); ok {
-		// Меняем тип токена с StringToken на IdentifierToken
-		token.Kind = IdentifierToken
-		return token, newPointer, true
+		return token.Value, newPointer, true
 	}
 
 	pointer := startPointer
@@ -22,7 +51,7 @@ func lexIdentifier(source string, startPointer uint) (*Token, uint, bool) {
 	// Первый символ должен быть буквой (A-Z, a-z)
 	isAlphabetical := (currentChar >= 'A' && currentChar <= 'Z') || (currentChar >= 'a' && currentChar <= 'z')
 	if !isAlphabetical {
-		return nil, startPointer, false
+		return "", startPointer, false
 	}
 
 	// Начинаем накапливать символы идентификатора
@@ -46,9 +75,6 @@ func lexIdentifier(source string, startPointer uint) (*Token, uint, bool) {
 		break
 	}
 
-	return &Token{
-		// Нецитируемые идентификаторы нечувствительны к регистру
-		Value: strings.ToLower(string(value)),
-		Kind:  IdentifierToken,
-	}, pointer, true
+	// Нецитируемые идентификаторы нечувствительны к регистру
+	return strings.ToLower(string(value)), pointer, true
 }
@@ -28,4 +28,40 @@ func TestLexIdentifier(t *testing.T) {
 		require.False(t, isValid)
 	})
 
+	t.Run("qualified identifier", func(t *testing.T) {
+		input := "Users.Name = u.\"Title\""
+		startPointer := uint(0)
+
+		got, newPointer, isValid := lexIdentifier(input, startPointer)
+		quoted, quotedPointer, quotedValid := lexIdentifier(input, 13)
+
+		require.True(t, isValid)
+		require.Equal(t, "users.name", got.Value)
+		require.Equal(t, uint(10), newPointer)
+		require.True(t, quotedValid)
+		require.Equal(t, "u.Title", quoted.Value)
+		require.Equal(t, uint(len(input)), quotedPointer)
+	})
+
+	t.Run("trailing dot is not part of identifier", func(t *testing.T) {
+		input := "users."
+		startPointer := uint(0)
+
+		got, newPointer, isValid := lexIdentifier(input, startPointer)
+
+		require.True(t, isValid)
+		require.Equal(t, "users", got.Value)
+		require.Equal(t, uint(5), newPointer)
+	})
+
+	t.Run("all columns of table", func(t *testing.T) {
+		input := "U.*, name"
+		startPointer := uint(0)
+
+		got, newPointer, isValid := lexIdentifier(input, startPointer)
+
+		require.True(t, isValid)
+		require.Equal(t, "u.*", got.Value)
+		require.Equal(t, uint(3), newPointer)
+	})
 }
@@ -19,6 +19,11 @@ func lexKeyword(source string, startPointer uint) (*Token, uint, bool) {
 	// Вычисляем новую позицию указателя после найденного ключевого слова
 	newPointer := startPointer + uint(len(match))
 
+	// Ключевое слово должно быть отдельным словом, иначе это начало идентификатора (например, date_created)
+	if !isWordBoundary(source, newPointer) {
+		return nil, startPointer, false
+	}
+
 	return &Token{
 		Value: match,
 		Kind:  KeywordToken,
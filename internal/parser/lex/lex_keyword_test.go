@@ -20,7 +20,49 @@ func TestLexKeyword(t *testing.T) {
 	})
 
 	t.Run("invalid keyword", func(t *testing.T) {
-		input := "not a keyword"
+		input := "nothing but identifiers"
+		startPointer := uint(0)
+
+		_, _, isValid := lexKeyword(input, startPointer)
+
+		require.False(t, isValid)
+	})
+
+	t.Run("keyword as identifier prefix", func(t *testing.T) {
+		input := "date_created"
+		startPointer := uint(0)
+
+		_, _, isValid := lexKeyword(input, startPointer)
+
+		require.False(t, isValid)
+	})
+
+	t.Run("keyword followed by literal", func(t *testing.T) {
+		input := "TEXT 'abc'"
+		want := "text"
+		startPointer := uint(0)
+
+		got, newPointer, isValid := lexKeyword(input, startPointer)
+
+		require.True(t, isValid)
+		require.Equal(t, want, got.Value)
+		require.Equal(t, uint(4), newPointer)
+	})
+
+	t.Run("ilike is not in", func(t *testing.T) {
+		input := "ILIKE 'a%'"
+		want := "ilike"
+		startPointer := uint(0)
+
+		got, newPointer, isValid := lexKeyword(input, startPointer)
+
+		require.True(t, isValid)
+		require.Equal(t, want, got.Value)
+		require.Equal(t, uint(5), newPointer)
+	})
+
+	t.Run("is as identifier prefix", func(t *testing.T) {
+		input := "issue_id IS NULL"
 		startPointer := uint(0)
 
 		_, _, isValid := lexKeyword(input, startPointer)
@@ -26,6 +26,17 @@ func TestLexOperator(t *testing.T) {
 			{"<", string(LessThanOperator), 1},
 			{">", string(GreaterThanOperator), 1},
 			{"!=", string(NotEqualOperator), 2},
+			{">=", string(GreaterOrEqualOperator), 2},
+			{"<=", string(LessOrEqualOperator), 2},
+			{"+", string(PlusOperator), 1},
+			{"-", string(MinusOperator), 1},
+			{"/", string(DivideOperator), 1},
+			{"%", string(ModuloOperator), 1},
+			{"||", string(ConcatOperator), 2},
+			{"->", string(JsonGetOperator), 2},
+			{"->>", string(JsonGetTextOperator), 3},
+			{"#>", string(JsonPathOperator), 2},
+			{"#>>", string(JsonPathTextOperator), 3},
 		}
 
 		for _, tt := range tests {
@@ -47,9 +58,11 @@ func TestLexOperator(t *testing.T) {
 			wantPointer uint
 		}{
 			{"====", string(EqualOperator), 1},
-			{"<=-", string(LessThanOperator), 1},
+			{"<=-", string(LessOrEqualOperator), 2},
 			{">--=", string(GreaterThanOperator), 1},
 			{"!===", string(NotEqualOperator), 2},
+			{"->>>", string(JsonGetTextOperator), 3},
+			{"-->", string(MinusOperator), 1},
 		}
 
 		for _, tt := range tests {
@@ -16,6 +16,11 @@ func lexNull(source string, startPointer uint) (*Token, uint, bool) {
 	// Вычисляем новую позицию указателя после найденного NULL
 	newPointer := startPointer + uint(len(match))
 
+	// NULL должен быть отдельным словом, иначе это начало идентификатора (например, nullable)
+	if !isWordBoundary(source, newPointer) {
+		return nil, startPointer, false
+	}
+
 	return &Token{
 		Value: match,
 		Kind:  NullToken,
@@ -17,7 +17,7 @@ func lexNumeric(source string, startPointer uint) (*Token, uint, bool) {
 
 		isDigit := currentChar >= '0' && currentChar <= '9'
 		isPeriod := currentChar == '.'
-		isExpMarker := currentChar == 'e'
+		isExpMarker := currentChar == 'e' || currentChar == 'E'
 
 		// Первый символ должен быть цифрой или точкой
 		if pointer == startPointer {
@@ -37,7 +37,7 @@ func lexNumeric(source string, startPointer uint) (*Token, uint, bool) {
 			continue
 		}
 
-		// Обработка экспоненциального маркера (e)
+		// Обработка экспоненциального маркера (e или E)
 		if isExpMarker {
 			if expMarkerFound {
 				return nil, startPointer, false // Двойной экспоненциальный маркер недопустим
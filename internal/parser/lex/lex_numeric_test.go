@@ -31,6 +31,18 @@ func TestLexNumeric(t *testing.T) {
 		require.NotEqual(t, startPointer, newPointer)
 	})
 
+	t.Run("valid exponent with upper case marker", func(t *testing.T) {
+		input := "1.25E-3,"
+		want := "1.25E-3"
+		startPointer := uint(0)
+
+		got, newPointer, isValid := lexNumeric(input, startPointer)
+
+		require.True(t, isValid)
+		require.Equal(t, want, got.Value)
+		require.Equal(t, uint(7), newPointer)
+	})
+
 	t.Run("invalid number", func(t *testing.T) {
 		input := "not a number"
 		startPointer := uint(0)
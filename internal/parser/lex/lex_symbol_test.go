@@ -28,4 +28,15 @@ func TestLexSymbol(t *testing.T) {
 		require.False(t, isValid)
 	})
 
+	t.Run("double colon is a single symbol", func(t *testing.T) {
+		input := "::int"
+		want := "::"
+		startPointer := uint(0)
+
+		got, newPointer, isValid := lexSymbol(input, startPointer)
+
+		require.True(t, isValid)
+		require.Equal(t, want, got.Value)
+		require.Equal(t, uint(2), newPointer)
+	})
 }
@@ -12,6 +12,7 @@ const (
 	NullToken                             // NULL значение
 	MathOperatorToken                     // Математические операторы: =, <, >, !=
 	LogicalOperatorToken                  // Логические операторы: AND, OR, NOT
+	HexStringToken                        // Шестнадцатеричные строки: X'DEADBEEF'
 )
 
 // Token представляет один токен в SQL-запросе
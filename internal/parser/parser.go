@@ -1,8 +1,10 @@
 package parser
 
 import (
+	"custom-database/internal/functions"
 	"custom-database/internal/parser/ast"
 	"custom-database/internal/parser/validator"
+	"strings"
 )
 
 type ParserService interface {
@@ -10,20 +12,30 @@ type ParserService interface {
 }
 
 type parser struct {
+	// functions реестр функций: по нему проверяются вызовы и распознаются пользовательские агрегатные функции
+	functions *functions.Registry
 }
 
+// NewParser создает парсер, которому известны только встроенные функции
 func NewParser() ParserService {
-	return &parser{}
+	return NewParserWithFunctions(functions.NewRegistry())
+}
+
+// NewParserWithFunctions создает парсер с реестром функций executor'а,
+// чтобы в запросах можно было вызывать зарегистрированные в нем функции
+func NewParserWithFunctions(registry *functions.Registry) ParserService {
+	return &parser{functions: registry}
 }
 
 func (p *parser) Parse(query string) (*ast.Ast, error) {
 	astService := ast.NewAst()
-	validator := validator.NewValidator()
+	validator := validator.NewValidatorWithFunctions(p.functions)
 
 	astResult, err := astService.Parse(query)
 	if err != nil {
 		return nil, err
 	}
+	p.resolveAggregateCalls(astResult)
 
 	err = validator.ValidateAST(astResult)
 	if err != nil {
@@ -32,3 +44,31 @@ func (p *parser) Parse(query string) (*ast.Ast, error) {
 
 	return astResult, nil
 }
+
+// resolveAggregateCalls превращает вызовы пользовательских агрегатных функций в агрегатные выражения.
+// Встроенные агрегатные функции распознаются при разборе по имени, а о пользовательских знает только реестр
+func (p *parser) resolveAggregateCalls(tree *ast.Ast) {
+	tree.WalkExpressions(func(expression *ast.Expression) {
+		if expression.Kind != ast.FunctionCallKind {
+			return
+		}
+		function, ok := p.functions.Lookup(expression.FunctionCall.Name.Value)
+		if !ok || function.Kind != functions.AggregateKind {
+			return
+		}
+
+		aggregate := &ast.AggregateExpression{
+			Name:      expression.FunctionCall.Name,
+			Arguments: expression.FunctionCall.Arguments,
+		}
+		aggregate.Name.Value = strings.ToLower(aggregate.Name.Value)
+		// name(*) разбирается как вызов с аргументом *
+		if len(aggregate.Arguments) == 1 && aggregate.Arguments[0].Kind == ast.StarKind && aggregate.Arguments[0].Literal == nil {
+			aggregate.Arguments, aggregate.Star = nil, true
+		}
+
+		expression.Kind = ast.AggregateKind
+		expression.Aggregate = aggregate
+		expression.FunctionCall = nil
+	})
+}
@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/functions"
 	"custom-database/internal/parser/ast"
 	"fmt"
 	"testing"
@@ -36,10 +38,10 @@ func TestParse(t *testing.T) {
 		require.Len(t, result.Statements, 1)
 		require.Equal(t, ast.InsertKind, result.Statements[0].Kind)
 		require.Equal(t, "users", result.Statements[0].InsertStatement.Table.Value)
-		require.Len(t, *result.Statements[0].InsertStatement.Values, 3)
-		require.Equal(t, "1", (*result.Statements[0].InsertStatement.Values)[0].Literal.Value)
-		require.Equal(t, "Phil", (*result.Statements[0].InsertStatement.Values)[1].Literal.Value)
-		require.Equal(t, "true", (*result.Statements[0].InsertStatement.Values)[2].Literal.Value)
+		require.Len(t, result.Statements[0].InsertStatement.Values[0], 3)
+		require.Equal(t, "1", result.Statements[0].InsertStatement.Values[0][0].Literal.Value)
+		require.Equal(t, "Phil", result.Statements[0].InsertStatement.Values[0][1].Literal.Value)
+		require.Equal(t, "true", result.Statements[0].InsertStatement.Values[0][2].Literal.Value)
 	})
 
 	t.Run("valid SELECT statement", func(t *testing.T) {
@@ -70,6 +72,40 @@ func TestParse(t *testing.T) {
 		require.Equal(t, "users", result.Statements[0].DropTableStatement.Table.Value)
 	})
 
+	t.Run("valid IF NOT EXISTS and IF EXISTS", func(t *testing.T) {
+		source := "CREATE TABLE IF NOT EXISTS users (id INT); DROP TABLE IF EXISTS users; DROP TABLE users;"
+		parser := NewParser()
+
+		result, err := parser.Parse(source)
+		_, missingNotErr := parser.Parse("CREATE TABLE IF EXISTS users (id INT);")
+		_, missingExistsErr := parser.Parse("DROP TABLE IF users;")
+
+		require.NoError(t, err)
+		require.Len(t, result.Statements, 3)
+		require.True(t, result.Statements[0].CreateTableStatement.IfNotExists)
+		require.Equal(t, "users", result.Statements[0].CreateTableStatement.Table.Value)
+		require.True(t, result.Statements[1].DropTableStatement.IfExists)
+		require.Equal(t, "users", result.Statements[1].DropTableStatement.Table.Value)
+		require.False(t, result.Statements[2].DropTableStatement.IfExists)
+		require.Error(t, missingNotErr)
+		require.Error(t, missingExistsErr)
+	})
+
+	t.Run("valid TRUNCATE TABLE statement", func(t *testing.T) {
+		source := "TRUNCATE TABLE users RESTART IDENTITY; TRUNCATE posts;"
+		parser := NewParser()
+
+		result, err := parser.Parse(source)
+
+		require.NoError(t, err)
+		require.Len(t, result.Statements, 2)
+		require.Equal(t, ast.TruncateTableKind, result.Statements[0].Kind)
+		require.Equal(t, "users", result.Statements[0].TruncateTableStatement.Table.Value)
+		require.True(t, result.Statements[0].TruncateTableStatement.RestartIdentity)
+		require.Equal(t, "posts", result.Statements[1].TruncateTableStatement.Table.Value)
+		require.False(t, result.Statements[1].TruncateTableStatement.RestartIdentity)
+	})
+
 	t.Run("valid multiple statements", func(t *testing.T) {
 		source := "CREATE TABLE users (id INT, name TEXT); INSERT INTO users VALUES (1, 'Phil');"
 		parser := NewParser()
@@ -87,7 +123,7 @@ func TestParse(t *testing.T) {
 		// Проверяем INSERT
 		require.Equal(t, ast.InsertKind, result.Statements[1].Kind)
 		require.Equal(t, "users", result.Statements[1].InsertStatement.Table.Value)
-		require.Len(t, *result.Statements[1].InsertStatement.Values, 2)
+		require.Len(t, result.Statements[1].InsertStatement.Values[0], 2)
 	})
 
 	t.Run("invalid statement - missing semicolon", func(t *testing.T) {
@@ -186,6 +222,23 @@ func TestParse(t *testing.T) {
 		require.Contains(t, err.Error(), "failed to parse")
 	})
 
+	t.Run("valid type names as table and column names", func(t *testing.T) {
+		source := "CREATE TABLE date (date DATE, timestamp TIMESTAMP, interval INTERVAL); SELECT date, interval FROM date WHERE date > DATE '2024-01-01' - interval;"
+		parser := NewParser()
+
+		result, err := parser.Parse(source)
+
+		require.NoError(t, err)
+		require.Len(t, result.Statements, 2)
+		columns := *result.Statements[0].CreateTableStatement.Columns
+		require.Equal(t, "date", result.Statements[0].CreateTableStatement.Table.Value)
+		require.Equal(t, "date", columns[0].Name.Value)
+		require.Equal(t, "date", columns[0].Datatype.Value)
+		require.Equal(t, "interval", columns[2].Name.Value)
+		require.Equal(t, ast.LiteralKind, result.Statements[1].SelectStatement.SelectedColumns[0].Kind)
+		require.Equal(t, "date", result.Statements[1].SelectStatement.SelectedColumns[0].Literal.Value)
+	})
+
 	t.Run("validator - CREATE TABLE with keyword as column name", func(t *testing.T) {
 		source := "CREATE TABLE users (SELECT INT);"
 		parser := NewParser()
@@ -347,18 +400,373 @@ func TestParse(t *testing.T) {
 		require.Contains(t, err.Error(), "INSERT statement must specify values")
 	})
 
+	t.Run("validator - SELECT with column missing from GROUP BY", func(t *testing.T) {
+		source := "SELECT region, id, count(*) FROM sales GROUP BY region;"
+		parser := NewParser()
+
+		result, err := parser.Parse(source)
+
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "column id must appear in the GROUP BY clause or be used in an aggregate function")
+	})
+
+	t.Run("validator - SELECT mixing aggregates and plain columns without GROUP BY", func(t *testing.T) {
+		source := "SELECT region, count(*) FROM sales;"
+		parser := NewParser()
+
+		result, err := parser.Parse(source)
+
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "column region must appear in the GROUP BY clause")
+	})
+
+	t.Run("validator - aggregate in WHERE and nested aggregates", func(t *testing.T) {
+		parser := NewParser()
+
+		_, whereErr := parser.Parse("SELECT id FROM sales WHERE count(*) > 1;")
+		_, nestedErr := parser.Parse("SELECT sum(count(*)) FROM sales;")
+		_, starErr := parser.Parse("SELECT sum(*) FROM sales;")
+
+		require.ErrorContains(t, whereErr, "aggregate functions are not allowed in WHERE")
+		require.ErrorContains(t, nestedErr, "aggregate function calls cannot be nested")
+		require.ErrorContains(t, starErr, "only count(*)")
+	})
+
+	t.Run("validator - valid grouped queries", func(t *testing.T) {
+		validQueries := []string{
+			"SELECT count(*) FROM sales;",
+			"SELECT region, count(*), sum(amount) FROM sales GROUP BY region;",
+			"SELECT qty + 1, max(amount) FROM sales GROUP BY qty + 1;",
+			"SELECT region FROM sales GROUP BY region HAVING count(DISTINCT qty) > 1 ORDER BY avg(amount) DESC;",
+			"SELECT s.region, count(*) FROM sales s GROUP BY region;",
+		}
+
+		parser := NewParser()
+
+		for _, query := range validQueries {
+			result, err := parser.Parse(query)
+			require.NoError(t, err, "Query should be valid: %s", query)
+			require.NotNil(t, result)
+		}
+	})
+
+	t.Run("validator - JOIN errors", func(t *testing.T) {
+		parser := NewParser()
+
+		_, duplicateErr := parser.Parse("SELECT * FROM users JOIN users ON users.id = users.id;")
+		_, aliasErr := parser.Parse("SELECT * FROM users u JOIN posts u ON u.id = u.user_id;")
+		_, aggregateErr := parser.Parse("SELECT * FROM users u JOIN posts p ON count(*) > 1;")
+		_, groupingErr := parser.Parse("SELECT u.id, count(*) FROM users u JOIN posts p ON u.id = p.user_id GROUP BY u.name;")
+
+		require.ErrorContains(t, duplicateErr, "table name users specified more than once")
+		require.ErrorContains(t, aliasErr, "table name u specified more than once")
+		require.ErrorContains(t, aggregateErr, "aggregate functions are not allowed in JOIN conditions")
+		require.ErrorContains(t, groupingErr, "column u.id must appear in the GROUP BY clause")
+	})
+
+	t.Run("validator - subquery errors", func(t *testing.T) {
+		parser := NewParser()
+
+		_, aliasErr := parser.Parse("SELECT * FROM (SELECT * FROM users);")
+		_, innerErr := parser.Parse("SELECT * FROM users WHERE id IN (SELECT id, count(*) FROM posts);")
+		_, keywordErr := parser.Parse("SELECT * FROM users WHERE EXISTS (SELECT * FROM select);")
+
+		require.ErrorContains(t, aliasErr, "subquery in FROM must have an alias")
+		require.ErrorContains(t, innerErr, "column id must appear in the GROUP BY clause")
+		require.Error(t, keywordErr)
+	})
+
+	t.Run("validator - WITH errors", func(t *testing.T) {
+		parser := NewParser()
+
+		_, duplicateErr := parser.Parse("WITH t AS (SELECT * FROM users), t AS (SELECT * FROM posts) SELECT * FROM t;")
+		_, columnErr := parser.Parse("WITH t(a, a) AS (SELECT id, name FROM users) SELECT * FROM t;")
+		_, aggregateErr := parser.Parse("WITH RECURSIVE t(n) AS (SELECT id FROM users UNION SELECT count(*) FROM t) SELECT * FROM t;")
+
+		require.ErrorContains(t, duplicateErr, "WITH query name t specified more than once")
+		require.ErrorContains(t, columnErr, "column a specified more than once in WITH query t")
+		require.ErrorContains(t, aggregateErr, "aggregate functions are not allowed in a recursive query's recursive term")
+	})
+
+	t.Run("validator - set operation errors", func(t *testing.T) {
+		parser := NewParser()
+
+		_, columnsErr := parser.Parse("SELECT id, name FROM users UNION SELECT id FROM posts;")
+		_, typesErr := parser.Parse("SELECT id, 'x' FROM users EXCEPT SELECT id, 1 FROM posts;")
+		_, dateErr := parser.Parse("SELECT DATE '2024-01-31' FROM users INTERSECT SELECT 1 FROM posts;")
+		_, aggregateErr := parser.Parse("SELECT id FROM users UNION SELECT id FROM posts ORDER BY max(id);")
+		valid, validErr := parser.Parse("SELECT DATE '2024-01-31', null FROM users UNION SELECT '2024-02-01', 1 FROM posts;")
+
+		require.ErrorContains(t, columnsErr, "each UNION query must have the same number of columns")
+		require.ErrorContains(t, typesErr, "EXCEPT types TEXT and NUMERIC cannot be matched")
+		require.ErrorContains(t, dateErr, "INTERSECT types DATE and NUMERIC cannot be matched")
+		require.ErrorContains(t, aggregateErr, "aggregate functions are not allowed in ORDER BY of UNION")
+		require.NoError(t, validErr)
+		require.NotNil(t, valid)
+	})
+
+	t.Run("validator - select list errors", func(t *testing.T) {
+		parser := NewParser()
+
+		_, distinctErr := parser.Parse("SELECT DISTINCT name FROM users ORDER BY id;")
+		_, aliasErr := parser.Parse("SELECT id AS select FROM users;")
+		valid, validErr := parser.Parse("SELECT DISTINCT name AS n, id % 2 FROM users ORDER BY n, 2;")
+
+		require.ErrorContains(t, distinctErr, "for SELECT DISTINCT, ORDER BY expressions must appear in select list")
+		require.Error(t, aliasErr)
+		require.NoError(t, validErr)
+		require.NotNil(t, valid)
+	})
+
+	t.Run("validator - CASE and CAST errors", func(t *testing.T) {
+		parser := NewParser()
+
+		_, typeErr := parser.Parse("SELECT CAST(id AS VARCHAR(0)) FROM users;")
+		_, parametersErr := parser.Parse("SELECT id::int(5) FROM users;")
+		_, keywordErr := parser.Parse("SELECT id AS end FROM users;")
+		_, booleanColumnErr := parser.Parse("CREATE TABLE flags (flag BOOLEAN);")
+		valid, validErr := parser.Parse("SELECT CASE WHEN name IS NULL THEN 'none' ELSE name END, coalesce(name, id::text) FROM users;")
+		booleanCast, booleanErr := parser.Parse("SELECT CAST(id AS BOOLEAN), 't'::boolean, boolean FROM boolean;")
+
+		require.ErrorContains(t, typeErr, "VARCHAR length 0 must be between 1 and 10485760")
+		require.ErrorContains(t, parametersErr, "Type INT does not accept parameters")
+		require.Error(t, keywordErr)
+		require.Error(t, booleanColumnErr)
+		require.NoError(t, booleanErr)
+		require.Equal(t, "boolean", booleanCast.Statements[0].SelectStatement.SelectedColumns[1].Cast.DataType.Value)
+		require.NoError(t, validErr)
+		require.NotNil(t, valid)
+	})
+
+	t.Run("validator - AND, OR and NOT", func(t *testing.T) {
+		parser := NewParser()
+
+		_, typeErr := parser.Parse("SELECT id FROM users WHERE id = 1 OR 'a';")
+		_, notErr := parser.Parse("SELECT id FROM users WHERE NOT 1;")
+		_, keywordErr := parser.Parse("SELECT id AS or FROM users;")
+		valid, validErr := parser.Parse("SELECT id > 1 AND NOT name IS NULL FROM users WHERE NULL OR id BETWEEN 1 AND 2 AND name != 'a';")
+
+		require.ErrorContains(t, typeErr, "argument of OR must be type BOOLEAN, not type TEXT")
+		require.ErrorContains(t, notErr, "argument of NOT must be type BOOLEAN, not type NUMERIC")
+		require.Error(t, keywordErr)
+		require.NoError(t, validErr)
+		require.NotNil(t, valid)
+	})
+
+	t.Run("validator - function call errors", func(t *testing.T) {
+		parser := NewParser()
+
+		_, unknownErr := parser.Parse("SELECT foo(id) FROM users;")
+		_, countErr := parser.Parse("SELECT substr(name) FROM users;")
+		_, noArgumentsErr := parser.Parse("SELECT now(id) FROM users;")
+		_, variadicErr := parser.Parse("SELECT concat() FROM users;")
+		_, typeErr := parser.Parse("SELECT upper(1) FROM users;")
+		_, secondTypeErr := parser.Parse("SELECT substr(name, 'a') FROM users;")
+		valid, validErr := parser.Parse("SELECT UPPER(name), substr(name, 1, 2), round(id, 2), power(2, 0.5), position('a' IN name) FROM users;")
+
+		require.ErrorContains(t, unknownErr, "function foo does not exist")
+		require.ErrorContains(t, countErr, "function substr expects 2 or 3 arguments, got 1")
+		require.ErrorContains(t, noArgumentsErr, "function now expects no arguments, got 1")
+		require.ErrorContains(t, variadicErr, "function concat expects at least 1 argument, got 0")
+		require.ErrorContains(t, typeErr, "function upper does not accept argument 1 of type NUMERIC")
+		require.ErrorContains(t, secondTypeErr, "function substr does not accept argument 2 of type TEXT")
+		require.NoError(t, validErr)
+		require.NotNil(t, valid)
+	})
+
+	t.Run("validator - INSERT column list and rows", func(t *testing.T) {
+		parser := NewParser()
+
+		_, duplicateErr := parser.Parse("INSERT INTO users (id, id) VALUES (1, 2);")
+		_, moreValuesErr := parser.Parse("INSERT INTO users (id) VALUES (1, 'Phil');")
+		_, moreColumnsErr := parser.Parse("INSERT INTO users (id, name) VALUES (1), (2);")
+		_, lengthErr := parser.Parse("INSERT INTO users VALUES (1, 'Phil'), (2);")
+		_, selectErr := parser.Parse("INSERT INTO users SELECT id FROM users WHERE count(*) > 1;")
+		valid, validErr := parser.Parse("INSERT INTO users (name, id) VALUES ('Phil', 1), ('Ann', 2);")
+
+		require.ErrorContains(t, duplicateErr, "Column id specified more than once")
+		require.ErrorContains(t, moreValuesErr, "INSERT has more expressions than target columns")
+		require.ErrorContains(t, moreColumnsErr, "INSERT has more target columns than expressions")
+		require.ErrorContains(t, lengthErr, "VALUES lists must all be the same length")
+		require.ErrorContains(t, selectErr, "aggregate functions are not allowed in WHERE")
+		require.NoError(t, validErr)
+		require.Len(t, valid.Statements[0].InsertStatement.Values, 2)
+	})
+
+	t.Run("validator - PRIMARY KEY and ON CONFLICT", func(t *testing.T) {
+		parser := NewParser()
+
+		_, multipleErr := parser.Parse("CREATE TABLE pairs (a INT PRIMARY KEY, b INT, PRIMARY KEY (a, b));")
+		_, unknownErr := parser.Parse("CREATE TABLE pairs (a INT, PRIMARY KEY (c));")
+		_, twiceErr := parser.Parse("CREATE TABLE pairs (a INT, PRIMARY KEY (a, a));")
+		_, targetErr := parser.Parse("INSERT INTO users VALUES (1, 'Phil') ON CONFLICT DO UPDATE SET name = excluded.name;")
+		_, assignmentErr := parser.Parse("INSERT INTO users VALUES (1, 'Phil') ON CONFLICT (id) DO UPDATE SET name = 'a', name = 'b';")
+		_, valueErr := parser.Parse("INSERT INTO users VALUES (1, 'Phil') ON CONFLICT (id) DO UPDATE SET name = count(*);")
+		valid, validErr := parser.Parse("INSERT INTO users VALUES (1, 'Phil') ON CONFLICT (id) DO UPDATE SET name = excluded.name;")
+
+		require.ErrorContains(t, multipleErr, "Multiple primary keys for table pairs are not allowed")
+		require.ErrorContains(t, unknownErr, "Column c named in key does not exist")
+		require.ErrorContains(t, twiceErr, "Column a appears twice in primary key")
+		require.ErrorContains(t, targetErr, "ON CONFLICT DO UPDATE requires a conflict target column list")
+		require.ErrorContains(t, assignmentErr, "Multiple assignments to same column name")
+		require.ErrorContains(t, valueErr, "aggregate functions are not allowed in ON CONFLICT DO UPDATE")
+		require.NoError(t, validErr)
+		require.Equal(t, ast.DoUpdateAction, valid.Statements[0].InsertStatement.OnConflict.Action)
+	})
+
+	t.Run("validator - RETURNING", func(t *testing.T) {
+		parser := NewParser()
+
+		_, aggregateErr := parser.Parse("INSERT INTO users VALUES (1, 'Phil') RETURNING count(*);")
+		_, windowErr := parser.Parse("INSERT INTO users VALUES (1, 'Phil') RETURNING row_number() OVER ();")
+		valid, validErr := parser.Parse("INSERT INTO users VALUES (1, 'Phil') ON CONFLICT DO NOTHING RETURNING users.*, upper(name) AS upper_name;")
+
+		require.ErrorContains(t, aggregateErr, "aggregate functions are not allowed in RETURNING")
+		require.ErrorContains(t, windowErr, "window functions are not allowed in RETURNING")
+		require.NoError(t, validErr)
+		require.Len(t, valid.Statements[0].InsertStatement.Returning, 2)
+	})
+
+	t.Run("validator - ALTER TABLE and column constraints", func(t *testing.T) {
+		parser := NewParser()
+
+		valid, validErr := parser.Parse("CREATE TABLE items (id INT NOT NULL, title VARCHAR(10) DEFAULT 'none', price DECIMAL(5,2) DEFAULT 1.5 * 2);")
+		_, columnErr := parser.Parse("CREATE TABLE items (id INT, price INT DEFAULT id + 1);")
+		_, subqueryErr := parser.Parse("ALTER TABLE items ALTER COLUMN price SET DEFAULT (SELECT max(id) FROM items);")
+		_, aggregateErr := parser.Parse("ALTER TABLE items ADD COLUMN total INT DEFAULT count(*);")
+		_, primaryKeyErr := parser.Parse("ALTER TABLE items ADD COLUMN code INT PRIMARY KEY;")
+		_, typeErr := parser.Parse("ALTER TABLE items ADD COLUMN code INT(5);")
+		_, nameErr := parser.Parse("ALTER TABLE items RENAME TO _items;")
+
+		require.NoError(t, validErr)
+		columns := *valid.Statements[0].CreateTableStatement.Columns
+		require.True(t, columns[0].NotNull)
+		require.NotNil(t, columns[2].Default)
+		require.ErrorContains(t, columnErr, "cannot use column reference in DEFAULT expression")
+		require.ErrorContains(t, subqueryErr, "cannot use subquery in DEFAULT expression")
+		require.ErrorContains(t, aggregateErr, "aggregate functions are not allowed in DEFAULT expressions")
+		require.ErrorContains(t, primaryKeyErr, "ALTER TABLE ADD COLUMN does not support PRIMARY KEY")
+		require.Error(t, typeErr)
+		require.Error(t, nameErr)
+	})
+
+	t.Run("validator - user-defined functions", func(t *testing.T) {
+		registry := functions.NewRegistry()
+		require.NoError(t, registry.Register(functions.Function{
+			Name:       "double",
+			Kind:       functions.ScalarKind,
+			Signatures: []functions.Signature{{Arguments: []disk_manager.DataType{disk_manager.INT_32_TYPE}, Result: disk_manager.INT_32_TYPE}},
+		}))
+		require.NoError(t, registry.Register(functions.Function{
+			Name:       "product",
+			Kind:       functions.AggregateKind,
+			Signatures: []functions.Signature{{Arguments: []disk_manager.DataType{disk_manager.DECIMAL_TYPE}, Result: disk_manager.DECIMAL_TYPE}},
+		}))
+		parser := NewParserWithFunctions(registry)
+
+		valid, validErr := parser.Parse("SELECT double(id), PRODUCT(id) FROM users GROUP BY id;")
+		_, typeErr := parser.Parse("SELECT double('a') FROM users;")
+		_, aggregateTypeErr := parser.Parse("SELECT product('a') FROM users;")
+		_, whereErr := parser.Parse("SELECT id FROM users WHERE product(id) > 1;")
+		_, unknownErr := NewParser().Parse("SELECT double(id) FROM users;")
+
+		require.NoError(t, validErr)
+		columns := valid.Statements[0].SelectStatement.SelectedColumns
+		require.Equal(t, ast.FunctionCallKind, columns[0].Kind)
+		require.Equal(t, ast.AggregateKind, columns[1].Kind)
+		require.Equal(t, "product", columns[1].Aggregate.Name.Value)
+		require.ErrorContains(t, typeErr, "function double does not accept argument 1 of type TEXT")
+		require.ErrorContains(t, aggregateTypeErr, "function product does not accept argument 1 of type TEXT")
+		require.ErrorContains(t, whereErr, "aggregate functions are not allowed in WHERE")
+		require.ErrorContains(t, unknownErr, "function double does not exist")
+	})
+
+	t.Run("validator - window function errors", func(t *testing.T) {
+		parser := NewParser()
+
+		_, whereErr := parser.Parse("SELECT id FROM users WHERE row_number() OVER () > 1;")
+		_, groupByErr := parser.Parse("SELECT count(*) FROM users GROUP BY rank() OVER (ORDER BY id);")
+		_, withoutOverErr := parser.Parse("SELECT rank() FROM users;")
+		_, notWindowErr := parser.Parse("SELECT lower(name) OVER () FROM users;")
+		_, argumentsErr := parser.Parse("SELECT row_number(id) OVER () FROM users;")
+		_, nestedErr := parser.Parse("SELECT sum(row_number() OVER ()) OVER () FROM users;")
+		_, frameErr := parser.Parse("SELECT sum(id) OVER (ROWS BETWEEN CURRENT ROW AND 1 PRECEDING) FROM users;")
+		_, startErr := parser.Parse("SELECT sum(id) OVER (ROWS UNBOUNDED FOLLOWING) FROM users;")
+		valid, validErr := parser.Parse("SELECT id, sum(id) OVER (PARTITION BY name ORDER BY id DESC ROWS BETWEEN 2 PRECEDING AND CURRENT ROW) FROM users;")
+
+		require.ErrorContains(t, whereErr, "window functions are not allowed in WHERE")
+		require.ErrorContains(t, groupByErr, "window functions are not allowed in GROUP BY")
+		require.ErrorContains(t, withoutOverErr, "window function rank requires an OVER clause")
+		require.ErrorContains(t, notWindowErr, "OVER specified, but lower is not a window function nor an aggregate function")
+		require.ErrorContains(t, argumentsErr, "window function row_number expects no arguments")
+		require.ErrorContains(t, nestedErr, "window function calls cannot be nested")
+		require.ErrorContains(t, frameErr, "frame starting from current row cannot have preceding rows")
+		require.ErrorContains(t, startErr, "frame start cannot be UNBOUNDED FOLLOWING")
+		require.NoError(t, validErr)
+		require.NotNil(t, valid)
+	})
+
 	// Тесты с валидными запросами из test.txt
 	t.Run("validator - valid queries from test.txt", func(t *testing.T) {
 		validQueries := []string{
+			"DROP TABLE IF EXISTS users;",
+			"DROP TABLE IF EXISTS posts;",
 			"CREATE TABLE users (id INT, name TEXT);",
-			"CREATE TABLE posts (id INT, title TEXT, content TEXT);",
+			"CREATE TABLE IF NOT EXISTS posts (id INT, user_id INT, title TEXT, content TEXT);",
+			"CREATE TABLE IF NOT EXISTS posts (id INT);",
 			"INSERT INTO users VALUES (1, 'Joffrey');",
 			"INSERT INTO users VALUES (2, 'Walter');",
 			"INSERT INTO users VALUES (3, null);",
+			"INSERT INTO posts VALUES (1, 1, 'Hello', 'First post');",
+			"INSERT INTO posts (id, user_id, title) VALUES (5, 3, 'Draft'), (6, 3, 'Untitled');",
 			"SELECT id, name FROM users;",
 			"SELECT name FROM users;",
 			"SELECT id FROM users;",
+			"SELECT u.name, p.title FROM users u JOIN posts p ON u.id = p.user_id;",
+			"SELECT u.name, p.title FROM users u LEFT JOIN posts p ON u.id = p.user_id;",
+			"SELECT u.name, count(p.id) FROM users AS u LEFT OUTER JOIN posts AS p ON p.user_id = u.id GROUP BY u.name;",
+			"SELECT name FROM users WHERE id IN (SELECT user_id FROM posts);",
+			"SELECT id FROM users UNION SELECT user_id FROM posts ORDER BY 1;",
+			"SELECT id FROM users EXCEPT SELECT user_id FROM posts;",
+			"WITH RECURSIVE t(n) AS (SELECT id FROM users WHERE id = 1 UNION ALL SELECT n + 1 FROM t WHERE n < 5) SELECT n FROM t;",
+			"SELECT name, (SELECT count(*) FROM posts p WHERE p.user_id = u.id) FROM users u;",
+			"SELECT id, name, row_number() OVER (ORDER BY id DESC), lag(name) OVER (ORDER BY id) FROM users;",
+			"SELECT DISTINCT u.name, p.title || '!' AS t FROM users u JOIN posts p ON u.id = p.user_id ORDER BY t;",
+			"SELECT id, name ILIKE 'j%', name IS NULL FROM users WHERE id IN (1, 3);",
+			"SELECT title FROM posts WHERE user_id BETWEEN 1 AND 2 ORDER BY title;",
+			"SELECT name FROM users WHERE (id > 1 AND name IS NOT NULL) OR NOT id IN (1, 2);",
+			"SELECT -id, -(id + 1) * 2 FROM users WHERE -id < -1;",
+			"SELECT u.name FROM users u WHERE EXISTS (SELECT 1 FROM posts p WHERE p.user_id = u.id AND p.title != 'Draft');",
+			"SELECT id, CASE WHEN name IS NULL THEN 'anonymous' ELSE name END, coalesce(name, id::text), CAST(id AS DECIMAL(5,2)) FROM users;",
+			"SELECT upper(title), length(content), substr(content, 1, 5), position('e' IN title), concat(id, ':', user_id), round(sqrt(id), 3), mod(id, 2) FROM posts;",
+			"SELECT name, kind, arguments, return_type FROM system_functions WHERE name = 'substr';",
+			"CREATE TABLE archive (id INT, title TEXT);",
+			"INSERT INTO archive SELECT id, title FROM posts WHERE user_id = 1;",
+			"SELECT id, title FROM archive;",
+			"DROP TABLE archive;",
+			"CREATE TABLE counters (name VARCHAR(20) PRIMARY KEY, hits INT);",
+			"INSERT INTO counters VALUES ('home', 1), ('about', 1);",
+			"INSERT INTO counters VALUES ('home', 1), ('blog', 1) ON CONFLICT DO NOTHING;",
+			"INSERT INTO counters VALUES ('home', 2) ON CONFLICT (name) DO UPDATE SET hits = counters.hits + excluded.hits;",
+			"INSERT INTO counters VALUES ('faq', 1) RETURNING *;",
+			"SELECT name, hits FROM counters ORDER BY name;",
+			"DROP TABLE counters;",
+			"CREATE TABLE settings (name VARCHAR(20) PRIMARY KEY, value TEXT);",
+			"INSERT INTO settings VALUES ('theme', 'dark');",
+			"ALTER TABLE settings ADD COLUMN version INT NOT NULL DEFAULT 1;",
+			"ALTER TABLE settings RENAME COLUMN value TO setting;",
+			"ALTER TABLE settings ALTER COLUMN setting SET DEFAULT 'none';",
+			"INSERT INTO settings (name) VALUES ('lang');",
+			"ALTER TABLE settings DROP COLUMN version;",
+			"ALTER TABLE settings RENAME TO preferences;",
+			"SELECT * FROM preferences ORDER BY name;",
+			"TRUNCATE TABLE preferences RESTART IDENTITY;",
+			"SELECT count(*) FROM preferences;",
+			"DROP TABLE preferences;",
 			"DROP TABLE users;",
+			"DROP TABLE IF EXISTS posts;",
 		}
 
 		parser := NewParser()
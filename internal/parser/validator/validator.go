@@ -1,8 +1,12 @@
 package validator
 
 import (
+	"custom-database/internal/disk_manager"
+	"custom-database/internal/functions"
 	"custom-database/internal/parser/ast"
+	"custom-database/internal/parser/lex"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -20,11 +24,18 @@ type ValidatorService interface {
 }
 
 type validator struct {
+	// functions реестр функций, по сигнатурам которых проверяются вызовы
+	functions *functions.Registry
 }
 
-// NewValidator создает новый экземпляр валидатора
+// NewValidator создает новый экземпляр валидатора со встроенными функциями
 func NewValidator() ValidatorService {
-	return &validator{}
+	return NewValidatorWithFunctions(functions.NewRegistry())
+}
+
+// NewValidatorWithFunctions создает валидатор, который проверяет вызовы функций по указанному реестру
+func NewValidatorWithFunctions(registry *functions.Registry) ValidatorService {
+	return &validator{functions: registry}
 }
 
 // ValidateAST проверяет AST дерево на семантические ошибки
@@ -68,6 +79,10 @@ func (v *validator) validateStatement(statement *ast.AstStatement) error {
 		return v.validateCreateTableStatement(statement.CreateTableStatement)
 	case ast.DropTableKind:
 		return v.validateDropTableStatement(statement.DropTableStatement)
+	case ast.AlterTableKind:
+		return v.validateAlterTableStatement(statement.AlterTableStatement)
+	case ast.TruncateTableKind:
+		return v.validateTruncateTableStatement(statement.TruncateTableStatement)
 	default:
 		return &ValidationError{
 			Message: fmt.Sprintf("Unknown statement type: %s", statement.Kind),
@@ -83,71 +98,137 @@ func (v *validator) validateSelectStatement(stmt *ast.SelectStatement) error {
 		}
 	}
 
-	// Проверка наличия таблицы в FROM
-	if stmt.Table.Value == "" {
-		return &ValidationError{
-			Message: "SELECT statement must have FROM clause",
+	if stmt.With != nil {
+		if err := v.validateWithClause(stmt.With); err != nil {
+			return err
 		}
 	}
 
-	// Проверка имени таблицы
-	if err := v.validateIdentifier(stmt.Table.Value, "table name"); err != nil {
-		return err
+	if stmt.SetOperation != nil {
+		return v.validateSetOperation(stmt)
 	}
 
-	// Проверка выбранных колонок
-	if len(stmt.SelectedColumns) == 0 {
+	// Проверка наличия таблицы в FROM
+	if stmt.Table.Value == "" && stmt.Subquery == nil {
 		return &ValidationError{
-			Message: "SELECT statement must specify columns or use *",
+			Message: "SELECT statement must have FROM clause",
 		}
 	}
 
-	// Валидация каждой колонки
+	if err := v.validateJoins(stmt); err != nil {
+		return err
+	}
+
+	// Валидация каждой колонки (пустой список означает SELECT *)
 	for i, col := range stmt.SelectedColumns {
-		if col == nil || col.Literal == nil {
+		if col == nil {
 			return &ValidationError{
 				Message: fmt.Sprintf("Column %d is invalid", i+1),
 			}
 		}
-		if err := v.validateIdentifier(col.Literal.Value, "column name"); err != nil {
+		if err := v.validateExpression(col); err != nil {
 			return err
 		}
+		if col.Alias != nil {
+			if err := v.validateIdentifier(col.Alias.Value, "column alias"); err != nil {
+				return err
+			}
+		}
 	}
 
-	return nil
-}
+	// Валидация условия WHERE
+	if stmt.Where != nil {
+		if err := v.validateExpression(stmt.Where); err != nil {
+			return err
+		}
+		if stmt.Where.ContainsAggregate() {
+			return &ValidationError{
+				Message: "aggregate functions are not allowed in WHERE",
+			}
+		}
+		if stmt.Where.ContainsWindow() {
+			return &ValidationError{
+				Message: "window functions are not allowed in WHERE",
+			}
+		}
+	}
 
-// validateInsertStatement проверяет INSERT оператор
-func (v *validator) validateInsertStatement(stmt *ast.InsertStatement) error {
-	if stmt == nil {
-		return &ValidationError{
-			Message: "INSERT statement is nil",
+	// Валидация выражений GROUP BY
+	for _, expression := range stmt.GroupBy {
+		if err := v.validateExpression(expression); err != nil {
+			return err
+		}
+		if expression.ContainsAggregate() {
+			return &ValidationError{
+				Message: "aggregate functions are not allowed in GROUP BY",
+			}
+		}
+		if expression.ContainsWindow() {
+			return &ValidationError{
+				Message: "window functions are not allowed in GROUP BY",
+			}
 		}
 	}
 
-	// Проверка имени таблицы
-	if stmt.Table.Value == "" {
-		return &ValidationError{
-			Message: "INSERT statement must specify table name",
+	// Валидация условия HAVING
+	if stmt.Having != nil {
+		if err := v.validateExpression(stmt.Having); err != nil {
+			return err
+		}
+		if stmt.Having.ContainsWindow() {
+			return &ValidationError{
+				Message: "window functions are not allowed in HAVING",
+			}
 		}
 	}
 
-	if err := v.validateIdentifier(stmt.Table.Value, "table name"); err != nil {
+	// Валидация выражений ORDER BY
+	for _, item := range stmt.OrderBy {
+		if item == nil {
+			return &ValidationError{
+				Message: "ORDER BY item is invalid",
+			}
+		}
+		if err := v.validateExpression(item.Expression); err != nil {
+			return err
+		}
+	}
+
+	if err := v.validateGrouping(stmt); err != nil {
 		return err
 	}
 
-	// Проверка значений
-	if stmt.Values == nil || len(*stmt.Values) == 0 {
-		return &ValidationError{
-			Message: "INSERT statement must specify values",
+	if stmt.Distinct {
+		if err := v.validateDistinctOrderBy(stmt); err != nil {
+			return err
 		}
 	}
 
-	// Валидация каждого значения
-	for i, value := range *stmt.Values {
-		if value == nil || value.Literal == nil {
+	return v.validateRowCounts(stmt)
+}
+
+// validateDistinctOrderBy проверяет, что SELECT DISTINCT сортирует только по колонкам результата:
+// по выражениям из списка SELECT, их псевдонимам или номерам
+func (v *validator) validateDistinctOrderBy(stmt *ast.SelectStatement) error {
+	// Колонки SELECT * и table.* известны только при выполнении
+	if len(stmt.SelectedColumns) == 0 || hasStar(stmt.SelectedColumns) {
+		return nil
+	}
+
+	for _, item := range stmt.OrderBy {
+		expression := item.Expression
+		if expression.Kind == ast.LiteralKind && expression.Literal.Kind == lex.NumericToken {
+			continue
+		}
+
+		expression = resolveAlias(expression, stmt.SelectedColumns)
+		selected := false
+		for _, column := range stmt.SelectedColumns {
+			selected = selected || column.Equals(expression) || sameColumn(column, expression)
+		}
+		if !selected {
 			return &ValidationError{
-				Message: fmt.Sprintf("Value %d is invalid", i+1),
+				Message: "for SELECT DISTINCT, ORDER BY expressions must appear in select list",
 			}
 		}
 	}
@@ -155,132 +236,1330 @@ func (v *validator) validateInsertStatement(stmt *ast.InsertStatement) error {
 	return nil
 }
 
-// validateCreateTableStatement проверяет CREATE TABLE оператор
-func (v *validator) validateCreateTableStatement(stmt *ast.CreateTableStatement) error {
-	if stmt == nil {
-		return &ValidationError{
-			Message: "CREATE TABLE statement is nil",
+// resolveAlias возвращает выражение из списка SELECT, если выражение ORDER BY - его псевдоним (AS alias)
+func resolveAlias(expression *ast.Expression, selected []*ast.Expression) *ast.Expression {
+	if !isIdentifier(expression) {
+		return expression
+	}
+	for _, column := range selected {
+		if column.Alias != nil && column.Alias.Value == expression.Literal.Value {
+			return column
 		}
 	}
+	return expression
+}
 
-	// Проверка имени таблицы
-	if stmt.Table.Value == "" {
-		return &ValidationError{
-			Message: "CREATE TABLE statement must specify table name",
+// hasStar проверяет, есть ли в списке SELECT * или table.*
+func hasStar(expressions []*ast.Expression) bool {
+	for _, expression := range expressions {
+		if expression.Kind == ast.StarKind {
+			return true
 		}
 	}
+	return false
+}
 
-	if err := v.validateIdentifier(stmt.Table.Value, "table name"); err != nil {
-		return err
+// validateRowCounts проверяет, что LIMIT и OFFSET - неотрицательные целые числа
+func (v *validator) validateRowCounts(stmt *ast.SelectStatement) error {
+	if stmt.Limit != nil {
+		if err := v.validateRowCount(stmt.Limit.Value, "LIMIT"); err != nil {
+			return err
+		}
+	}
+	if stmt.Offset != nil {
+		if err := v.validateRowCount(stmt.Offset.Value, "OFFSET"); err != nil {
+			return err
+		}
 	}
 
-	// Проверка колонок
-	if stmt.Columns == nil || len(*stmt.Columns) == 0 {
+	return nil
+}
+
+// validateSetOperation проверяет составной запрос (UNION, INTERSECT, EXCEPT): обе части корректны,
+// у них одинаковое количество колонок, и типы колонок, известные без схемы таблиц, совместимы
+func (v *validator) validateSetOperation(stmt *ast.SelectStatement) error {
+	operation := stmt.SetOperation
+	if operation.Left == nil || operation.Right == nil {
 		return &ValidationError{
-			Message: "CREATE TABLE statement must specify at least one column",
+			Message: fmt.Sprintf("%s query is invalid", operation.Kind),
 		}
 	}
+	if err := v.validateSelectStatement(operation.Left); err != nil {
+		return err
+	}
+	if err := v.validateSelectStatement(operation.Right); err != nil {
+		return err
+	}
 
-	// Валидация каждой колонки
-	columnNames := make(map[string]bool)
-	for i, col := range *stmt.Columns {
-		if col == nil {
+	// Колонки результата определяет первый простой запрос каждой части, SELECT * проверяется при выполнении
+	left, right := operation.Left.FirstQuery().SelectedColumns, operation.Right.FirstQuery().SelectedColumns
+	if len(left) > 0 && len(right) > 0 && !hasStar(left) && !hasStar(right) {
+		if len(left) != len(right) {
 			return &ValidationError{
-				Message: fmt.Sprintf("Column definition %d is invalid", i+1),
+				Message: fmt.Sprintf("each %s query must have the same number of columns", operation.Kind),
+			}
+		}
+		for i := range left {
+			leftType, rightType := literalType(left[i]), literalType(right[i])
+			if !compatibleLiteralTypes(leftType, rightType) {
+				return &ValidationError{
+					Message: fmt.Sprintf("%s types %s and %s cannot be matched", operation.Kind, leftType, rightType),
+				}
 			}
 		}
+	}
 
-		// Проверка имени колонки
-		if col.Name.Value == "" {
+	// ORDER BY составного запроса ссылается на колонки результата
+	for _, item := range stmt.OrderBy {
+		if item == nil {
 			return &ValidationError{
-				Message: fmt.Sprintf("Column %d must have a name", i+1),
+				Message: "ORDER BY item is invalid",
 			}
 		}
-
-		if err := v.validateIdentifier(col.Name.Value, "column name"); err != nil {
+		if err := v.validateExpression(item.Expression); err != nil {
 			return err
 		}
+		if item.Expression.ContainsAggregate() {
+			return &ValidationError{
+				Message: fmt.Sprintf("aggregate functions are not allowed in ORDER BY of %s", operation.Kind),
+			}
+		}
+	}
 
-		// Проверка на дублирование имен колонок
-		if columnNames[col.Name.Value] {
+	return v.validateRowCounts(stmt)
+}
+
+// literalType возвращает тип выражения, который известен без схемы таблиц: тип литерала, BOOLEAN для условий.
+// Пустая строка - тип зависит от колонок таблиц или NULL
+func literalType(expression *ast.Expression) string {
+	switch expression.Kind {
+	case ast.LiteralKind:
+		switch expression.Literal.Kind {
+		case lex.NumericToken:
+			return "NUMERIC"
+		case lex.StringToken:
+			return "TEXT"
+		case lex.HexStringToken:
+			return "BYTEA"
+		}
+	case ast.TypedLiteralKind:
+		return strings.ToUpper(expression.DataType.Value)
+	case ast.BinaryKind:
+		switch lex.MathOperator(expression.Binary.Operator.Value) {
+		case lex.EqualOperator, lex.NotEqualOperator,
+			lex.LessThanOperator, lex.GreaterThanOperator,
+			lex.LessOrEqualOperator, lex.GreaterOrEqualOperator:
+			return "BOOLEAN"
+		case lex.ConcatOperator:
+			return "TEXT"
+		}
+	case ast.ExistsKind, ast.InKind, ast.LikeKind, ast.InListKind, ast.BetweenKind, ast.IsNullKind, ast.LogicalKind:
+		return "BOOLEAN"
+	case ast.CastKind:
+		switch dataType := strings.ToUpper(expression.Cast.DataType.Value); dataType {
+		case "INT", "DECIMAL", "NUMERIC":
+			return "NUMERIC"
+		case "VARCHAR", "CHAR":
+			return "TEXT"
+		default:
+			return dataType
+		}
+	case ast.AggregateKind:
+		if expression.Aggregate.Name.Value == "count" {
+			return "NUMERIC"
+		}
+	}
+	return ""
+}
+
+// compatibleLiteralTypes проверяет, что значения двух типов можно привести к общему типу.
+// Строковый литерал приводится к дате, времени, интервалу и BYTEA
+func compatibleLiteralTypes(left, right string) bool {
+	if left == "" || right == "" || left == right {
+		return true
+	}
+
+	castable := map[string]bool{"DATE": true, "TIMESTAMP": true, "INTERVAL": true, "BYTEA": true}
+	return (left == "TEXT" && castable[right]) || (right == "TEXT" && castable[left])
+}
+
+// validateWithClause проверяет общие табличные выражения: имена запросов и их колонок уникальны,
+// рекурсивная часть WITH RECURSIVE не содержит группировки
+func (v *validator) validateWithClause(with *ast.WithClause) error {
+	names := map[string]bool{}
+	for _, query := range with.Queries {
+		if query == nil || query.Select == nil {
 			return &ValidationError{
-				Message: fmt.Sprintf("Duplicate column name: %s", col.Name.Value),
+				Message: "WITH query is invalid",
 			}
 		}
-		columnNames[col.Name.Value] = true
 
-		// Проверка типа данных
-		if col.Datatype.Value == "" {
+		if err := v.validateIdentifier(query.Name.Value, "WITH query name"); err != nil {
+			return err
+		}
+		if names[query.Name.Value] {
 			return &ValidationError{
-				Message: fmt.Sprintf("Column %s must have a data type", col.Name.Value),
+				Message: fmt.Sprintf("WITH query name %s specified more than once", query.Name.Value),
+			}
+		}
+		names[query.Name.Value] = true
+
+		columns := map[string]bool{}
+		for _, column := range query.Columns {
+			if err := v.validateIdentifier(column.Value, "column name"); err != nil {
+				return err
+			}
+			if columns[column.Value] {
+				return &ValidationError{
+					Message: fmt.Sprintf("column %s specified more than once in WITH query %s", column.Value, query.Name.Value),
+				}
 			}
+			columns[column.Value] = true
 		}
 
-		if err := v.validateDataType(col.Datatype.Value); err != nil {
+		if err := v.validateSelectStatement(query.Select); err != nil {
 			return err
 		}
+
+		// Группировка по строкам одной итерации не имеет смысла для рекурсивного запроса
+		operation := query.Select.SetOperation
+		if with.Recursive && operation != nil && operation.Kind == ast.UnionOperation &&
+			readsTable(operation.Right, query.Name.Value) && isGrouped(operation.Right) {
+			return &ValidationError{
+				Message: "aggregate functions are not allowed in a recursive query's recursive term",
+			}
+		}
 	}
 
 	return nil
 }
 
-// validateDropTableStatement проверяет DROP TABLE оператор
-func (v *validator) validateDropTableStatement(stmt *ast.DropTableStatement) error {
-	if stmt == nil {
-		return &ValidationError{
-			Message: "DROP TABLE statement is nil",
+// readsTable проверяет, что в FROM или JOIN запроса указана таблица name
+func readsTable(stmt *ast.SelectStatement, name string) bool {
+	if stmt.Subquery == nil && stmt.Table.Value == name {
+		return true
+	}
+	for _, join := range stmt.Joins {
+		if join != nil && join.Subquery == nil && join.Table.Value == name {
+			return true
 		}
 	}
+	return false
+}
 
-	// Проверка имени таблицы
-	if stmt.Table.Value == "" {
-		return &ValidationError{
-			Message: "DROP TABLE statement must specify table name",
+// isGrouped проверяет, что в запросе есть GROUP BY, HAVING или агрегатные функции
+func isGrouped(stmt *ast.SelectStatement) bool {
+	if len(stmt.GroupBy) > 0 || stmt.Having != nil {
+		return true
+	}
+	for _, expression := range stmt.SelectedColumns {
+		if expression != nil && expression.ContainsAggregate() {
+			return true
 		}
 	}
+	return false
+}
 
-	return v.validateIdentifier(stmt.Table.Value, "table name")
+// validateJoins проверяет присоединяемые таблицы: имена и псевдонимы уникальны,
+// условие ON указано для всех соединений, кроме CROSS JOIN, и не содержит агрегатных функций
+func (v *validator) validateJoins(stmt *ast.SelectStatement) error {
+	names := map[string]bool{}
+	addTable := func(table lex.Token, subquery *ast.SelectStatement, alias *lex.Token) error {
+		if subquery != nil {
+			// Подзапрос в FROM (derived table) доступен только через псевдоним
+			if alias == nil {
+				return &ValidationError{
+					Message: "subquery in FROM must have an alias",
+				}
+			}
+			if err := v.validateSelectStatement(subquery); err != nil {
+				return err
+			}
+		} else if err := v.validateIdentifier(table.Value, "table name"); err != nil {
+			return err
+		}
+
+		name := table.Value
+		if alias != nil {
+			if err := v.validateIdentifier(alias.Value, "table alias"); err != nil {
+				return err
+			}
+			name = alias.Value
+		}
+
+		if names[name] {
+			return &ValidationError{
+				Message: fmt.Sprintf("table name %s specified more than once", name),
+			}
+		}
+		names[name] = true
+		return nil
+	}
+
+	if err := addTable(stmt.Table, stmt.Subquery, stmt.Alias); err != nil {
+		return err
+	}
+
+	for _, join := range stmt.Joins {
+		if join == nil {
+			return &ValidationError{
+				Message: "JOIN clause is invalid",
+			}
+		}
+		if err := addTable(join.Table, join.Subquery, join.Alias); err != nil {
+			return err
+		}
+
+		if join.Kind == ast.CrossJoin {
+			if join.Condition != nil {
+				return &ValidationError{
+					Message: "CROSS JOIN cannot have ON condition",
+				}
+			}
+			continue
+		}
+
+		if join.Condition == nil {
+			return &ValidationError{
+				Message: fmt.Sprintf("%s JOIN requires ON condition", join.Kind),
+			}
+		}
+		if err := v.validateExpression(join.Condition); err != nil {
+			return err
+		}
+		if join.Condition.ContainsAggregate() {
+			return &ValidationError{
+				Message: "aggregate functions are not allowed in JOIN conditions",
+			}
+		}
+		if join.Condition.ContainsWindow() {
+			return &ValidationError{
+				Message: "window functions are not allowed in JOIN conditions",
+			}
+		}
+	}
+
+	return nil
 }
 
-// validateIdentifier проверяет корректность идентификатора
-func (v *validator) validateIdentifier(value, context string) error {
-	if value == "" {
-		return &ValidationError{
-			Message: fmt.Sprintf("%s cannot be empty", context),
+// validateGrouping проверяет запрос с группировкой: в SELECT, HAVING и ORDER BY колонки могут
+// использоваться только внутри агрегатных функций или если они (или все выражение) указаны в GROUP BY
+func (v *validator) validateGrouping(stmt *ast.SelectStatement) error {
+	expressions := append([]*ast.Expression{}, stmt.SelectedColumns...)
+	if stmt.Having != nil {
+		expressions = append(expressions, stmt.Having)
+	}
+	for _, item := range stmt.OrderBy {
+		expressions = append(expressions, resolveAlias(item.Expression, stmt.SelectedColumns))
+	}
+
+	grouped := len(stmt.GroupBy) > 0 || stmt.Having != nil
+	for _, expression := range expressions {
+		grouped = grouped || expression.ContainsAggregate()
+	}
+	if !grouped {
+		return nil
+	}
+
+	for _, expression := range expressions {
+		if err := v.validateGroupedExpression(expression, stmt.GroupBy); err != nil {
+			return err
 		}
 	}
 
-	// Проверка на ключевые слова
-	keywords := []string{"SELECT", "FROM", "INSERT", "INTO", "VALUES", "CREATE", "TABLE", "DROP", "INT", "TEXT", "NULL"}
-	for _, keyword := range keywords {
-		if strings.ToUpper(value) == keyword {
+	return nil
+}
+
+// validateGroupedExpression проверяет, что выражение вычислимо по ключам группировки и агрегатам
+func (v *validator) validateGroupedExpression(expression *ast.Expression, groupBy []*ast.Expression) error {
+	for _, groupExpression := range groupBy {
+		if expression.Equals(groupExpression) || sameColumn(expression, groupExpression) {
+			return nil
+		}
+	}
+
+	switch expression.Kind {
+	case ast.AggregateKind, ast.SubqueryKind, ast.ExistsKind:
+		// Подзапрос вычисляется отдельно для каждой группы, его колонки к группировке не относятся
+		return nil
+	case ast.LiteralKind:
+		if expression.Literal.Kind == lex.IdentifierToken {
 			return &ValidationError{
-				Message: fmt.Sprintf("%s cannot be a keyword: %s", context, value),
+				Message: fmt.Sprintf("column %s must appear in the GROUP BY clause or be used in an aggregate function", expression.Literal.Value),
 			}
 		}
+		return nil
 	}
 
-	// Проверка на специальные символы
-	if strings.ContainsAny(value, " \t\n\r()[]{},;") {
+	for _, child := range expression.Children() {
+		if err := v.validateGroupedExpression(child, groupBy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sameColumn проверяет, что оба выражения - ссылки на одну колонку,
+// когда одна из них уточнена именем таблицы, а другая нет (u.name и name)
+func sameColumn(a, b *ast.Expression) bool {
+	if !isIdentifier(a) || !isIdentifier(b) {
+		return false
+	}
+
+	nameA, nameB := a.Literal.Value, b.Literal.Value
+	if strings.Contains(nameA, ".") == strings.Contains(nameB, ".") {
+		return false
+	}
+	return columnPart(nameA) == columnPart(nameB)
+}
+
+// isIdentifier проверяет, что выражение - ссылка на колонку
+func isIdentifier(expression *ast.Expression) bool {
+	return expression.Kind == ast.LiteralKind && expression.Literal.Kind == lex.IdentifierToken
+}
+
+// columnPart возвращает имя колонки без имени таблицы
+func columnPart(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// validateRowCount проверяет, что значение LIMIT или OFFSET - неотрицательное целое число
+func (v *validator) validateRowCount(value, clause string) error {
+	count, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || count < 0 {
 		return &ValidationError{
-			Message: fmt.Sprintf("%s contains invalid characters: %s", context, value),
+			Message: fmt.Sprintf("%s must be a non-negative integer, got: %s", clause, value),
 		}
 	}
 
 	return nil
 }
 
-// validateDataType проверяет корректность типа данных
-func (v *validator) validateDataType(dataType string) error {
-	validTypes := []string{"INT", "TEXT"}
+// validateInsertStatement проверяет INSERT оператор
+func (v *validator) validateInsertStatement(stmt *ast.InsertStatement) error {
+	if stmt == nil {
+		return &ValidationError{
+			Message: "INSERT statement is nil",
+		}
+	}
 
-	for _, validType := range validTypes {
-		if strings.ToUpper(dataType) == validType {
-			return nil
+	// Проверка имени таблицы
+	if stmt.Table.Value == "" {
+		return &ValidationError{
+			Message: "INSERT statement must specify table name",
 		}
 	}
 
-	return &ValidationError{
-		Message: fmt.Sprintf("Invalid data type: %s. Valid types: %s", dataType, strings.Join(validTypes, ", ")),
+	if err := v.validateIdentifier(stmt.Table.Value, "table name"); err != nil {
+		return err
+	}
+
+	// Проверка списка колонок
+	columnNames := make(map[string]bool)
+	for _, column := range stmt.Columns {
+		if err := v.validateIdentifier(column.Value, "column name"); err != nil {
+			return err
+		}
+		if columnNames[column.Value] {
+			return &ValidationError{
+				Message: fmt.Sprintf("Column %s specified more than once", column.Value),
+			}
+		}
+		columnNames[column.Value] = true
+	}
+
+	if err := v.validateOnConflict(stmt.OnConflict); err != nil {
+		return err
+	}
+	if err := v.validateReturning(stmt.Returning); err != nil {
+		return err
+	}
+
+	if stmt.Select != nil {
+		return v.validateSelectStatement(stmt.Select)
+	}
+
+	// Проверка значений
+	if len(stmt.Values) == 0 {
+		return &ValidationError{
+			Message: "INSERT statement must specify values",
+		}
+	}
+
+	for _, values := range stmt.Values {
+		if len(values) == 0 {
+			return &ValidationError{
+				Message: "INSERT statement must specify values",
+			}
+		}
+
+		// Количество значений должно совпадать со списком колонок, а без него - между строками VALUES
+		switch {
+		case len(stmt.Columns) > 0 && len(values) > len(stmt.Columns):
+			return &ValidationError{
+				Message: "INSERT has more expressions than target columns",
+			}
+		case len(stmt.Columns) > 0 && len(values) < len(stmt.Columns):
+			return &ValidationError{
+				Message: "INSERT has more target columns than expressions",
+			}
+		case len(values) != len(stmt.Values[0]):
+			return &ValidationError{
+				Message: "VALUES lists must all be the same length",
+			}
+		}
+
+		// Валидация каждого значения
+		for i, value := range values {
+			if value == nil {
+				return &ValidationError{
+					Message: fmt.Sprintf("Value %d is invalid", i+1),
+				}
+			}
+			if err := v.validateExpression(value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateReturning проверяет список RETURNING: он вычисляется для каждой записанной строки отдельно,
+// поэтому агрегатные и оконные функции в нем запрещены
+func (v *validator) validateReturning(returning []*ast.Expression) error {
+	for _, expression := range returning {
+		if err := v.validateExpression(expression); err != nil {
+			return err
+		}
+		if expression.ContainsAggregate() {
+			return &ValidationError{
+				Message: "aggregate functions are not allowed in RETURNING",
+			}
+		}
+		if expression.ContainsWindow() {
+			return &ValidationError{
+				Message: "window functions are not allowed in RETURNING",
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateOnConflict проверяет ON CONFLICT: для DO UPDATE нужен список колонок ключа,
+// каждой колонке можно присвоить значение только один раз
+func (v *validator) validateOnConflict(onConflict *ast.OnConflictClause) error {
+	if onConflict == nil {
+		return nil
+	}
+
+	for _, column := range onConflict.Columns {
+		if err := v.validateIdentifier(column.Value, "column name"); err != nil {
+			return err
+		}
+	}
+	if onConflict.Action == ast.DoNothingAction {
+		return nil
+	}
+
+	if len(onConflict.Columns) == 0 {
+		return &ValidationError{
+			Message: "ON CONFLICT DO UPDATE requires a conflict target column list",
+		}
+	}
+
+	assigned := make(map[string]bool)
+	for _, update := range onConflict.Updates {
+		if err := v.validateIdentifier(update.Column.Value, "column name"); err != nil {
+			return err
+		}
+		if assigned[update.Column.Value] {
+			return &ValidationError{
+				Message: fmt.Sprintf("Multiple assignments to same column %s", update.Column.Value),
+			}
+		}
+		assigned[update.Column.Value] = true
+
+		if err := v.validateExpression(update.Value); err != nil {
+			return err
+		}
+		if update.Value.ContainsAggregate() {
+			return &ValidationError{
+				Message: "aggregate functions are not allowed in ON CONFLICT DO UPDATE",
+			}
+		}
+		if update.Value.ContainsWindow() {
+			return &ValidationError{
+				Message: "window functions are not allowed in ON CONFLICT DO UPDATE",
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateCreateTableStatement проверяет CREATE TABLE оператор
+func (v *validator) validateCreateTableStatement(stmt *ast.CreateTableStatement) error {
+	if stmt == nil {
+		return &ValidationError{
+			Message: "CREATE TABLE statement is nil",
+		}
+	}
+
+	// Проверка имени таблицы
+	if stmt.Table.Value == "" {
+		return &ValidationError{
+			Message: "CREATE TABLE statement must specify table name",
+		}
+	}
+
+	if err := v.validateIdentifier(stmt.Table.Value, "table name"); err != nil {
+		return err
+	}
+
+	// Проверка колонок
+	if stmt.Columns == nil || len(*stmt.Columns) == 0 {
+		return &ValidationError{
+			Message: "CREATE TABLE statement must specify at least one column",
+		}
+	}
+
+	// Валидация каждой колонки
+	columnNames := make(map[string]bool)
+	for i, col := range *stmt.Columns {
+		if col == nil {
+			return &ValidationError{
+				Message: fmt.Sprintf("Column definition %d is invalid", i+1),
+			}
+		}
+
+		if err := v.validateColumnDefinition(i, col.Name, col.Datatype, col.Parameters, col.Default); err != nil {
+			return err
+		}
+
+		// Проверка на дублирование имен колонок
+		if columnNames[col.Name.Value] {
+			return &ValidationError{
+				Message: fmt.Sprintf("Duplicate column name: %s", col.Name.Value),
+			}
+		}
+		columnNames[col.Name.Value] = true
+	}
+
+	return v.validatePrimaryKey(stmt, columnNames)
+}
+
+// validateColumnDefinition проверяет определение колонки (index - ее номер, начиная с 0): имя, тип данных,
+// параметры типа и значение по умолчанию
+func (v *validator) validateColumnDefinition(index int, name lex.Token, dataType lex.Token, parameters []*lex.Token, defaultValue *ast.Expression) error {
+	// Проверка имени колонки
+	if name.Value == "" {
+		return &ValidationError{
+			Message: fmt.Sprintf("Column %d must have a name", index+1),
+		}
+	}
+
+	if err := v.validateIdentifier(name.Value, "column name"); err != nil {
+		return err
+	}
+
+	// Проверка типа данных
+	if dataType.Value == "" {
+		return &ValidationError{
+			Message: fmt.Sprintf("Column %s must have a data type", name.Value),
+		}
+	}
+
+	if err := v.validateDataType(dataType.Value); err != nil {
+		return err
+	}
+
+	if err := v.validateTypeParameters(dataType.Value, parameters); err != nil {
+		return err
+	}
+
+	if defaultValue != nil {
+		return v.validateDefault(defaultValue)
+	}
+	return nil
+}
+
+// validateDefault проверяет значение по умолчанию. Оно вычисляется без строки таблицы,
+// поэтому в нем нельзя ссылаться на колонки и использовать подзапросы, агрегатные и оконные функции
+func (v *validator) validateDefault(expr *ast.Expression) error {
+	if err := v.validateExpression(expr); err != nil {
+		return err
+	}
+	return checkDefaultExpression(expr)
+}
+
+// checkDefaultExpression проверяет выражение значения по умолчанию и все его подвыражения
+func checkDefaultExpression(expr *ast.Expression) error {
+	switch expr.Kind {
+	case ast.SubqueryKind, ast.ExistsKind, ast.InKind:
+		return &ValidationError{
+			Message: "cannot use subquery in DEFAULT expression",
+		}
+	case ast.AggregateKind:
+		return &ValidationError{
+			Message: "aggregate functions are not allowed in DEFAULT expressions",
+		}
+	case ast.WindowKind:
+		return &ValidationError{
+			Message: "window functions are not allowed in DEFAULT expressions",
+		}
+	case ast.LiteralKind:
+		if expr.Literal.Kind == lex.IdentifierToken {
+			return &ValidationError{
+				Message: "cannot use column reference in DEFAULT expression",
+			}
+		}
+	}
+
+	for _, child := range expr.Children() {
+		if err := checkDefaultExpression(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateAlterTableStatement проверяет ALTER TABLE оператор
+func (v *validator) validateAlterTableStatement(stmt *ast.AlterTableStatement) error {
+	if stmt == nil {
+		return &ValidationError{
+			Message: "ALTER TABLE statement is nil",
+		}
+	}
+
+	if err := v.validateIdentifier(stmt.Table.Value, "table name"); err != nil {
+		return err
+	}
+
+	switch stmt.Action {
+	case ast.AddColumnAction:
+		added := stmt.Added
+		if err := v.validateColumnDefinition(0, added.Name, added.Datatype, added.Parameters, added.Default); err != nil {
+			return err
+		}
+		// Новая колонка заполняется одним и тем же значением, поэтому она не может быть первичным ключом непустой таблицы
+		if added.PrimaryKey {
+			return &ValidationError{
+				Message: "ALTER TABLE ADD COLUMN does not support PRIMARY KEY",
+			}
+		}
+		return nil
+	case ast.RenameTableAction:
+		return v.validateIdentifier(stmt.NewName.Value, "table name")
+	case ast.RenameColumnAction:
+		if err := v.validateIdentifier(stmt.Column.Value, "column name"); err != nil {
+			return err
+		}
+		return v.validateIdentifier(stmt.NewName.Value, "column name")
+	case ast.SetDefaultAction:
+		if err := v.validateIdentifier(stmt.Column.Value, "column name"); err != nil {
+			return err
+		}
+		return v.validateDefault(stmt.Default)
+	}
+
+	return v.validateIdentifier(stmt.Column.Value, "column name")
+}
+
+// validatePrimaryKey проверяет, что первичный ключ объявлен один раз и состоит из колонок таблицы
+func (v *validator) validatePrimaryKey(stmt *ast.CreateTableStatement, columnNames map[string]bool) error {
+	keys := 0
+	for _, col := range *stmt.Columns {
+		if col.PrimaryKey {
+			keys++
+		}
+	}
+	if len(stmt.PrimaryKey) > 0 {
+		keys++
+	}
+	if keys > 1 {
+		return &ValidationError{
+			Message: fmt.Sprintf("Multiple primary keys for table %s are not allowed", stmt.Table.Value),
+		}
+	}
+
+	keyColumns := make(map[string]bool)
+	for _, column := range stmt.PrimaryKey {
+		if !columnNames[column.Value] {
+			return &ValidationError{
+				Message: fmt.Sprintf("Column %s named in key does not exist", column.Value),
+			}
+		}
+		if keyColumns[column.Value] {
+			return &ValidationError{
+				Message: fmt.Sprintf("Column %s appears twice in primary key", column.Value),
+			}
+		}
+		keyColumns[column.Value] = true
+	}
+
+	return nil
+}
+
+// validateDropTableStatement проверяет DROP TABLE оператор
+func (v *validator) validateDropTableStatement(stmt *ast.DropTableStatement) error {
+	if stmt == nil {
+		return &ValidationError{
+			Message: "DROP TABLE statement is nil",
+		}
+	}
+
+	// Проверка имени таблицы
+	if stmt.Table.Value == "" {
+		return &ValidationError{
+			Message: "DROP TABLE statement must specify table name",
+		}
+	}
+
+	return v.validateIdentifier(stmt.Table.Value, "table name")
+}
+
+// validateTruncateTableStatement проверяет TRUNCATE TABLE оператор
+func (v *validator) validateTruncateTableStatement(stmt *ast.TruncateTableStatement) error {
+	if stmt == nil {
+		return &ValidationError{
+			Message: "TRUNCATE TABLE statement is nil",
+		}
+	}
+
+	return v.validateIdentifier(stmt.Table.Value, "table name")
+}
+
+// validateExpression проверяет выражение и все его подвыражения
+func (v *validator) validateExpression(expr *ast.Expression) error {
+	if expr == nil {
+		return &ValidationError{
+			Message: "Expression is nil",
+		}
+	}
+
+	switch expr.Kind {
+	case ast.LiteralKind:
+		if expr.Literal == nil {
+			return &ValidationError{
+				Message: "Literal expression has no value",
+			}
+		}
+		// Идентификаторы - это имена колонок, остальные литералы проверять не нужно
+		if expr.Literal.Kind == lex.IdentifierToken {
+			return v.validateIdentifier(expr.Literal.Value, "column name")
+		}
+		return nil
+	case ast.TypedLiteralKind:
+		if expr.Literal == nil || expr.DataType == nil {
+			return &ValidationError{
+				Message: "Typed literal must have a type and a value",
+			}
+		}
+		return v.validateDataType(expr.DataType.Value)
+	case ast.BinaryKind:
+		if expr.Binary == nil {
+			return &ValidationError{
+				Message: "Binary expression is invalid",
+			}
+		}
+		if err := v.validateExpression(expr.Binary.A); err != nil {
+			return err
+		}
+		return v.validateExpression(expr.Binary.B)
+	case ast.FunctionCallKind:
+		if expr.FunctionCall == nil {
+			return &ValidationError{
+				Message: "Function call is invalid",
+			}
+		}
+		if err := v.validateIdentifier(expr.FunctionCall.Name.Value, "function name"); err != nil {
+			return err
+		}
+		if ast.IsWindowFunction(expr.FunctionCall.Name.Value) {
+			return &ValidationError{
+				Message: fmt.Sprintf("window function %s requires an OVER clause", expr.FunctionCall.Name.Value),
+			}
+		}
+		for _, argument := range expr.FunctionCall.Arguments {
+			if err := v.validateExpression(argument); err != nil {
+				return err
+			}
+		}
+		return v.validateFunctionCall(expr.FunctionCall)
+	case ast.AggregateKind:
+		return v.validateAggregate(expr.Aggregate)
+	case ast.WindowKind:
+		if expr.Window == nil {
+			return &ValidationError{
+				Message: "Window function call is invalid",
+			}
+		}
+		return v.validateWindow(expr.Window)
+	case ast.StarKind:
+		// Имя таблицы в table.*
+		if expr.Literal != nil {
+			return v.validateIdentifier(expr.Literal.Value, "table name")
+		}
+		return nil
+	case ast.LikeKind, ast.InListKind, ast.BetweenKind, ast.IsNullKind:
+		return v.validatePredicate(expr)
+	case ast.CaseKind:
+		return v.validateCase(expr.Case)
+	case ast.LogicalKind:
+		return v.validateLogical(expr.Logical)
+	case ast.CastKind:
+		if expr.Cast == nil || expr.Cast.Operand == nil {
+			return &ValidationError{
+				Message: "CAST expression is invalid",
+			}
+		}
+		// BOOLEAN допустим только как тип приведения
+		if !strings.EqualFold(expr.Cast.DataType.Value, string(lex.BooleanKeyword)) {
+			if err := v.validateDataType(expr.Cast.DataType.Value); err != nil {
+				return err
+			}
+		}
+		if err := v.validateTypeParameters(expr.Cast.DataType.Value, expr.Cast.Parameters); err != nil {
+			return err
+		}
+		return v.validateExpression(expr.Cast.Operand)
+	case ast.SubqueryKind, ast.ExistsKind, ast.InKind:
+		if expr.Subquery == nil || expr.Subquery.Select == nil {
+			return &ValidationError{
+				Message: "Subquery is invalid",
+			}
+		}
+		if expr.Kind == ast.InKind {
+			if err := v.validateExpression(expr.Subquery.Operand); err != nil {
+				return err
+			}
+		}
+		return v.validateSelectStatement(expr.Subquery.Select)
+	default:
+		return &ValidationError{
+			Message: fmt.Sprintf("Unknown expression type: %s", expr.Kind),
+		}
+	}
+}
+
+// validateFunctionCall проверяет вызов функции по сигнатурам из реестра: функция существует,
+// количество аргументов подходит и типы литералов среди аргументов допустимы.
+// Типы колонок здесь неизвестны, их проверяет executor до чтения строк
+func (v *validator) validateFunctionCall(call *ast.FunctionCallExpression) error {
+	name := call.Name.Value
+	function, ok := v.functions.Lookup(name)
+	if !ok || function.Kind != functions.ScalarKind {
+		return &ValidationError{
+			Message: fmt.Sprintf("function %s does not exist", name),
+		}
+	}
+	if !function.AcceptsCount(len(call.Arguments)) {
+		return &ValidationError{
+			Message: fmt.Sprintf("function %s expects %s, got %d", name, function.ArgumentCounts(), len(call.Arguments)),
+		}
+	}
+
+	argumentTypes := make([]disk_manager.DataType, 0, len(call.Arguments))
+	for _, argument := range call.Arguments {
+		argumentTypes = append(argumentTypes, literalDataType(argument))
+	}
+	if _, ok := function.Resolve(argumentTypes); ok {
+		return nil
+	}
+
+	// Ищем первый аргумент, тип которого не принимает ни одна сигнатура с таким количеством аргументов
+	for i, argumentType := range argumentTypes {
+		accepted := false
+		for _, signature := range function.Signatures {
+			if signature.AcceptsCount(len(argumentTypes)) && functions.AcceptsType(signature.Parameter(i), argumentType) {
+				accepted = true
+				break
+			}
+		}
+		if !accepted {
+			return &ValidationError{
+				Message: fmt.Sprintf("function %s does not accept argument %d of type %s", name, i+1, literalType(call.Arguments[i])),
+			}
+		}
+	}
+	return &ValidationError{
+		Message: fmt.Sprintf("function %s does not accept this combination of argument types", name),
+	}
+}
+
+// literalDataType возвращает тип выражения, который известен без схемы таблиц, как тип колонки.
+// Числовой литерал считается INT: он подходит и для INT, и для DECIMAL параметров
+func literalDataType(expression *ast.Expression) disk_manager.DataType {
+	switch literalType(expression) {
+	case "NUMERIC":
+		return disk_manager.INT_32_TYPE
+	case "TEXT":
+		return disk_manager.TEXT_TYPE
+	case "BYTEA":
+		return disk_manager.BYTEA_TYPE
+	case "BOOLEAN":
+		return disk_manager.BOOLEAN_TYPE
+	case "DATE":
+		return disk_manager.DATE_TYPE
+	case "TIMESTAMP":
+		return disk_manager.TIMESTAMP_TYPE
+	case "INTERVAL":
+		return disk_manager.INTERVAL_TYPE
+	case "JSON":
+		return disk_manager.JSON_TYPE
+	}
+	return 0
+}
+
+// validateCase проверяет выражение CASE: хотя бы одну ветку WHEN и все подвыражения
+func (v *validator) validateCase(caseExpression *ast.CaseExpression) error {
+	if caseExpression == nil || len(caseExpression.Whens) == 0 {
+		return &ValidationError{
+			Message: "CASE expression must have at least one WHEN clause",
+		}
+	}
+
+	if caseExpression.Operand != nil {
+		if err := v.validateExpression(caseExpression.Operand); err != nil {
+			return err
+		}
+	}
+	for _, when := range caseExpression.Whens {
+		if err := v.validateExpression(when.Condition); err != nil {
+			return err
+		}
+		if err := v.validateExpression(when.Result); err != nil {
+			return err
+		}
+	}
+	if caseExpression.Else != nil {
+		return v.validateExpression(caseExpression.Else)
+	}
+	return nil
+}
+
+// predicateArguments количество аргументов предиката, для IN - минимальное
+var predicateArguments = map[ast.ExpressionKind]int{
+	ast.LikeKind:    1,
+	ast.InListKind:  1,
+	ast.BetweenKind: 2,
+	ast.IsNullKind:  0,
+}
+
+// validatePredicate проверяет предикат LIKE, IN (список), BETWEEN или IS NULL: операнд и аргументы
+func (v *validator) validatePredicate(expr *ast.Expression) error {
+	predicate := expr.Predicate
+	if predicate == nil || predicate.Operand == nil {
+		return &ValidationError{
+			Message: fmt.Sprintf("%s predicate is invalid", expr.Kind),
+		}
+	}
+
+	count := predicateArguments[expr.Kind]
+	if len(predicate.Arguments) < count || (expr.Kind != ast.InListKind && len(predicate.Arguments) != count) {
+		return &ValidationError{
+			Message: fmt.Sprintf("%s predicate expects %d arguments, got %d", expr.Kind, count, len(predicate.Arguments)),
+		}
+	}
+
+	if err := v.validateExpression(predicate.Operand); err != nil {
+		return err
+	}
+	for _, argument := range predicate.Arguments {
+		if err := v.validateExpression(argument); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// logicalOperands количество операндов логических операторов
+var logicalOperands = map[lex.Keyword]int{
+	lex.AndKeyword: 2,
+	lex.OrKeyword:  2,
+	lex.NotKeyword: 1,
+}
+
+// validateLogical проверяет логическое выражение AND, OR или NOT: количество операндов и то,
+// что операнд с известным без схемы таблиц типом - условие
+func (v *validator) validateLogical(logical *ast.LogicalExpression) error {
+	if logical == nil {
+		return &ValidationError{
+			Message: "Logical expression is invalid",
+		}
+	}
+
+	operator := strings.ToUpper(string(logical.Operator))
+	count, ok := logicalOperands[logical.Operator]
+	if !ok || len(logical.Operands) != count {
+		return &ValidationError{
+			Message: fmt.Sprintf("%s expression expects %d operands, got %d", operator, count, len(logical.Operands)),
+		}
+	}
+
+	for _, operand := range logical.Operands {
+		if err := v.validateExpression(operand); err != nil {
+			return err
+		}
+		if operandType := literalType(operand); operandType != "" && operandType != "BOOLEAN" {
+			return &ValidationError{
+				Message: fmt.Sprintf("argument of %s must be type BOOLEAN, not type %s", operator, operandType),
+			}
+		}
+	}
+	return nil
+}
+
+// validateAggregate проверяет вызов агрегатной функции: один аргумент (или * для count)
+// и отсутствие вложенных агрегатных функций
+func (v *validator) validateAggregate(aggregate *ast.AggregateExpression) error {
+	if aggregate == nil {
+		return &ValidationError{
+			Message: "Aggregate function call is invalid",
+		}
+	}
+
+	name := aggregate.Name.Value
+	if aggregate.Star {
+		if name != "count" {
+			return &ValidationError{
+				Message: fmt.Sprintf("%s(*) is not supported, only count(*)", name),
+			}
+		}
+		return nil
+	}
+
+	if len(aggregate.Arguments) != 1 {
+		return &ValidationError{
+			Message: fmt.Sprintf("aggregate function %s expects exactly 1 argument, got %d", name, len(aggregate.Arguments)),
+		}
+	}
+
+	argument := aggregate.Arguments[0]
+	if err := v.validateExpression(argument); err != nil {
+		return err
+	}
+	if argument.ContainsAggregate() {
+		return &ValidationError{
+			Message: "aggregate function calls cannot be nested",
+		}
+	}
+
+	// Типы аргументов встроенных агрегатных функций проверяет executor, пользовательских - сигнатура
+	if function, ok := v.functions.Lookup(name); ok && !function.Builtin {
+		if _, ok := function.Resolve([]disk_manager.DataType{literalDataType(argument)}); !ok {
+			return &ValidationError{
+				Message: fmt.Sprintf("function %s does not accept argument 1 of type %s", name, literalType(argument)),
+			}
+		}
+	}
+
+	return nil
+}
+
+// isAggregateFunction проверяет, является ли функция встроенной или пользовательской агрегатной функцией
+func (v *validator) isAggregateFunction(name string) bool {
+	function, ok := v.functions.Lookup(name)
+	return ok && function.Kind == functions.AggregateKind
+}
+
+// validateWindow проверяет вызов оконной функции: функция оконная или агрегатная, количество аргументов,
+// отсутствие вложенных оконных функций и корректность рамки
+func (v *validator) validateWindow(window *ast.WindowExpression) error {
+	name := window.Name.Value
+	if !ast.IsWindowFunction(name) && !v.isAggregateFunction(name) {
+		return &ValidationError{
+			Message: fmt.Sprintf("OVER specified, but %s is not a window function nor an aggregate function", name),
+		}
+	}
+	if window.Distinct {
+		return &ValidationError{
+			Message: "DISTINCT is not implemented for window functions",
+		}
+	}
+
+	arguments := len(window.Arguments)
+	switch {
+	case name == "row_number" || name == "rank" || name == "dense_rank":
+		if arguments != 0 {
+			return &ValidationError{
+				Message: fmt.Sprintf("window function %s expects no arguments", name),
+			}
+		}
+	case name == "lag" || name == "lead":
+		if arguments < 1 || arguments > 3 {
+			return &ValidationError{
+				Message: fmt.Sprintf("window function %s expects 1 to 3 arguments", name),
+			}
+		}
+	case window.Star:
+		if name != "count" {
+			return &ValidationError{
+				Message: fmt.Sprintf("only count(*) is allowed, got %s(*)", name),
+			}
+		}
+	case arguments != 1:
+		return &ValidationError{
+			Message: fmt.Sprintf("window function %s expects exactly 1 argument", name),
+		}
+	}
+
+	expressions := append([]*ast.Expression{}, window.Arguments...)
+	expressions = append(expressions, window.PartitionBy...)
+	for _, item := range window.OrderBy {
+		if item == nil {
+			return &ValidationError{
+				Message: "ORDER BY item is invalid",
+			}
+		}
+		expressions = append(expressions, item.Expression)
+	}
+	for _, expression := range expressions {
+		if err := v.validateExpression(expression); err != nil {
+			return err
+		}
+		if expression.ContainsWindow() {
+			return &ValidationError{
+				Message: "window function calls cannot be nested",
+			}
+		}
+	}
+
+	if window.Frame != nil {
+		return v.validateWindowFrame(window.Frame)
+	}
+	return nil
+}
+
+// validateWindowFrame проверяет рамку окна: начало рамки не может быть после ее конца
+func (v *validator) validateWindowFrame(frame *ast.WindowFrame) error {
+	for _, bound := range []ast.FrameBound{frame.Start, frame.End} {
+		if bound.Offset != nil {
+			if err := v.validateRowCount(bound.Offset.Value, "frame offset"); err != nil {
+				return err
+			}
+		}
+	}
+
+	start, end := frame.Start.Kind, frame.End.Kind
+	switch {
+	case start == ast.UnboundedFollowing:
+		return &ValidationError{
+			Message: "frame start cannot be UNBOUNDED FOLLOWING",
+		}
+	case end == ast.UnboundedPreceding:
+		return &ValidationError{
+			Message: "frame end cannot be UNBOUNDED PRECEDING",
+		}
+	case start == ast.CurrentRow && end == ast.OffsetPreceding:
+		return &ValidationError{
+			Message: "frame starting from current row cannot have preceding rows",
+		}
+	case start == ast.OffsetFollowing && (end == ast.OffsetPreceding || end == ast.CurrentRow):
+		return &ValidationError{
+			Message: "frame starting from following row cannot have preceding rows",
+		}
+	}
+
+	return nil
+}
+
+// validateIdentifier проверяет корректность идентификатора
+func (v *validator) validateIdentifier(value, context string) error {
+	if value == "" {
+		return &ValidationError{
+			Message: fmt.Sprintf("%s cannot be empty", context),
+		}
+	}
+
+	// Проверка на ключевые слова
+	keywords := []string{"SELECT", "FROM", "INSERT", "INTO", "VALUES", "CREATE", "TABLE", "DROP", "WHERE", "ORDER", "BY", "ASC", "DESC", "NULLS", "LIMIT", "OFFSET", "GROUP", "HAVING", "DISTINCT", "AS", "JOIN", "INNER", "LEFT", "RIGHT", "FULL", "OUTER", "CROSS", "ON", "EXISTS", "IN", "NOT", "WITH", "RECURSIVE", "UNION", "ALL", "INTERSECT", "EXCEPT", "OVER", "PARTITION", "ROWS", "ROW", "BETWEEN", "AND", "OR", "UNBOUNDED", "PRECEDING", "FOLLOWING", "CURRENT", "LIKE", "ILIKE", "IS", "CASE", "WHEN", "THEN", "ELSE", "END", "CAST", "INT", "TEXT", "DECIMAL", "NUMERIC", "VARCHAR", "CHAR", "BYTEA", "JSON", "NULL"}
+	for _, keyword := range keywords {
+		if strings.ToUpper(value) == keyword {
+			return &ValidationError{
+				Message: fmt.Sprintf("%s cannot be a keyword: %s", context, value),
+			}
+		}
+	}
+
+	// Проверка на специальные символы
+	if strings.ContainsAny(value, " \t\n\r()[]{},;*") {
+		return &ValidationError{
+			Message: fmt.Sprintf("%s contains invalid characters: %s", context, value),
+		}
+	}
+
+	return nil
+}
+
+// validateDataType проверяет корректность типа данных
+func (v *validator) validateDataType(dataType string) error {
+	validTypes := []string{"INT", "TEXT", "DATE", "TIMESTAMP", "INTERVAL", "DECIMAL", "NUMERIC", "VARCHAR", "CHAR", "BYTEA", "JSON"}
+
+	for _, validType := range validTypes {
+		if strings.ToUpper(dataType) == validType {
+			return nil
+		}
+	}
+
+	return &ValidationError{
+		Message: fmt.Sprintf("Invalid data type: %s. Valid types: %s", dataType, strings.Join(validTypes, ", ")),
+	}
+}
+
+// validateTypeParameters проверяет параметры типа данных:
+// DECIMAL(p) или DECIMAL(p,s), где 1 <= p <= 1000 и 0 <= s <= p; VARCHAR(n) и CHAR(n), где 1 <= n <= 10485760
+func (v *validator) validateTypeParameters(dataType string, parameters []*lex.Token) error {
+	if len(parameters) == 0 {
+		return nil
+	}
+
+	values := make([]int, 0, len(parameters))
+	for _, parameter := range parameters {
+		value, err := strconv.Atoi(parameter.Value)
+		if err != nil {
+			return &ValidationError{
+				Message: fmt.Sprintf("Invalid type parameter for %s: %s", strings.ToUpper(dataType), parameter.Value),
+			}
+		}
+		values = append(values, value)
+	}
+
+	switch strings.ToUpper(dataType) {
+	case "DECIMAL", "NUMERIC":
+		if len(values) > 2 {
+			return &ValidationError{
+				Message: fmt.Sprintf("%s accepts at most 2 parameters: precision and scale", strings.ToUpper(dataType)),
+			}
+		}
+		if values[0] < 1 || values[0] > 1000 {
+			return &ValidationError{
+				Message: fmt.Sprintf("%s precision %d must be between 1 and 1000", strings.ToUpper(dataType), values[0]),
+			}
+		}
+		if len(values) == 2 && values[1] > values[0] {
+			return &ValidationError{
+				Message: fmt.Sprintf("%s scale %d must be between 0 and precision %d", strings.ToUpper(dataType), values[1], values[0]),
+			}
+		}
+		return nil
+	case "VARCHAR", "CHAR":
+		if len(values) > 1 {
+			return &ValidationError{
+				Message: fmt.Sprintf("%s accepts only 1 parameter: length", strings.ToUpper(dataType)),
+			}
+		}
+		if values[0] < 1 || values[0] > 10485760 {
+			return &ValidationError{
+				Message: fmt.Sprintf("%s length %d must be between 1 and 10485760", strings.ToUpper(dataType), values[0]),
+			}
+		}
+		return nil
+	default:
+		return &ValidationError{
+			Message: fmt.Sprintf("Type %s does not accept parameters", strings.ToUpper(dataType)),
+		}
 	}
 }
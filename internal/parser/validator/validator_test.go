@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"custom-database/internal/parser/lex"
 	"testing"
 )
 
@@ -147,3 +148,98 @@ func TestValidator_validateDataType(t *testing.T) {
 		})
 	}
 }
+
+func TestValidator_validateTypeParameters(t *testing.T) {
+	validator := &validator{}
+
+	parameters := func(values ...string) []*lex.Token {
+		tokens := []*lex.Token{}
+		for _, value := range values {
+			tokens = append(tokens, &lex.Token{Kind: lex.NumericToken, Value: value})
+		}
+		return tokens
+	}
+
+	tests := []struct {
+		name       string
+		dataType   string
+		parameters []*lex.Token
+		wantErr    bool
+	}{
+		{
+			name:       "DECIMAL without parameters",
+			dataType:   "decimal",
+			parameters: parameters(),
+			wantErr:    false,
+		},
+		{
+			name:       "DECIMAL with precision and scale",
+			dataType:   "decimal",
+			parameters: parameters("10", "2"),
+			wantErr:    false,
+		},
+		{
+			name:       "NUMERIC with precision only",
+			dataType:   "numeric",
+			parameters: parameters("5"),
+			wantErr:    false,
+		},
+		{
+			name:       "Scale greater than precision",
+			dataType:   "decimal",
+			parameters: parameters("2", "5"),
+			wantErr:    true,
+		},
+		{
+			name:       "Zero precision",
+			dataType:   "decimal",
+			parameters: parameters("0"),
+			wantErr:    true,
+		},
+		{
+			name:       "Too many parameters",
+			dataType:   "decimal",
+			parameters: parameters("10", "2", "1"),
+			wantErr:    true,
+		},
+		{
+			name:       "Fractional precision",
+			dataType:   "decimal",
+			parameters: parameters("10.5"),
+			wantErr:    true,
+		},
+		{
+			name:       "VARCHAR with length",
+			dataType:   "varchar",
+			parameters: parameters("255"),
+			wantErr:    false,
+		},
+		{
+			name:       "CHAR with zero length",
+			dataType:   "char",
+			parameters: parameters("0"),
+			wantErr:    true,
+		},
+		{
+			name:       "VARCHAR with two parameters",
+			dataType:   "varchar",
+			parameters: parameters("10", "2"),
+			wantErr:    true,
+		},
+		{
+			name:       "Parameters for INT",
+			dataType:   "int",
+			parameters: parameters("10"),
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.validateTypeParameters(tt.dataType, tt.parameters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validator.validateTypeParameters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}